@@ -24,18 +24,88 @@ type BatchSubmitter struct {
 	lastStoredBlock eth.BlockID
 	lastL1Tip       eth.L1BlockRef
 
-	state *channelManager
+	state    *channelManager
+	throttle *feeThrottle
+	tuner    *sizeTuner
 }
 
 // NewBatchSubmitter initializes the BatchSubmitter, gathering any resources
 // that will be needed during operation.
 func NewBatchSubmitter(cfg Config, l log.Logger, m metrics.Metricer) (*BatchSubmitter, error) {
+	state := NewChannelManager(l, m, cfg.Channel)
+	if cfg.StatePath != "" {
+		state.store = newChannelStore(cfg.StatePath)
+	}
+	tuner := newSizeTuner(cfg.Sizing, cfg.Channel.ApproxComprRatio, l, m)
+	if cfg.Sizing.Enabled() {
+		state.tuner = tuner
+	}
 	return &BatchSubmitter{
-		Config: cfg,
-		state:  NewChannelManager(l, m, cfg.Channel),
+		Config:   cfg,
+		state:    state,
+		throttle: newFeeThrottle(cfg.Throttle, l, m),
+		tuner:    tuner,
 	}, nil
 }
 
+// restoreState restores the submission state recorded before a restart, if any, so that the
+// submission resumes after the last submitted block, and the frames of the pending channel already
+// submitted are not submitted again. The state is dropped if its blocks were reorged or are safe
+// already, in which case the submission starts at the safe head as usual.
+func (b *BatchSubmitter) restoreState(ctx context.Context) error {
+	if b.state.store == nil {
+		return nil
+	}
+	stored, err := b.state.store.Load()
+	if err != nil {
+		return err
+	}
+	cursor := stored.Cursor()
+	if cursor == (eth.BlockID{}) {
+		return nil
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, b.NetworkTimeout)
+	syncStatus, err := b.RollupClient.SyncStatus(tctx)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to get sync status: %w", err)
+	}
+	if cursor.Number <= syncStatus.SafeL2.Number {
+		b.log.Info("Recorded submission state is already safe", "cursor", cursor, "safe", syncStatus.SafeL2)
+		return b.state.Restore(&storedState{}, nil)
+	}
+
+	var blocks []*types.Block
+	ids := stored.Blocks()
+	if len(ids) == 0 {
+		ids = []eth.BlockID{cursor}
+	}
+	for _, id := range ids {
+		tctx, cancel := context.WithTimeout(ctx, b.NetworkTimeout)
+		block, err := b.L2Client.BlockByNumber(tctx, new(big.Int).SetUint64(id.Number))
+		cancel()
+		if err != nil {
+			return fmt.Errorf("getting L2 block: %w", err)
+		}
+		if block.Hash() != id.Hash {
+			b.log.Warn("Recorded submission state was reorged", "block", id, "canonical", block.Hash())
+			return b.state.Restore(&storedState{}, nil)
+		}
+		if len(stored.Channels) > 0 {
+			blocks = append(blocks, block)
+		}
+	}
+
+	if err := b.state.Restore(stored, blocks); err != nil {
+		b.log.Warn("Failed to restore the recorded channels, submitting their blocks again", "err", err)
+		return nil
+	}
+	b.lastStoredBlock = cursor
+	b.log.Info("Restored submission state", "cursor", cursor, "safe", syncStatus.SafeL2)
+	return nil
+}
+
 // loadBlocksIntoState loads all blocks since the previous stored block
 // It does the following:
 // 1. Fetch the sync status of the proposer
@@ -124,6 +194,7 @@ func (b *BatchSubmitter) calculateL2BlockRangeToStore(ctx context.Context) (eth.
 	if syncStatus.SafeL2.Number >= syncStatus.UnsafeL2.Number {
 		return eth.BlockID{}, eth.BlockID{}, errors.New("L2 safe head ahead of L2 unsafe head")
 	}
+	b.metr.RecordSafeHeadDistance(syncStatus.HeadL1, syncStatus.SafeL2, syncStatus.UnsafeL2)
 
 	return b.lastStoredBlock, syncStatus.UnsafeL2.ID(), nil
 }
@@ -145,16 +216,17 @@ func (b *BatchSubmitter) recordConfirmedTx(id txID, receipt *types.Receipt) {
 	b.log.Info("Transaction confirmed", "tx_hash", receipt.TxHash, "status", receipt.Status, "block_hash", receipt.BlockHash, "block_number", receipt.BlockNumber)
 	l1block := eth.BlockID{Number: receipt.BlockNumber.Uint64(), Hash: receipt.BlockHash}
 	b.state.TxConfirmed(id, l1block)
+	b.throttle.TxConfirmed(receipt)
 }
 
-// l1Tip gets the current L1 tip as a L1BlockRef. The passed context is assumed
+// l1Tip gets the current L1 tip as a L1BlockRef, with its base fee. The passed context is assumed
 // to be a lifetime context, so it is internally wrapped with a network timeout.
-func (b *BatchSubmitter) l1Tip(ctx context.Context) (eth.L1BlockRef, error) {
+func (b *BatchSubmitter) l1Tip(ctx context.Context) (eth.L1BlockRef, *big.Int, error) {
 	tctx, cancel := context.WithTimeout(ctx, b.NetworkTimeout)
 	defer cancel()
 	head, err := b.L1Client.HeaderByNumber(tctx, nil)
 	if err != nil {
-		return eth.L1BlockRef{}, fmt.Errorf("getting latest L1 block: %w", err)
+		return eth.L1BlockRef{}, nil, fmt.Errorf("getting latest L1 block: %w", err)
 	}
-	return eth.InfoToL1BlockRef(eth.HeaderBlockInfo(head)), nil
+	return eth.InfoToL1BlockRef(eth.HeaderBlockInfo(head)), head.BaseFee, nil
 }
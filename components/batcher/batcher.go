@@ -6,14 +6,20 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/urfave/cli"
 
 	"github.com/kroma-network/kroma/components/batcher/metrics"
+	batcherrpc "github.com/kroma-network/kroma/components/batcher/rpc"
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
 	"github.com/kroma-network/kroma/utils"
 	"github.com/kroma-network/kroma/utils/monitoring"
 	klog "github.com/kroma-network/kroma/utils/service/log"
@@ -21,6 +27,9 @@ import (
 	"github.com/kroma-network/kroma/utils/service/txmgr"
 )
 
+// ErrInboxRotated is returned when a batcher tx was included after the rotation of its batch inbox address.
+var ErrInboxRotated = errors.New("batch inbox address rotated before the tx inclusion")
+
 // Main is the entrypoint into the Batcher.
 func Main(version string, cliCtx *cli.Context) error {
 	cliCfg := NewCLIConfig(cliCtx)
@@ -41,9 +50,39 @@ func Main(version string, cliCtx *cli.Context) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	batcher, err := NewBatcher(ctx, *batcherCfg, l, m)
+	if err != nil {
+		l.Error("Unable to create batcher", "err", err)
+		return err
+	}
+
 	monitoring.MaybeStartPprof(ctx, cliCfg.PprofConfig, l)
 	monitoring.MaybeStartMetrics(ctx, cliCfg.MetricsConfig, l, m, batcherCfg.L1Client, batcherCfg.TxManager.From())
-	server, err := monitoring.StartRPC(cliCfg.RPCConfig.ToServiceCLIConfig(), version, krpc.WithLogger(l))
+	var ready func() error
+	if txMgr, ok := batcherCfg.TxManager.(*txmgr.SimpleTxManager); ok {
+		ready = txMgr.Ready
+	}
+	monitoring.MaybeStartHealth(ctx, cliCfg.HealthConfig, l, ready)
+	jwtSecret, err := cliCfg.RPCConfig.ReadJWTSecret()
+	if err != nil {
+		return err
+	}
+	rpcOpts := []krpc.ServerOption{krpc.WithLogger(l), krpc.WithJWTSecret(jwtSecret)}
+	var apis []rpc.API
+	if cliCfg.RPCConfig.EnableAdmin {
+		apis = append(apis, rpc.API{
+			Namespace: "admin",
+			Service:   batcherrpc.NewAdminAPI(batcher),
+		})
+	}
+	if txMgr, ok := batcherCfg.TxManager.(*txmgr.SimpleTxManager); ok {
+		rpcOpts = append(rpcOpts, krpc.WithHealthzHandler(krpc.ReadyHealthzHandler(version, txMgr.Ready)))
+		if txMgr.EnableAdmin {
+			apis = append(apis, txmgr.NewAdminAPI(txMgr))
+		}
+	}
+	rpcOpts = append(rpcOpts, krpc.WithAPIs(apis))
+	server, err := monitoring.StartRPC(cliCfg.RPCConfig.ToServiceCLIConfig(), version, rpcOpts...)
 	if err != nil {
 		return err
 	}
@@ -56,18 +95,18 @@ func Main(version string, cliCtx *cli.Context) error {
 	m.RecordInfo(version)
 	m.RecordUp()
 
-	batcher, err := NewBatcher(ctx, *batcherCfg, l, m)
-	if err != nil {
-		l.Error("Unable to create batcher", "err", err)
-		return err
-	}
-
 	if err := batcher.Start(); err != nil {
 		l.Error("Unable to start batcher", "err", err)
 		return err
 	}
 	<-utils.WaitInterrupt()
-	batcher.Stop(context.Background())
+	// The pending channel is submitted before stopping, for at most the drain timeout.
+	stopCtx, cancelStop := context.WithTimeout(context.Background(), cliCfg.DrainTimeout)
+	defer cancelStop()
+	batcher.Stop(stopCtx)
+	if txMgr, ok := batcherCfg.TxManager.(*txmgr.SimpleTxManager); ok {
+		txMgr.Close()
+	}
 
 	return nil
 }
@@ -78,6 +117,11 @@ type Batcher struct {
 	killCtx           context.Context
 	cancelKillCtx     context.CancelFunc
 	running           bool
+	// paused halts the batch submissions while set, see Pause.
+	paused atomic.Bool
+	// leading is whether this instance leads the batch submission, with the leader election.
+	// It is only accessed by the loop.
+	leading bool
 
 	cfg            Config
 	l              log.Logger
@@ -96,6 +140,12 @@ func NewBatcher(parentCtx context.Context, cfg Config, l log.Logger, m metrics.M
 	if err != nil {
 		return nil, fmt.Errorf("failed to init batch submitter: %w", err)
 	}
+	// With the leader election, the state is restored once elected.
+	if cfg.Elector == nil {
+		if err := batchSubmitter.restoreState(parentCtx); err != nil {
+			return nil, fmt.Errorf("failed to restore the submission state: %w", err)
+		}
+	}
 
 	balance, err := cfg.L1Client.BalanceAt(parentCtx, cfg.TxManager.From(), nil)
 	if err != nil {
@@ -122,8 +172,22 @@ func (b *Batcher) Start() error {
 	b.shutdownCtx, b.cancelShutdownCtx = context.WithCancel(context.Background())
 	b.killCtx, b.cancelKillCtx = context.WithCancel(context.Background())
 
+	// The elector outlives the loop, so that the leader flushes the pending frames on shutdown
+	// before releasing the lock.
+	electorCtx, cancelElector := context.WithCancel(context.Background())
+	if b.cfg.Elector != nil {
+		b.wg.Add(1)
+		go func() {
+			defer b.wg.Done()
+			b.cfg.Elector.Run(electorCtx)
+		}()
+	}
+
 	b.wg.Add(1)
-	go b.loop()
+	go func() {
+		defer cancelElector()
+		b.loop()
+	}()
 
 	b.l.Info("Batcher started")
 
@@ -156,6 +220,92 @@ func (b *Batcher) Stop(ctx context.Context) error {
 	return nil
 }
 
+// Pause halts the batch submissions until Resume is called. The txs in flight are still awaited, and
+// the L2 blocks keep being loaded meanwhile, to be submitted once resumed. Unlike Stop, the pending
+// frames are not flushed, and a shutdown while paused does not submit them either.
+func (b *Batcher) Pause() error {
+	if !b.paused.CompareAndSwap(false, true) {
+		return errors.New("batcher is already paused")
+	}
+	b.l.Warn("Batcher paused")
+	return nil
+}
+
+// Resume resumes the batch submissions halted by Pause.
+func (b *Batcher) Resume() error {
+	if !b.paused.CompareAndSwap(true, false) {
+		return errors.New("batcher is not paused")
+	}
+	b.l.Info("Batcher resumed")
+	return nil
+}
+
+// FlushChannel closes the pending channel with all the pending blocks, so that it is submitted at
+// the next poll, without waiting for it to be full or to time out, and regardless of the L1 base fee.
+func (b *Batcher) FlushChannel(ctx context.Context) error {
+	l1tip, _, err := b.batchSubmitter.l1Tip(ctx)
+	if err != nil {
+		return err
+	}
+	return b.batchSubmitter.state.Flush(l1tip.ID())
+}
+
+// isLeader returns whether this instance is the leader, always true without the leader election.
+func (b *Batcher) isLeader() bool {
+	return b.cfg.Elector == nil || b.cfg.Elector.IsLeader()
+}
+
+// lead returns whether this instance leads the batch submission. Once elected, the submission
+// resumes from the recorded state, or from the safe head. Once stepped down, the state is dropped,
+// since the new leader goes on with the submission.
+func (b *Batcher) lead() bool {
+	if b.cfg.Elector == nil {
+		return true
+	}
+	isLeader := b.cfg.Elector.IsLeader()
+	if isLeader == b.leading {
+		return isLeader
+	}
+	b.leading = isLeader
+	b.batchSubmitter.state.Clear()
+	b.batchSubmitter.lastStoredBlock = eth.BlockID{}
+	if !isLeader {
+		b.l.Warn("Stepped down from the batch submission")
+		return false
+	}
+	b.l.Info("Leading the batch submission")
+	if err := b.batchSubmitter.restoreState(b.shutdownCtx); err != nil {
+		b.l.Error("failed to restore the submission state", "err", err)
+	}
+	return true
+}
+
+// Status returns the status of the batcher, for the admin API.
+func (b *Batcher) Status() batcherrpc.BatcherStatus {
+	state := b.batchSubmitter.state
+	status := batcherrpc.BatcherStatus{
+		Leader:        b.isLeader(),
+		Paused:        b.paused.Load(),
+		Throttled:     b.batchSubmitter.throttle.Throttled(),
+		PendingBlocks: state.PendingBlocks(),
+	}
+	if id, pendingTxs, confirmedTxs, ok := state.PendingChannel(); ok {
+		status.PendingChannel = &batcherrpc.ChannelStatus{
+			ID:           id,
+			PendingTxs:   pendingTxs,
+			ConfirmedTxs: confirmedTxs,
+		}
+	}
+	for _, ch := range state.InFlightChannels() {
+		status.InFlightChannels = append(status.InFlightChannels, batcherrpc.ChannelStatus{
+			ID:           ch.id,
+			PendingTxs:   ch.pendingTxs,
+			ConfirmedTxs: ch.confirmedTxs,
+		})
+	}
+	return status
+}
+
 // The following things occur:
 // New L2 block (reorg or not)
 // L1 transaction is confirmed
@@ -176,6 +326,9 @@ func (b *Batcher) loop() {
 	for {
 		select {
 		case <-ticker.C:
+			if !b.lead() {
+				continue
+			}
 			if err := b.batchSubmitter.loadBlocksIntoState(b.shutdownCtx); errors.Is(err, ErrReorg) {
 				err := b.batchSubmitter.state.Close()
 				if err != nil {
@@ -191,17 +344,69 @@ func (b *Batcher) loop() {
 				b.l.Error("failed to submit batch channel frame", "err", err)
 			}
 		case <-b.shutdownCtx.Done():
-			if err := b.submitBatch(b.killCtx); err != nil {
-				b.l.Error("failed to submit batch channel frame", "err", err)
+			if !b.isLeader() {
+				return
 			}
+			b.drain()
 			return
 		}
 	}
 }
 
+// drain closes the pending channel and submits its remaining frames, along with the ones of the
+// channels in flight, until they are all confirmed or the ctx passed to Stop is done.
+// The frames left unsubmitted are recorded in the store, if any, and submitted after the restart.
+func (b *Batcher) drain() {
+	if b.paused.Load() {
+		b.l.Warn("Batcher paused, leaving the pending frames unsubmitted")
+		return
+	}
+	state := b.batchSubmitter.state
+	l1tip, _, err := b.batchSubmitter.l1Tip(b.killCtx)
+	if err != nil {
+		b.l.Error("failed to query L1 tip to drain the pending channel", "err", err)
+		return
+	}
+	if err := state.Drain(l1tip.ID()); err != nil {
+		b.l.Error("failed to close the pending channel", "err", err)
+	}
+
+	ticker := time.NewTicker(b.cfg.PollInterval)
+	defer ticker.Stop()
+
+	b.l.Info("draining the pending frames", "pending_blocks", state.PendingBlocks())
+	for !state.Drained() {
+		if b.paused.Load() || !b.isLeader() {
+			b.l.Warn("Batcher paused or no longer the leader, stopping the drain")
+			return
+		}
+		if err := b.submitBatch(b.killCtx); err != nil {
+			b.l.Error("failed to submit batch channel frame", "err", err)
+		}
+		if state.Drained() {
+			break
+		}
+		select {
+		case <-ticker.C:
+		case <-b.killCtx.Done():
+			b.l.Warn("drain timed out, leaving the pending frames unsubmitted", "pending_blocks", state.PendingBlocks())
+			return
+		}
+	}
+	b.l.Info("drained the pending frames")
+}
+
 // submitBatch loops through the block data loaded into `state` and
 // submits the associated data to the L1 in the form of channel frames.
 func (b *Batcher) submitBatch(ctx context.Context) error {
+	// wg and sends track the txs sent concurrently if MaxPendingTxs is more than 1,
+	// and failed holds the first error among them.
+	var wg sync.WaitGroup
+	sends := make(chan struct{}, b.cfg.MaxPendingTxs)
+	failed := make(chan error, 1)
+	// drained is set once there is no more tx data, so that the backlog is caught up.
+	drained := false
+
 	for {
 		// Attempt to gracefully terminate the current channel, ensuring that no new frames will be
 		// produced. Any remaining frames must still be published to the L1 to prevent stalling.
@@ -219,25 +424,59 @@ func (b *Batcher) submitBatch(ctx context.Context) error {
 		default:
 		}
 
-		l1tip, err := b.batchSubmitter.l1Tip(ctx)
+		if b.paused.Load() {
+			b.l.Debug("batch submissions are paused")
+			break
+		}
+		if !b.isLeader() {
+			b.l.Warn("no longer the leader, stopping the batch submission")
+			break
+		}
+
+		l1tip, baseFee, err := b.batchSubmitter.l1Tip(ctx)
 		if err != nil {
 			b.l.Error("failed to query L1 tip", "err", err)
 			break
 		}
 		b.batchSubmitter.recordL1Tip(l1tip)
+		b.batchSubmitter.tuner.SetBaseFee(baseFee)
+
+		// Delay the submission while the L1 base fee is high, unless the pending data is urgent.
+		// The pending blocks keep accumulating meanwhile, and are submitted once the base fee drops.
+		// They are not delayed on shutdown either, so that they are drained in time.
+		if b.cfg.Throttle.Enabled() && b.shutdownCtx.Err() == nil {
+			state := b.batchSubmitter.state
+			urgent := state.Urgent(l1tip.ID(), b.cfg.Throttle.UrgencyMargin)
+			if b.batchSubmitter.throttle.Delay(baseFee, urgent, state.PendingBlocks()) {
+				b.l.Debug("delaying the batch submission", "base_fee", baseFee, "l1_tip", l1tip.ID())
+				break
+			}
+		}
 
 		// Collect next transaction data
 		txdata, err := b.batchSubmitter.state.TxData(l1tip.ID())
 		if err == io.EOF {
 			b.l.Trace("no transaction data available")
+			drained = true
 			break
 		} else if err != nil {
 			b.l.Error("unable to get tx data", "err", err)
 			break
 		}
 
+		// The txs are included after the L1 tip, so the alternative DA is active for them too.
+		altDA := b.cfg.DAClient != nil && b.cfg.Rollup.IsAltDA(l1tip.Time)
+		inbox := b.cfg.Rollup.BatchInboxAddressAt(l1tip.Number + 1)
+
+		if b.cfg.MaxPendingTxs > 1 {
+			if err := b.sendTransactionAsync(ctx, txdata, altDA, inbox, sends, &wg, failed); err != nil {
+				break
+			}
+			continue
+		}
+
 		// Record TX Status
-		receipt, err := b.sendTransaction(ctx, txdata.Bytes())
+		receipt, err := b.sendTxData(ctx, txdata, altDA, inbox)
 		if err != nil {
 			b.batchSubmitter.recordFailedTx(txdata.ID(), err)
 			return fmt.Errorf("failed to send batch submit transaction: %w", err)
@@ -245,13 +484,82 @@ func (b *Batcher) submitBatch(ctx context.Context) error {
 		b.batchSubmitter.recordConfirmedTx(txdata.ID(), receipt)
 	}
 
+	wg.Wait()
+	select {
+	case err := <-failed:
+		return fmt.Errorf("failed to send batch submit transaction: %w", err)
+	default:
+	}
+	if drained {
+		b.batchSubmitter.throttle.CaughtUp()
+	}
+	return nil
+}
+
+// sendTransactionAsync sends the tx data in the background once fewer than MaxPendingTxs txs are in flight,
+// so that the txmgr publishes and bumps them concurrently, each at its own nonce.
+// It returns an error, without sending, if a previous tx has failed or ctx is done meanwhile.
+func (b *Batcher) sendTransactionAsync(ctx context.Context, txdata txData, altDA bool, inbox common.Address, sends chan struct{}, wg *sync.WaitGroup, failed chan error) error {
+	abort := func(err error) error {
+		b.batchSubmitter.recordFailedTx(txdata.ID(), err)
+		return err
+	}
+	// Put the error back, so that submitBatch returns it.
+	select {
+	case err := <-failed:
+		failed <- err
+		return abort(err)
+	default:
+	}
+	select {
+	case sends <- struct{}{}:
+	case err := <-failed:
+		failed <- err
+		return abort(err)
+	case <-ctx.Done():
+		return abort(ctx.Err())
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer func() { <-sends }()
+		receipt, err := b.sendTxData(ctx, txdata, altDA, inbox)
+		if err != nil {
+			b.batchSubmitter.recordFailedTx(txdata.ID(), err)
+			select {
+			case failed <- err:
+			default:
+			}
+			return
+		}
+		b.batchSubmitter.recordConfirmedTx(txdata.ID(), receipt)
+	}()
 	return nil
 }
 
-// sendTransaction creates & submits a transaction to the batch inbox address with the given `data`.
+// sendTxData sends the tx data to the given batch inbox address. With the alternative DA, the tx data is
+// posted to the DA layer first, and the tx only carries its commitment.
+func (b *Batcher) sendTxData(ctx context.Context, txdata txData, altDA bool, inbox common.Address) (*types.Receipt, error) {
+	data := txdata.Bytes()
+	if altDA {
+		comm, err := b.cfg.DAClient.SetInput(ctx, data)
+		if err != nil {
+			b.l.Error("batcher unable to post the tx data to the DA layer", "err", err)
+			return nil, fmt.Errorf("failed to post the tx data to the DA layer: %w", err)
+		}
+		b.l.Debug("posted the tx data to the DA layer", "commitment", comm, "size", len(data))
+		data = append([]byte{derive.DerivationVersionAltDA}, comm...)
+	}
+	return b.sendTransaction(ctx, data, inbox)
+}
+
+// sendTransaction creates & submits a transaction to the given batch inbox address with the given `data`.
 // It currently uses the underlying `txmgr` to handle transaction sending & price management.
-// This is a blocking method. It should not be called concurrently.
-func (b *Batcher) sendTransaction(ctx context.Context, data []byte) (*types.Receipt, error) {
+// This is a blocking method. It can be called concurrently only if the txmgr allows MaxPendingTxs
+// concurrent sends.
+// If the batch inbox address was rotated before the transaction got included, it is ignored by the
+// derivation, so ErrInboxRotated is returned for its data to be resubmitted to the new address.
+func (b *Batcher) sendTransaction(ctx context.Context, data []byte, inbox common.Address) (*types.Receipt, error) {
 	// Do the gas estimation offline. A value of 0 will cause the [txmgr] to estimate the gas limit.
 	intrinsicGas, err := core.IntrinsicGas(data, nil, false, true, true, false)
 	if err != nil {
@@ -260,7 +568,7 @@ func (b *Batcher) sendTransaction(ctx context.Context, data []byte) (*types.Rece
 
 	// Send the transaction through the txmgr
 	receipt, err := b.cfg.TxManager.Send(ctx, txmgr.TxCandidate{
-		To:       &b.batchSubmitter.Rollup.BatchInboxAddress,
+		To:       &inbox,
 		TxData:   data,
 		GasLimit: intrinsicGas,
 	})
@@ -269,6 +577,11 @@ func (b *Batcher) sendTransaction(ctx context.Context, data []byte) (*types.Rece
 		return nil, err
 	}
 
+	if active := b.cfg.Rollup.BatchInboxAddressAt(receipt.BlockNumber.Uint64()); active != inbox {
+		b.l.Warn("batcher tx included after the batch inbox rotation", "tx_hash", receipt.TxHash, "block", receipt.BlockNumber, "inbox", inbox, "active_inbox", active)
+		return nil, ErrInboxRotated
+	}
+
 	// The transaction was successfully submitted
 	b.l.Info("batcher tx successfully published", "tx_hash", receipt.TxHash)
 	return receipt, nil
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"time"
 
 	"github.com/ethereum/go-ethereum/core/types"
 
@@ -69,6 +70,20 @@ type ChannelConfig struct {
 	// average from experiments to avoid the chances of creating a small
 	// additional leftover frame.
 	ApproxComprRatio float64
+	// CompressionAlgo is the compression algorithm of the channels. Zlib if empty.
+	CompressionAlgo derive.CompressionAlgo
+	// CompressionAlgosTime is the activation time of the compression algorithms other than zlib.
+	// The channels whose first block has an L1 origin before it are compressed with zlib. Only zlib
+	// may be configured if nil.
+	CompressionAlgosTime *uint64
+
+	// SpanBatchTime is the activation time of the span batches. The channels whose first block has
+	// an L1 origin at or after it, so that they are included after it too, are made of a single span
+	// batch. Span batches are never used if nil.
+	SpanBatchTime *uint64
+	// GenesisL2Time and BlockTime are the rollup parameters the span batches are encoded with.
+	GenesisL2Time uint64
+	BlockTime     uint64
 }
 
 // Check validates the [ChannelConfig] parameters.
@@ -94,9 +109,38 @@ func (cc *ChannelConfig) Check() error {
 		return fmt.Errorf("max frame size %d is less than the minimum 23", cc.MaxFrameSize)
 	}
 
+	if err := cc.compressionAlgo().Check(); err != nil {
+		return err
+	}
+	if algo := cc.compressionAlgo(); algo != derive.Zlib && cc.CompressionAlgosTime == nil {
+		return fmt.Errorf("compression algorithm %s is not activated in the rollup config", algo)
+	}
+
+	if cc.SpanBatchTime != nil && cc.BlockTime == 0 {
+		return errors.New("block time cannot be zero with span batches")
+	}
+
 	return nil
 }
 
+func (c ChannelConfig) compressionAlgo() derive.CompressionAlgo {
+	if c.CompressionAlgo == "" {
+		return derive.Zlib
+	}
+	return c.CompressionAlgo
+}
+
+// channelCompressionAlgo returns the compression algorithm of the channel whose first block has
+// an L1 origin at the given time. Zlib is used until the other algorithms activate: the channel is
+// included after the L1 origin of its first block, so that they are active at its inclusion.
+func (c ChannelConfig) channelCompressionAlgo(l1Time uint64) derive.CompressionAlgo {
+	algo := c.compressionAlgo()
+	if algo != derive.Zlib && (c.CompressionAlgosTime == nil || l1Time < *c.CompressionAlgosTime) {
+		return derive.Zlib
+	}
+	return algo
+}
+
 // InputThreshold calculates the input data threshold in bytes from the given
 // parameters.
 func (c ChannelConfig) InputThreshold() uint64 {
@@ -138,19 +182,38 @@ type channelBuilder struct {
 	frames []frameData
 	// total amount of output data of all frames created yet
 	outputBytes int
+	// time the channel was opened, and the time it got full, 0 until then
+	openTime time.Time
+	fullTime time.Time
 }
 
 // newChannelBuilder creates a new channel builder or returns an error if the
 // channel out could not be created.
 func newChannelBuilder(cfg ChannelConfig) (*channelBuilder, error) {
-	co, err := derive.NewChannelOut()
+	co, err := derive.NewChannelOut(cfg.compressionAlgo())
+	if err != nil {
+		return nil, err
+	}
+
+	return &channelBuilder{
+		cfg:      cfg,
+		co:       co,
+		openTime: time.Now(),
+	}, nil
+}
+
+// newChannelBuilderWithID creates a new channel builder of the given channel ID, to rebuild the
+// channel from its blocks.
+func newChannelBuilderWithID(cfg ChannelConfig, id derive.ChannelID) (*channelBuilder, error) {
+	co, err := derive.NewChannelOutWithID(cfg.compressionAlgo(), id)
 	if err != nil {
 		return nil, err
 	}
 
 	return &channelBuilder{
-		cfg: cfg,
-		co:  co,
+		cfg:      cfg,
+		co:       co,
+		openTime: time.Now(),
 	}, nil
 }
 
@@ -186,6 +249,8 @@ func (c *channelBuilder) Reset() error {
 	c.frames = c.frames[:0]
 	c.timeout = 0
 	c.fullErr = nil
+	c.openTime = time.Now()
+	c.fullTime = time.Time{}
 	return c.co.Reset()
 }
 
@@ -209,6 +274,18 @@ func (c *channelBuilder) AddBlock(block *types.Block) (derive.L1BlockInfo, error
 	if err != nil {
 		return l1info, fmt.Errorf("converting block to batch: %w", err)
 	}
+	if algo := c.cfg.channelCompressionAlgo(l1info.Time); len(c.blocks) == 0 && algo != c.co.CompressionAlgo() {
+		co, err := derive.NewChannelOutWithID(algo, c.co.ID())
+		if err != nil {
+			return l1info, fmt.Errorf("creating channel out: %w", err)
+		}
+		c.co = co
+	}
+	if len(c.blocks) == 0 && c.cfg.SpanBatchTime != nil && l1info.Time >= *c.cfg.SpanBatchTime {
+		if err := c.co.UseSpanBatch(c.cfg.GenesisL2Time, c.cfg.BlockTime); err != nil {
+			return l1info, fmt.Errorf("using span batch: %w", err)
+		}
+	}
 
 	if _, err = c.co.AddBatch(batch); errors.Is(err, derive.ErrTooManyRLPBytes) {
 		c.setFullErr(err)
@@ -326,6 +403,26 @@ func (c *channelBuilder) FullErr() error {
 
 func (c *channelBuilder) setFullErr(err error) {
 	c.fullErr = &ChannelFullError{Err: err}
+	if c.fullTime.IsZero() {
+		c.fullTime = time.Now()
+	}
+}
+
+// OpenDuration returns how long the channel was open before getting full, or so far if it is not
+// full yet. The duration of a rebuilt channel starts when it was rebuilt.
+func (c *channelBuilder) OpenDuration() time.Duration {
+	if c.fullTime.IsZero() {
+		return time.Since(c.openTime)
+	}
+	return c.fullTime.Sub(c.openTime)
+}
+
+// SinceFull returns how long ago the channel got full, 0 if it is not full yet.
+func (c *channelBuilder) SinceFull() time.Duration {
+	if c.fullTime.IsZero() {
+		return 0
+	}
+	return time.Since(c.fullTime)
 }
 
 // OutputFrames creates new frames with the channel out. It should be called
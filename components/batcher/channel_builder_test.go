@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"math/big"
 	"math/rand"
@@ -45,6 +46,10 @@ func TestChannelConfig_Check(t *testing.T) {
 	timeoutChannelConfig := defaultTestChannelConfig
 	timeoutChannelConfig.ChannelTimeout = 0
 	timeoutChannelConfig.SubSafetyMargin = 1
+	algoChannelConfig := defaultTestChannelConfig
+	algoChannelConfig.CompressionAlgo = "lz4"
+	inactiveAlgoChannelConfig := defaultTestChannelConfig
+	inactiveAlgoChannelConfig.CompressionAlgo = derive.Brotli
 	tests := []test{
 		{
 			input: defaultTestChannelConfig,
@@ -64,6 +69,18 @@ func TestChannelConfig_Check(t *testing.T) {
 				require.EqualError(t, output, "max frame size cannot be zero")
 			},
 		},
+		{
+			input: algoChannelConfig,
+			assertion: func(output error) {
+				require.ErrorContains(t, output, "unsupported compression algorithm")
+			},
+		},
+		{
+			input: inactiveAlgoChannelConfig,
+			assertion: func(output error) {
+				require.ErrorContains(t, output, "not activated in the rollup config")
+			},
+		},
 	}
 	for i := 1; i < derive.FrameV0OverHeadSize; i++ {
 		smallChannelConfig := defaultTestChannelConfig
@@ -415,7 +432,7 @@ func TestChannelBuilder_OutputWrongFramePanic(t *testing.T) {
 
 	// Mock the internals of `channelBuilder.outputFrame`
 	// to construct a single frame
-	co, err := derive.NewChannelOut()
+	co, err := derive.NewChannelOut(derive.Zlib)
 	require.NoError(t, err)
 	var buf bytes.Buffer
 	fn, err := co.OutputFrame(&buf, channelConfig.MaxFrameSize)
@@ -731,6 +748,146 @@ func TestChannelBuilder_OutputBytes(t *testing.T) {
 	require.Equal(cb.OutputBytes(), flen)
 }
 
+// TestChannelBuilder_SpanBatch tests that the channels whose first block has an L1 origin from the
+// span batch activation on are made of a single span batch, and of singular batches before.
+func TestChannelBuilder_SpanBatch(t *testing.T) {
+	spanBatchTime := uint64(100)
+	cfg := defaultTestChannelConfig
+	cfg.SpanBatchTime = &spanBatchTime
+	cfg.BlockTime = 2
+
+	for _, tt := range []struct {
+		name   string
+		l1Time uint64
+		span   bool
+	}{
+		{name: "before activation", l1Time: 99},
+		{name: "after activation", l1Time: 100, span: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cb, err := newChannelBuilder(cfg)
+			require.NoError(t, err)
+
+			var parent common.Hash
+			for i := uint64(1); i <= 3; i++ {
+				block := newL2BlockWithTimes(i, 2*i, tt.l1Time, parent)
+				_, err := cb.AddBlock(block)
+				require.NoError(t, err)
+				parent = block.Hash()
+			}
+			cb.Close()
+			require.NoError(t, cb.OutputFrames())
+
+			ch := derive.NewChannel(cb.ID(), eth.L1BlockRef{})
+			for cb.HasFrame() {
+				var frame derive.Frame
+				require.NoError(t, frame.UnmarshalBinary(bytes.NewReader(cb.NextFrame().data)))
+				require.NoError(t, ch.AddFrame(frame, eth.L1BlockRef{}))
+			}
+			require.True(t, ch.IsReady())
+			next, err := derive.BatchReader(ch.Reader(), eth.L1BlockRef{})
+			require.NoError(t, err)
+			var batches []*derive.BatchData
+			for {
+				batch, err := next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				require.NoError(t, err)
+				batches = append(batches, batch.Batch)
+			}
+
+			if !tt.span {
+				require.Len(t, batches, 3)
+				require.Nil(t, batches[0].SpanBatch)
+				return
+			}
+			require.Len(t, batches, 1)
+			require.NotNil(t, batches[0].SpanBatch)
+			require.Len(t, batches[0].SpanBatch.Blocks, 3)
+			require.Equal(t, uint64(2), batches[0].SpanBatch.RelTimestamp)
+			var buf bytes.Buffer
+			require.NoError(t, batches[0].EncodeRLP(&buf))
+			require.Equal(t, buf.Len(), cb.InputBytes())
+		})
+	}
+}
+
+// TestChannelBuilder_Durations tests that the open duration stops once the channel
+// is full, and that the duration since then starts.
+func TestChannelBuilder_Durations(t *testing.T) {
+	cb, err := newChannelBuilder(defaultTestChannelConfig)
+	require.NoError(t, err)
+	require.Zero(t, cb.SinceFull())
+
+	cb.openTime = time.Now().Add(-time.Minute)
+	require.GreaterOrEqual(t, cb.OpenDuration(), time.Minute)
+
+	cb.Close()
+	require.True(t, cb.IsFull())
+	openDuration := cb.OpenDuration()
+	require.GreaterOrEqual(t, openDuration, time.Minute)
+
+	cb.fullTime = cb.fullTime.Add(-time.Second)
+	require.Equal(t, openDuration-time.Second, cb.OpenDuration())
+	require.GreaterOrEqual(t, cb.SinceFull(), time.Second)
+
+	require.NoError(t, cb.Reset())
+	require.Zero(t, cb.SinceFull())
+	require.Less(t, cb.OpenDuration(), time.Minute)
+}
+
+// TestChannelBuilder_CompressionAlgosActivation tests that the channels whose first block has an
+// L1 origin before the activation of the compression algorithms are compressed with zlib.
+func TestChannelBuilder_CompressionAlgosActivation(t *testing.T) {
+	activation := uint64(100)
+	cfg := defaultTestChannelConfig
+	cfg.CompressionAlgo = derive.Zstd
+	cfg.CompressionAlgosTime = &activation
+	require.NoError(t, cfg.Check())
+
+	for _, test := range []struct {
+		l1Time uint64
+		algo   derive.CompressionAlgo
+	}{
+		{l1Time: 99, algo: derive.Zlib},
+		{l1Time: 100, algo: derive.Zstd},
+	} {
+		cb, err := newChannelBuilder(cfg)
+		require.NoError(t, err)
+		id := cb.ID()
+		_, err = cb.AddBlock(newL2BlockWithTimes(1, 2, test.l1Time, common.Hash{}))
+		require.NoError(t, err)
+		require.Equal(t, test.algo, cb.co.CompressionAlgo(), "L1 time %d", test.l1Time)
+		require.Equal(t, id, cb.ID())
+
+		// The next blocks of the channel keep its algorithm.
+		_, err = cb.AddBlock(newL2BlockWithTimes(2, 4, 200, cb.Blocks()[0].Hash()))
+		require.NoError(t, err)
+		require.Equal(t, test.algo, cb.co.CompressionAlgo())
+	}
+}
+
+// newL2BlockWithTimes returns a minimal L2 block of the given number and time, whose L1 origin
+// has the given time.
+func newL2BlockWithTimes(number, time, l1Time uint64, parent common.Hash) *types.Block {
+	l1Block := types.NewBlock(&types.Header{
+		BaseFee:    big.NewInt(10),
+		Difficulty: common.Big0,
+		Number:     big.NewInt(100),
+		Time:       l1Time,
+	}, nil, nil, nil, trie.NewStackTrie(nil))
+	l1InfoTx, err := derive.L1InfoDeposit(number, l1Block, eth.SystemConfig{})
+	if err != nil {
+		panic(err)
+	}
+	return types.NewBlock(&types.Header{
+		Number:     new(big.Int).SetUint64(number),
+		ParentHash: parent,
+		Time:       time,
+	}, []*types.Transaction{types.NewTx(l1InfoTx), types.NewTx(&types.DynamicFeeTx{})}, nil, nil, trie.NewStackTrie(nil))
+}
+
 func defaultChannelBuilderSetup(t *testing.T) (*channelBuilder, ChannelConfig) {
 	t.Helper()
 	cfg := defaultTestChannelConfig
@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"sort"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/kroma-network/kroma/components/batcher/metrics"
@@ -20,11 +23,13 @@ var ErrReorg = errors.New("block does not extend existing chain")
 // channelManager stores a contiguous set of blocks & turns them into channels.
 // Upon receiving tx confirmation (or a tx failure), it does channel error handling.
 //
-// For simplicity, it only creates a single pending channel at a time & waits for
-// the channel to either successfully be submitted or timeout before creating a new
-// channel.
-// Functions on channelManager are not safe for concurrent access.
+// It builds a single pending channel at a time. Once the pending channel is full and all its
+// frames are handed out, it is moved to the in-flight channels while its txs are confirmed,
+// and the next channel is built, so that the txs of several channels can be pipelined.
+// Functions on channelManager are safe for concurrent access, so that the txs of
+// several frames can be sent concurrently.
 type channelManager struct {
+	mu   sync.Mutex
 	log  log.Logger
 	metr metrics.Metricer
 	cfg  ChannelConfig
@@ -43,8 +48,41 @@ type channelManager struct {
 	// Set of confirmed txID -> inclusion block. For determining if the channel is timed out
 	confirmedTransactions map[txID]eth.BlockID
 
+	// Full channels built before the pending channel, oldest first, whose txs are not all confirmed
+	inFlight []*inFlightChannel
+
 	// if set to true, prevents production of any new channel frames
 	closed bool
+	// set by Drain, so that the pending channel is kept until fully submitted, even if its txs fail
+	draining bool
+
+	// Hashes of the frames of the pending channel handed out for submission, recorded in the store
+	frameHashes map[txID]common.Hash
+	// Last block of the last fully submitted channel, recorded in the store
+	submitted eth.BlockID
+	// Store persisting the submission state across restarts, nil if not configured
+	store *channelStore
+	// Tuner of the size of the new channels, nil if not configured
+	tuner *sizeTuner
+}
+
+// inFlightChannel is a full channel whose frames were handed out for submission, waiting for
+// its txs to be confirmed. The frames of its failed txs are handed out again.
+type inFlightChannel struct {
+	builder               *channelBuilder
+	pendingTransactions   map[txID]txData
+	confirmedTransactions map[txID]eth.BlockID
+	frameHashes           map[txID]common.Hash
+}
+
+// isTimedOut returns true if the channel has timed out, see pendingChannelIsTimedOut.
+func (ch *inFlightChannel) isTimedOut(channelTimeout uint64) bool {
+	return isTimedOut(ch.confirmedTransactions, channelTimeout)
+}
+
+// isFullySubmitted returns true if all the frames of the channel are confirmed.
+func (ch *inFlightChannel) isFullySubmitted() bool {
+	return ch.builder.IsFull() && len(ch.pendingTransactions)+ch.builder.NumFrames() == 0
 }
 
 func NewChannelManager(log log.Logger, metr metrics.Metricer, cfg ChannelConfig) *channelManager {
@@ -55,22 +93,34 @@ func NewChannelManager(log log.Logger, metr metrics.Metricer, cfg ChannelConfig)
 
 		pendingTransactions:   make(map[txID]txData),
 		confirmedTransactions: make(map[txID]eth.BlockID),
+		frameHashes:           make(map[txID]common.Hash),
 	}
 }
 
 // Clear clears the entire state of the channel manager.
-// It is intended to be used after an L2 reorg.
+// It is intended to be used after an L2 reorg, or when stepping down from the leadership.
+// The store is left as is, since it may be shared with the new leader, and a recorded state
+// which no longer matches the chain is dropped when restored anyway.
 func (c *channelManager) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.log.Trace("clearing channel manager state")
 	c.blocks = c.blocks[:0]
 	c.tip = common.Hash{}
 	c.closed = false
+	c.draining = false
+	c.submitted = eth.BlockID{}
+	c.inFlight = nil
 	c.clearPendingChannel()
 }
 
 // TxFailed records a transaction as failed. It will attempt to resubmit the data
 // in the failed transaction.
 func (c *channelManager) TxFailed(id txID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if data, ok := c.pendingTransactions[id]; ok {
 		c.log.Trace("marked transaction as failed", "id", id)
 		// Note: when the batcher is changed to send multiple frames per tx,
@@ -78,15 +128,22 @@ func (c *channelManager) TxFailed(id txID) {
 		// and re-queue them.
 		c.pendingChannel.PushFrame(data.Frame())
 		delete(c.pendingTransactions, id)
+	} else if i := c.inFlightIndex(id); i >= 0 {
+		c.log.Trace("marked transaction of in-flight channel as failed", "id", id)
+		ch := c.inFlight[i]
+		data := ch.pendingTransactions[id]
+		ch.builder.PushFrame(data.Frame())
+		delete(ch.pendingTransactions, id)
 	} else {
 		c.log.Warn("unknown transaction marked as failed", "id", id)
 	}
 
 	c.metr.RecordBatchTxFailed()
-	if c.closed && len(c.confirmedTransactions) == 0 && len(c.pendingTransactions) == 0 && c.pendingChannel != nil {
+	if c.closed && !c.draining && len(c.confirmedTransactions) == 0 && len(c.pendingTransactions) == 0 && c.pendingChannel != nil {
 		c.log.Info("Channel has no submitted transactions, clearing for shutdown", "chID", c.pendingChannel.ID())
 		c.clearPendingChannel()
 	}
+	c.persist()
 }
 
 // TxConfirmed marks a transaction as confirmed on L1. Unfortunately even if all frames in
@@ -94,14 +151,25 @@ func (c *channelManager) TxFailed(id txID) {
 // resubmitted.
 // This function may reset the pending channel if the pending channel has timed out.
 func (c *channelManager) TxConfirmed(id txID, inclusionBlock eth.BlockID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.metr.RecordBatchTxSubmitted()
 	c.log.Debug("marked transaction as confirmed", "id", id, "block", inclusionBlock)
-	if _, ok := c.pendingTransactions[id]; !ok {
+	if _, ok := c.pendingTransactions[id]; ok {
+		c.pendingTxConfirmed(id, inclusionBlock)
+	} else if i := c.inFlightIndex(id); i >= 0 {
+		c.inFlightTxConfirmed(i, id, inclusionBlock)
+	} else {
 		c.log.Warn("unknown transaction marked as confirmed", "id", id, "block", inclusionBlock)
 		// TODO: This can occur if we clear the channel while there are still pending transactions
 		// We need to keep track of stale transactions instead
 		return
 	}
+	c.persist()
+}
+
+func (c *channelManager) pendingTxConfirmed(id txID, inclusionBlock eth.BlockID) {
 	delete(c.pendingTransactions, id)
 	c.confirmedTransactions[id] = inclusionBlock
 	c.pendingChannel.FramePublished(inclusionBlock.Number)
@@ -114,11 +182,82 @@ func (c *channelManager) TxConfirmed(id txID, inclusionBlock eth.BlockID) {
 		c.blocks = append(c.pendingChannel.Blocks(), c.blocks...)
 		c.clearPendingChannel()
 	}
-	// If we are done with this channel, record that.
+	// If we are done with this channel, record that once the in-flight channels before it are.
 	if c.pendingChannelIsFullySubmitted() {
-		c.metr.RecordChannelFullySubmitted(c.pendingChannel.ID())
-		c.log.Info("Channel is fully submitted", "id", c.pendingChannel.ID())
+		c.inFlight = append(c.inFlight, c.pendingInFlightChannel())
+		c.clearPendingChannel()
+		c.popSubmittedChannels()
+	}
+}
+
+func (c *channelManager) inFlightTxConfirmed(i int, id txID, inclusionBlock eth.BlockID) {
+	ch := c.inFlight[i]
+	delete(ch.pendingTransactions, id)
+	ch.confirmedTransactions[id] = inclusionBlock
+	ch.builder.FramePublished(inclusionBlock.Number)
+
+	// If this channel timed out, its blocks are submitted again, and so are the blocks of the
+	// channels after it, for simplicity.
+	if ch.isTimedOut(c.cfg.ChannelTimeout) {
+		c.metr.RecordChannelTimedOut(ch.builder.ID())
+		c.log.Warn("In-flight channel timed out", "id", ch.builder.ID(), "later_channels", len(c.inFlight)-i-1)
+		var blocks []*types.Block
+		for _, later := range c.inFlight[i:] {
+			blocks = append(blocks, later.builder.Blocks()...)
+		}
+		if c.pendingChannel != nil {
+			blocks = append(blocks, c.pendingChannel.Blocks()...)
+		}
+		c.blocks = append(blocks, c.blocks...)
+		c.inFlight = c.inFlight[:i]
 		c.clearPendingChannel()
+		return
+	}
+	c.popSubmittedChannels()
+}
+
+// inFlightIndex returns the index of the in-flight channel the pending tx belongs to, -1 if none.
+func (c *channelManager) inFlightIndex(id txID) int {
+	for i, ch := range c.inFlight {
+		if _, ok := ch.pendingTransactions[id]; ok {
+			return i
+		}
+	}
+	return -1
+}
+
+// pendingInFlightChannel returns the pending channel with its txs as an in-flight channel.
+func (c *channelManager) pendingInFlightChannel() *inFlightChannel {
+	return &inFlightChannel{
+		builder:               c.pendingChannel,
+		pendingTransactions:   c.pendingTransactions,
+		confirmedTransactions: c.confirmedTransactions,
+		frameHashes:           c.frameHashes,
+	}
+}
+
+// rotatePendingChannel moves the pending channel to the in-flight channels once it is full and
+// all its frames are handed out, so that the next channel is built while its txs are confirmed.
+func (c *channelManager) rotatePendingChannel() {
+	if c.pendingChannel == nil || !c.pendingChannel.IsFull() || c.pendingChannel.HasFrame() || len(c.pendingTransactions) == 0 {
+		return
+	}
+	c.log.Debug("Pipelining the next channel", "id", c.pendingChannel.ID(), "in_flight_channels", len(c.inFlight)+1)
+	c.inFlight = append(c.inFlight, c.pendingInFlightChannel())
+	c.clearPendingChannel()
+}
+
+// popSubmittedChannels records the oldest in-flight channels which are fully submitted, in order,
+// so that the submitted block never skips a channel still in flight.
+func (c *channelManager) popSubmittedChannels() {
+	for len(c.inFlight) > 0 && c.inFlight[0].isFullySubmitted() {
+		ch := c.inFlight[0]
+		c.metr.RecordChannelFullySubmitted(ch.builder.ID(), len(ch.builder.Blocks()), ch.builder.OutputBytes(), ch.builder.SinceFull())
+		c.log.Info("Channel is fully submitted", "id", ch.builder.ID())
+		if blocks := ch.builder.Blocks(); len(blocks) > 0 {
+			c.submitted = eth.ToBlockID(blocks[len(blocks)-1])
+		}
+		c.inFlight = c.inFlight[1:]
 	}
 }
 
@@ -128,6 +267,7 @@ func (c *channelManager) clearPendingChannel() {
 	c.pendingChannel = nil
 	c.pendingTransactions = make(map[txID]txData)
 	c.confirmedTransactions = make(map[txID]eth.BlockID)
+	c.frameHashes = make(map[txID]common.Hash)
 }
 
 // pendingChannelIsTimedOut returns true if submitted channel has timed out.
@@ -137,14 +277,20 @@ func (c *channelManager) pendingChannelIsTimedOut() bool {
 	if c.pendingChannel == nil {
 		return false // no channel to be timed out
 	}
+	return isTimedOut(c.confirmedTransactions, c.cfg.ChannelTimeout)
+}
+
+// isTimedOut returns true if the difference in L1 inclusion blocks between the first & last
+// confirmed txs of a channel is greater than or equal to the channel timeout.
+func isTimedOut(confirmedTransactions map[txID]eth.BlockID, channelTimeout uint64) bool {
 	// No confirmed transactions => not timed out
-	if len(c.confirmedTransactions) == 0 {
+	if len(confirmedTransactions) == 0 {
 		return false
 	}
 	// If there are confirmed transactions, find the first + last confirmed block numbers
 	min := uint64(math.MaxUint64)
 	max := uint64(0)
-	for _, inclusionBlock := range c.confirmedTransactions {
+	for _, inclusionBlock := range confirmedTransactions {
 		if inclusionBlock.Number < min {
 			min = inclusionBlock.Number
 		}
@@ -152,7 +298,7 @@ func (c *channelManager) pendingChannelIsTimedOut() bool {
 			max = inclusionBlock.Number
 		}
 	}
-	return max-min >= c.cfg.ChannelTimeout
+	return max-min >= channelTimeout
 }
 
 // pendingChannelIsFullySubmitted returns true if the channel has been fully submitted.
@@ -163,8 +309,23 @@ func (c *channelManager) pendingChannelIsFullySubmitted() bool {
 	return c.pendingChannel.IsFull() && len(c.pendingTransactions)+c.pendingChannel.NumFrames() == 0
 }
 
-// nextTxData pops off c.datas & handles updating the internal state
+// nextTxData pops off c.datas & handles updating the internal state.
+// The frames of the failed txs of the in-flight channels are handed out first, oldest channel first.
 func (c *channelManager) nextTxData() (txData, error) {
+	for _, ch := range c.inFlight {
+		if !ch.builder.HasFrame() {
+			continue
+		}
+		frame := ch.builder.NextFrame()
+		txdata := txData{frame}
+		id := txdata.ID()
+
+		c.log.Trace("returning next tx data of in-flight channel", "id", id)
+		ch.pendingTransactions[id] = txdata
+		c.persist()
+		return txdata, nil
+	}
+
 	if c.pendingChannel == nil || !c.pendingChannel.HasFrame() {
 		c.log.Trace("no next tx data")
 		return txData{}, io.EOF // TODO: not enough data error instead
@@ -176,23 +337,201 @@ func (c *channelManager) nextTxData() (txData, error) {
 
 	c.log.Trace("returning next tx data", "id", id)
 	c.pendingTransactions[id] = txdata
+	c.frameHashes[id] = crypto.Keccak256Hash(frame.data)
+	c.persist()
 	return txdata, nil
 }
 
+// hasFrame returns true if a frame of the pending or in-flight channels is ready to be handed out.
+func (c *channelManager) hasFrame() bool {
+	for _, ch := range c.inFlight {
+		if ch.builder.HasFrame() {
+			return true
+		}
+	}
+	return c.pendingChannel != nil && c.pendingChannel.HasFrame()
+}
+
+// persist records the submission state in the store, if any. It must be called with the lock held.
+// A failure is only logged, since the submissions can go on without the store.
+func (c *channelManager) persist() {
+	if c.store == nil {
+		return
+	}
+	state := &storedState{Submitted: c.submitted}
+	for _, ch := range c.inFlight {
+		state.Channels = append(state.Channels, storeChannel(ch))
+	}
+	if c.pendingChannel != nil && len(c.frameHashes) > 0 {
+		state.Channels = append(state.Channels, storeChannel(c.pendingInFlightChannel()))
+	}
+	if err := c.store.Save(state); err != nil {
+		c.log.Error("Failed to persist the channel state", "err", err)
+	}
+}
+
+func storeChannel(ch *inFlightChannel) *storedChannel {
+	stored := &storedChannel{
+		ID:               ch.builder.ID(),
+		Full:             ch.builder.IsFull(),
+		Timeout:          ch.builder.timeout,
+		TargetFrameSize:  ch.builder.cfg.TargetFrameSize,
+		ApproxComprRatio: ch.builder.cfg.ApproxComprRatio,
+	}
+	for _, block := range ch.builder.Blocks() {
+		stored.Blocks = append(stored.Blocks, eth.ToBlockID(block))
+	}
+	for id, hash := range ch.frameHashes {
+		frame := storedFrame{Number: id.frameNumber, Hash: hash}
+		if inclusionBlock, ok := ch.confirmedTransactions[id]; ok {
+			frame.Inclusion = &inclusionBlock
+		}
+		stored.Frames = append(stored.Frames, frame)
+	}
+	sort.Slice(stored.Frames, func(i, j int) bool {
+		return stored.Frames[i].Number < stored.Frames[j].Number
+	})
+	return stored
+}
+
+// Restore restores the submission state recorded before a restart, given the L2 blocks of its
+// channels, in order. Each channel is rebuilt from its blocks, and checked against the hashes
+// of its frames handed out for submission, so that the confirmed frames are not submitted again.
+// The frames in flight before the restart are submitted again, since their txs may not be mined.
+func (c *channelManager) Restore(state *storedState, blocks []*types.Block) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// A state which fails to be restored is dropped, so that the blocks are loaded from the safe head.
+	defer c.persist()
+
+	var channels []*inFlightChannel
+	for _, stored := range state.Channels {
+		if len(blocks) < len(stored.Blocks) {
+			return fmt.Errorf("got %d blocks for the %d blocks of the channel %s", len(blocks), len(stored.Blocks), stored.ID)
+		}
+		ch, err := c.restoreChannel(stored, blocks[:len(stored.Blocks)])
+		if err != nil {
+			return fmt.Errorf("restoring channel %s: %w", stored.ID, err)
+		}
+		channels = append(channels, ch)
+		blocks = blocks[len(stored.Blocks):]
+	}
+	if len(blocks) > 0 {
+		return fmt.Errorf("got %d blocks more than the blocks of the channels", len(blocks))
+	}
+
+	c.submitted = state.Submitted
+	c.tip = state.Cursor().Hash
+	c.inFlight = channels
+	c.popSubmittedChannels()
+	// The last channel goes on as the pending channel, unless it is fully submitted.
+	if n := len(c.inFlight); n > 0 && !c.inFlight[n-1].isFullySubmitted() {
+		ch := c.inFlight[n-1]
+		c.inFlight = c.inFlight[:n-1]
+		c.pendingChannel = ch.builder
+		c.pendingTransactions = ch.pendingTransactions
+		c.confirmedTransactions = ch.confirmedTransactions
+		c.frameHashes = ch.frameHashes
+	}
+	for _, ch := range channels {
+		c.log.Info("Restored channel",
+			"id", ch.builder.ID(),
+			"blocks", len(ch.builder.Blocks()),
+			"confirmed_frames", len(ch.confirmedTransactions),
+			"frames_pending", ch.builder.NumFrames(),
+			"full", ch.builder.IsFull())
+	}
+	return nil
+}
+
+// restoreChannel rebuilds the recorded channel from its blocks, with the frames which are not
+// confirmed left to be handed out.
+func (c *channelManager) restoreChannel(stored *storedChannel, blocks []*types.Block) (*inFlightChannel, error) {
+	cfg := c.cfg
+	if stored.TargetFrameSize != 0 {
+		cfg.TargetFrameSize = stored.TargetFrameSize
+	}
+	if stored.ApproxComprRatio != 0 {
+		cfg.ApproxComprRatio = stored.ApproxComprRatio
+	}
+	cb, err := newChannelBuilderWithID(cfg, stored.ID)
+	if err != nil {
+		return nil, fmt.Errorf("creating channel: %w", err)
+	}
+	for i, block := range blocks {
+		if block.Hash() != stored.Blocks[i].Hash {
+			return nil, fmt.Errorf("block %d does not match the block %s of the channel", block.NumberU64(), stored.Blocks[i])
+		}
+		if _, err := cb.AddBlock(block); err != nil {
+			return nil, fmt.Errorf("adding block[%d] to channel builder: %w", i, err)
+		}
+	}
+	if stored.Timeout != 0 {
+		cb.updateTimeout(stored.Timeout, ErrChannelTimeoutClose)
+	}
+	if stored.Full {
+		cb.Close()
+	}
+	if err := cb.OutputFrames(); err != nil {
+		return nil, err
+	}
+
+	handedOut := make(map[uint16]storedFrame)
+	for _, frame := range stored.Frames {
+		handedOut[frame.Number] = frame
+	}
+	frames := cb.frames
+	cb.frames = nil
+	ch := &inFlightChannel{
+		builder:               cb,
+		pendingTransactions:   make(map[txID]txData),
+		confirmedTransactions: make(map[txID]eth.BlockID),
+		frameHashes:           make(map[txID]common.Hash),
+	}
+	for _, frame := range frames {
+		recorded, ok := handedOut[frame.id.frameNumber]
+		if !ok {
+			cb.frames = append(cb.frames, frame)
+			continue
+		}
+		delete(handedOut, frame.id.frameNumber)
+		if crypto.Keccak256Hash(frame.data) != recorded.Hash {
+			return nil, fmt.Errorf("rebuilt frame %d does not match the submitted one", frame.id.frameNumber)
+		}
+		ch.frameHashes[frame.id] = recorded.Hash
+		if recorded.Inclusion != nil {
+			ch.confirmedTransactions[frame.id] = *recorded.Inclusion
+			continue
+		}
+		cb.frames = append(cb.frames, frame)
+	}
+	if len(handedOut) > 0 {
+		return nil, fmt.Errorf("%d submitted frames are missing from the rebuilt channel", len(handedOut))
+	}
+	return ch, nil
+}
+
 // TxData returns the next tx data that should be submitted to L1.
 //
 // It currently only uses one frame per transaction. If the pending channel is
-// full, it only returns the remaining frames of this channel until it got
-// successfully fully sent to L1. It returns io.EOF if there's no pending frame.
+// full, it returns the remaining frames of this channel, and then starts the
+// next channel while the txs of this one are in flight. It returns io.EOF if
+// there's no pending frame.
 func (c *channelManager) TxData(l1Head eth.BlockID) (txData, error) {
-	dataPending := c.pendingChannel != nil && c.pendingChannel.HasFrame()
-	c.log.Debug("Requested tx data", "l1Head", l1Head, "data_pending", dataPending, "blocks_pending", len(c.blocks))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dataPending := c.hasFrame()
+	c.log.Debug("Requested tx data", "l1Head", l1Head, "data_pending", dataPending, "blocks_pending", len(c.blocks), "in_flight_channels", len(c.inFlight))
 
 	// Short circuit if there is a pending frame or the channel manager is closed.
 	if dataPending || c.closed {
 		return c.nextTxData()
 	}
 
+	c.rotatePendingChannel()
+
 	// No pending frame, so we have to add new blocks to the channel
 
 	// If we have no saved blocks, we will not be able to create valid frames
@@ -220,12 +559,181 @@ func (c *channelManager) TxData(l1Head eth.BlockID) (txData, error) {
 	return c.nextTxData()
 }
 
+// Urgent returns whether the pending data must be submitted now, even if the L1 base fee is high.
+// It is urgent if some frames of the pending channel are already submitted, so that the channel
+// does not time out, if some channels are in flight, if the pending channel was flushed, or if the end of the proposer
+// window of the oldest pending block, less the sub safety margin, is within margin L1 blocks of the L1 head.
+func (c *channelManager) Urgent(l1Head eth.BlockID, margin uint64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed || len(c.inFlight) > 0 || len(c.pendingTransactions)+len(c.confirmedTransactions) > 0 {
+		return true
+	}
+	if c.pendingChannel != nil && errors.Is(c.pendingChannel.FullErr(), ErrTerminated) {
+		return true
+	}
+
+	var oldest *types.Block
+	if c.pendingChannel != nil && len(c.pendingChannel.Blocks()) > 0 {
+		oldest = c.pendingChannel.Blocks()[0]
+	} else if len(c.blocks) > 0 {
+		oldest = c.blocks[0]
+	} else {
+		return false
+	}
+	if len(oldest.Transactions()) == 0 {
+		return true
+	}
+	l1info, err := derive.L1InfoDepositTxData(oldest.Transactions()[0].Data())
+	if err != nil {
+		c.log.Warn("Failed to read the L1 info of the oldest pending block", "block", oldest.Hash(), "err", err)
+		return true
+	}
+	deadline := l1info.Number + c.cfg.ProposerWindowSize - c.cfg.SubSafetyMargin
+	return l1Head.Number+margin >= deadline
+}
+
+// PendingBlocks returns the number of L2 blocks that are not fully submitted yet.
+func (c *channelManager) PendingBlocks() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := len(c.blocks)
+	for _, ch := range c.inFlight {
+		n += len(ch.builder.Blocks())
+	}
+	if c.pendingChannel != nil {
+		n += len(c.pendingChannel.Blocks())
+	}
+	return n
+}
+
+// PendingChannel returns the ID of the pending channel, with the number of its txs in flight and
+// confirmed. ok is false if there is no pending channel.
+func (c *channelManager) PendingChannel() (id derive.ChannelID, pendingTxs int, confirmedTxs int, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.pendingChannel == nil {
+		return derive.ChannelID{}, 0, 0, false
+	}
+	return c.pendingChannel.ID(), len(c.pendingTransactions), len(c.confirmedTransactions), true
+}
+
+// channelStatus is the submission status of an in-flight channel.
+type channelStatus struct {
+	id           derive.ChannelID
+	pendingTxs   int
+	confirmedTxs int
+}
+
+// InFlightChannels returns the status of the full channels built before the pending channel whose
+// txs are not all confirmed, oldest first.
+func (c *channelManager) InFlightChannels() []channelStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	statuses := make([]channelStatus, 0, len(c.inFlight))
+	for _, ch := range c.inFlight {
+		statuses = append(statuses, channelStatus{
+			id:           ch.builder.ID(),
+			pendingTxs:   len(ch.pendingTransactions),
+			confirmedTxs: len(ch.confirmedTransactions),
+		})
+	}
+	return statuses
+}
+
+// Flush adds the pending blocks to the pending channel and closes it, so that all its frames are
+// output at once, without waiting for the channel to be full or to time out. Unlike Close, new
+// channels are still created afterwards. The blocks which do not fit in the channel are left for
+// the next one.
+func (c *channelManager) Flush(l1Head eth.BlockID) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rotatePendingChannel()
+	if c.closed || (c.pendingChannel != nil && c.pendingChannel.IsFull()) {
+		return nil
+	}
+	if c.pendingChannel == nil && len(c.blocks) == 0 {
+		return nil
+	}
+
+	if err := c.ensurePendingChannel(l1Head); err != nil {
+		return err
+	}
+	if err := c.processBlocks(); err != nil {
+		return err
+	}
+	c.log.Info("Flushing channel", "id", c.pendingChannel.ID(), "blocks_pending", len(c.blocks))
+	c.pendingChannel.Close()
+
+	return c.outputFrames()
+}
+
+// Drain closes the channel manager like Close, but keeps the pending channel even if none of its
+// frames were submitted yet. The pending blocks are added to it as long as it is not full, and it
+// is closed, so that all its remaining frames are output to be submitted before shutting down.
+// The blocks left out are submitted again after the restart.
+func (c *channelManager) Drain(l1Head eth.BlockID) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+
+	c.rotatePendingChannel()
+	if len(c.blocks) > 0 {
+		if err := c.ensurePendingChannel(l1Head); err != nil {
+			return err
+		}
+		if err := c.processBlocks(); err != nil {
+			return err
+		}
+		c.registerL1Block(l1Head)
+	}
+
+	c.closed = true
+	c.draining = true
+
+	if c.pendingChannel == nil {
+		return nil
+	}
+
+	c.pendingChannel.Close()
+
+	return c.outputFrames()
+}
+
+// Drained returns whether all the frames output so far were submitted and confirmed.
+func (c *channelManager) Drained() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.hasFrame() || len(c.pendingTransactions) > 0 {
+		return false
+	}
+	for _, ch := range c.inFlight {
+		if len(ch.pendingTransactions) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
 func (c *channelManager) ensurePendingChannel(l1Head eth.BlockID) error {
 	if c.pendingChannel != nil {
 		return nil
 	}
 
-	cb, err := newChannelBuilder(c.cfg)
+	cfg := c.cfg
+	if c.tuner != nil {
+		cfg = c.tuner.ChannelConfig(cfg)
+	}
+	cb, err := newChannelBuilder(cfg)
 	if err != nil {
 		return fmt.Errorf("creating new channel: %w", err)
 	}
@@ -305,12 +813,16 @@ func (c *channelManager) outputFrames() error {
 	}
 
 	inBytes, outBytes := c.pendingChannel.InputBytes(), c.pendingChannel.OutputBytes()
+	if c.tuner != nil {
+		c.tuner.ChannelClosed(inBytes, outBytes, c.pendingChannel.FullErr())
+	}
 	c.metr.RecordChannelClosed(
 		c.pendingChannel.ID(),
 		len(c.blocks),
 		c.pendingChannel.NumFrames(),
 		inBytes,
 		outBytes,
+		c.pendingChannel.OpenDuration(),
 		c.pendingChannel.FullErr(),
 	)
 
@@ -334,6 +846,9 @@ func (c *channelManager) outputFrames() error {
 // if the block does not extend the last block loaded into the state. If no
 // blocks were added yet, the parent hash check is skipped.
 func (c *channelManager) AddL2Block(block *types.Block) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if c.tip != (common.Hash{}) && c.tip != block.ParentHash() {
 		return ErrReorg
 	}
@@ -358,6 +873,9 @@ func l2BlockRefFromBlockAndL1Info(block *types.Block, l1info derive.L1BlockInfo)
 // and prevents the creation of any new channels.
 // Any outputted frames still need to be published.
 func (c *channelManager) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if c.closed {
 		return nil
 	}
@@ -4,6 +4,7 @@ import (
 	"io"
 	"math/big"
 	"math/rand"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -506,3 +507,340 @@ func TestChannelManagerCloseAllTxsFailed(t *testing.T) {
 	_, err = m.TxData(eth.BlockID{})
 	require.ErrorIs(err, io.EOF, "Expected closed channel manager to produce no more tx data")
 }
+
+// TestChannelManagerDrain ensures that a drained channel manager outputs all the frames
+// of the pending blocks, even if none were submitted yet, and keeps them when their txs fail.
+func TestChannelManagerDrain(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics,
+		ChannelConfig{
+			TargetNumFrames:  100,
+			TargetFrameSize:  1000,
+			MaxFrameSize:     1000,
+			ApproxComprRatio: 1.0,
+			ChannelTimeout:   1000,
+		})
+	require.True(m.Drained())
+
+	a := newMiniL2Block(10)
+	b := newMiniL2BlockWithNumberParent(10, big.NewInt(1), a.Hash())
+	require.NoError(m.AddL2Block(a))
+	require.NoError(m.AddL2Block(b))
+
+	require.NoError(m.Drain(eth.BlockID{}))
+	require.False(m.Drained())
+	require.Equal(2, m.PendingBlocks())
+
+	txdata, err := m.TxData(eth.BlockID{})
+	require.NoError(err, "Expected drained channel manager to produce the pending blocks frames")
+	m.TxFailed(txdata.ID())
+	require.False(m.Drained())
+
+	var txs []txData
+	for {
+		txdata, err := m.TxData(eth.BlockID{})
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err)
+		txs = append(txs, txdata)
+	}
+	require.NotEmpty(txs, "Expected the failed frame to be handed out again")
+	require.False(m.Drained())
+	for _, txdata := range txs {
+		m.TxConfirmed(txdata.ID(), eth.BlockID{Number: 1})
+	}
+	require.True(m.Drained())
+	require.Zero(m.PendingBlocks())
+
+	// No new channel is created once drained.
+	require.NoError(m.AddL2Block(newMiniL2BlockWithNumberParent(10, big.NewInt(2), b.Hash())))
+	_, err = m.TxData(eth.BlockID{})
+	require.ErrorIs(err, io.EOF)
+}
+
+// TestChannelManagerFlush ensures that a flushed channel outputs all its frames
+// at once, and that new channels are still created afterwards.
+func TestChannelManagerFlush(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics,
+		ChannelConfig{
+			TargetNumFrames:  100,
+			TargetFrameSize:  1000,
+			MaxFrameSize:     1000,
+			ApproxComprRatio: 1.0,
+			ChannelTimeout:   1000,
+		})
+
+	// Nothing to flush.
+	require.NoError(m.Flush(eth.BlockID{}))
+	_, _, _, ok := m.PendingChannel()
+	require.False(ok)
+
+	a := newMiniL2Block(10)
+	b := newMiniL2BlockWithNumberParent(10, big.NewInt(1), a.Hash())
+
+	require.NoError(m.AddL2Block(a))
+	_, err := m.TxData(eth.BlockID{})
+	require.ErrorIs(err, io.EOF, "Expected the channel to wait for more data")
+
+	require.NoError(m.Flush(eth.BlockID{}))
+	require.True(m.Urgent(eth.BlockID{}, 0), "Expected a flushed channel to be urgent")
+	for {
+		txdata, err := m.TxData(eth.BlockID{})
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err)
+		m.TxConfirmed(txdata.ID(), eth.BlockID{})
+	}
+	_, _, _, ok = m.PendingChannel()
+	require.False(ok, "Expected the flushed channel to be fully submitted")
+	require.Equal(0, m.PendingBlocks())
+
+	require.NoError(m.AddL2Block(b))
+	_, err = m.TxData(eth.BlockID{})
+	require.ErrorIs(err, io.EOF, "Expected the new channel to wait for more data")
+	id, pendingTxs, confirmedTxs, ok := m.PendingChannel()
+	require.True(ok, "Expected a new channel after the flush")
+	require.NotEqual(derive.ChannelID{}, id)
+	require.Zero(pendingTxs)
+	require.Zero(confirmedTxs)
+	require.Equal(1, m.PendingBlocks())
+}
+
+// TestChannelManagerRestore ensures that a restarted channel manager resumes
+// the pending channel recorded in its store, without handing out the
+// confirmed frames again.
+func TestChannelManagerRestore(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LvlCrit)
+	cfg := ChannelConfig{
+		TargetNumFrames:  100,
+		TargetFrameSize:  1000,
+		MaxFrameSize:     1000,
+		ApproxComprRatio: 1.0,
+		ChannelTimeout:   1000,
+	}
+	store := newChannelStore(filepath.Join(t.TempDir(), "batcher", "state.json"))
+	m := NewChannelManager(log, metrics.NoopMetrics, cfg)
+	m.store = store
+
+	a := newMiniL2Block(50_000)
+	require.NoError(m.AddL2Block(a))
+	confirmed, err := m.TxData(eth.BlockID{})
+	require.NoError(err)
+	inFlight, err := m.TxData(eth.BlockID{})
+	require.NoError(err)
+	m.TxConfirmed(confirmed.ID(), eth.BlockID{Number: 1})
+
+	stored, err := store.Load()
+	require.NoError(err)
+	require.Len(stored.Channels, 1)
+	require.Equal(eth.ToBlockID(a), stored.Cursor())
+	require.Len(stored.Channels[0].Frames, 2)
+
+	// The restarted channel manager hands out the frame in flight again, but
+	// not the confirmed one.
+	restarted := NewChannelManager(log, metrics.NoopMetrics, cfg)
+	restarted.store = store
+	require.NoError(restarted.Restore(stored, []*types.Block{a}))
+	id, pendingTxs, confirmedTxs, ok := restarted.PendingChannel()
+	require.True(ok)
+	require.Equal(confirmed.ID().chID, id)
+	require.Zero(pendingTxs)
+	require.Equal(1, confirmedTxs)
+	txdata, err := restarted.TxData(eth.BlockID{})
+	require.NoError(err)
+	require.Equal(inFlight, txdata)
+
+	// The next blocks must extend the restored channel.
+	b := newMiniL2BlockWithNumberParent(0, big.NewInt(1), a.Hash())
+	require.NoError(restarted.AddL2Block(b))
+	require.ErrorIs(restarted.AddL2Block(a), ErrReorg)
+}
+
+// TestChannelManagerRestoreMismatch ensures that a recorded channel whose
+// blocks do not match is dropped.
+func TestChannelManagerRestoreMismatch(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LvlCrit)
+	cfg := ChannelConfig{
+		TargetNumFrames:  100,
+		TargetFrameSize:  1000,
+		MaxFrameSize:     1000,
+		ApproxComprRatio: 1.0,
+		ChannelTimeout:   1000,
+	}
+	store := newChannelStore(filepath.Join(t.TempDir(), "state.json"))
+	m := NewChannelManager(log, metrics.NoopMetrics, cfg)
+	m.store = store
+
+	require.NoError(m.AddL2Block(newMiniL2Block(50_000)))
+	_, err := m.TxData(eth.BlockID{})
+	require.NoError(err)
+	stored, err := store.Load()
+	require.NoError(err)
+
+	restarted := NewChannelManager(log, metrics.NoopMetrics, cfg)
+	restarted.store = store
+	require.Error(restarted.Restore(stored, []*types.Block{newMiniL2Block(10)}))
+	_, _, _, ok := restarted.PendingChannel()
+	require.False(ok)
+	stored, err = store.Load()
+	require.NoError(err)
+	require.Empty(stored.Channels, "Expected the mismatching channel to be dropped")
+}
+
+// drainTxData hands out all the tx data ready in the channel manager.
+func drainTxData(t *testing.T, m *channelManager) []txData {
+	var txs []txData
+	for {
+		txdata, err := m.TxData(eth.BlockID{})
+		if err == io.EOF {
+			return txs
+		}
+		require.NoError(t, err)
+		txs = append(txs, txdata)
+	}
+}
+
+// TestChannelManagerPipelining ensures that the next channel is built while the
+// txs of a full channel are in flight, and that the channels are recorded as
+// submitted in order.
+func TestChannelManagerPipelining(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics,
+		ChannelConfig{
+			TargetNumFrames:  100,
+			TargetFrameSize:  1000,
+			MaxFrameSize:     1000,
+			ApproxComprRatio: 1.0,
+			ChannelTimeout:   1000,
+		})
+
+	a := newMiniL2Block(50_000)
+	b := newMiniL2BlockWithNumberParent(0, big.NewInt(1), a.Hash())
+
+	require.NoError(m.AddL2Block(a))
+	require.NoError(m.Flush(eth.BlockID{}))
+	first := drainTxData(t, m)
+	require.Greater(len(first), 1)
+
+	// The next channel is started while the txs of the first one are in flight.
+	require.NoError(m.AddL2Block(b))
+	require.NoError(m.Flush(eth.BlockID{}))
+	inFlight := m.InFlightChannels()
+	require.Len(inFlight, 1)
+	require.Equal(first[0].ID().chID, inFlight[0].id)
+	require.Equal(len(first), inFlight[0].pendingTxs)
+	second := drainTxData(t, m)
+	require.NotEmpty(second)
+	require.NotEqual(first[0].ID().chID, second[0].ID().chID)
+	require.True(m.Urgent(eth.BlockID{}, 0), "Expected in-flight channels to be urgent")
+
+	// The second channel is not recorded as submitted before the first one.
+	for _, txdata := range second {
+		m.TxConfirmed(txdata.ID(), eth.BlockID{Number: 1})
+	}
+	require.Len(m.InFlightChannels(), 2)
+	require.Equal(2, m.PendingBlocks())
+	require.Equal(eth.BlockID{}, m.submitted)
+
+	// The failed txs of an in-flight channel are handed out again.
+	m.TxFailed(first[0].ID())
+	retried := drainTxData(t, m)
+	require.Equal(first[:1], retried)
+
+	for _, txdata := range first {
+		m.TxConfirmed(txdata.ID(), eth.BlockID{Number: 2})
+	}
+	require.Empty(m.InFlightChannels())
+	require.Equal(0, m.PendingBlocks())
+	require.Equal(eth.ToBlockID(b), m.submitted)
+}
+
+// TestChannelManagerInFlightTimeout ensures that the blocks of a timed out
+// in-flight channel are submitted again, along with the blocks of the channels
+// after it.
+func TestChannelManagerInFlightTimeout(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics,
+		ChannelConfig{
+			TargetNumFrames:  100,
+			TargetFrameSize:  1000,
+			MaxFrameSize:     1000,
+			ApproxComprRatio: 1.0,
+			ChannelTimeout:   10,
+		})
+
+	a := newMiniL2Block(50_000)
+	b := newMiniL2BlockWithNumberParent(0, big.NewInt(1), a.Hash())
+
+	require.NoError(m.AddL2Block(a))
+	require.NoError(m.Flush(eth.BlockID{}))
+	first := drainTxData(t, m)
+	require.Greater(len(first), 1)
+	require.NoError(m.AddL2Block(b))
+	require.NoError(m.Flush(eth.BlockID{}))
+	require.NotEmpty(drainTxData(t, m))
+
+	m.TxConfirmed(first[0].ID(), eth.BlockID{Number: 1})
+	m.TxConfirmed(first[1].ID(), eth.BlockID{Number: 11})
+	require.Empty(m.InFlightChannels())
+	_, _, _, ok := m.PendingChannel()
+	require.False(ok)
+	require.Equal([]*types.Block{a, b}, m.blocks)
+}
+
+// TestChannelManagerRestorePipelined ensures that a restarted channel manager
+// resumes all the channels in flight recorded in its store.
+func TestChannelManagerRestorePipelined(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LvlCrit)
+	cfg := ChannelConfig{
+		TargetNumFrames:  100,
+		TargetFrameSize:  1000,
+		MaxFrameSize:     1000,
+		ApproxComprRatio: 1.0,
+		ChannelTimeout:   1000,
+	}
+	store := newChannelStore(filepath.Join(t.TempDir(), "state.json"))
+	m := NewChannelManager(log, metrics.NoopMetrics, cfg)
+	m.store = store
+
+	a := newMiniL2Block(50_000)
+	b := newMiniL2BlockWithNumberParent(0, big.NewInt(1), a.Hash())
+
+	require.NoError(m.AddL2Block(a))
+	require.NoError(m.Flush(eth.BlockID{}))
+	first := drainTxData(t, m)
+	require.Greater(len(first), 1)
+	m.TxConfirmed(first[0].ID(), eth.BlockID{Number: 1})
+	require.NoError(m.AddL2Block(b))
+	require.NoError(m.Flush(eth.BlockID{}))
+	second := drainTxData(t, m)
+
+	stored, err := store.Load()
+	require.NoError(err)
+	require.Len(stored.Channels, 2)
+	require.Equal(eth.ToBlockID(b), stored.Cursor())
+	require.Equal([]eth.BlockID{eth.ToBlockID(a), eth.ToBlockID(b)}, stored.Blocks())
+
+	// The frames in flight are handed out again, oldest channel first.
+	restarted := NewChannelManager(log, metrics.NoopMetrics, cfg)
+	restarted.store = store
+	require.NoError(restarted.Restore(stored, []*types.Block{a, b}))
+	inFlight := restarted.InFlightChannels()
+	require.Len(inFlight, 1)
+	require.Equal(first[0].ID().chID, inFlight[0].id)
+	require.Equal(1, inFlight[0].confirmedTxs)
+	id, _, _, ok := restarted.PendingChannel()
+	require.True(ok)
+	require.Equal(second[0].ID().chID, id)
+	require.Equal(append(first[1:], second...), drainTxData(t, restarted))
+}
@@ -0,0 +1,112 @@
+package batcher
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
+)
+
+// storedState is the submission state recorded by the channelStore.
+type storedState struct {
+	// Submitted is the last L2 block of the last fully submitted channel.
+	Submitted eth.BlockID `json:"submitted"`
+	// Channels are the in-flight channels and the pending channel, oldest first, if some of their
+	// frames were handed out for submission. The other channels are rebuilt from the L2 blocks
+	// after a restart anyway.
+	Channels []*storedChannel `json:"channels,omitempty"`
+}
+
+// Cursor returns the last L2 block whose batch is submitted or in the recorded channels.
+func (s *storedState) Cursor() eth.BlockID {
+	for i := len(s.Channels) - 1; i >= 0; i-- {
+		if blocks := s.Channels[i].Blocks; len(blocks) > 0 {
+			return blocks[len(blocks)-1]
+		}
+	}
+	return s.Submitted
+}
+
+// Blocks returns the L2 blocks of the recorded channels, in order.
+func (s *storedState) Blocks() []eth.BlockID {
+	var blocks []eth.BlockID
+	for _, ch := range s.Channels {
+		blocks = append(blocks, ch.Blocks...)
+	}
+	return blocks
+}
+
+type storedChannel struct {
+	ID derive.ChannelID `json:"id"`
+	// Blocks are the L2 blocks added to the channel, in order. The channel is rebuilt from them.
+	Blocks []eth.BlockID `json:"blocks"`
+	// Full is set once the channel is closed, so that all its frames are output.
+	Full bool `json:"full"`
+	// Timeout is the L1 block number timeout of the channel, 0 if none.
+	Timeout uint64 `json:"timeout"`
+	// TargetFrameSize and ApproxComprRatio are the parameters the channel was built with, which
+	// may differ from the configured ones with the adaptive sizing.
+	TargetFrameSize  uint64  `json:"target_frame_size,omitempty"`
+	ApproxComprRatio float64 `json:"approx_compr_ratio,omitempty"`
+	// Frames are the frames handed out for submission, confirmed or not.
+	Frames []storedFrame `json:"frames"`
+}
+
+type storedFrame struct {
+	Number uint16 `json:"number"`
+	// Hash is the keccak256 hash of the frame data, checked against the rebuilt channel.
+	Hash common.Hash `json:"hash"`
+	// Inclusion is the L1 block the frame got included in, nil if not confirmed.
+	Inclusion *eth.BlockID `json:"inclusion,omitempty"`
+}
+
+// channelStore persists the submission state to a JSON file, so that a restarted batcher resumes
+// the pending channel instead of submitting its blocks again in a new channel.
+type channelStore struct {
+	path string
+}
+
+func newChannelStore(path string) *channelStore {
+	return &channelStore{path: path}
+}
+
+// Load reads the recorded state. A missing file is an empty state.
+func (s *channelStore) Load() (*storedState, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &storedState{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read the channel store: %w", err)
+	}
+	var state storedState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode the channel store %s: %w", s.path, err)
+	}
+	return &state, nil
+}
+
+// Save writes the state to a temporary file and renames it, so that a crash never leaves
+// a partially written store behind.
+func (s *channelStore) Save(state *storedState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode the channel store: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create the directory of the channel store: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write the channel store: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to replace the channel store: %w", err)
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/kroma-network/kroma/components/batcher"
 	"github.com/kroma-network/kroma/components/batcher/flags"
 	klog "github.com/kroma-network/kroma/utils/service/log"
+	"github.com/kroma-network/kroma/utils/signer/client"
 )
 
 var (
@@ -28,6 +29,9 @@ func main() {
 	app.Description = "Service for generating and submitting L2 tx batches to L1."
 
 	app.Action = curryMain(Version)
+	app.Commands = []cli.Command{
+		client.FailoverDrillCommand(flags.EnvVarPrefix),
+	}
 	err := app.Run(os.Args)
 	if err != nil {
 		log.Crit("Application failed", "message", err)
@@ -2,19 +2,28 @@ package batcher
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/urfave/cli"
 
 	"github.com/kroma-network/kroma/components/batcher/flags"
 	"github.com/kroma-network/kroma/components/batcher/metrics"
 	"github.com/kroma-network/kroma/components/batcher/rpc"
 	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
 	"github.com/kroma-network/kroma/components/node/sources"
 	"github.com/kroma-network/kroma/utils"
+	"github.com/kroma-network/kroma/utils/altda"
+	"github.com/kroma-network/kroma/utils/service/clock"
+	khealth "github.com/kroma-network/kroma/utils/service/health"
+	"github.com/kroma-network/kroma/utils/service/leader"
 	klog "github.com/kroma-network/kroma/utils/service/log"
 	kmetrics "github.com/kroma-network/kroma/utils/service/metrics"
 	kpprof "github.com/kroma-network/kroma/utils/service/pprof"
@@ -29,6 +38,10 @@ type Config struct {
 	RollupClient *sources.RollupClient
 	TxManager    txmgr.TxManager
 
+	// MaxPendingTxs is the maximum number of batch txs in flight at once. It must not exceed
+	// the MaxPendingTxs of the TxManager, which allows the concurrent sends.
+	MaxPendingTxs uint64
+
 	NetworkTimeout time.Duration
 	PollInterval   time.Duration
 
@@ -37,6 +50,29 @@ type Config struct {
 
 	// Channel builder parameters
 	Channel ChannelConfig
+
+	// Throttle delays the batch submissions while the L1 base fee is high.
+	Throttle ThrottleConfig
+
+	// Sizing tunes the size of the channels to the L1 base fee and the observed compression ratios.
+	Sizing SizingConfig
+
+	// StatePath is the path of the file recording the submission state, which is restored after a
+	// restart. If empty, the state is not persisted.
+	StatePath string
+
+	// Elector elects the instance submitting the batches among the batcher instances. Nil if the
+	// leader election is disabled, in which case this instance always submits them.
+	Elector *leader.Elector
+
+	// DAClient posts the tx data to the alternative DA layer once it is active. Nil if not configured,
+	// in which case the tx data is always sent to L1.
+	DAClient DAClient
+}
+
+// DAClient posts the data to the alternative DA layer and returns its commitment.
+type DAClient interface {
+	SetInput(ctx context.Context, data []byte) (altda.Commitment, error)
 }
 
 // Check ensures that the [Config] is valid.
@@ -47,11 +83,17 @@ func (c *Config) Check() error {
 	if err := c.Channel.Check(); err != nil {
 		return err
 	}
+	if c.Throttle.Enabled() && c.Throttle.UrgencyMargin+c.Channel.SubSafetyMargin >= c.Channel.ProposerWindowSize {
+		return errors.New("throttle urgency margin plus sub safety margin must be less than the proposer window size")
+	}
+	if c.Sizing.Enabled() && (c.Sizing.MinFrameSize == 0 || c.Sizing.MinFrameSize > c.Sizing.MaxFrameSize) {
+		return errors.New("adaptive sizing min frame size must be positive and at most the max frame size")
+	}
 	return nil
 }
 
 type CLIConfig struct {
-	// L1EthRpc is the HTTP provider URL for L1.
+	// L1EthRpc is the HTTP provider URL for L1, or a comma-separated list of them.
 	L1EthRpc string
 
 	// L2EthRpc is the HTTP provider URL for the L2 execution engine.
@@ -92,11 +134,43 @@ type CLIConfig struct {
 	// compression algorithm.
 	ApproxComprRatio float64
 
+	// CompressionAlgo is the compression algorithm of the channels.
+	CompressionAlgo derive.CompressionAlgo
+
+	// AltDA is the DA server to post the tx data to, once the alternative DA is active.
+	AltDA altda.CLIConfig
+
+	// ThrottleMaxBaseFeeGwei is the L1 base fee in gwei above which the non-urgent batch submissions
+	// are delayed. If 0, the throttling is disabled.
+	ThrottleMaxBaseFeeGwei float64
+
+	// ThrottleUrgencyMargin is the number of L1 blocks before the end of the proposer window of the
+	// oldest pending L2 block from which the batches are submitted whatever the L1 base fee.
+	ThrottleUrgencyMargin uint64
+
+	// SizingTargetCostPerByteGwei is the target L1 base fee cost in gwei per byte of batch tx data
+	// the size of the channels is tuned to. If 0, the adaptive sizing is disabled.
+	SizingTargetCostPerByteGwei float64
+
+	// SizingMinL1TxSize is the minimum target size of a batch tx with the adaptive sizing.
+	SizingMinL1TxSize uint64
+
+	// StatePath is the path of the file recording the pending channel and the last submitted block,
+	// which are restored after a restart. If empty, they are not persisted.
+	StatePath string
+
+	// DrainTimeout is the maximum duration of the submission of the pending channel on shutdown.
+	DrainTimeout time.Duration
+
+	// Leader configures the leader election between the batcher instances.
+	Leader leader.CLIConfig
+
 	TxMgrConfig   txmgr.CLIConfig
 	RPCConfig     rpc.CLIConfig
 	LogConfig     klog.CLIConfig
 	MetricsConfig kmetrics.CLIConfig
 	PprofConfig   kpprof.CLIConfig
+	HealthConfig  khealth.CLIConfig
 }
 
 func (c CLIConfig) Check() error {
@@ -112,9 +186,30 @@ func (c CLIConfig) Check() error {
 	if err := c.PprofConfig.Check(); err != nil {
 		return err
 	}
+	if err := c.HealthConfig.Check(); err != nil {
+		return err
+	}
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
+	if err := c.CompressionAlgo.Check(); err != nil {
+		return err
+	}
+	if err := c.AltDA.Check(); err != nil {
+		return err
+	}
+	if err := c.Leader.Check(); err != nil {
+		return err
+	}
+	if c.ThrottleMaxBaseFeeGwei < 0 {
+		return errors.New("ThrottleMaxBaseFeeGwei must not be negative")
+	}
+	if c.SizingTargetCostPerByteGwei < 0 {
+		return errors.New("SizingTargetCostPerByteGwei must not be negative")
+	}
+	if c.SizingTargetCostPerByteGwei > 0 && (c.SizingMinL1TxSize <= 1 || c.SizingMinL1TxSize > c.MaxL1TxSize) {
+		return errors.New("SizingMinL1TxSize must be more than 1 and at most MaxL1TxSize")
+	}
 	return nil
 }
 
@@ -134,11 +229,24 @@ func NewCLIConfig(ctx *cli.Context) CLIConfig {
 		TargetL1TxSize:     ctx.GlobalUint64(flags.TargetL1TxSizeBytesFlag.Name),
 		TargetNumFrames:    ctx.GlobalInt(flags.TargetNumFramesFlag.Name),
 		ApproxComprRatio:   ctx.GlobalFloat64(flags.ApproxComprRatioFlag.Name),
+		CompressionAlgo:    derive.CompressionAlgo(ctx.GlobalString(flags.CompressionAlgoFlag.Name)),
 		TxMgrConfig:        txmgr.ReadCLIConfig(ctx),
 		RPCConfig:          rpc.ReadCLIConfig(ctx),
 		LogConfig:          klog.ReadCLIConfig(ctx),
 		MetricsConfig:      kmetrics.ReadCLIConfig(ctx),
 		PprofConfig:        kpprof.ReadCLIConfig(ctx),
+		HealthConfig:       khealth.ReadCLIConfig(ctx),
+		AltDA: altda.CLIConfig{
+			ServerURL: ctx.GlobalString(flags.AltDAServerFlag.Name),
+			Timeout:   ctx.GlobalDuration(flags.AltDATimeoutFlag.Name),
+		},
+		ThrottleMaxBaseFeeGwei:      ctx.GlobalFloat64(flags.ThrottleMaxBaseFeeFlag.Name),
+		ThrottleUrgencyMargin:       ctx.GlobalUint64(flags.ThrottleUrgencyMarginFlag.Name),
+		SizingTargetCostPerByteGwei: ctx.GlobalFloat64(flags.SizingTargetCostPerByteFlag.Name),
+		SizingMinL1TxSize:           ctx.GlobalUint64(flags.SizingMinL1TxSizeBytesFlag.Name),
+		StatePath:                   ctx.GlobalString(flags.StatePathFlag.Name),
+		DrainTimeout:                ctx.GlobalDuration(flags.DrainTimeoutFlag.Name),
+		Leader:                      leader.ReadCLIConfig(ctx),
 	}
 }
 
@@ -147,7 +255,8 @@ func NewBatcherConfig(cfg CLIConfig, l log.Logger, m metrics.Metricer) (*Config,
 	ctx := context.Background()
 
 	// Connect to L1 and L2 providers. Perform these last since they are the most expensive.
-	l1Client, err := utils.DialEthClientWithTimeout(ctx, cfg.L1EthRpc)
+	// The tx manager fails over between all the L1 endpoints, while the reads go to the primary one.
+	l1Client, err := utils.DialEthClientWithTimeout(ctx, txmgr.PrimaryL1RPCURL(cfg.L1EthRpc))
 	if err != nil {
 		return nil, err
 	}
@@ -167,6 +276,23 @@ func NewBatcherConfig(cfg CLIConfig, l log.Logger, m metrics.Metricer) (*Config,
 		return nil, fmt.Errorf("querying rollup config: %w", err)
 	}
 
+	var daClient DAClient
+	if cfg.AltDA.Enabled() {
+		if rcfg.AltDATime == nil {
+			return nil, errors.New("a DA server is configured, but the alternative DA is not scheduled")
+		}
+		daClient = altda.NewClient(cfg.AltDA)
+	}
+
+	var elector *leader.Elector
+	if cfg.Leader.Enabled() {
+		elector = leader.NewElector(leader.NewConsulLock(cfg.Leader), cfg.Leader.TTL, clock.SystemClock, l)
+	}
+
+	cfg.TxMgrConfig.ContractLabels = make(map[common.Address]string)
+	for _, inbox := range rcfg.BatchInboxAddresses() {
+		cfg.TxMgrConfig.ContractLabels[inbox] = "batch_inbox"
+	}
 	txManager, err := txmgr.NewSimpleTxManager("batcher", l, m, cfg.TxMgrConfig)
 	if err != nil {
 		return nil, err
@@ -181,16 +307,43 @@ func NewBatcherConfig(cfg CLIConfig, l log.Logger, m metrics.Metricer) (*Config,
 		PollInterval:   cfg.PollInterval,
 		NetworkTimeout: cfg.TxMgrConfig.NetworkTimeout,
 		TxManager:      txManager,
+		MaxPendingTxs:  cfg.TxMgrConfig.MaxPendingTxs,
 		Rollup:         rcfg,
 		Channel: ChannelConfig{
-			ProposerWindowSize: rcfg.ProposerWindowSize,
-			ChannelTimeout:     rcfg.ChannelTimeout,
-			MaxChannelDuration: cfg.MaxChannelDuration,
-			SubSafetyMargin:    cfg.SubSafetyMargin,
-			MaxFrameSize:       cfg.MaxL1TxSize - 1,    // subtract 1 byte for version
-			TargetFrameSize:    cfg.TargetL1TxSize - 1, // subtract 1 byte for version
-			TargetNumFrames:    cfg.TargetNumFrames,
-			ApproxComprRatio:   cfg.ApproxComprRatio,
+			ProposerWindowSize:   rcfg.ProposerWindowSize,
+			ChannelTimeout:       rcfg.ChannelTimeout,
+			MaxChannelDuration:   cfg.MaxChannelDuration,
+			SubSafetyMargin:      cfg.SubSafetyMargin,
+			MaxFrameSize:         cfg.MaxL1TxSize - 1,    // subtract 1 byte for version
+			TargetFrameSize:      cfg.TargetL1TxSize - 1, // subtract 1 byte for version
+			TargetNumFrames:      cfg.TargetNumFrames,
+			ApproxComprRatio:     cfg.ApproxComprRatio,
+			CompressionAlgo:      cfg.CompressionAlgo,
+			CompressionAlgosTime: rcfg.CompressionAlgosTime,
+			SpanBatchTime:        rcfg.SpanBatchTime,
+			GenesisL2Time:        rcfg.Genesis.L2Time,
+			BlockTime:            rcfg.BlockTime,
 		},
+		Throttle: ThrottleConfig{
+			MaxBaseFee:    gweiToWei(cfg.ThrottleMaxBaseFeeGwei),
+			UrgencyMargin: cfg.ThrottleUrgencyMargin,
+		},
+		Sizing: SizingConfig{
+			TargetCostPerByte: gweiToWei(cfg.SizingTargetCostPerByteGwei),
+			MinFrameSize:      cfg.SizingMinL1TxSize - 1, // subtract 1 byte for version
+			MaxFrameSize:      cfg.MaxL1TxSize - 1,
+		},
+		DAClient:  daClient,
+		StatePath: cfg.StatePath,
+		Elector:   elector,
 	}, nil
 }
+
+// gweiToWei converts the fee in gwei to wei. It returns nil for 0.
+func gweiToWei(gwei float64) *big.Int {
+	if gwei == 0 {
+		return nil
+	}
+	wei, _ := new(big.Float).Mul(big.NewFloat(gwei), big.NewFloat(params.GWei)).Int(nil)
+	return wei
+}
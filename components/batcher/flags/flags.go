@@ -1,10 +1,14 @@
 package flags
 
 import (
+	"time"
+
 	"github.com/urfave/cli"
 
 	"github.com/kroma-network/kroma/components/batcher/rpc"
 	kservice "github.com/kroma-network/kroma/utils/service"
+	khealth "github.com/kroma-network/kroma/utils/service/health"
+	"github.com/kroma-network/kroma/utils/service/leader"
 	klog "github.com/kroma-network/kroma/utils/service/log"
 	kmetrics "github.com/kroma-network/kroma/utils/service/metrics"
 	kpprof "github.com/kroma-network/kroma/utils/service/pprof"
@@ -12,28 +16,28 @@ import (
 	"github.com/kroma-network/kroma/utils/service/txmgr"
 )
 
-const envVarPrefix = "BATCHER"
+const EnvVarPrefix = "BATCHER"
 
 var (
 	// Required flags
 
 	L1EthRpcFlag = cli.StringFlag{
 		Name:     "l1-eth-rpc",
-		Usage:    "HTTP provider URL for L1",
+		Usage:    "HTTP provider URL for L1. A comma-separated list of URLs makes the tx manager fail over between them, with the first one being the primary",
 		Required: true,
-		EnvVar:   kservice.PrefixEnvVar(envVarPrefix, "L1_ETH_RPC"),
+		EnvVar:   kservice.PrefixEnvVar(EnvVarPrefix, "L1_ETH_RPC"),
 	}
 	L2EthRpcFlag = cli.StringFlag{
 		Name:     "l2-eth-rpc",
 		Usage:    "HTTP provider URL for L2 execution engine",
 		Required: true,
-		EnvVar:   kservice.PrefixEnvVar(envVarPrefix, "L2_ETH_RPC"),
+		EnvVar:   kservice.PrefixEnvVar(EnvVarPrefix, "L2_ETH_RPC"),
 	}
 	RollupRpcFlag = cli.StringFlag{
 		Name:     "rollup-rpc",
 		Usage:    "HTTP provider URL for Rollup node",
 		Required: true,
-		EnvVar:   kservice.PrefixEnvVar(envVarPrefix, "ROLLUP_RPC"),
+		EnvVar:   kservice.PrefixEnvVar(EnvVarPrefix, "ROLLUP_RPC"),
 	}
 	SubSafetyMarginFlag = cli.Uint64Flag{
 		Name: "sub-safety-margin",
@@ -41,14 +45,14 @@ var (
 			"from a channel's timeout and proposing window, to guarantee safe inclusion " +
 			"of a channel on L1.",
 		Required: true,
-		EnvVar:   kservice.PrefixEnvVar(envVarPrefix, "SUB_SAFETY_MARGIN"),
+		EnvVar:   kservice.PrefixEnvVar(EnvVarPrefix, "SUB_SAFETY_MARGIN"),
 	}
 	PollIntervalFlag = cli.DurationFlag{
 		Name: "poll-interval",
 		Usage: "Delay between querying L2 for more transactions and " +
 			"creating a new batch",
 		Required: true,
-		EnvVar:   kservice.PrefixEnvVar(envVarPrefix, "POLL_INTERVAL"),
+		EnvVar:   kservice.PrefixEnvVar(EnvVarPrefix, "POLL_INTERVAL"),
 	}
 
 	// Optional flags
@@ -57,31 +61,81 @@ var (
 		Name:   "max-channel-duration",
 		Usage:  "The maximum duration of L1-blocks to keep a channel open. 0 to disable.",
 		Value:  0,
-		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "MAX_CHANNEL_DURATION"),
+		EnvVar: kservice.PrefixEnvVar(EnvVarPrefix, "MAX_CHANNEL_DURATION"),
 	}
 	MaxL1TxSizeBytesFlag = cli.Uint64Flag{
 		Name:   "max-l1-tx-size-bytes",
 		Usage:  "The maximum size of a batch tx submitted to L1.",
 		Value:  120_000,
-		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "MAX_L1_TX_SIZE_BYTES"),
+		EnvVar: kservice.PrefixEnvVar(EnvVarPrefix, "MAX_L1_TX_SIZE_BYTES"),
 	}
 	TargetL1TxSizeBytesFlag = cli.Uint64Flag{
 		Name:   "target-l1-tx-size-bytes",
 		Usage:  "The target size of a batch tx submitted to L1.",
 		Value:  100_000,
-		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "TARGET_L1_TX_SIZE_BYTES"),
+		EnvVar: kservice.PrefixEnvVar(EnvVarPrefix, "TARGET_L1_TX_SIZE_BYTES"),
 	}
 	TargetNumFramesFlag = cli.IntFlag{
 		Name:   "target-num-frames",
 		Usage:  "The target number of frames to create per channel",
 		Value:  1,
-		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "TARGET_NUM_FRAMES"),
+		EnvVar: kservice.PrefixEnvVar(EnvVarPrefix, "TARGET_NUM_FRAMES"),
 	}
 	ApproxComprRatioFlag = cli.Float64Flag{
 		Name:   "approx-compr-ratio",
 		Usage:  "The approximate compression ratio (<= 1.0)",
 		Value:  1.0,
-		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "APPROX_COMPR_RATIO"),
+		EnvVar: kservice.PrefixEnvVar(EnvVarPrefix, "APPROX_COMPR_RATIO"),
+	}
+	CompressionAlgoFlag = cli.StringFlag{
+		Name:   "compression-algo",
+		Usage:  "The compression algorithm of the channels: zlib, brotli or zstd",
+		Value:  "zlib",
+		EnvVar: kservice.PrefixEnvVar(EnvVarPrefix, "COMPRESSION_ALGO"),
+	}
+	AltDAServerFlag = cli.StringFlag{
+		Name:   "alt-da.server",
+		Usage:  "HTTP URL of the DA server to post the tx data to, once the alternative DA is active. Only the commitments are sent to L1 then",
+		EnvVar: kservice.PrefixEnvVar(EnvVarPrefix, "ALT_DA_SERVER"),
+	}
+	AltDATimeoutFlag = cli.DurationFlag{
+		Name:   "alt-da.timeout",
+		Usage:  "Timeout of the requests to the DA server",
+		Value:  30 * time.Second,
+		EnvVar: kservice.PrefixEnvVar(EnvVarPrefix, "ALT_DA_TIMEOUT"),
+	}
+	ThrottleMaxBaseFeeFlag = cli.Float64Flag{
+		Name:   "throttle.max-base-fee",
+		Usage:  "L1 base fee in gwei above which the non-urgent batch submissions are delayed until it drops. If 0 it is disabled",
+		EnvVar: kservice.PrefixEnvVar(EnvVarPrefix, "THROTTLE_MAX_BASE_FEE"),
+	}
+	ThrottleUrgencyMarginFlag = cli.Uint64Flag{
+		Name:   "throttle.urgency-margin",
+		Usage:  "Number of L1 blocks before the end of the proposer window of the oldest pending L2 block, less the sub safety margin, from which the batches are submitted whatever the L1 base fee",
+		Value:  20,
+		EnvVar: kservice.PrefixEnvVar(EnvVarPrefix, "THROTTLE_URGENCY_MARGIN"),
+	}
+	SizingTargetCostPerByteFlag = cli.Float64Flag{
+		Name:   "sizing.target-cost-per-byte",
+		Usage:  "Target L1 base fee cost in gwei per byte of batch tx data. If set, the target tx size and the approximate compression ratio are tuned to the L1 base fee and the observed compression ratios instead of being fixed. If 0 it is disabled",
+		EnvVar: kservice.PrefixEnvVar(EnvVarPrefix, "SIZING_TARGET_COST_PER_BYTE"),
+	}
+	SizingMinL1TxSizeBytesFlag = cli.Uint64Flag{
+		Name:   "sizing.min-l1-tx-size-bytes",
+		Usage:  "The minimum target size of a batch tx submitted to L1 with the adaptive sizing. The max-l1-tx-size-bytes is the maximum",
+		Value:  10_000,
+		EnvVar: kservice.PrefixEnvVar(EnvVarPrefix, "SIZING_MIN_L1_TX_SIZE_BYTES"),
+	}
+	StatePathFlag = cli.StringFlag{
+		Name:   "state-path",
+		Usage:  "Path of the file recording the pending channel and the last submitted block, so that a restart does not submit them again. If empty, they are not persisted",
+		EnvVar: kservice.PrefixEnvVar(EnvVarPrefix, "STATE_PATH"),
+	}
+	DrainTimeoutFlag = cli.DurationFlag{
+		Name:   "drain-timeout",
+		Usage:  "Maximum duration of the submission of the pending channel on shutdown. The frames left unsubmitted are submitted again after the restart",
+		Value:  2 * time.Minute,
+		EnvVar: kservice.PrefixEnvVar(EnvVarPrefix, "DRAIN_TIMEOUT"),
 	}
 )
 
@@ -99,16 +153,27 @@ var optionalFlags = []cli.Flag{
 	TargetL1TxSizeBytesFlag,
 	TargetNumFramesFlag,
 	ApproxComprRatioFlag,
+	CompressionAlgoFlag,
+	AltDAServerFlag,
+	AltDATimeoutFlag,
+	ThrottleMaxBaseFeeFlag,
+	ThrottleUrgencyMarginFlag,
+	SizingTargetCostPerByteFlag,
+	SizingMinL1TxSizeBytesFlag,
+	StatePathFlag,
+	DrainTimeoutFlag,
 }
 
 func init() {
-	requiredFlags = append(requiredFlags, krpc.CLIFlags(envVarPrefix)...)
+	requiredFlags = append(requiredFlags, krpc.CLIFlags(EnvVarPrefix)...)
 
-	optionalFlags = append(optionalFlags, klog.CLIFlags(envVarPrefix)...)
-	optionalFlags = append(optionalFlags, kmetrics.CLIFlags(envVarPrefix)...)
-	optionalFlags = append(optionalFlags, kpprof.CLIFlags(envVarPrefix)...)
-	optionalFlags = append(optionalFlags, rpc.CLIFlags(envVarPrefix)...)
-	optionalFlags = append(optionalFlags, txmgr.CLIFlags(envVarPrefix)...)
+	optionalFlags = append(optionalFlags, klog.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, kmetrics.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, kpprof.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, khealth.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, rpc.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, txmgr.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, leader.CLIFlags(EnvVarPrefix)...)
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
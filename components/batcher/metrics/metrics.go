@@ -2,10 +2,13 @@ package metrics
 
 import (
 	"context"
+	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/kroma-network/kroma/components/node/eth"
@@ -30,14 +33,21 @@ type Metricer interface {
 	RecordL2BlocksLoaded(l2ref eth.L2BlockRef)
 	RecordChannelOpened(id derive.ChannelID, numPendingBlocks int)
 	RecordL2BlocksAdded(l2ref eth.L2BlockRef, numBlocksAdded, numPendingBlocks, inputBytes, outputComprBytes int)
-	RecordChannelClosed(id derive.ChannelID, numPendingBlocks int, numFrames int, inputBytes int, outputComprBytes int, reason error)
-	RecordChannelFullySubmitted(id derive.ChannelID)
+	RecordChannelClosed(id derive.ChannelID, numPendingBlocks int, numFrames int, inputBytes int, outputComprBytes int, openDuration time.Duration, reason error)
+	RecordChannelFullySubmitted(id derive.ChannelID, numBlocks int, outputBytes int, submitDuration time.Duration)
 	RecordChannelTimedOut(id derive.ChannelID)
 
 	RecordBatchTxSubmitted()
 	RecordBatchTxSuccess()
 	RecordBatchTxFailed()
 
+	RecordThrottle(throttled bool, backlogBlocks int)
+	RecordThrottleSavings(savedFee *big.Int)
+
+	RecordChannelSizing(targetFrameSize uint64, approxComprRatio float64)
+
+	RecordSafeHeadDistance(l1Head eth.L1BlockRef, safe eth.L2BlockRef, unsafe eth.L2BlockRef)
+
 	Document() []kmetrics.DocumentedMetric
 }
 
@@ -65,8 +75,22 @@ type Metrics struct {
 	ChannelNumFrames       prometheus.Gauge
 	ChannelComprRatio      prometheus.Histogram
 	ChannelComprRatioValue prometheus.Gauge
+	ChannelFrames          prometheus.Histogram
+	ChannelOpenDuration    prometheus.Histogram
+	ChannelSubmitDuration  prometheus.Histogram
+	SubmittedBytesPerBlock prometheus.Histogram
+
+	SafeHeadDistance         prometheus.Gauge
+	SafeHeadL1OriginDistance prometheus.Gauge
 
 	BatcherTxEvs kmetrics.EventVec
+
+	Throttled          prometheus.Gauge
+	ThrottleBacklog    prometheus.Gauge
+	ThrottleSavingGwei prometheus.Counter
+
+	SizingTargetFrameSize  prometheus.Gauge
+	SizingApproxComprRatio prometheus.Gauge
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -150,8 +174,70 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "channel_compr_ratio_value",
 			Help:      "Compression ratios of closed channel.",
 		}),
+		ChannelFrames: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "channel_frames",
+			Help:      "Number of frames of closed channels.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 10),
+		}),
+		ChannelOpenDuration: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "channel_open_duration_seconds",
+			Help:      "Duration from the opening of channels until they got full and closed.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 14),
+		}),
+		ChannelSubmitDuration: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "channel_submit_duration_seconds",
+			Help:      "Duration from the closing of channels until all their frames were confirmed on L1.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 14),
+		}),
+		SubmittedBytesPerBlock: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "submitted_bytes_per_block",
+			Help:      "Average number of bytes submitted per L2 block of fully submitted channels.",
+			Buckets:   prometheus.ExponentialBuckets(64, 2, 12),
+		}),
+
+		SafeHeadDistance: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "safe_head_distance_blocks",
+			Help:      "Number of L2 blocks between the L2 safe head and the L2 unsafe head.",
+		}),
+		SafeHeadL1OriginDistance: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "safe_head_l1_origin_distance_blocks",
+			Help:      "Number of L1 blocks between the L1 origin of the L2 safe head and the L1 head. The batches of the next L2 block must be included before it exceeds the proposer window size.",
+		}),
 
 		BatcherTxEvs: kmetrics.NewEventVec(factory, ns, "batcher_tx", "BatcherTx", []string{"stage"}),
+
+		Throttled: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "throttled",
+			Help:      "1 if the batch submissions are delayed because of a high L1 base fee.",
+		}),
+		ThrottleBacklog: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "throttle_backlog_blocks",
+			Help:      "Number of L2 blocks pending submission while the batch submissions are delayed.",
+		}),
+		ThrottleSavingGwei: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "throttle_saved_fee_gwei",
+			Help:      "Estimated L1 base fee saved, in gwei, by delaying the batch submissions.",
+		}),
+
+		SizingTargetFrameSize: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "sizing_target_frame_size_bytes",
+			Help:      "Target frame size of the last channel opened, tuned by the adaptive sizing.",
+		}),
+		SizingApproxComprRatio: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "sizing_approx_compr_ratio",
+			Help:      "Approximate compression ratio of the last channel opened, tuned by the adaptive sizing.",
+		}),
 	}
 }
 
@@ -220,10 +306,12 @@ func (m *Metrics) RecordL2BlocksAdded(l2ref eth.L2BlockRef, numBlocksAdded, numP
 	m.ChannelReadyBytes.Set(float64(outputComprBytes))
 }
 
-func (m *Metrics) RecordChannelClosed(id derive.ChannelID, numPendingBlocks int, numFrames int, inputBytes int, outputComprBytes int, reason error) {
+func (m *Metrics) RecordChannelClosed(id derive.ChannelID, numPendingBlocks int, numFrames int, inputBytes int, outputComprBytes int, openDuration time.Duration, reason error) {
 	m.ChannelEvs.Record(StageClosed)
 	m.PendingBlocksCount.WithLabelValues(StageClosed).Set(float64(numPendingBlocks))
 	m.ChannelNumFrames.Set(float64(numFrames))
+	m.ChannelFrames.Observe(float64(numFrames))
+	m.ChannelOpenDuration.Observe(openDuration.Seconds())
 	m.ChannelInputBytes.WithLabelValues(StageClosed).Set(float64(inputBytes))
 	m.ChannelOutputBytes.Set(float64(outputComprBytes))
 
@@ -242,8 +330,14 @@ func ClosedReasonToNum(reason error) int {
 	return 0
 }
 
-func (m *Metrics) RecordChannelFullySubmitted(id derive.ChannelID) {
+// RecordChannelFullySubmitted records a channel whose txs are all confirmed, with the number of its
+// L2 blocks, the number of bytes of its frames and how long ago it got full.
+func (m *Metrics) RecordChannelFullySubmitted(id derive.ChannelID, numBlocks int, outputBytes int, submitDuration time.Duration) {
 	m.ChannelEvs.Record(StageFullySubmitted)
+	m.ChannelSubmitDuration.Observe(submitDuration.Seconds())
+	if numBlocks > 0 {
+		m.SubmittedBytesPerBlock.Observe(float64(outputBytes) / float64(numBlocks))
+	}
 }
 
 func (m *Metrics) RecordChannelTimedOut(id derive.ChannelID) {
@@ -261,3 +355,34 @@ func (m *Metrics) RecordBatchTxSuccess() {
 func (m *Metrics) RecordBatchTxFailed() {
 	m.BatcherTxEvs.Record(TxStageFailed)
 }
+
+// RecordThrottle records whether the batch submissions are delayed, with the number of L2 blocks
+// pending submission.
+func (m *Metrics) RecordThrottle(throttled bool, backlogBlocks int) {
+	if throttled {
+		m.Throttled.Set(1)
+	} else {
+		m.Throttled.Set(0)
+	}
+	m.ThrottleBacklog.Set(float64(backlogBlocks))
+}
+
+// RecordThrottleSavings records the L1 base fee, in wei, saved by a delayed batch tx.
+func (m *Metrics) RecordThrottleSavings(savedFee *big.Int) {
+	gwei, _ := new(big.Float).Quo(new(big.Float).SetInt(savedFee), big.NewFloat(params.GWei)).Float64()
+	m.ThrottleSavingGwei.Add(gwei)
+}
+
+// RecordChannelSizing records the target frame size and approximate compression ratio tuned for
+// a new channel.
+func (m *Metrics) RecordChannelSizing(targetFrameSize uint64, approxComprRatio float64) {
+	m.SizingTargetFrameSize.Set(float64(targetFrameSize))
+	m.SizingApproxComprRatio.Set(approxComprRatio)
+}
+
+// RecordSafeHeadDistance records how far the L2 safe head lags behind the L2 unsafe head, and
+// how far its L1 origin lags behind the L1 head.
+func (m *Metrics) RecordSafeHeadDistance(l1Head eth.L1BlockRef, safe eth.L2BlockRef, unsafe eth.L2BlockRef) {
+	m.SafeHeadDistance.Set(float64(unsafe.Number) - float64(safe.Number))
+	m.SafeHeadL1OriginDistance.Set(float64(l1Head.Number) - float64(safe.L1Origin.Number))
+}
@@ -1,6 +1,9 @@
 package metrics
 
 import (
+	"math/big"
+	"time"
+
 	"github.com/kroma-network/kroma/components/node/eth"
 	"github.com/kroma-network/kroma/components/node/rollup/derive"
 	kmetrics "github.com/kroma-network/kroma/utils/service/metrics"
@@ -24,11 +27,18 @@ func (*noopMetrics) RecordL2BlocksLoaded(eth.L2BlockRef)                    {}
 func (*noopMetrics) RecordChannelOpened(derive.ChannelID, int)              {}
 func (*noopMetrics) RecordL2BlocksAdded(eth.L2BlockRef, int, int, int, int) {}
 
-func (*noopMetrics) RecordChannelClosed(derive.ChannelID, int, int, int, int, error) {}
+func (*noopMetrics) RecordChannelClosed(derive.ChannelID, int, int, int, int, time.Duration, error) {}
 
-func (*noopMetrics) RecordChannelFullySubmitted(derive.ChannelID) {}
-func (*noopMetrics) RecordChannelTimedOut(derive.ChannelID)       {}
+func (*noopMetrics) RecordChannelFullySubmitted(derive.ChannelID, int, int, time.Duration) {}
+func (*noopMetrics) RecordChannelTimedOut(derive.ChannelID)                                {}
 
 func (*noopMetrics) RecordBatchTxSubmitted() {}
 func (*noopMetrics) RecordBatchTxSuccess()   {}
 func (*noopMetrics) RecordBatchTxFailed()    {}
+
+func (*noopMetrics) RecordThrottle(bool, int)       {}
+func (*noopMetrics) RecordThrottleSavings(*big.Int) {}
+
+func (*noopMetrics) RecordChannelSizing(uint64, float64) {}
+
+func (*noopMetrics) RecordSafeHeadDistance(eth.L1BlockRef, eth.L2BlockRef, eth.L2BlockRef) {}
@@ -2,11 +2,42 @@ package rpc
 
 import (
 	"context"
+
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
 )
 
 type batcherClient interface {
 	Start() error
 	Stop(ctx context.Context) error
+	Pause() error
+	Resume() error
+	FlushChannel(ctx context.Context) error
+	Status() BatcherStatus
+}
+
+// BatcherStatus is the status of the batcher returned by admin_batcherStatus.
+type BatcherStatus struct {
+	// Leader is false while a standby instance, with the leader election.
+	Leader bool `json:"leader"`
+	// Paused is true while the submissions are paused by admin_pauseBatcher.
+	Paused bool `json:"paused"`
+	// Throttled is true while the submissions are delayed because of a high L1 base fee.
+	Throttled bool `json:"throttled"`
+	// PendingBlocks is the number of L2 blocks not fully submitted yet.
+	PendingBlocks int `json:"pendingBlocks"`
+	// PendingChannel is the channel being built or submitted, nil if none.
+	PendingChannel *ChannelStatus `json:"pendingChannel"`
+	// InFlightChannels are the full channels built before the pending channel whose txs are
+	// not all confirmed yet, oldest first.
+	InFlightChannels []ChannelStatus `json:"inFlightChannels"`
+}
+
+type ChannelStatus struct {
+	ID derive.ChannelID `json:"id"`
+	// PendingTxs is the number of txs of the channel in flight.
+	PendingTxs int `json:"pendingTxs"`
+	// ConfirmedTxs is the number of txs of the channel confirmed on L1.
+	ConfirmedTxs int `json:"confirmedTxs"`
 }
 
 type adminAPI struct {
@@ -26,3 +57,24 @@ func (a *adminAPI) StartBatcher(_ context.Context) error {
 func (a *adminAPI) StopBatcher(ctx context.Context) error {
 	return a.b.Stop(ctx)
 }
+
+// PauseBatcher halts the batch submissions, without stopping the batcher. The L2 blocks keep being
+// loaded meanwhile, and are submitted once the submissions are resumed.
+func (a *adminAPI) PauseBatcher(_ context.Context) error {
+	return a.b.Pause()
+}
+
+// ResumeBatcher resumes the batch submissions halted by PauseBatcher.
+func (a *adminAPI) ResumeBatcher(_ context.Context) error {
+	return a.b.Resume()
+}
+
+// FlushChannel closes the pending channel with all the pending blocks, so that it is submitted
+// at once, without waiting for it to be full or to time out.
+func (a *adminAPI) FlushChannel(ctx context.Context) error {
+	return a.b.FlushChannel(ctx)
+}
+
+func (a *adminAPI) BatcherStatus(_ context.Context) BatcherStatus {
+	return a.b.Status()
+}
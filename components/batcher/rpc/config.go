@@ -1,6 +1,12 @@
 package rpc
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli"
 
 	kservice "github.com/kroma-network/kroma/utils/service"
@@ -9,6 +15,7 @@ import (
 
 const (
 	EnableAdminFlagName = "rpc.enable-admin"
+	JWTSecretFlagName   = "rpc.jwt-secret"
 )
 
 func CLIFlags(envPrefix string) []cli.Flag {
@@ -18,19 +25,36 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			Usage:  "Enable the admin API (experimental)",
 			EnvVar: kservice.PrefixEnvVar(envPrefix, "RPC_ENABLE_ADMIN"),
 		},
+		cli.StringFlag{
+			Name:   JWTSecretFlagName,
+			Usage:  "Path to the JWT secret, 32 hex-formatted bytes, authenticating the RPC requests. Required with the admin API",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "RPC_JWT_SECRET"),
+		},
 	}
 }
 
 type CLIConfig struct {
 	krpc.CLIConfig
-	EnableAdmin bool
+	EnableAdmin   bool
+	JWTSecretPath string
 }
 
 func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 	return CLIConfig{
-		CLIConfig:   krpc.ReadCLIConfig(ctx),
-		EnableAdmin: ctx.GlobalBool(EnableAdminFlagName),
+		CLIConfig:     krpc.ReadCLIConfig(ctx),
+		EnableAdmin:   ctx.GlobalBool(EnableAdminFlagName),
+		JWTSecretPath: ctx.GlobalString(JWTSecretFlagName),
+	}
+}
+
+func (c CLIConfig) Check() error {
+	if err := c.CLIConfig.Check(); err != nil {
+		return err
+	}
+	if c.EnableAdmin && c.JWTSecretPath == "" {
+		return errors.New("the admin API requires a JWT secret")
 	}
+	return nil
 }
 
 func (c *CLIConfig) ToServiceCLIConfig() krpc.CLIConfig {
@@ -39,3 +63,19 @@ func (c *CLIConfig) ToServiceCLIConfig() krpc.CLIConfig {
 		ListenPort: c.ListenPort,
 	}
 }
+
+// ReadJWTSecret reads the JWT secret authenticating the RPC requests, nil if none is configured.
+func (c *CLIConfig) ReadJWTSecret() ([]byte, error) {
+	if c.JWTSecretPath == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(c.JWTSecretPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the JWT secret: %w", err)
+	}
+	secret := common.FromHex(strings.TrimSpace(string(data)))
+	if len(secret) != 32 {
+		return nil, fmt.Errorf("invalid JWT secret in path %s, not 32 hex-formatted bytes", c.JWTSecretPath)
+	}
+	return secret, nil
+}
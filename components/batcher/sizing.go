@@ -0,0 +1,119 @@
+package batcher
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/kroma-network/kroma/components/batcher/metrics"
+)
+
+// comprRatioWeight is the weight of the last observed compression ratio in its moving average.
+const comprRatioWeight = 0.2
+
+// SizingConfig configures the adaptive sizing of the channels.
+type SizingConfig struct {
+	// TargetCostPerByte is the target L1 base fee cost, in wei, per byte of batch tx data.
+	// The adaptive sizing is disabled if nil or zero.
+	TargetCostPerByte *big.Int
+	// MinFrameSize and MaxFrameSize bound the tuned target frame size.
+	MinFrameSize uint64
+	MaxFrameSize uint64
+}
+
+func (c SizingConfig) Enabled() bool {
+	return c.TargetCostPerByte != nil && c.TargetCostPerByte.Sign() > 0
+}
+
+// sizeTuner tunes the target frame size and the approximate compression ratio of the new channels,
+// rather than using fixed ones.
+//
+// The compression ratio is the moving average of the ones observed on the channels closed because
+// their input target was reached, so that their last frame is close to the target frame size.
+// The target frame size is the smallest one whose cost per byte at the L1 base fee, with the fixed
+// cost of the tx spread over its data, is at most the target cost per byte. The frames get larger
+// when the L1 base fee rises, and smaller, so that the channels are submitted sooner, when it drops.
+type sizeTuner struct {
+	mu   sync.Mutex
+	cfg  SizingConfig
+	log  log.Logger
+	metr metrics.Metricer
+
+	baseFee    *big.Int
+	comprRatio float64
+}
+
+func newSizeTuner(cfg SizingConfig, approxComprRatio float64, l log.Logger, m metrics.Metricer) *sizeTuner {
+	return &sizeTuner{
+		cfg:        cfg,
+		log:        l,
+		metr:       m,
+		comprRatio: approxComprRatio,
+	}
+}
+
+// SetBaseFee records the current L1 base fee.
+func (t *sizeTuner) SetBaseFee(baseFee *big.Int) {
+	if !t.cfg.Enabled() || baseFee == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.baseFee = baseFee
+}
+
+// ChannelClosed records the compression ratio of the closed channel.
+func (t *sizeTuner) ChannelClosed(inputBytes int, outputBytes int, fullErr error) {
+	if !t.cfg.Enabled() || inputBytes == 0 || !errors.Is(fullErr, ErrInputTargetReached) {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ratio := float64(outputBytes) / float64(inputBytes)
+	t.comprRatio = comprRatioWeight*ratio + (1-comprRatioWeight)*t.comprRatio
+}
+
+// ChannelConfig returns the config of a new channel, with the tuned target frame size and
+// approximate compression ratio.
+func (t *sizeTuner) ChannelConfig(cfg ChannelConfig) ChannelConfig {
+	if !t.cfg.Enabled() {
+		return cfg
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.baseFee != nil {
+		cfg.TargetFrameSize = t.frameSize()
+	}
+	cfg.ApproxComprRatio = t.comprRatio
+	t.metr.RecordChannelSizing(cfg.TargetFrameSize, cfg.ApproxComprRatio)
+	t.log.Debug("Tuned channel size", "target_frame_size", cfg.TargetFrameSize, "approx_compr_ratio", cfg.ApproxComprRatio, "base_fee", t.baseFee)
+	return cfg
+}
+
+// frameSize returns the smallest frame size within bounds whose cost per byte, that is
+// baseFee * (TxDataNonZeroGasEIP2028 + TxGas / size), is at most the target cost per byte.
+func (t *sizeTuner) frameSize() uint64 {
+	perByte := new(big.Int).Mul(t.baseFee, big.NewInt(int64(params.TxDataNonZeroGasEIP2028)))
+	margin := new(big.Int).Sub(t.cfg.TargetCostPerByte, perByte)
+	if margin.Sign() <= 0 {
+		return t.cfg.MaxFrameSize
+	}
+	// Round up, so that the cost per byte does not exceed the target.
+	size := new(big.Int).Mul(t.baseFee, big.NewInt(int64(params.TxGas)))
+	size.Add(size, new(big.Int).Sub(margin, common.Big1))
+	size.Div(size, margin)
+	if !size.IsUint64() || size.Uint64() > t.cfg.MaxFrameSize {
+		return t.cfg.MaxFrameSize
+	}
+	if size.Uint64() < t.cfg.MinFrameSize {
+		return t.cfg.MinFrameSize
+	}
+	return size.Uint64()
+}
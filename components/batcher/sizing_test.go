@@ -0,0 +1,113 @@
+package batcher
+
+import (
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/batcher/metrics"
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/testlog"
+)
+
+func gwei(n float64) *big.Int {
+	return gweiToWei(n)
+}
+
+func TestSizeTunerFrameSize(t *testing.T) {
+	log := testlog.Logger(t, log.LvlCrit)
+	tuner := newSizeTuner(SizingConfig{
+		TargetCostPerByte: gwei(200),
+		MinFrameSize:      1_000,
+		MaxFrameSize:      120_000,
+	}, 1.0, log, metrics.NoopMetrics)
+	cfg := ChannelConfig{TargetFrameSize: 100_000, ApproxComprRatio: 1.0}
+
+	// The target frame size is left as is until the L1 base fee is known.
+	require.Equal(t, uint64(100_000), tuner.ChannelConfig(cfg).TargetFrameSize)
+
+	tests := []struct {
+		baseFee *big.Int
+		size    uint64
+	}{
+		// 10 gwei * (16 + 21000 / 5250) = 200 gwei per byte.
+		{baseFee: gwei(10), size: 5_250},
+		// The tx overhead is spread over larger frames when the base fee rises.
+		{baseFee: gwei(12), size: 31_500},
+		{baseFee: gwei(12.4), size: 120_000},
+		// The target can not be reached, whatever the frame size.
+		{baseFee: gwei(13), size: 120_000},
+		{baseFee: gwei(1), size: 1_000},
+	}
+	for _, test := range tests {
+		tuner.SetBaseFee(test.baseFee)
+		size := tuner.ChannelConfig(cfg).TargetFrameSize
+		require.Equal(t, test.size, size, "base fee %v", test.baseFee)
+		if size > 1_000 && size < 120_000 {
+			cost := new(big.Int).Mul(test.baseFee, new(big.Int).SetUint64(params.TxDataNonZeroGasEIP2028*size+params.TxGas))
+			require.LessOrEqual(t, cost.Cmp(new(big.Int).Mul(gwei(200), new(big.Int).SetUint64(size))), 0)
+		}
+	}
+}
+
+func TestSizeTunerComprRatio(t *testing.T) {
+	log := testlog.Logger(t, log.LvlCrit)
+	tuner := newSizeTuner(SizingConfig{
+		TargetCostPerByte: gwei(200),
+		MinFrameSize:      1_000,
+		MaxFrameSize:      120_000,
+	}, 1.0, log, metrics.NoopMetrics)
+	cfg := ChannelConfig{TargetFrameSize: 100_000, ApproxComprRatio: 1.0}
+
+	tuner.ChannelClosed(1000, 500, &ChannelFullError{Err: ErrInputTargetReached})
+	require.InDelta(t, 0.9, tuner.ChannelConfig(cfg).ApproxComprRatio, 1e-9)
+	tuner.ChannelClosed(1000, 500, &ChannelFullError{Err: ErrInputTargetReached})
+	require.InDelta(t, 0.82, tuner.ChannelConfig(cfg).ApproxComprRatio, 1e-9)
+
+	// The small channels closed before reaching their input target are not representative.
+	tuner.ChannelClosed(100, 100, &ChannelFullError{Err: ErrMaxDurationReached})
+	require.InDelta(t, 0.82, tuner.ChannelConfig(cfg).ApproxComprRatio, 1e-9)
+}
+
+func TestSizeTunerDisabled(t *testing.T) {
+	log := testlog.Logger(t, log.LvlCrit)
+	tuner := newSizeTuner(SizingConfig{}, 1.0, log, metrics.NoopMetrics)
+	cfg := ChannelConfig{TargetFrameSize: 100_000, ApproxComprRatio: 0.5}
+
+	tuner.SetBaseFee(gwei(10))
+	tuner.ChannelClosed(1000, 100, &ChannelFullError{Err: ErrInputTargetReached})
+	require.Equal(t, cfg, tuner.ChannelConfig(cfg))
+}
+
+// TestChannelManagerSizing ensures that the new channels are built with the tuned
+// config, which is recorded so that they can be rebuilt after a restart.
+func TestChannelManagerSizing(t *testing.T) {
+	log := testlog.Logger(t, log.LvlCrit)
+	cfg := ChannelConfig{
+		MaxFrameSize:     120_000,
+		TargetFrameSize:  100_000,
+		TargetNumFrames:  1,
+		ApproxComprRatio: 1.0,
+		ChannelTimeout:   1000,
+	}
+	m := NewChannelManager(log, metrics.NoopMetrics, cfg)
+	m.tuner = newSizeTuner(SizingConfig{
+		TargetCostPerByte: gwei(200),
+		MinFrameSize:      1_000,
+		MaxFrameSize:      cfg.MaxFrameSize,
+	}, cfg.ApproxComprRatio, log, metrics.NoopMetrics)
+	m.tuner.SetBaseFee(gwei(10))
+
+	require.NoError(t, m.AddL2Block(newMiniL2Block(0)))
+	_, err := m.TxData(eth.BlockID{})
+	require.ErrorIs(t, err, io.EOF)
+	require.Equal(t, uint64(5_250), m.pendingChannel.cfg.TargetFrameSize)
+
+	stored := storeChannel(m.pendingInFlightChannel())
+	require.Equal(t, uint64(5_250), stored.TargetFrameSize)
+	require.Equal(t, 1.0, stored.ApproxComprRatio)
+}
@@ -0,0 +1,107 @@
+package batcher
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/components/batcher/metrics"
+)
+
+// ThrottleConfig configures the delaying of the batch submissions while the L1 base fee is high.
+type ThrottleConfig struct {
+	// MaxBaseFee is the L1 base fee above which the non-urgent batch submissions are delayed.
+	// The throttling is disabled if nil or zero.
+	MaxBaseFee *big.Int
+	// UrgencyMargin is the number of L1 blocks before the end of the proposer window of the oldest
+	// pending L2 block, less the sub safety margin, from which the batches are submitted whatever
+	// the L1 base fee. It must leave enough time to submit the backlog.
+	UrgencyMargin uint64
+}
+
+func (c ThrottleConfig) Enabled() bool {
+	return c.MaxBaseFee != nil && c.MaxBaseFee.Sign() > 0
+}
+
+// feeThrottle delays the batch submissions while the L1 base fee is above the configured maximum,
+// unless they are urgent, and lets the batcher catch up on the backlog once the base fee drops.
+// The txs sent while catching up are accounted as savings, at the difference between the base fee
+// the submissions were first delayed at and the one they resumed at.
+type feeThrottle struct {
+	mu   sync.Mutex
+	cfg  ThrottleConfig
+	log  log.Logger
+	metr metrics.Metricer
+
+	// delayedBaseFee is the base fee the submissions were first delayed at, nil if they are not delayed.
+	delayedBaseFee *big.Int
+	// saving is the base fee saved per gas by the txs sent while catching up, nil if not catching up.
+	saving *big.Int
+}
+
+func newFeeThrottle(cfg ThrottleConfig, l log.Logger, m metrics.Metricer) *feeThrottle {
+	return &feeThrottle{
+		cfg:  cfg,
+		log:  l,
+		metr: m,
+	}
+}
+
+// Delay returns whether the batch submission must be delayed at the given L1 base fee.
+// backlogBlocks is the number of L2 blocks pending submission.
+func (t *feeThrottle) Delay(baseFee *big.Int, urgent bool, backlogBlocks int) bool {
+	if !t.cfg.Enabled() || baseFee == nil {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !urgent && baseFee.Cmp(t.cfg.MaxBaseFee) > 0 {
+		if t.delayedBaseFee == nil {
+			t.log.Info("Delaying the batch submissions, L1 base fee is too high", "base_fee", baseFee, "max_base_fee", t.cfg.MaxBaseFee)
+			t.delayedBaseFee = baseFee
+			t.saving = nil
+		}
+		t.metr.RecordThrottle(true, backlogBlocks)
+		return true
+	}
+
+	if t.delayedBaseFee != nil {
+		t.log.Info("Resuming the batch submissions", "base_fee", baseFee, "delayed_base_fee", t.delayedBaseFee, "urgent", urgent, "backlog_blocks", backlogBlocks)
+		if saving := new(big.Int).Sub(t.delayedBaseFee, baseFee); saving.Sign() > 0 {
+			t.saving = saving
+		}
+		t.delayedBaseFee = nil
+	}
+	t.metr.RecordThrottle(false, backlogBlocks)
+	return false
+}
+
+// Throttled returns whether the submissions are currently delayed.
+func (t *feeThrottle) Throttled() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.delayedBaseFee != nil
+}
+
+// TxConfirmed records the savings of the tx if it was sent while catching up.
+func (t *feeThrottle) TxConfirmed(receipt *types.Receipt) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.saving == nil {
+		return
+	}
+	t.metr.RecordThrottleSavings(new(big.Int).Mul(t.saving, new(big.Int).SetUint64(receipt.GasUsed)))
+}
+
+// CaughtUp must be called once the backlog is submitted, ending the accounting of the savings.
+func (t *feeThrottle) CaughtUp() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.saving = nil
+}
@@ -0,0 +1,102 @@
+package batcher
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/batcher/metrics"
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/testlog"
+)
+
+type throttleMetrics struct {
+	metrics.Metricer
+	throttled bool
+	backlog   int
+	saved     *big.Int
+}
+
+func (m *throttleMetrics) RecordThrottle(throttled bool, backlogBlocks int) {
+	m.throttled = throttled
+	m.backlog = backlogBlocks
+}
+
+func (m *throttleMetrics) RecordThrottleSavings(savedFee *big.Int) {
+	m.saved.Add(m.saved, savedFee)
+}
+
+// TestChannelManagerUrgent checks that the pending data gets urgent close to the end of the
+// proposer window of the oldest pending block, or once some frames of the channel are submitted.
+func TestChannelManagerUrgent(t *testing.T) {
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics, ChannelConfig{
+		ProposerWindowSize: 50,
+		SubSafetyMargin:    10,
+		ChannelTimeout:     100,
+		MaxFrameSize:       120_000,
+		TargetFrameSize:    1,
+		TargetNumFrames:    1,
+		ApproxComprRatio:   1.0,
+	})
+
+	// Nothing is pending.
+	require.False(t, m.Urgent(eth.BlockID{Number: 1000}, 5))
+	require.Equal(t, 0, m.PendingBlocks())
+
+	// The mini blocks have the L1 origin 100, so their deadline is 100+50-10 = 140.
+	require.NoError(t, m.AddL2Block(newMiniL2Block(0)))
+	require.NoError(t, m.AddL2Block(newMiniL2BlockWithNumberParent(0, big.NewInt(1), m.blocks[0].Hash())))
+	require.Equal(t, 2, m.PendingBlocks())
+	require.False(t, m.Urgent(eth.BlockID{Number: 134}, 5))
+	require.True(t, m.Urgent(eth.BlockID{Number: 135}, 5))
+
+	// Once a frame is in flight, the channel must be fully submitted.
+	_, err := m.TxData(eth.BlockID{Number: 100})
+	require.NoError(t, err)
+	require.Equal(t, 2, m.PendingBlocks())
+	require.True(t, m.Urgent(eth.BlockID{Number: 100}, 5))
+}
+
+func TestFeeThrottle(t *testing.T) {
+	log := testlog.Logger(t, log.LvlCrit)
+	metr := &throttleMetrics{Metricer: metrics.NoopMetrics, saved: new(big.Int)}
+	throttle := newFeeThrottle(ThrottleConfig{MaxBaseFee: big.NewInt(100), UrgencyMargin: 5}, log, metr)
+	receipt := &types.Receipt{TxHash: common.Hash{0x01}, GasUsed: 1000}
+
+	require.False(t, throttle.Delay(big.NewInt(100), false, 1))
+	require.False(t, metr.throttled)
+
+	// The submissions are delayed while the base fee is high and they are not urgent.
+	require.True(t, throttle.Delay(big.NewInt(150), false, 2))
+	require.True(t, metr.throttled)
+	require.Equal(t, 2, metr.backlog)
+	require.True(t, throttle.Delay(big.NewInt(200), false, 3))
+	require.False(t, throttle.Delay(big.NewInt(200), true, 4))
+	require.False(t, metr.throttled)
+	// Urgent submissions at a higher base fee save nothing.
+	throttle.TxConfirmed(receipt)
+	require.Zero(t, metr.saved.Sign())
+
+	// The txs catching up once the base fee dropped are accounted as savings.
+	require.True(t, throttle.Delay(big.NewInt(150), false, 2))
+	require.False(t, throttle.Delay(big.NewInt(50), false, 5))
+	require.Equal(t, 5, metr.backlog)
+	throttle.TxConfirmed(receipt)
+	throttle.TxConfirmed(receipt)
+	require.Equal(t, big.NewInt(2*100*1000), metr.saved)
+
+	throttle.CaughtUp()
+	throttle.TxConfirmed(receipt)
+	require.Equal(t, big.NewInt(2*100*1000), metr.saved)
+}
+
+func TestFeeThrottleDisabled(t *testing.T) {
+	log := testlog.Logger(t, log.LvlCrit)
+	throttle := newFeeThrottle(ThrottleConfig{}, log, metrics.NoopMetrics)
+	require.False(t, throttle.Delay(big.NewInt(1_000_000), false, 1))
+}
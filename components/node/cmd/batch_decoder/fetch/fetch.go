@@ -11,10 +11,12 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 
 	"github.com/kroma-network/kroma/components/node/rollup/derive"
+	"github.com/kroma-network/kroma/utils/altda"
 )
 
 type TransactionWithMetadata struct {
@@ -30,6 +32,9 @@ type TransactionWithMetadata struct {
 	FrameErr    string             `json:"frame_parse_error"`
 	ValidFrames bool               `json:"valid_data"`
 	Tx          *types.Transaction `json:"tx"`
+
+	// DACommitment is the commitment to the data posted to the alternative DA layer, if any.
+	DACommitment hexutil.Bytes `json:"da_commitment,omitempty"`
 }
 
 type Config struct {
@@ -38,6 +43,9 @@ type Config struct {
 	BatchInbox   common.Address
 	BatchSenders map[common.Address]struct{}
 	OutDirectory string
+	// DAClient fetches the frames of the txs carrying an alternative DA commitment.
+	// They are recorded as invalid if nil.
+	DAClient *altda.Client
 }
 
 // Batches fetches & stores all transactions sent to the batch inbox address in
@@ -82,7 +90,11 @@ func fetchBatchesPerBlock(client *ethclient.Client, number *big.Int, signer type
 
 			validFrames := true
 			frameError := ""
-			frames, err := derive.ParseFrames(tx.Data())
+			data, comm, err := txData(tx, config)
+			var frames []derive.Frame
+			if err == nil {
+				frames, err = derive.ParseFrames(data)
+			}
 			if err != nil {
 				fmt.Printf("Found a transaction (%s) with invalid data: %v\n", tx.Hash().String(), err)
 				validFrames = false
@@ -96,18 +108,19 @@ func fetchBatchesPerBlock(client *ethclient.Client, number *big.Int, signer type
 			}
 
 			txm := &TransactionWithMetadata{
-				Tx:          tx,
-				Sender:      sender,
-				ValidSender: validSender,
-				TxIndex:     uint64(i),
-				BlockNumber: block.NumberU64(),
-				BlockHash:   block.Hash(),
-				BlockTime:   block.Time(),
-				ChainId:     config.ChainID.Uint64(),
-				InboxAddr:   config.BatchInbox,
-				Frames:      frames,
-				FrameErr:    frameError,
-				ValidFrames: validFrames,
+				Tx:           tx,
+				Sender:       sender,
+				DACommitment: hexutil.Bytes(comm),
+				ValidSender:  validSender,
+				TxIndex:      uint64(i),
+				BlockNumber:  block.NumberU64(),
+				BlockHash:    block.Hash(),
+				BlockTime:    block.Time(),
+				ChainId:      config.ChainID.Uint64(),
+				InboxAddr:    config.BatchInbox,
+				Frames:       frames,
+				FrameErr:     frameError,
+				ValidFrames:  validFrames,
 			}
 			filename := path.Join(config.OutDirectory, fmt.Sprintf("%s.json", tx.Hash().String()))
 			file, err := os.Create(filename)
@@ -123,3 +136,26 @@ func fetchBatchesPerBlock(client *ethclient.Client, number *big.Int, signer type
 	}
 	return
 }
+
+// txData returns the frames data of the batcher tx, fetched from the alternative DA layer
+// if the tx carries a commitment to it.
+func txData(tx *types.Transaction, config Config) ([]byte, altda.Commitment, error) {
+	data := tx.Data()
+	if len(data) == 0 || data[0] != derive.DerivationVersionAltDA {
+		return data, nil, nil
+	}
+	comm, err := altda.DecodeCommitment(data[1:])
+	if err != nil {
+		return nil, nil, err
+	}
+	if config.DAClient == nil {
+		return nil, comm, fmt.Errorf("no DA server configured to fetch the data of %s", comm)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	input, err := config.DAClient.GetInput(ctx, comm)
+	if err != nil {
+		return nil, comm, fmt.Errorf("failed to fetch the data of %s: %w", comm, err)
+	}
+	return input, comm, nil
+}
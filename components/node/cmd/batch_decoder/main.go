@@ -2,18 +2,24 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/urfave/cli"
 
+	"github.com/kroma-network/kroma/components/node/chaincfg"
 	"github.com/kroma-network/kroma/components/node/cmd/batch_decoder/fetch"
 	"github.com/kroma-network/kroma/components/node/cmd/batch_decoder/reassemble"
+	"github.com/kroma-network/kroma/components/node/cmd/batch_decoder/stats"
+	"github.com/kroma-network/kroma/components/node/rollup"
 	"github.com/kroma-network/kroma/components/node/rollup/derive"
+	"github.com/kroma-network/kroma/utils/altda"
 )
 
 func main() {
@@ -56,6 +62,11 @@ func main() {
 					Usage:    "L1 RPC URL",
 					EnvVar:   "L1_RPC",
 				},
+				cli.StringFlag{
+					Name:   "da-server",
+					Usage:  "(Optional) URL of the DA server to fetch the data of the alternative DA commitments from",
+					EnvVar: "DA_SERVER",
+				},
 			},
 			Action: func(cliCtx *cli.Context) error {
 				client, err := ethclient.Dial(cliCtx.String("l1"))
@@ -78,6 +89,9 @@ func main() {
 					BatchInbox:   common.HexToAddress(cliCtx.String("inbox")),
 					OutDirectory: cliCtx.String("out"),
 				}
+				if daServer := cliCtx.String("da-server"); daServer != "" {
+					config.DAClient = altda.NewClient(altda.CLIConfig{ServerURL: daServer, Timeout: 10 * time.Second})
+				}
 				totalValid, totalInvalid := fetch.Batches(client, config)
 				fmt.Printf("Fetched batches in range [%v,%v). Found %v valid & %v invalid batches\n", config.Start, config.End, totalValid, totalInvalid)
 				fmt.Printf("Fetch Config: Chain ID: %v. Inbox Address: %v. Valid Senders: %v.\n", config.ChainID, config.BatchInbox, config.BatchSenders)
@@ -115,6 +129,36 @@ func main() {
 				return nil
 			},
 		},
+		{
+			Name:  "stats",
+			Usage: "Prints the statistics of the L2 blocks decoded from the reassembled channels",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "in",
+					Value: "/tmp/batch_decoder/channel_cache",
+					Usage: "Cache directory for the found channels",
+				},
+				cli.StringFlag{
+					Name:  "network",
+					Usage: fmt.Sprintf("Predefined network selection. Available networks: %s", strings.Join(chaincfg.AvailableNetworks(), ", ")),
+				},
+				cli.StringFlag{
+					Name:  "rollup-config",
+					Usage: "Rollup chain parameters, used if no network is selected",
+				},
+			},
+			Action: func(cliCtx *cli.Context) error {
+				rollupCfg, err := loadRollupConfig(cliCtx.String("network"), cliCtx.String("rollup-config"))
+				if err != nil {
+					log.Fatal(err)
+				}
+				stats.Blocks(stats.Config{
+					InDirectory: cliCtx.String("in"),
+					Rollup:      rollupCfg,
+				})
+				return nil
+			},
+		},
 		{
 			Name:  "force-close",
 			Usage: "Create the tx data which will force close a channel",
@@ -161,3 +205,28 @@ func main() {
 		log.Fatal(err)
 	}
 }
+
+// loadRollupConfig returns the rollup config of the predefined network, or else read from the file.
+func loadRollupConfig(network string, path string) (*rollup.Config, error) {
+	if network != "" {
+		config, err := chaincfg.GetRollupConfig(network)
+		if err != nil {
+			return nil, err
+		}
+		return &config, nil
+	}
+	if path == "" {
+		return nil, fmt.Errorf("either a network or a rollup config is required")
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rollup config: %w", err)
+	}
+	defer file.Close()
+
+	var config rollup.Config
+	if err := json.NewDecoder(file).Decode(&config); err != nil {
+		return nil, fmt.Errorf("failed to decode rollup config: %w", err)
+	}
+	return &config, nil
+}
@@ -1,6 +1,7 @@
 package reassemble
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,6 +11,7 @@ import (
 	"sort"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
 
 	"github.com/kroma-network/kroma/components/node/cmd/batch_decoder/fetch"
 	"github.com/kroma-network/kroma/components/node/eth"
@@ -22,7 +24,14 @@ type ChannelWithMetadata struct {
 	InvalidFrames  bool                `json:"invalid_frames"`
 	InvalidBatches bool                `json:"invalid_batches"`
 	Frames         []FrameWithMetadata `json:"frames"`
-	Batches        []derive.BatchV1    `json:"batches"`
+	Batches        []derive.BatchData  `json:"batches"`
+
+	// Compression is the compression algorithm of the channel, read from its version byte.
+	Compression derive.CompressionAlgo `json:"compression,omitempty"`
+	// CompressedBytes is the size of the channel data, as submitted in its frames.
+	CompressedBytes int `json:"compressed_bytes"`
+	// UncompressedBytes is the size of the RLP-encoded batches of the channel.
+	UncompressedBytes int `json:"uncompressed_bytes"`
 }
 
 type FrameWithMetadata struct {
@@ -75,6 +84,28 @@ func Channels(config Config) {
 	}
 }
 
+// LoadChannels loads all the channels re-assembled to the given directory.
+func LoadChannels(directory string) []ChannelWithMetadata {
+	files, err := os.ReadDir(directory)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var out []ChannelWithMetadata
+	for _, file := range files {
+		f, err := os.Open(path.Join(directory, file.Name()))
+		if err != nil {
+			log.Fatal(err)
+		}
+		var ch ChannelWithMetadata
+		if err := json.NewDecoder(f).Decode(&ch); err != nil {
+			log.Fatalf("Failed to decode %v. Err: %v\n", file.Name(), err)
+		}
+		f.Close()
+		out = append(out, ch)
+	}
+	return out
+}
+
 func writeChannel(ch ChannelWithMetadata, filename string) error {
 	file, err := os.Create(filename)
 	if err != nil {
@@ -101,18 +132,35 @@ func processFrames(id derive.ChannelID, frames []FrameWithMetadata) ChannelWithM
 		}
 	}
 
-	var batches []derive.BatchV1
-	invalidBatches := false
+	var (
+		batches           []derive.BatchData
+		invalidBatches    bool
+		compression       derive.CompressionAlgo
+		compressedBytes   int
+		uncompressedBytes int
+	)
 	if ch.IsReady() {
-		br, err := derive.BatchReader(ch.Reader(), eth.L1BlockRef{})
+		data, err := io.ReadAll(ch.Reader())
+		if err != nil {
+			fmt.Printf("Error reading channel %v. Err: %v\n", id.String(), err)
+		}
+		compression = compressionAlgo(data)
+		compressedBytes = len(data)
+		br, err := derive.BatchReader(bytes.NewReader(data), eth.L1BlockRef{})
 		if err == nil {
 			for batch, err := br(); err != io.EOF; batch, err = br() {
 				if err != nil {
 					fmt.Printf("Error reading batch for channel %v. Err: %v\n", id.String(), err)
 					invalidBatches = true
-				} else {
-					batches = append(batches, batch.Batch.BatchV1)
+					// The RLP stream can not be read further after an error.
+					break
 				}
+				batchBytes, err := rlp.EncodeToBytes(batch.Batch)
+				if err != nil {
+					log.Fatal(err)
+				}
+				uncompressedBytes += len(batchBytes)
+				batches = append(batches, *batch.Batch)
 			}
 		} else {
 			fmt.Printf("Error creating batch reader for channel %v. Err: %v\n", id.String(), err)
@@ -128,6 +176,26 @@ func processFrames(id derive.ChannelID, frames []FrameWithMetadata) ChannelWithM
 		InvalidFrames:  invalidFrame,
 		InvalidBatches: invalidBatches,
 		Batches:        batches,
+
+		Compression:       compression,
+		CompressedBytes:   compressedBytes,
+		UncompressedBytes: uncompressedBytes,
+	}
+}
+
+// compressionAlgo returns the compression algorithm of the channel data from its version byte.
+// The zlib channels have no version byte.
+func compressionAlgo(data []byte) derive.CompressionAlgo {
+	if len(data) == 0 {
+		return ""
+	}
+	switch data[0] {
+	case derive.ChannelVersionBrotli:
+		return derive.Brotli
+	case derive.ChannelVersionZstd:
+		return derive.Zstd
+	default:
+		return derive.Zlib
 	}
 }
 
@@ -0,0 +1,136 @@
+package stats
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/kroma-network/kroma/components/node/cmd/batch_decoder/reassemble"
+	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
+)
+
+type Config struct {
+	InDirectory string
+	Rollup      *rollup.Config
+}
+
+// BlockStats are the statistics of an L2 block decoded from a batch.
+type BlockStats struct {
+	Number    uint64
+	Timestamp uint64
+	EpochNum  uint64
+	Txs       int
+	TxBytes   int
+	SpanBatch bool
+	Channel   derive.ChannelID
+	// InclusionBlock is the L1 block the last frame of the channel was included in.
+	InclusionBlock uint64
+}
+
+// Blocks loads the channels re-assembled to the input directory and prints the statistics of
+// the L2 blocks of their batches, sorted by block number, followed by the statistics of the
+// channels and the L2 blocks which are submitted more than once or missing.
+func Blocks(config Config) {
+	channels := reassemble.LoadChannels(config.InDirectory)
+	sort.Slice(channels, func(i, j int) bool {
+		return inclusionBlock(channels[i]) < inclusionBlock(channels[j])
+	})
+
+	var blocks []BlockStats
+	for _, ch := range channels {
+		for _, batch := range ch.Batches {
+			blocks = append(blocks, batchBlocks(config.Rollup, ch, batch)...)
+		}
+	}
+	sort.SliceStable(blocks, func(i, j int) bool {
+		return blocks[i].Number < blocks[j].Number
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "L2 BLOCK\tTIMESTAMP\tEPOCH\tTXS\tTX BYTES\tBATCH\tCHANNEL\tL1 INCLUSION")
+	for _, b := range blocks {
+		batchType := "singular"
+		if b.SpanBatch {
+			batchType = "span"
+		}
+		fmt.Fprintf(w, "%d\t%d\t%d\t%d\t%d\t%s\t%s\t%d\n", b.Number, b.Timestamp, b.EpochNum, b.Txs, b.TxBytes, batchType, b.Channel, b.InclusionBlock)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "CHANNEL\tREADY\tFRAMES\tBATCHES\tCOMPRESSION\tCOMPRESSED\tUNCOMPRESSED\tRATIO\tL1 INCLUSION")
+	for _, ch := range channels {
+		var ratio float64
+		if ch.UncompressedBytes > 0 {
+			ratio = float64(ch.CompressedBytes) / float64(ch.UncompressedBytes)
+		}
+		fmt.Fprintf(w, "%s\t%v\t%d\t%d\t%s\t%d\t%d\t%.3f\t%d\n", ch.ID, ch.IsReady, len(ch.Frames), len(ch.Batches), ch.Compression, ch.CompressedBytes, ch.UncompressedBytes, ratio, inclusionBlock(ch))
+	}
+	if err := w.Flush(); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println()
+	for i := 1; i < len(blocks); i++ {
+		prev, b := blocks[i-1], blocks[i]
+		if b.Number == prev.Number {
+			fmt.Printf("L2 block %d is submitted more than once, in channels %s and %s\n", b.Number, prev.Channel, b.Channel)
+		} else if b.Number > prev.Number+1 {
+			fmt.Printf("L2 blocks [%d, %d] are missing\n", prev.Number+1, b.Number-1)
+		}
+	}
+	for _, ch := range channels {
+		if !ch.IsReady || ch.InvalidFrames || ch.InvalidBatches {
+			fmt.Printf("Channel %s is not fully decoded: ready %v, invalid frames %v, invalid batches %v\n", ch.ID, ch.IsReady, ch.InvalidFrames, ch.InvalidBatches)
+		}
+	}
+	fmt.Printf("Decoded %d L2 blocks from %d channels\n", len(blocks), len(channels))
+}
+
+// batchBlocks returns the statistics of the L2 blocks of the batch.
+func batchBlocks(cfg *rollup.Config, ch reassemble.ChannelWithMetadata, batch derive.BatchData) []BlockStats {
+	if batch.SpanBatch == nil {
+		return []BlockStats{blockStats(cfg, ch, batch.Timestamp, uint64(batch.EpochNum), batch.Transactions, false)}
+	}
+	var out []BlockStats
+	timestamp := batch.SpanBatch.Timestamp(cfg)
+	for _, block := range batch.SpanBatch.Blocks {
+		out = append(out, blockStats(cfg, ch, timestamp, uint64(block.EpochNum), block.Transactions, true))
+		timestamp += cfg.BlockTime
+	}
+	return out
+}
+
+func blockStats(cfg *rollup.Config, ch reassemble.ChannelWithMetadata, timestamp uint64, epochNum uint64, txs []hexutil.Bytes, span bool) BlockStats {
+	number, err := cfg.TargetBlockNumber(timestamp)
+	if err != nil {
+		log.Fatalf("Invalid timestamp %d of a batch in channel %s. Err: %v\n", timestamp, ch.ID, err)
+	}
+	b := BlockStats{
+		Number:         number,
+		Timestamp:      timestamp,
+		EpochNum:       epochNum,
+		Txs:            len(txs),
+		SpanBatch:      span,
+		Channel:        ch.ID,
+		InclusionBlock: inclusionBlock(ch),
+	}
+	for _, tx := range txs {
+		b.TxBytes += len(tx)
+	}
+	return b
+}
+
+// inclusionBlock returns the L1 block the last frame of the channel was included in.
+func inclusionBlock(ch reassemble.ChannelWithMetadata) uint64 {
+	var number uint64
+	for _, frame := range ch.Frames {
+		if frame.InclusionBlock > number {
+			number = frame.InclusionBlock
+		}
+	}
+	return number
+}
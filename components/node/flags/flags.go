@@ -173,6 +173,17 @@ var (
 		Value:   6060,
 		EnvVars: prefixEnvVars("PPROF_PORT"),
 	}
+	AltDAServerFlag = &cli.StringFlag{
+		Name:    "alt-da.server",
+		Usage:   "HTTP URL of the DA server to fetch the data posted to the alternative DA layer from. Required once the alternative DA is scheduled",
+		EnvVars: prefixEnvVars("ALT_DA_SERVER"),
+	}
+	AltDATimeoutFlag = &cli.DurationFlag{
+		Name:    "alt-da.timeout",
+		Usage:   "Timeout of the requests to the DA server",
+		Value:   30 * time.Second,
+		EnvVars: prefixEnvVars("ALT_DA_TIMEOUT"),
+	}
 	SnapshotLog = &cli.StringFlag{
 		Name:    "snapshotlog.file",
 		Usage:   "Path to the snapshot log file",
@@ -238,6 +249,8 @@ var optionalFlags = []cli.Flag{
 	PprofEnabledFlag,
 	PprofAddrFlag,
 	PprofPortFlag,
+	AltDAServerFlag,
+	AltDATimeoutFlag,
 	SnapshotLog,
 	HeartbeatEnabledFlag,
 	HeartbeatMonikerFlag,
@@ -9,6 +9,7 @@ import (
 	"github.com/kroma-network/kroma/components/node/p2p"
 	"github.com/kroma-network/kroma/components/node/rollup"
 	"github.com/kroma-network/kroma/components/node/rollup/driver"
+	"github.com/kroma-network/kroma/utils/altda"
 	kpprof "github.com/kroma-network/kroma/utils/service/pprof"
 )
 
@@ -33,6 +34,9 @@ type Config struct {
 
 	Pprof kpprof.CLIConfig
 
+	// AltDA is the DA server to fetch the data of the commitments from, once the alternative DA is active.
+	AltDA altda.CLIConfig
+
 	// Used to poll the L1 for new finalized or safe blocks
 	L1EpochPollInterval time.Duration
 
@@ -92,6 +96,12 @@ func (cfg *Config) Check() error {
 	if err := cfg.Pprof.Check(); err != nil {
 		return fmt.Errorf("pprof config error: %w", err)
 	}
+	if err := cfg.AltDA.Check(); err != nil {
+		return fmt.Errorf("alt-da config error: %w", err)
+	}
+	if cfg.Rollup.AltDATime != nil && !cfg.AltDA.Enabled() {
+		return errors.New("the alternative DA is scheduled, but no DA server is configured")
+	}
 	if cfg.P2P != nil {
 		if err := cfg.P2P.Check(); err != nil {
 			return fmt.Errorf("p2p config error: %w", err)
@@ -16,8 +16,10 @@ import (
 	"github.com/kroma-network/kroma/components/node/eth"
 	"github.com/kroma-network/kroma/components/node/metrics"
 	"github.com/kroma-network/kroma/components/node/p2p"
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
 	"github.com/kroma-network/kroma/components/node/rollup/driver"
 	"github.com/kroma-network/kroma/components/node/sources"
+	"github.com/kroma-network/kroma/utils/altda"
 )
 
 type KromaNode struct {
@@ -198,7 +200,12 @@ func (n *KromaNode) initL2(ctx context.Context, cfg *Config, snapshotLog log.Log
 		return err
 	}
 
-	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n, n, n.log, snapshotLog, n.metrics)
+	var daFetcher derive.DAFetcher
+	if cfg.AltDA.Enabled() {
+		daFetcher = altda.NewClient(cfg.AltDA)
+	}
+
+	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, daFetcher, n, n, n.log, snapshotLog, n.metrics)
 
 	return nil
 }
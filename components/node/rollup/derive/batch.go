@@ -21,6 +21,9 @@ import (
 // BatchV1Type := 0
 // batchV1 := BatchV1Type ++ RLP([epoch, timestamp, transaction_list]
 //
+// SpanBatchType := 1
+// spanBatch := SpanBatchType ++ prefix ++ payload (see SpanBatch)
+//
 // An empty input is not a valid batch.
 //
 // Note: the type system is based on L1 typed transactions.
@@ -32,6 +35,7 @@ var encodeBufferPool = sync.Pool{
 
 const (
 	BatchV1Type = iota
+	SpanBatchType
 )
 
 type BatchV1 struct {
@@ -45,6 +49,8 @@ type BatchV1 struct {
 
 type BatchData struct {
 	BatchV1
+	// SpanBatch is set instead of the BatchV1 for the batches of the SpanBatchType.
+	SpanBatch *SpanBatch `json:",omitempty"`
 	// batches may contain additional data with new upgrades
 }
 
@@ -71,6 +77,10 @@ func (b *BatchData) MarshalBinary() ([]byte, error) {
 }
 
 func (b *BatchData) encodeTyped(buf *bytes.Buffer) error {
+	if b.SpanBatch != nil {
+		buf.WriteByte(SpanBatchType)
+		return b.SpanBatch.encode(buf)
+	}
 	buf.WriteByte(BatchV1Type)
 	return rlp.Encode(buf, &b.BatchV1)
 }
@@ -102,6 +112,9 @@ func (b *BatchData) decodeTyped(data []byte) error {
 	switch data[0] {
 	case BatchV1Type:
 		return rlp.DecodeBytes(data[1:], &b.BatchV1)
+	case SpanBatchType:
+		b.SpanBatch = new(SpanBatch)
+		return b.SpanBatch.decode(data[1:])
 	default:
 		return fmt.Errorf("unrecognized batch type: %d", data[0])
 	}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 
 	"github.com/ethereum/go-ethereum/log"
 
@@ -42,16 +43,33 @@ type BatchQueue struct {
 
 	l1Blocks []eth.L1BlockRef
 
-	// batches in order of when we've first seen them, grouped by L2 timestamp
+	// batches in order of when we've first seen them, grouped by L2 timestamp.
+	// They are the singular batches included before the span batch activation.
 	batches map[uint64][]*BatchWithL1InclusionBlock
+
+	// orderedBatches are the batches, singular or span, in order of when we've first seen them,
+	// once the span batches are activated. A span batch covers a range of L2 timestamps,
+	// so they cannot be grouped by L2 timestamp.
+	orderedBatches []*BatchWithL1InclusionBlock
+
+	// nextSpan is the rest of the singular batches of the accepted span batch, to derive next.
+	nextSpan []*BatchData
+
+	l2 SafeBlockFetcher
+}
+
+// SafeBlockFetcher fetches the blocks of the safe L2 chain, which the span batches may overlap with.
+type SafeBlockFetcher interface {
+	PayloadByNumber(context.Context, uint64) (*eth.ExecutionPayload, error)
 }
 
 // NewBatchQueue creates a BatchQueue, which should be Reset(origin) before use.
-func NewBatchQueue(log log.Logger, cfg *rollup.Config, prev NextBatchProvider) *BatchQueue {
+func NewBatchQueue(log log.Logger, cfg *rollup.Config, prev NextBatchProvider, l2 SafeBlockFetcher) *BatchQueue {
 	return &BatchQueue{
 		log:    log,
 		config: cfg,
 		prev:   prev,
+		l2:     l2,
 	}
 }
 
@@ -60,6 +78,17 @@ func (bq *BatchQueue) Origin() eth.L1BlockRef {
 }
 
 func (bq *BatchQueue) NextBatch(ctx context.Context, safeL2Head eth.L2BlockRef) (*BatchData, error) {
+	// The blocks of the accepted span batch are derived first, as long as they follow the safe head.
+	if len(bq.nextSpan) > 0 {
+		if bq.nextSpan[0].Timestamp == safeL2Head.Time+bq.config.BlockTime {
+			return bq.popNextBatch(safeL2Head), nil
+		}
+		bq.log.Warn("dropping the rest of the span batch, which does not follow the safe head",
+			"next_timestamp", bq.nextSpan[0].Timestamp, "l2_safe_head", safeL2Head.ID(), "l2_safe_head_time", safeL2Head.Time,
+			"blocks", len(bq.nextSpan))
+		bq.nextSpan = bq.nextSpan[:0]
+	}
+
 	// Note: We use the origin that we will have to determine if it's behind. This is important
 	// because it's the future origin that gets saved into the l1Blocks array.
 	// We always update the origin of this stage if it is not the same so after the update code
@@ -119,6 +148,8 @@ func (bq *BatchQueue) Reset(ctx context.Context, base eth.L1BlockRef, _ eth.Syst
 	// It is set in the engine queue (two stages away) such that the L2 Safe Head origin is the progress
 	bq.origin = base
 	bq.batches = make(map[uint64][]*BatchWithL1InclusionBlock)
+	bq.orderedBatches = bq.orderedBatches[:0]
+	bq.nextSpan = bq.nextSpan[:0]
 	// Include the new origin as an origin to build on
 	// Note: This is only for the initialization case. During normal resets we will later
 	// throw out this block.
@@ -135,12 +166,26 @@ func (bq *BatchQueue) AddBatch(batch *BatchData, l2SafeHead eth.L2BlockRef) {
 		L1InclusionBlock: bq.origin,
 		Batch:            batch,
 	}
+	if span := batch.SpanBatch; span != nil {
+		// The span batches may need the L2 chain to be checked, so they are only checked when derived.
+		if !bq.config.IsSpanBatch(bq.origin.Time) {
+			bq.log.Warn("dropping span batch included before the span batch activation", "l1_inclusion_block", bq.origin.ID(), "l1_inclusion_time", bq.origin.Time)
+			return
+		}
+		bq.log.Debug("Adding span batch", "batch_timestamp", span.Timestamp(bq.config), "blocks", len(span.Blocks))
+		bq.orderedBatches = append(bq.orderedBatches, &data)
+		return
+	}
 	validity := CheckBatch(bq.config, bq.log, bq.l1Blocks, l2SafeHead, &data)
 	if validity == BatchDrop {
 		return // if we do drop the batch, CheckBatch will log the drop reason with WARN level.
 	}
 	bq.log.Debug("Adding batch", "batch_timestamp", batch.Timestamp, "parent_hash", batch.ParentHash, "batch_epoch", batch.Epoch(), "txs", len(batch.Transactions))
-	bq.batches[batch.Timestamp] = append(bq.batches[batch.Timestamp], &data)
+	if bq.config.IsSpanBatch(bq.origin.Time) {
+		bq.orderedBatches = append(bq.orderedBatches, &data)
+	} else {
+		bq.batches[batch.Timestamp] = append(bq.batches[batch.Timestamp], &data)
+	}
 }
 
 // deriveNextBatch derives the next batch to apply on top of the current L2 safe head,
@@ -166,48 +211,22 @@ func (bq *BatchQueue) deriveNextBatch(ctx context.Context, outOfData bool, l2Saf
 	// There may be none: in that case we force-create an empty batch
 	nextTimestamp := l2SafeHead.Time + bq.config.BlockTime
 	var nextBatch *BatchWithL1InclusionBlock
-
-	// Go over all batches, in order of inclusion, and find the first batch we can accept.
-	// We filter in-place by only remembering the batches that may be processed in the future, or those we are undecided on.
-	var remaining []*BatchWithL1InclusionBlock
-	candidates := bq.batches[nextTimestamp]
-batchLoop:
-	for i, batch := range candidates {
-		validity := CheckBatch(bq.config, bq.log.New("batch_index", i), bq.l1Blocks, l2SafeHead, batch)
-		switch validity {
-		case BatchFuture:
-			return nil, NewCriticalError(fmt.Errorf("found batch with timestamp %d marked as future batch, but expected timestamp %d", batch.Batch.Timestamp, nextTimestamp))
-		case BatchDrop:
-			bq.log.Warn("dropping batch",
-				"batch_timestamp", batch.Batch.Timestamp,
-				"parent_hash", batch.Batch.ParentHash,
-				"batch_epoch", batch.Batch.Epoch(),
-				"txs", len(batch.Batch.Transactions),
-				"l2_safe_head", l2SafeHead.ID(),
-				"l2_safe_head_time", l2SafeHead.Time,
-			)
-			continue
-		case BatchAccept:
-			nextBatch = batch
-			// don't keep the current batch in the remaining items since we are processing it now,
-			// but retain every batch we didn't get to yet.
-			remaining = append(remaining, candidates[i+1:]...)
-			break batchLoop
-		case BatchUndecided:
-			remaining = append(remaining, batch)
-			bq.batches[nextTimestamp] = remaining
-			return nil, io.EOF
-		default:
-			return nil, NewCriticalError(fmt.Errorf("unknown batch validity type: %d", validity))
-		}
-	}
-	// clean up if we remove the final batch for this timestamp
-	if len(remaining) == 0 {
-		delete(bq.batches, nextTimestamp)
+	var nextSpan []*BatchData
+	var err error
+	if bq.config.IsSpanBatch(bq.origin.Time) {
+		nextBatch, nextSpan, err = bq.nextOrderedBatch(ctx, l2SafeHead)
 	} else {
-		bq.batches[nextTimestamp] = remaining
+		nextBatch, err = bq.nextBatchByTimestamp(l2SafeHead)
+	}
+	if err != nil {
+		return nil, err
 	}
 
+	if nextSpan != nil {
+		bq.nextSpan = nextSpan
+		bq.log.Info("Found next span batch", "epoch", epoch, "batch_timestamp", nextTimestamp, "blocks", len(nextSpan))
+		return bq.popNextBatch(l2SafeHead), nil
+	}
 	if nextBatch != nil {
 		// advance epoch if necessary
 		if nextBatch.Batch.EpochNum == rollup.Epoch(epoch.Number)+1 {
@@ -244,7 +263,7 @@ batchLoop:
 	if nextTimestamp < nextEpoch.Time || firstOfEpoch {
 		bq.log.Info("Generating next batch", "epoch", epoch, "timestamp", nextTimestamp)
 		return &BatchData{
-			BatchV1{
+			BatchV1: BatchV1{
 				ParentHash:   l2SafeHead.Hash,
 				EpochNum:     rollup.Epoch(epoch.Number),
 				EpochHash:    epoch.Hash,
@@ -260,3 +279,134 @@ batchLoop:
 	bq.l1Blocks = bq.l1Blocks[1:]
 	return nil, io.EOF
 }
+
+// nextBatchByTimestamp returns the first-seen batch of the next timestamp that matches all validity
+// conditions, if any, or io.EOF if there is not sufficient information to proceed filtering yet.
+func (bq *BatchQueue) nextBatchByTimestamp(l2SafeHead eth.L2BlockRef) (*BatchWithL1InclusionBlock, error) {
+	nextTimestamp := l2SafeHead.Time + bq.config.BlockTime
+	var nextBatch *BatchWithL1InclusionBlock
+
+	// Go over all batches, in order of inclusion, and find the first batch we can accept.
+	// We filter in-place by only remembering the batches that may be processed in the future, or those we are undecided on.
+	var remaining []*BatchWithL1InclusionBlock
+	candidates := bq.batches[nextTimestamp]
+batchLoop:
+	for i, batch := range candidates {
+		validity := CheckBatch(bq.config, bq.log.New("batch_index", i), bq.l1Blocks, l2SafeHead, batch)
+		switch validity {
+		case BatchFuture:
+			return nil, NewCriticalError(fmt.Errorf("found batch with timestamp %d marked as future batch, but expected timestamp %d", batch.Batch.Timestamp, nextTimestamp))
+		case BatchDrop:
+			bq.log.Warn("dropping batch",
+				"batch_timestamp", batch.Batch.Timestamp,
+				"parent_hash", batch.Batch.ParentHash,
+				"batch_epoch", batch.Batch.Epoch(),
+				"txs", len(batch.Batch.Transactions),
+				"l2_safe_head", l2SafeHead.ID(),
+				"l2_safe_head_time", l2SafeHead.Time,
+			)
+			continue
+		case BatchAccept:
+			nextBatch = batch
+			// don't keep the current batch in the remaining items since we are processing it now,
+			// but retain every batch we didn't get to yet.
+			remaining = append(remaining, candidates[i+1:]...)
+			break batchLoop
+		case BatchUndecided:
+			remaining = append(remaining, batch)
+			bq.batches[nextTimestamp] = remaining
+			return nil, io.EOF
+		default:
+			return nil, NewCriticalError(fmt.Errorf("unknown batch validity type: %d", validity))
+		}
+	}
+	// clean up if we remove the final batch for this timestamp
+	if len(remaining) == 0 {
+		delete(bq.batches, nextTimestamp)
+	} else {
+		bq.batches[nextTimestamp] = remaining
+	}
+	return nextBatch, nil
+}
+
+// nextOrderedBatch is like nextBatchByTimestamp once the span batches are activated. It goes over the
+// ordered batches, whose timestamps are only known by checking them, so the batches for a later
+// timestamp are kept for later, and the batches after an undecided one are kept as they are not checked yet.
+// If the first accepted batch is a span batch, its singular batches from the safe head on are returned.
+func (bq *BatchQueue) nextOrderedBatch(ctx context.Context, l2SafeHead eth.L2BlockRef) (*BatchWithL1InclusionBlock, []*BatchData, error) {
+	// The singular batches grouped before the activation were included before the ordered ones.
+	if len(bq.batches) > 0 {
+		timestamps := make([]uint64, 0, len(bq.batches))
+		for timestamp := range bq.batches {
+			timestamps = append(timestamps, timestamp)
+		}
+		sort.Slice(timestamps, func(i, j int) bool { return timestamps[i] < timestamps[j] })
+		var grouped []*BatchWithL1InclusionBlock
+		for _, timestamp := range timestamps {
+			grouped = append(grouped, bq.batches[timestamp]...)
+		}
+		bq.orderedBatches = append(grouped, bq.orderedBatches...)
+		bq.batches = make(map[uint64][]*BatchWithL1InclusionBlock)
+	}
+
+	var remaining []*BatchWithL1InclusionBlock
+	for i, batch := range bq.orderedBatches {
+		var validity BatchValidity
+		var nextSpan []*BatchData
+		if batch.Batch.SpanBatch != nil {
+			validity, nextSpan = CheckSpanBatch(ctx, bq.config, bq.log.New("batch_index", i), bq.l1Blocks, l2SafeHead, batch, bq.l2)
+		} else {
+			validity = CheckBatch(bq.config, bq.log.New("batch_index", i), bq.l1Blocks, l2SafeHead, batch)
+		}
+		switch validity {
+		case BatchFuture:
+			remaining = append(remaining, batch)
+			continue
+		case BatchDrop:
+			if batch.Batch.SpanBatch != nil {
+				bq.log.Warn("dropping span batch",
+					"batch_timestamp", batch.Batch.SpanBatch.Timestamp(bq.config),
+					"blocks", len(batch.Batch.SpanBatch.Blocks),
+					"l2_safe_head", l2SafeHead.ID(),
+					"l2_safe_head_time", l2SafeHead.Time,
+				)
+				continue
+			}
+			bq.log.Warn("dropping batch",
+				"batch_timestamp", batch.Batch.Timestamp,
+				"parent_hash", batch.Batch.ParentHash,
+				"batch_epoch", batch.Batch.Epoch(),
+				"txs", len(batch.Batch.Transactions),
+				"l2_safe_head", l2SafeHead.ID(),
+				"l2_safe_head_time", l2SafeHead.Time,
+			)
+			continue
+		case BatchAccept:
+			// don't keep the current batch in the remaining items since we are processing it now,
+			// but retain every batch we didn't get to yet.
+			bq.orderedBatches = append(remaining, bq.orderedBatches[i+1:]...)
+			return batch, nextSpan, nil
+		case BatchUndecided:
+			bq.orderedBatches = append(remaining, bq.orderedBatches[i:]...)
+			return nil, nil, io.EOF
+		default:
+			return nil, nil, NewCriticalError(fmt.Errorf("unknown batch validity type: %d", validity))
+		}
+	}
+	bq.orderedBatches = remaining
+	return nil, nil, nil
+}
+
+// popNextBatch pops the next singular batch of the accepted span batch, which builds on the safe head.
+func (bq *BatchQueue) popNextBatch(safeL2Head eth.L2BlockRef) *BatchData {
+	nextBatch := bq.nextSpan[0]
+	bq.nextSpan = bq.nextSpan[1:]
+	// The hashes of the blocks of a span batch are only known once they are derived.
+	nextBatch.ParentHash = safeL2Head.Hash
+	// advance epoch if necessary
+	if nextBatch.EpochNum == rollup.Epoch(bq.l1Blocks[0].Number)+1 {
+		bq.l1Blocks = bq.l1Blocks[1:]
+	}
+	bq.log.Info("Popped next batch of the span batch", "batch_epoch", nextBatch.EpochNum, "batch_timestamp", nextBatch.Timestamp)
+	return nextBatch
+}
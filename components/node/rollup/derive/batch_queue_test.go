@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/binary"
 	"io"
+	"math/big"
 	"math/rand"
 	"testing"
 
@@ -48,7 +49,7 @@ func mockHash(time uint64, layer uint8) common.Hash {
 func b(timestamp uint64, epoch eth.L1BlockRef) *BatchData {
 	rng := rand.New(rand.NewSource(int64(timestamp)))
 	data := testutils.RandomData(rng, 20)
-	return &BatchData{BatchV1{
+	return &BatchData{BatchV1: BatchV1{
 		ParentHash:   mockHash(timestamp-2, 2),
 		Timestamp:    timestamp,
 		EpochNum:     rollup.Epoch(epoch.Number),
@@ -102,7 +103,7 @@ func TestBatchQueueNewOrigin(t *testing.T) {
 		origin:  l1[0],
 	}
 
-	bq := NewBatchQueue(log, cfg, input)
+	bq := NewBatchQueue(log, cfg, input, nil)
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 	require.Equal(t, []eth.L1BlockRef{l1[0]}, bq.l1Blocks)
 
@@ -164,7 +165,7 @@ func TestBatchQueueEager(t *testing.T) {
 		origin:  l1[0],
 	}
 
-	bq := NewBatchQueue(log, cfg, input)
+	bq := NewBatchQueue(log, cfg, input, nil)
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 	// Advance the origin
 	input.origin = l1[1]
@@ -214,7 +215,7 @@ func TestBatchQueueInvalidInternalAdvance(t *testing.T) {
 		origin:  l1[0],
 	}
 
-	bq := NewBatchQueue(log, cfg, input)
+	bq := NewBatchQueue(log, cfg, input, nil)
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 
 	// Load continuous batches for epoch 0
@@ -307,7 +308,7 @@ func TestBatchQueueMissing(t *testing.T) {
 		origin:  l1[0],
 	}
 
-	bq := NewBatchQueue(log, cfg, input)
+	bq := NewBatchQueue(log, cfg, input, nil)
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 
 	for i := 0; i < len(batches); i++ {
@@ -369,3 +370,241 @@ func TestBatchQueueMissing(t *testing.T) {
 	require.Empty(t, b.BatchV1.Transactions)
 	require.Equal(t, rollup.Epoch(1), b.EpochNum)
 }
+
+// TestBatchQueueUndecidedDuplicate asserts that the singular batches are derived alike before and after
+// the span batch activation: an undecided batch is kept until the next L1 origin is known, and its
+// duplicate is dropped once it is accepted.
+func TestBatchQueueUndecidedDuplicate(t *testing.T) {
+	l1 := L1Chain([]uint64{10, 12, 30})
+	spanBatchTime := uint64(0)
+	for _, tt := range []struct {
+		name          string
+		spanBatchTime *uint64
+		// undecided is the number of batches buffered while the batch is undecided.
+		undecided int
+	}{
+		// Only the undecided batch of the timestamp is kept, as before the span batches.
+		{name: "before span batch activation", undecided: 1},
+		// The batches after the undecided one are kept unchecked.
+		{name: "after span batch activation", spanBatchTime: &spanBatchTime, undecided: 2},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &rollup.Config{
+				Genesis: rollup.Genesis{
+					L2Time: 10,
+				},
+				BlockTime:          2,
+				MaxProposerDrift:   600,
+				ProposerWindowSize: 30,
+				SpanBatchTime:      tt.spanBatchTime,
+			}
+			safeHead := eth.L2BlockRef{
+				Hash:     mockHash(10, 2),
+				Number:   0,
+				Time:     10,
+				L1Origin: l1[0].ID(),
+			}
+
+			// The batch advances the epoch, which cannot be checked until the next L1 origin is known.
+			batch, duplicate := b(12, l1[1]), b(12, l1[1])
+			input := &fakeBatchQueueInput{
+				batches: []*BatchData{batch, duplicate},
+				errors:  []error{nil, nil},
+				origin:  l1[0],
+			}
+			bq := NewBatchQueue(testlog.Logger(t, log.LvlCrit), cfg, input, nil)
+			_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
+
+			for i := 0; i < len(input.batches); i++ {
+				b, e := bq.NextBatch(context.Background(), safeHead)
+				require.ErrorIs(t, e, NotEnoughData)
+				require.Nil(t, b)
+			}
+			require.Len(t, append(bq.batches[12], bq.orderedBatches...), tt.undecided)
+
+			input.origin = l1[1]
+			b, e := bq.NextBatch(context.Background(), safeHead)
+			require.NoError(t, e)
+			require.Equal(t, batch, b)
+			safeHead.Number += 1
+			safeHead.Time += 2
+			safeHead.Hash = mockHash(b.Timestamp, 2)
+			safeHead.L1Origin = b.Epoch()
+
+			b, e = bq.NextBatch(context.Background(), safeHead)
+			require.ErrorIs(t, e, io.EOF)
+			require.Nil(t, b)
+			require.Empty(t, bq.batches)
+			require.Empty(t, bq.orderedBatches)
+		})
+	}
+}
+
+func spanBatch(t *testing.T, cfg *rollup.Config, batches ...*BatchData) *BatchData {
+	builder := newSpanBatchBuilder(cfg.Genesis.L2Time, cfg.BlockTime)
+	for _, batch := range batches {
+		require.NoError(t, builder.check(batch))
+		builder.add(batch)
+	}
+	return &BatchData{SpanBatch: &builder.batch}
+}
+
+func spanBatchTestConfig() *rollup.Config {
+	spanBatchTime := uint64(0)
+	return &rollup.Config{
+		Genesis: rollup.Genesis{
+			L2:     eth.BlockID{Hash: mockHash(10, 2)},
+			L2Time: 10,
+		},
+		BlockTime:          2,
+		MaxProposerDrift:   600,
+		ProposerWindowSize: 30,
+		SpanBatchTime:      &spanBatchTime,
+	}
+}
+
+// safePayload returns the payload of the safe block of the batch, with its L1 info deposit.
+func safePayload(t *testing.T, batch *BatchData, number uint64, l1Origin eth.L1BlockRef, seqNumber uint64) *eth.ExecutionPayload {
+	l1Info, err := L1InfoDepositBytes(seqNumber, &testutils.MockBlockInfo{
+		InfoHash:    l1Origin.Hash,
+		InfoNum:     l1Origin.Number,
+		InfoTime:    l1Origin.Time,
+		InfoBaseFee: big.NewInt(7),
+	}, eth.SystemConfig{})
+	require.NoError(t, err)
+	txs := []eth.Data{l1Info}
+	for _, tx := range batch.Transactions {
+		txs = append(txs, eth.Data(tx))
+	}
+	return &eth.ExecutionPayload{
+		BlockNumber:  eth.Uint64Quantity(number),
+		BlockHash:    mockHash(batch.Timestamp, 2),
+		Timestamp:    eth.Uint64Quantity(batch.Timestamp),
+		Transactions: txs,
+	}
+}
+
+// TestBatchQueueSpanBatch adds a span batch and asserts that its blocks are all derived from it.
+func TestBatchQueueSpanBatch(t *testing.T) {
+	log := testlog.Logger(t, log.LvlCrit)
+	l1 := L1Chain([]uint64{10, 20, 30})
+	cfg := spanBatchTestConfig()
+	safeHead := eth.L2BlockRef{
+		Hash:     mockHash(10, 2),
+		Number:   0,
+		Time:     10,
+		L1Origin: l1[0].ID(),
+	}
+
+	batches := []*BatchData{b(12, l1[0]), b(14, l1[0]), b(16, l1[0]), b(18, l1[0]), b(20, l1[1]), b(22, l1[1])}
+	input := &fakeBatchQueueInput{
+		batches: []*BatchData{spanBatch(t, cfg, batches...), nil},
+		errors:  []error{nil, io.EOF},
+		origin:  l1[0],
+	}
+
+	bq := NewBatchQueue(log, cfg, input, nil)
+	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
+	// Advance the origin
+	input.origin = l1[1]
+
+	for _, expected := range batches {
+		batch, err := bq.NextBatch(context.Background(), safeHead)
+		require.NoError(t, err)
+		require.Equal(t, expected, batch)
+
+		safeHead.Number += 1
+		safeHead.Time += 2
+		safeHead.Hash = mockHash(batch.Timestamp, 2)
+		safeHead.L1Origin = batch.Epoch()
+	}
+	batch, err := bq.NextBatch(context.Background(), safeHead)
+	require.ErrorIs(t, err, io.EOF)
+	require.Nil(t, batch)
+}
+
+// TestBatchQueueSpanBatchOverlap asserts that a span batch is only accepted from the safe head on,
+// if its blocks before match the safe chain.
+func TestBatchQueueSpanBatchOverlap(t *testing.T) {
+	l1 := L1Chain([]uint64{10, 20, 30})
+	cfg := spanBatchTestConfig()
+	batches := []*BatchData{b(12, l1[0]), b(14, l1[0]), b(16, l1[0]), b(18, l1[0])}
+	// The safe head is the second block of the span batch.
+	safeHead := eth.L2BlockRef{
+		Hash:     mockHash(14, 2),
+		Number:   2,
+		Time:     14,
+		L1Origin: l1[0].ID(),
+	}
+
+	for _, tt := range []struct {
+		name     string
+		safeTx   hexutil.Bytes
+		expected []*BatchData
+		err      error
+	}{
+		{name: "matching", safeTx: batches[1].Transactions[0], expected: batches[2:], err: io.EOF},
+		{name: "mismatching", safeTx: hexutil.Bytes{0x01}, err: NotEnoughData},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			l2 := &testutils.MockL2Client{}
+			l2.ExpectPayloadByNumber(0, &eth.ExecutionPayload{BlockHash: mockHash(10, 2)}, nil)
+			l2.ExpectPayloadByNumber(1, safePayload(t, batches[0], 1, l1[0], 1), nil)
+			safeBatch := *batches[1]
+			safeBatch.Transactions = []hexutil.Bytes{tt.safeTx}
+			l2.ExpectPayloadByNumber(2, safePayload(t, &safeBatch, 2, l1[0], 2), nil)
+
+			input := &fakeBatchQueueInput{
+				batches: []*BatchData{spanBatch(t, cfg, batches...), nil},
+				errors:  []error{nil, io.EOF},
+				origin:  l1[0],
+			}
+			bq := NewBatchQueue(testlog.Logger(t, log.LvlCrit), cfg, input, l2)
+			_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
+			input.origin = l1[1]
+
+			head := safeHead
+			for _, expected := range tt.expected {
+				batch, err := bq.NextBatch(context.Background(), head)
+				require.NoError(t, err)
+				require.Equal(t, expected, batch)
+
+				head.Number += 1
+				head.Time += 2
+				head.Hash = mockHash(batch.Timestamp, 2)
+			}
+			batch, err := bq.NextBatch(context.Background(), head)
+			require.ErrorIs(t, err, tt.err)
+			require.Nil(t, batch)
+			require.Empty(t, bq.orderedBatches)
+		})
+	}
+}
+
+// TestBatchQueueSpanBatchBeforeActivation asserts that the span batches included before their activation are dropped.
+func TestBatchQueueSpanBatchBeforeActivation(t *testing.T) {
+	l1 := L1Chain([]uint64{10, 20, 30})
+	cfg := spanBatchTestConfig()
+	spanBatchTime := uint64(21)
+	cfg.SpanBatchTime = &spanBatchTime
+	safeHead := eth.L2BlockRef{
+		Hash:     mockHash(10, 2),
+		Number:   0,
+		Time:     10,
+		L1Origin: l1[0].ID(),
+	}
+
+	input := &fakeBatchQueueInput{
+		batches: []*BatchData{spanBatch(t, cfg, b(12, l1[0]), b(14, l1[0]))},
+		errors:  []error{nil},
+		origin:  l1[0],
+	}
+	bq := NewBatchQueue(testlog.Logger(t, log.LvlCrit), cfg, input, nil)
+	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
+	input.origin = l1[1]
+
+	batch, err := bq.NextBatch(context.Background(), safeHead)
+	require.ErrorIs(t, err, NotEnoughData)
+	require.Nil(t, batch)
+	require.Empty(t, bq.orderedBatches)
+}
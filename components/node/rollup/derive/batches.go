@@ -1,6 +1,9 @@
 package derive
 
 import (
+	"bytes"
+	"context"
+
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
@@ -142,3 +145,147 @@ func CheckBatch(cfg *rollup.Config, log log.Logger, l1Blocks []eth.L1BlockRef, l
 
 	return BatchAccept
 }
+
+// CheckSpanBatch checks if the given span batch can be applied on top of the given l2SafeHead, like CheckBatch.
+// The blocks of the span batch up to the safe head must match the safe chain, which is fetched from l2, and
+// the other blocks must each be valid, as checked by CheckBatch. If the span batch is accepted, its singular
+// batches from the one following the safe head are returned. Their parent hashes are only known once
+// the previous one is derived, so they must be set then.
+func CheckSpanBatch(ctx context.Context, cfg *rollup.Config, log log.Logger, l1Blocks []eth.L1BlockRef, l2SafeHead eth.L2BlockRef,
+	batch *BatchWithL1InclusionBlock, l2 SafeBlockFetcher,
+) (BatchValidity, []*BatchData) {
+	span := batch.Batch.SpanBatch
+	startTimestamp, endTimestamp := span.Timestamp(cfg), span.LastTimestamp(cfg)
+	log = log.New(
+		"batch_timestamp", startTimestamp,
+		"batch_last_timestamp", endTimestamp,
+		"blocks", len(span.Blocks),
+	)
+
+	// sanity check we have consistent inputs
+	if len(l1Blocks) == 0 {
+		log.Warn("missing L1 block input, cannot proceed with batch checking")
+		return BatchUndecided, nil
+	}
+
+	if !cfg.IsSpanBatch(batch.L1InclusionBlock.Time) {
+		log.Warn("dropping span batch included before the span batch activation", "l1_inclusion_time", batch.L1InclusionBlock.Time)
+		return BatchDrop, nil
+	}
+	if span.RelTimestamp == 0 || span.RelTimestamp%cfg.BlockTime != 0 {
+		log.Warn("dropping span batch with a timestamp not matching any L2 block")
+		return BatchDrop, nil
+	}
+
+	nextTimestamp := l2SafeHead.Time + cfg.BlockTime
+	if startTimestamp > nextTimestamp {
+		log.Trace("received out-of-order span batch for future processing after next batch", "next_timestamp", nextTimestamp)
+		return BatchFuture, nil
+	}
+	if endTimestamp < nextTimestamp {
+		log.Warn("dropping span batch with old timestamps", "min_timestamp", nextTimestamp)
+		return BatchDrop, nil
+	}
+
+	// The blocks up to the safe head must already be in the safe chain.
+	overlap := int((nextTimestamp - startTimestamp) / cfg.BlockTime)
+	parentHash := l2SafeHead.Hash
+	if overlap > 0 {
+		parentNum := l2SafeHead.Number - uint64(overlap)
+		parent, err := l2.PayloadByNumber(ctx, parentNum)
+		if err != nil {
+			log.Warn("failed to fetch the parent of the span batch", "number", parentNum, "err", err)
+			return BatchUndecided, nil
+		}
+		parentHash = parent.BlockHash
+		for i := 0; i < overlap; i++ {
+			payload, err := l2.PayloadByNumber(ctx, parentNum+1+uint64(i))
+			if err != nil {
+				log.Warn("failed to fetch the safe block overlapping with the span batch", "number", parentNum+1+uint64(i), "err", err)
+				return BatchUndecided, nil
+			}
+			ref, err := PayloadToBlockRef(payload, &cfg.Genesis)
+			if err != nil {
+				log.Warn("failed to read the safe block overlapping with the span batch", "number", parentNum+1+uint64(i), "err", err)
+				return BatchUndecided, nil
+			}
+			if ref.L1Origin.Number != uint64(span.Blocks[i].EpochNum) {
+				log.Warn("dropping span batch overlapping with a safe block of another L1 origin", "block", ref.ID(), "l1_origin", ref.L1Origin)
+				return BatchDrop, nil
+			}
+			var txs []eth.Data
+			for _, tx := range payload.Transactions {
+				if len(tx) > 0 && tx[0] != types.DepositTxType {
+					txs = append(txs, tx)
+				}
+			}
+			if !equalTransactions(txs, span.Blocks[i]) {
+				log.Warn("dropping span batch overlapping with a safe block of other txs", "block", ref.ID())
+				return BatchDrop, nil
+			}
+		}
+	}
+	if !bytes.Equal(parentHash[:spanBatchCheckLength], span.ParentCheck[:]) {
+		log.Warn("ignoring span batch with mismatching parent hash", "parent_hash", parentHash)
+		return BatchDrop, nil
+	}
+
+	// The L1 origin of the last block must be buffered, since it was before the L1 inclusion block.
+	lastEpochNum := uint64(span.Blocks[len(span.Blocks)-1].EpochNum)
+	if lastEpochNum < l1Blocks[0].Number || lastEpochNum-l1Blocks[0].Number >= uint64(len(l1Blocks)) {
+		log.Warn("dropping span batch with an L1 origin out of the current L1 blocks", "l1_origin", lastEpochNum,
+			"first", l1Blocks[0].ID(), "last", l1Blocks[len(l1Blocks)-1].ID())
+		return BatchDrop, nil
+	}
+	if lastOrigin := l1Blocks[lastEpochNum-l1Blocks[0].Number]; !bytes.Equal(lastOrigin.Hash[:spanBatchCheckLength], span.L1OriginCheck[:]) {
+		log.Warn("span batch is for different L1 chain, L1 origin hash does not match", "expected", lastOrigin.ID())
+		return BatchDrop, nil
+	}
+
+	// Check each of the next blocks as a singular batch, on top of the blocks before.
+	batches := make([]*BatchData, 0, len(span.Blocks)-overlap)
+	safeHead := l2SafeHead
+	for i := overlap; i < len(span.Blocks); i++ {
+		block := span.Blocks[i]
+		singular := &BatchData{BatchV1: BatchV1{
+			ParentHash:   safeHead.Hash,
+			EpochNum:     block.EpochNum,
+			Timestamp:    safeHead.Time + cfg.BlockTime,
+			Transactions: block.Transactions,
+		}}
+		if epochNum := uint64(block.EpochNum); epochNum >= l1Blocks[0].Number && epochNum-l1Blocks[0].Number < uint64(len(l1Blocks)) {
+			singular.EpochHash = l1Blocks[epochNum-l1Blocks[0].Number].Hash
+		}
+		validity := CheckBatch(cfg, log.New("block_index", i), l1Blocks, safeHead, &BatchWithL1InclusionBlock{
+			L1InclusionBlock: batch.L1InclusionBlock,
+			Batch:            singular,
+		})
+		if validity != BatchAccept {
+			return validity, nil
+		}
+		batches = append(batches, singular)
+
+		if uint64(block.EpochNum) == l1Blocks[0].Number+1 {
+			l1Blocks = l1Blocks[1:]
+		}
+		// The hash of the block is not known before it is derived.
+		safeHead = eth.L2BlockRef{
+			Number:   safeHead.Number + 1,
+			Time:     singular.Timestamp,
+			L1Origin: singular.Epoch(),
+		}
+	}
+	return BatchAccept, batches
+}
+
+func equalTransactions(txs []eth.Data, block SpanBatchBlock) bool {
+	if len(txs) != len(block.Transactions) {
+		return false
+	}
+	for i := range txs {
+		if !bytes.Equal(txs[i], block.Transactions[i]) {
+			return false
+		}
+	}
+	return true
+}
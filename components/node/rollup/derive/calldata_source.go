@@ -13,6 +13,7 @@ import (
 
 	"github.com/kroma-network/kroma/components/node/eth"
 	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/utils/altda"
 )
 
 type DataIter interface {
@@ -23,22 +24,30 @@ type L1TransactionFetcher interface {
 	InfoAndTxsByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, types.Transactions, error)
 }
 
+// DAFetcher fetches the data of a commitment from the alternative DA layer.
+type DAFetcher interface {
+	GetInput(ctx context.Context, comm altda.Commitment) ([]byte, error)
+}
+
 // DataSourceFactory readers raw transactions from a given block & then filters for
 // batch submitter transactions.
 // This is not a stage in the pipeline, but a wrapper for another stage in the pipeline
 type DataSourceFactory struct {
-	log     log.Logger
-	cfg     *rollup.Config
-	fetcher L1TransactionFetcher
+	log       log.Logger
+	cfg       *rollup.Config
+	fetcher   L1TransactionFetcher
+	daFetcher DAFetcher
 }
 
-func NewDataSourceFactory(log log.Logger, cfg *rollup.Config, fetcher L1TransactionFetcher) *DataSourceFactory {
-	return &DataSourceFactory{log: log, cfg: cfg, fetcher: fetcher}
+// NewDataSourceFactory creates a data source factory. The daFetcher fetches the data of the
+// commitments once the alternative DA is active, and may be nil if it is not configured.
+func NewDataSourceFactory(log log.Logger, cfg *rollup.Config, fetcher L1TransactionFetcher, daFetcher DAFetcher) *DataSourceFactory {
+	return &DataSourceFactory{log: log, cfg: cfg, fetcher: fetcher, daFetcher: daFetcher}
 }
 
 // OpenData returns a DataIter. This struct implements the `Next` function.
 func (ds *DataSourceFactory) OpenData(ctx context.Context, id eth.BlockID, batcherAddr common.Address) DataIter {
-	return NewDataSource(ctx, ds.log, ds.cfg, ds.fetcher, id, batcherAddr)
+	return NewDataSource(ctx, ds.log, ds.cfg, ds.fetcher, ds.daFetcher, id, batcherAddr)
 }
 
 // DataSource is a fault tolerant approach to fetching data.
@@ -48,32 +57,41 @@ type DataSource struct {
 	// Internal state + data
 	open bool
 	data []eth.Data
+	// altDA is whether the alternative DA is active at the L1 block, so that the data of the
+	// commitments is fetched from the DA layer.
+	altDA bool
 	// Required to re-attempt fetching
-	id      eth.BlockID
-	cfg     *rollup.Config // TODO: `DataFromEVMTransactions` should probably not take the full config
-	fetcher L1TransactionFetcher
-	log     log.Logger
+	id        eth.BlockID
+	cfg       *rollup.Config // TODO: `DataFromEVMTransactions` should probably not take the full config
+	fetcher   L1TransactionFetcher
+	daFetcher DAFetcher
+	log       log.Logger
 
 	batcherAddr common.Address
 }
 
 // NewDataSource creates a new calldata source. It suppresses errors in fetching the L1 block if they occur.
 // If there is an error, it will attempt to fetch the result on the next call to `Next`.
-func NewDataSource(ctx context.Context, log log.Logger, cfg *rollup.Config, fetcher L1TransactionFetcher, block eth.BlockID, batcherAddr common.Address) DataIter {
-	_, txs, err := fetcher.InfoAndTxsByHash(ctx, block.Hash)
+func NewDataSource(ctx context.Context, log log.Logger, cfg *rollup.Config, fetcher L1TransactionFetcher, daFetcher DAFetcher, block eth.BlockID, batcherAddr common.Address) DataIter {
+	info, txs, err := fetcher.InfoAndTxsByHash(ctx, block.Hash)
 	if err != nil {
 		return &DataSource{
 			open:        false,
 			id:          block,
 			cfg:         cfg,
 			fetcher:     fetcher,
+			daFetcher:   daFetcher,
 			log:         log,
 			batcherAddr: batcherAddr,
 		}
 	} else {
 		return &DataSource{
-			open: true,
-			data: DataFromEVMTransactions(cfg, batcherAddr, txs, log.New("origin", block)),
+			open:      true,
+			data:      DataFromEVMTransactions(cfg, batcherAddr, block.Number, txs, log.New("origin", block)),
+			altDA:     cfg.IsAltDA(info.Time()),
+			id:        block,
+			daFetcher: daFetcher,
+			log:       log,
 		}
 	}
 }
@@ -83,32 +101,64 @@ func NewDataSource(ctx context.Context, log log.Logger, cfg *rollup.Config, fetc
 // otherwise it returns a temporary error if fetching the block returns an error.
 func (ds *DataSource) Next(ctx context.Context) (eth.Data, error) {
 	if !ds.open {
-		if _, txs, err := ds.fetcher.InfoAndTxsByHash(ctx, ds.id.Hash); err == nil {
+		if info, txs, err := ds.fetcher.InfoAndTxsByHash(ctx, ds.id.Hash); err == nil {
 			ds.open = true
-			ds.data = DataFromEVMTransactions(ds.cfg, ds.batcherAddr, txs, log.New("origin", ds.id))
+			ds.data = DataFromEVMTransactions(ds.cfg, ds.batcherAddr, ds.id.Number, txs, log.New("origin", ds.id))
+			ds.altDA = ds.cfg.IsAltDA(info.Time())
 		} else if errors.Is(err, ethereum.NotFound) {
 			return nil, NewResetError(fmt.Errorf("failed to open calldata source: %w", err))
 		} else {
 			return nil, NewTemporaryError(fmt.Errorf("failed to open calldata source: %w", err))
 		}
 	}
-	if len(ds.data) == 0 {
-		return nil, io.EOF
-	} else {
+	for len(ds.data) > 0 {
 		data := ds.data[0]
+		if ds.altDA && len(data) > 0 && data[0] == DerivationVersionAltDA {
+			input, err := ds.fetchAltDA(ctx, data[1:])
+			if errors.Is(err, altda.ErrInvalidCommitment) {
+				ds.log.Warn("ignoring batcher tx with an invalid DA commitment", "origin", ds.id, "err", err)
+				ds.data = ds.data[1:]
+				continue
+			} else if err != nil {
+				return nil, NewTemporaryError(err)
+			}
+			data = input
+		}
 		ds.data = ds.data[1:]
 		return data, nil
 	}
+	return nil, io.EOF
+}
+
+// fetchAltDA fetches the data of the commitment from the DA layer and verifies it against the commitment.
+// The data must be available: failures to fetch it are retried rather than skipped.
+func (ds *DataSource) fetchAltDA(ctx context.Context, commitment []byte) ([]byte, error) {
+	comm, err := altda.DecodeCommitment(commitment)
+	if err != nil {
+		return nil, err
+	}
+	if ds.daFetcher == nil {
+		return nil, fmt.Errorf("no DA server configured to fetch the data of %s", comm)
+	}
+	data, err := ds.daFetcher.GetInput(ctx, comm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the data of %s from the DA layer: %w", comm, err)
+	}
+	if err := comm.Verify(data); err != nil {
+		return nil, fmt.Errorf("failed to verify the data of %s from the DA layer: %w", comm, err)
+	}
+	return data, nil
 }
 
 // DataFromEVMTransactions filters all of the transactions and returns the calldata from transactions
-// that are sent to the batch inbox address from the batch sender address.
+// that are sent to the batch inbox address active at the given L1 block from the batch sender address.
 // This will return an empty array if no valid transactions are found.
-func DataFromEVMTransactions(config *rollup.Config, batcherAddr common.Address, txs types.Transactions, log log.Logger) []eth.Data {
+func DataFromEVMTransactions(config *rollup.Config, batcherAddr common.Address, l1Block uint64, txs types.Transactions, log log.Logger) []eth.Data {
 	var out []eth.Data
 	l1Signer := config.L1Signer()
+	batchInbox := config.BatchInboxAddressAt(l1Block)
 	for j, tx := range txs {
-		if to := tx.To(); to != nil && *to == config.BatchInboxAddress {
+		if to := tx.To(); to != nil && *to == batchInbox {
 			seqDataSubmitter, err := l1Signer.Sender(tx) // optimization: only derive sender if To is correct
 			if err != nil {
 				log.Warn("tx in inbox with invalid signature", "index", j, "err", err)
@@ -1,7 +1,9 @@
 package derive
 
 import (
+	"context"
 	"crypto/ecdsa"
+	"io"
 	"math/big"
 	"math/rand"
 	"testing"
@@ -17,6 +19,7 @@ import (
 	"github.com/kroma-network/kroma/components/node/rollup"
 	"github.com/kroma-network/kroma/components/node/testlog"
 	"github.com/kroma-network/kroma/components/node/testutils"
+	"github.com/kroma-network/kroma/utils/altda"
 )
 
 type testTx struct {
@@ -120,8 +123,125 @@ func TestDataFromEVMTransactions(t *testing.T) {
 			}
 		}
 
-		out := DataFromEVMTransactions(cfg, batcherAddr, txs, testlog.Logger(t, log.LvlCrit))
+		out := DataFromEVMTransactions(cfg, batcherAddr, 0, txs, testlog.Logger(t, log.LvlCrit))
 		require.ElementsMatch(t, expectedData, out)
 	}
 
 }
+
+// TestDataFromEVMTransactionsInboxRotation tests that the batches are only accepted at the batch
+// inbox address active at the L1 block of the transactions.
+func TestDataFromEVMTransactionsInboxRotation(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	batcherPriv := testutils.RandomKey()
+	batcherAddr := crypto.PubkeyToAddress(batcherPriv.PublicKey)
+	cfg := &rollup.Config{
+		L1ChainID:         big.NewInt(100),
+		BatchInboxAddress: testutils.RandomAddress(rng),
+		BatchInboxRotations: []rollup.BatchInboxRotation{
+			{L1Block: 10, Address: testutils.RandomAddress(rng)},
+			{L1Block: 20, Address: testutils.RandomAddress(rng)},
+		},
+	}
+	signer := cfg.L1Signer()
+
+	var txs types.Transactions
+	for _, inbox := range cfg.BatchInboxAddresses() {
+		inbox := inbox
+		tx := testTx{to: &inbox, dataLen: 100, author: batcherPriv}
+		txs = append(txs, tx.Create(t, signer, rng))
+	}
+
+	for _, test := range []struct {
+		l1Block uint64
+		index   int
+	}{
+		{l1Block: 0, index: 0},
+		{l1Block: 9, index: 0},
+		{l1Block: 10, index: 1},
+		{l1Block: 19, index: 1},
+		{l1Block: 20, index: 2},
+		{l1Block: 100, index: 2},
+	} {
+		out := DataFromEVMTransactions(cfg, batcherAddr, test.l1Block, txs, testlog.Logger(t, log.LvlCrit))
+		require.Equal(t, []eth.Data{txs[test.index].Data()}, out, "L1 block %d", test.l1Block)
+	}
+}
+
+// TestDataSourceAltDA tests that the data of the commitments is fetched from the DA layer once the
+// alternative DA is active, skipping the invalid commitments and retrying the unavailable data.
+func TestDataSourceAltDA(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	batcherPriv := testutils.RandomKey()
+	batcherAddr := crypto.PubkeyToAddress(batcherPriv.PublicKey)
+	altDATime := uint64(100)
+	cfg := &rollup.Config{
+		L1ChainID:         big.NewInt(100),
+		BatchInboxAddress: testutils.RandomAddress(rng),
+		AltDATime:         &altDATime,
+	}
+	signer := cfg.L1Signer()
+	newTx := func(data []byte) *types.Transaction {
+		return types.MustSignNewTx(batcherPriv, signer, &types.DynamicFeeTx{
+			ChainID:   signer.ChainID(),
+			GasTipCap: big.NewInt(2 * params.GWei),
+			GasFeeCap: big.NewInt(30 * params.GWei),
+			Gas:       100_000,
+			To:        &cfg.BatchInboxAddress,
+			Data:      data,
+		})
+	}
+
+	store := altda.NewMemStore()
+	posted := append([]byte{DerivationVersion0}, testutils.RandomData(rng, 100)...)
+	comm, err := store.SetInput(context.Background(), posted)
+	require.NoError(t, err)
+	plain := append([]byte{DerivationVersion0}, testutils.RandomData(rng, 100)...)
+	missing := append([]byte{DerivationVersion0}, testutils.RandomData(rng, 100)...)
+	txs := types.Transactions{
+		newTx(append([]byte{DerivationVersionAltDA}, comm...)),
+		newTx(plain),
+		newTx([]byte{DerivationVersionAltDA, 0x05, 0x01}),
+		newTx(append([]byte{DerivationVersionAltDA}, altda.Keccak256Commitment(missing)...)),
+	}
+
+	t.Run("active", func(t *testing.T) {
+		l1F := &testutils.MockL1Source{}
+		block := eth.BlockID{Hash: testutils.RandomHash(rng), Number: 10}
+		l1F.ExpectInfoAndTxsByHash(block.Hash, &testutils.MockBlockInfo{InfoTime: altDATime}, txs, nil)
+		src := NewDataSource(context.Background(), testlog.Logger(t, log.LvlCrit), cfg, l1F, store, block, batcherAddr)
+
+		data, err := src.Next(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, eth.Data(posted), data)
+		data, err = src.Next(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, eth.Data(plain), data)
+
+		// The invalid commitment is skipped, and the missing data is retried until available.
+		_, err = src.Next(context.Background())
+		require.ErrorIs(t, err, ErrTemporary)
+		_, err = store.SetInput(context.Background(), missing)
+		require.NoError(t, err)
+		data, err = src.Next(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, eth.Data(missing), data)
+		_, err = src.Next(context.Background())
+		require.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("inactive", func(t *testing.T) {
+		l1F := &testutils.MockL1Source{}
+		block := eth.BlockID{Hash: testutils.RandomHash(rng), Number: 10}
+		l1F.ExpectInfoAndTxsByHash(block.Hash, &testutils.MockBlockInfo{InfoTime: altDATime - 1}, txs, nil)
+		src := NewDataSource(context.Background(), testlog.Logger(t, log.LvlCrit), cfg, l1F, store, block, batcherAddr)
+
+		for _, tx := range txs {
+			data, err := src.Next(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, eth.Data(tx.Data()), data)
+		}
+		_, err := src.Next(context.Background())
+		require.ErrorIs(t, err, io.EOF)
+	})
+}
@@ -2,7 +2,6 @@ package derive
 
 import (
 	"bytes"
-	"compress/zlib"
 	"crypto/rand"
 	"errors"
 	"fmt"
@@ -35,10 +34,15 @@ type ChannelOut struct {
 	// rlpLength is the uncompressed size of the channel. Must be less than MAX_RLP_BYTES_PER_CHANNEL
 	rlpLength int
 
+	// algo is the compression algorithm of the channel, negotiated by its version byte.
+	algo CompressionAlgo
 	// Compressor stage. Write input data to it
-	compress *zlib.Writer
+	compress compressor
 	// post compression buffer
 	buf bytes.Buffer
+	// span accumulates the batches of a channel made of a single span batch, which is only
+	// written to the compressor at Close. Nil for the channels of singular batches.
+	span *spanBatchBuilder
 
 	closed bool
 }
@@ -47,18 +51,26 @@ func (co *ChannelOut) ID() ChannelID {
 	return co.id
 }
 
-func NewChannelOut() (*ChannelOut, error) {
+// CompressionAlgo returns the compression algorithm of the channel.
+func (co *ChannelOut) CompressionAlgo() CompressionAlgo {
+	return co.algo
+}
+
+// NewChannelOut creates a channel whose data is compressed with the given algorithm.
+func NewChannelOut(algo CompressionAlgo) (*ChannelOut, error) {
 	c := &ChannelOut{
 		id:        ChannelID{}, // TODO: use GUID here instead of fully random data
 		frame:     0,
 		rlpLength: 0,
+		algo:      algo,
 	}
 	_, err := rand.Read(c.id[:])
 	if err != nil {
 		return nil, err
 	}
 
-	compress, err := zlib.NewWriterLevel(&c.buf, zlib.BestCompression)
+	writeChannelVersion(&c.buf, algo)
+	compress, err := newCompressor(algo, &c.buf)
 	if err != nil {
 		return nil, err
 	}
@@ -67,17 +79,41 @@ func NewChannelOut() (*ChannelOut, error) {
 	return c, nil
 }
 
+// NewChannelOutWithID creates a channel with the given ID, e.g. to rebuild a channel whose
+// frames were partially submitted.
+func NewChannelOutWithID(algo CompressionAlgo, id ChannelID) (*ChannelOut, error) {
+	co, err := NewChannelOut(algo)
+	if err != nil {
+		return nil, err
+	}
+	co.id = id
+	return co, nil
+}
+
 // TODO: reuse ChannelOut for performance
 func (co *ChannelOut) Reset() error {
 	co.frame = 0
 	co.rlpLength = 0
 	co.buf.Reset()
+	writeChannelVersion(&co.buf, co.algo)
 	co.compress.Reset(&co.buf)
+	co.span = nil
 	co.closed = false
 	_, err := rand.Read(co.id[:])
 	return err
 }
 
+// UseSpanBatch makes the channel encode the batches added to it into a single span batch, whose
+// timestamps are encoded relative to the L2 genesis time. The span batch is only compressed at Close,
+// so no frames are ready before. It must be called before any batch is added, and until the next Reset.
+func (co *ChannelOut) UseSpanBatch(genesisL2Time uint64, blockTime uint64) error {
+	if co.closed || co.rlpLength != 0 {
+		return errors.New("cannot use a span batch in a non-empty channel")
+	}
+	co.span = newSpanBatchBuilder(genesisL2Time, blockTime)
+	return nil
+}
+
 // AddBlock adds a block to the channel. It returns the RLP encoded byte size
 // and an error if there is a problem adding the block. The only sentinel error
 // that it returns is ErrTooManyRLPBytes. If this error is returned, the channel
@@ -106,6 +142,9 @@ func (co *ChannelOut) AddBatch(batch *BatchData) (uint64, error) {
 	if co.closed {
 		return 0, errors.New("already closed")
 	}
+	if co.span != nil {
+		return co.addToSpanBatch(batch)
+	}
 
 	// We encode to a temporary buffer to determine the encoded length to
 	// ensure that the total size of all RLP elements is less than or equal to MAX_RLP_BYTES_PER_CHANNEL
@@ -123,6 +162,22 @@ func (co *ChannelOut) AddBatch(batch *BatchData) (uint64, error) {
 	return uint64(written), err
 }
 
+// addToSpanBatch adds the batch to the span batch of the channel, which is not written yet.
+func (co *ChannelOut) addToSpanBatch(batch *BatchData) (uint64, error) {
+	if err := co.span.check(batch); err != nil {
+		return 0, err
+	}
+	size := co.span.rlpSizeWith(batch)
+	if size > MaxRLPBytesPerChannel {
+		return 0, fmt.Errorf("could not add %d bytes to channel of %d bytes, max is %d. err: %w",
+			size-co.rlpLength, co.rlpLength, MaxRLPBytesPerChannel, ErrTooManyRLPBytes)
+	}
+	co.span.add(batch)
+	added := size - co.rlpLength
+	co.rlpLength = size
+	return uint64(added), nil
+}
+
 // InputBytes returns the total amount of RLP-encoded input bytes.
 func (co *ChannelOut) InputBytes() int {
 	return co.rlpLength
@@ -146,6 +201,11 @@ func (co *ChannelOut) Close() error {
 		return errors.New("already closed")
 	}
 	co.closed = true
+	if co.span != nil && len(co.span.batch.Blocks) > 0 {
+		if err := rlp.Encode(co.compress, &BatchData{SpanBatch: &co.span.batch}); err != nil {
+			return err
+		}
+	}
 	return co.compress.Close()
 }
 
@@ -222,7 +282,7 @@ func BlockToBatch(block *types.Block) (*BatchData, L1BlockInfo, error) {
 	}
 
 	return &BatchData{
-		BatchV1{
+		BatchV1: BatchV1{
 			ParentHash:   block.ParentHash(),
 			EpochNum:     rollup.Epoch(l1Info.Number),
 			EpochHash:    l1Info.BlockHash,
@@ -0,0 +1,114 @@
+package derive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/kroma-network/kroma/components/node/rollup"
+)
+
+// CompressionAlgo is the algorithm the data of a channel is compressed with.
+type CompressionAlgo string
+
+const (
+	Zlib   CompressionAlgo = "zlib"
+	Brotli CompressionAlgo = "brotli"
+	Zstd   CompressionAlgo = "zstd"
+)
+
+// CompressionAlgos are the supported compression algorithms.
+var CompressionAlgos = []CompressionAlgo{Zlib, Brotli, Zstd}
+
+// Channel versions, the first byte of the channel data, negotiating its compression algorithm.
+// The zlib channels have no version byte: a zlib stream starts with its CMF byte, whose low nibble
+// is the deflate method 8, so it never collides with the versions below.
+const (
+	ChannelVersionBrotli byte = 0x01
+	ChannelVersionZstd   byte = 0x02
+)
+
+const brotliLevel = 10
+
+func (a CompressionAlgo) Check() error {
+	for _, algo := range CompressionAlgos {
+		if a == algo {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported compression algorithm %q, must be one of %v", string(a), CompressionAlgos)
+}
+
+// compressor is the interface shared between the writers of the compression algorithms.
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+func newCompressor(algo CompressionAlgo, w io.Writer) (compressor, error) {
+	switch algo {
+	case Zlib:
+		return zlib.NewWriterLevel(w, zlib.BestCompression)
+	case Brotli:
+		return brotli.NewWriterLevel(w, brotliLevel), nil
+	case Zstd:
+		return zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.SpeedBestCompression), zstd.WithEncoderConcurrency(1))
+	default:
+		return nil, algo.Check()
+	}
+}
+
+// newDecompressor reads the channel version from r and returns a reader of the decompressed data.
+func newDecompressor(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	version, err := br.Peek(1)
+	if err != nil {
+		return nil, err
+	}
+	switch version[0] {
+	case ChannelVersionBrotli:
+		_, _ = br.Discard(1)
+		return brotli.NewReader(br), nil
+	case ChannelVersionZstd:
+		_, _ = br.Discard(1)
+		zr, err := zstd.NewReader(br, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(MaxRLPBytesPerChannel))
+		if err != nil {
+			return nil, err
+		}
+		return zr.IOReadCloser(), nil
+	default:
+		if version[0]&0x0f != 8 {
+			return nil, fmt.Errorf("unknown channel version %d", version[0])
+		}
+		return zlib.NewReader(br)
+	}
+}
+
+// checkChannelVersion returns an error if the channel data is compressed with brotli or zstd while
+// they are not active at the given L1 timestamp, so that the channel is dropped like any channel
+// which is not a zlib stream before their activation.
+func checkChannelVersion(cfg *rollup.Config, data []byte, timestamp uint64) error {
+	if len(data) == 0 || cfg.IsCompressionAlgos(timestamp) {
+		return nil
+	}
+	if data[0] == ChannelVersionBrotli || data[0] == ChannelVersionZstd {
+		return fmt.Errorf("channel version %d is not active at L1 time %d", data[0], timestamp)
+	}
+	return nil
+}
+
+// writeChannelVersion writes the channel version of the compression algorithm to buf, if any.
+func writeChannelVersion(buf *bytes.Buffer, algo CompressionAlgo) {
+	switch algo {
+	case Brotli:
+		buf.WriteByte(ChannelVersionBrotli)
+	case Zstd:
+		buf.WriteByte(ChannelVersionZstd)
+	}
+}
@@ -0,0 +1,102 @@
+package derive
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/node/testutils"
+)
+
+func TestChannelOutCompressionAlgos(t *testing.T) {
+	versions := map[CompressionAlgo]byte{
+		Brotli: ChannelVersionBrotli,
+		Zstd:   ChannelVersionZstd,
+	}
+	for _, algo := range CompressionAlgos {
+		algo := algo
+		t.Run(string(algo), func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1234))
+			var batches []*BatchData
+			for i := 0; i < 5; i++ {
+				batches = append(batches, &BatchData{BatchV1: BatchV1{
+					ParentHash:   testutils.RandomHash(rng),
+					EpochNum:     rollup.Epoch(i),
+					EpochHash:    testutils.RandomHash(rng),
+					Timestamp:    uint64(2 * i),
+					Transactions: []hexutil.Bytes{testutils.RandomData(rng, 100)},
+				}})
+			}
+
+			cout, err := NewChannelOut(algo)
+			require.NoError(t, err)
+			// The channel is read twice, to check that a reset channel writes the version byte again.
+			for round := 0; round < 2; round++ {
+				for _, batch := range batches {
+					_, err := cout.AddBatch(batch)
+					require.NoError(t, err)
+				}
+				require.NoError(t, cout.Close())
+
+				data := cout.buf.Bytes()
+				if version, ok := versions[algo]; ok {
+					require.Equal(t, version, data[0])
+				} else {
+					require.Equal(t, byte(8), data[0]&0x0f, "zlib channels have no version byte")
+				}
+
+				next, err := BatchReader(bytes.NewReader(data), eth.L1BlockRef{})
+				require.NoError(t, err)
+				for _, batch := range batches {
+					read, err := next()
+					require.NoError(t, err)
+					require.Equal(t, batch, read.Batch)
+				}
+				_, err = next()
+				require.ErrorIs(t, err, io.EOF)
+
+				require.NoError(t, cout.Reset())
+			}
+		})
+	}
+}
+
+func TestCheckChannelVersion(t *testing.T) {
+	activation := uint64(100)
+	cfg := &rollup.Config{}
+	for _, algo := range CompressionAlgos {
+		cout, err := NewChannelOut(algo)
+		require.NoError(t, err)
+		require.NoError(t, cout.Close())
+		data := cout.buf.Bytes()
+
+		cfg.CompressionAlgosTime = nil
+		if algo == Zlib {
+			require.NoError(t, checkChannelVersion(cfg, data, 0))
+			continue
+		}
+		require.ErrorContains(t, checkChannelVersion(cfg, data, 1000), "not active", "algorithms never activated")
+		cfg.CompressionAlgosTime = &activation
+		require.ErrorContains(t, checkChannelVersion(cfg, data, 99), "not active")
+		require.NoError(t, checkChannelVersion(cfg, data, 100))
+	}
+}
+
+func TestNewChannelOutInvalidAlgo(t *testing.T) {
+	_, err := NewChannelOut("lz4")
+	require.ErrorContains(t, err, "unsupported compression algorithm")
+}
+
+func TestBatchReaderUnknownChannelVersion(t *testing.T) {
+	_, err := BatchReader(bytes.NewReader([]byte{0x03, 0x00}), eth.L1BlockRef{})
+	require.ErrorContains(t, err, "unknown channel version")
+
+	_, err = BatchReader(bytes.NewReader(nil), eth.L1BlockRef{})
+	require.ErrorIs(t, err, io.EOF)
+}
@@ -19,6 +19,10 @@ func frameSize(frame Frame) uint64 {
 
 const DerivationVersion0 = 0
 
+// DerivationVersionAltDA is the version of the batcher txs carrying a commitment to the
+// DerivationVersion0 data posted to the alternative DA layer, once it is active.
+const DerivationVersionAltDA = 1
+
 // MaxChannelBankSize is the amount of memory space, in number of bytes,
 // till the bank is pruned by removing channels,
 // starting with the oldest channel.
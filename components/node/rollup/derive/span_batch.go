@@ -0,0 +1,290 @@
+package derive
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"math/bits"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/kroma-network/kroma/components/node/rollup"
+)
+
+// Span batch format
+//
+// SpanBatchType := 1
+// spanBatch := SpanBatchType ++ prefix ++ payload
+// prefix := rel_timestamp ++ l1_origin_num ++ parent_check ++ l1_origin_check
+// payload := block_count ++ origin_bits ++ block_tx_counts ++ txs
+//
+// The integers are encoded as unsigned varints, the checks are the first 20 bytes of the hashes.
+// See the [Span Batch Format] specs for the meaning of the fields.
+//
+// [Span Batch Format]: https://github.com/kroma-network/kroma/blob/dev/specs/derivation.md#span-batch-format
+
+const spanBatchCheckLength = 20
+
+var ErrSpanBatchEmpty = errors.New("span batch has no blocks")
+
+// SpanBatch is a range of consecutive L2 blocks, one every block time, encoded into a single batch.
+// Only the parent of the first block and the L1 origin of the last block are committed to: the rest
+// of the chain follows from the blocks being consecutive.
+type SpanBatch struct {
+	// RelTimestamp is the timestamp of the first block, relative to the L2 genesis time.
+	RelTimestamp uint64
+	// ParentCheck is the first 20 bytes of the hash of the parent of the first block.
+	ParentCheck [spanBatchCheckLength]byte
+	// L1OriginCheck is the first 20 bytes of the hash of the L1 origin of the last block.
+	L1OriginCheck [spanBatchCheckLength]byte
+	Blocks        []SpanBatchBlock
+}
+
+// SpanBatchBlock is an L2 block of a span batch.
+type SpanBatchBlock struct {
+	EpochNum     rollup.Epoch
+	Transactions []hexutil.Bytes
+}
+
+// Timestamp returns the timestamp of the first block of the span batch.
+func (b *SpanBatch) Timestamp(cfg *rollup.Config) uint64 {
+	return cfg.Genesis.L2Time + b.RelTimestamp
+}
+
+// LastTimestamp returns the timestamp of the last block of the span batch.
+func (b *SpanBatch) LastTimestamp(cfg *rollup.Config) uint64 {
+	return b.Timestamp(cfg) + uint64(len(b.Blocks)-1)*cfg.BlockTime
+}
+
+func (b *SpanBatch) encode(buf *bytes.Buffer) error {
+	n := len(b.Blocks)
+	if n == 0 {
+		return ErrSpanBatchEmpty
+	}
+	writeUvarint(buf, b.RelTimestamp)
+	writeUvarint(buf, uint64(b.Blocks[n-1].EpochNum))
+	buf.Write(b.ParentCheck[:])
+	buf.Write(b.L1OriginCheck[:])
+
+	writeUvarint(buf, uint64(n))
+	originBits := new(big.Int)
+	for i := 1; i < n; i++ {
+		switch b.Blocks[i].EpochNum {
+		case b.Blocks[i-1].EpochNum:
+		case b.Blocks[i-1].EpochNum + 1:
+			originBits.SetBit(originBits, i-1, 1)
+		default:
+			return fmt.Errorf("span batch block %d has L1 origin %d, not following %d", i, b.Blocks[i].EpochNum, b.Blocks[i-1].EpochNum)
+		}
+	}
+	buf.Write(originBits.FillBytes(make([]byte, originBitsLength(n))))
+	for _, block := range b.Blocks {
+		writeUvarint(buf, uint64(len(block.Transactions)))
+	}
+	for _, block := range b.Blocks {
+		for _, tx := range block.Transactions {
+			writeUvarint(buf, uint64(len(tx)))
+			buf.Write(tx)
+		}
+	}
+	return nil
+}
+
+func (b *SpanBatch) decode(data []byte) error {
+	r := bytes.NewReader(data)
+	relTimestamp, err := binary.ReadUvarint(r)
+	if err != nil {
+		return fmt.Errorf("failed to read span batch timestamp: %w", err)
+	}
+	l1OriginNum, err := binary.ReadUvarint(r)
+	if err != nil {
+		return fmt.Errorf("failed to read span batch L1 origin number: %w", err)
+	}
+	var parentCheck, l1OriginCheck [spanBatchCheckLength]byte
+	if _, err := io.ReadFull(r, parentCheck[:]); err != nil {
+		return fmt.Errorf("failed to read span batch parent check: %w", err)
+	}
+	if _, err := io.ReadFull(r, l1OriginCheck[:]); err != nil {
+		return fmt.Errorf("failed to read span batch L1 origin check: %w", err)
+	}
+
+	// Every block takes at least a byte, its tx count, and every tx takes at least its length,
+	// so the counts are bounded by the remaining data before anything is allocated.
+	blockCount, err := binary.ReadUvarint(r)
+	if err != nil {
+		return fmt.Errorf("failed to read span batch block count: %w", err)
+	}
+	if blockCount == 0 {
+		return ErrSpanBatchEmpty
+	}
+	if blockCount > uint64(r.Len()) {
+		return fmt.Errorf("span batch block count %d is too large", blockCount)
+	}
+	n := int(blockCount)
+	rawBits := make([]byte, originBitsLength(n))
+	if _, err := io.ReadFull(r, rawBits); err != nil {
+		return fmt.Errorf("failed to read span batch origin bits: %w", err)
+	}
+	originBits := new(big.Int).SetBytes(rawBits)
+	if originBits.BitLen() > n-1 {
+		return errors.New("span batch origin bits have padding bits set")
+	}
+
+	blocks := make([]SpanBatchBlock, n)
+	txCounts := make([]uint64, n)
+	totalTxs := uint64(0)
+	for i := range txCounts {
+		if txCounts[i], err = binary.ReadUvarint(r); err != nil {
+			return fmt.Errorf("failed to read span batch tx count of block %d: %w", i, err)
+		}
+		if totalTxs += txCounts[i]; txCounts[i] > uint64(r.Len()) || totalTxs > uint64(r.Len()) {
+			return fmt.Errorf("span batch tx count of block %d is too large", i)
+		}
+	}
+	for i := range blocks {
+		if txCounts[i] > 0 {
+			blocks[i].Transactions = make([]hexutil.Bytes, txCounts[i])
+		}
+		for j := range blocks[i].Transactions {
+			length, err := binary.ReadUvarint(r)
+			if err != nil {
+				return fmt.Errorf("failed to read span batch tx length: %w", err)
+			}
+			if length > uint64(r.Len()) {
+				return fmt.Errorf("span batch tx length %d is too large", length)
+			}
+			tx := make([]byte, length)
+			if _, err := io.ReadFull(r, tx); err != nil {
+				return err
+			}
+			blocks[i].Transactions[j] = tx
+		}
+	}
+	if r.Len() != 0 {
+		return fmt.Errorf("span batch has %d trailing bytes", r.Len())
+	}
+
+	// The L1 origins are counted back from the one of the last block.
+	epochNum := l1OriginNum
+	for i := n - 1; i >= 0; i-- {
+		blocks[i].EpochNum = rollup.Epoch(epochNum)
+		if i > 0 && originBits.Bit(i-1) == 1 {
+			if epochNum == 0 {
+				return errors.New("span batch L1 origins go below zero")
+			}
+			epochNum--
+		}
+	}
+
+	b.RelTimestamp = relTimestamp
+	b.ParentCheck = parentCheck
+	b.L1OriginCheck = l1OriginCheck
+	b.Blocks = blocks
+	return nil
+}
+
+// spanBatchBuilder accumulates consecutive singular batches into a span batch, keeping track of its
+// encoded size, so that a channel can be filled without encoding the span batch for every block.
+type spanBatchBuilder struct {
+	genesisL2Time uint64
+	blockTime     uint64
+
+	batch         SpanBatch
+	lastTimestamp uint64
+	// txsSize is the encoded size of the tx counts and the txs of the blocks.
+	txsSize int
+}
+
+func newSpanBatchBuilder(genesisL2Time, blockTime uint64) *spanBatchBuilder {
+	return &spanBatchBuilder{
+		genesisL2Time: genesisL2Time,
+		blockTime:     blockTime,
+	}
+}
+
+// check returns an error if the singular batch does not extend the span batch.
+func (s *spanBatchBuilder) check(batch *BatchData) error {
+	if batch.SpanBatch != nil {
+		return errors.New("cannot add a span batch to a span batch")
+	}
+	if len(s.batch.Blocks) == 0 {
+		if batch.Timestamp <= s.genesisL2Time {
+			return fmt.Errorf("batch timestamp %d is not after the L2 genesis time %d", batch.Timestamp, s.genesisL2Time)
+		}
+		return nil
+	}
+	if batch.Timestamp != s.lastTimestamp+s.blockTime {
+		return fmt.Errorf("batch timestamp %d does not follow the span batch timestamp %d", batch.Timestamp, s.lastTimestamp)
+	}
+	last := s.batch.Blocks[len(s.batch.Blocks)-1].EpochNum
+	if batch.EpochNum != last && batch.EpochNum != last+1 {
+		return fmt.Errorf("batch L1 origin %d does not follow the span batch L1 origin %d", batch.EpochNum, last)
+	}
+	return nil
+}
+
+// rlpSizeWith returns the size of the RLP-encoded span batch, if the singular batch was added.
+func (s *spanBatchBuilder) rlpSizeWith(batch *BatchData) int {
+	n := len(s.batch.Blocks) + 1
+	relTimestamp := s.batch.RelTimestamp
+	if n == 1 {
+		relTimestamp = batch.Timestamp - s.genesisL2Time
+	}
+	size := 1 + // type
+		uvarintSize(relTimestamp) + uvarintSize(uint64(batch.EpochNum)) + 2*spanBatchCheckLength +
+		uvarintSize(uint64(n)) + originBitsLength(n) + s.txsSize + blockTxsSize(batch)
+	return rlpStringSize(size)
+}
+
+// add adds the singular batch to the span batch. It must be checked first.
+func (s *spanBatchBuilder) add(batch *BatchData) {
+	if len(s.batch.Blocks) == 0 {
+		s.batch.RelTimestamp = batch.Timestamp - s.genesisL2Time
+		copy(s.batch.ParentCheck[:], batch.ParentHash[:spanBatchCheckLength])
+	}
+	copy(s.batch.L1OriginCheck[:], batch.EpochHash[:spanBatchCheckLength])
+	s.batch.Blocks = append(s.batch.Blocks, SpanBatchBlock{
+		EpochNum:     batch.EpochNum,
+		Transactions: batch.Transactions,
+	})
+	s.lastTimestamp = batch.Timestamp
+	s.txsSize += blockTxsSize(batch)
+}
+
+// blockTxsSize returns the encoded size of the tx count and the txs of the singular batch.
+func blockTxsSize(batch *BatchData) int {
+	size := uvarintSize(uint64(len(batch.Transactions)))
+	for _, tx := range batch.Transactions {
+		size += uvarintSize(uint64(len(tx))) + len(tx)
+	}
+	return size
+}
+
+// originBitsLength returns the length of the origin bits of n blocks, a bit per block but the first.
+func originBitsLength(n int) int {
+	return (n - 1 + 7) / 8
+}
+
+func writeUvarint(buf *bytes.Buffer, v uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	buf.Write(tmp[:binary.PutUvarint(tmp[:], v)])
+}
+
+func uvarintSize(v uint64) int {
+	size := 1
+	for ; v >= 0x80; v >>= 7 {
+		size++
+	}
+	return size
+}
+
+// rlpStringSize returns the size of the RLP encoding of a string of n bytes, n > 1.
+func rlpStringSize(n int) int {
+	if n < 56 {
+		return 1 + n
+	}
+	return 1 + (bits.Len(uint(n))+7)/8 + n
+}
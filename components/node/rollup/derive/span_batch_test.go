@@ -0,0 +1,184 @@
+package derive
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/components/node/testutils"
+)
+
+func TestSpanBatchRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	batches := []*BatchData{
+		{
+			SpanBatch: &SpanBatch{
+				RelTimestamp:  2,
+				ParentCheck:   [20]byte{0x01},
+				L1OriginCheck: [20]byte{0x02},
+				Blocks:        []SpanBatchBlock{{EpochNum: 0}},
+			},
+		},
+		{
+			SpanBatch: &SpanBatch{
+				RelTimestamp:  1647026951,
+				ParentCheck:   [20]byte{19: 0x42},
+				L1OriginCheck: [20]byte{0: 0x42},
+				Blocks: []SpanBatchBlock{
+					{EpochNum: 7, Transactions: []hexutil.Bytes{testutils.RandomData(rng, 100)}},
+					{EpochNum: 7},
+					{EpochNum: 8, Transactions: []hexutil.Bytes{testutils.RandomData(rng, 1), testutils.RandomData(rng, 300)}},
+					{EpochNum: 9},
+					{EpochNum: 9},
+					{EpochNum: 9},
+					{EpochNum: 9},
+					{EpochNum: 9},
+					{EpochNum: 10, Transactions: []hexutil.Bytes{testutils.RandomData(rng, 50)}},
+				},
+			},
+		},
+	}
+
+	for i, batch := range batches {
+		enc, err := batch.MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, byte(SpanBatchType), enc[0])
+		var dec BatchData
+		require.NoError(t, dec.UnmarshalBinary(enc), "test case %v", i)
+		require.Equal(t, batch, &dec, "Batch not equal test case %v", i)
+
+		enc, err = rlp.EncodeToBytes(batch)
+		require.NoError(t, err)
+		dec = BatchData{}
+		require.NoError(t, rlp.DecodeBytes(enc, &dec))
+		require.Equal(t, batch, &dec, "RLP batch not equal test case %v", i)
+	}
+}
+
+func TestSpanBatchEncodeInvalidOrigins(t *testing.T) {
+	batch := &BatchData{SpanBatch: &SpanBatch{
+		RelTimestamp: 2,
+		Blocks:       []SpanBatchBlock{{EpochNum: 3}, {EpochNum: 5}},
+	}}
+	_, err := batch.MarshalBinary()
+	require.ErrorContains(t, err, "not following")
+
+	batch.SpanBatch.Blocks = nil
+	_, err = batch.MarshalBinary()
+	require.ErrorIs(t, err, ErrSpanBatchEmpty)
+}
+
+func TestSpanBatchDecodeInvalid(t *testing.T) {
+	// type, rel_timestamp 2, l1_origin_num 1 and the checks
+	prefix := append([]byte{SpanBatchType, 2, 1}, make([]byte, 40)...)
+	withPrefix := func(payload ...byte) []byte {
+		return append(append([]byte{}, prefix...), payload...)
+	}
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"missing prefix", []byte{SpanBatchType, 2, 1}},
+		{"no blocks", withPrefix(0)},
+		{"block count too large", withPrefix(0xff, 0xff, 0x03, 0, 0)},
+		// 2 blocks, origin bits 0x02 has the padding bit 1 set
+		{"padding bits", withPrefix(2, 0x02, 0, 0)},
+		{"tx count too large", withPrefix(1, 5, 1)},
+		{"tx length too large", withPrefix(1, 1, 5, 0)},
+		{"trailing data", withPrefix(1, 0, 0)},
+		// 2 blocks, origin changing from the L1 origin 1 of the last block
+		{"origin below zero", append([]byte{SpanBatchType, 2, 0}, append(make([]byte, 40), 2, 0x01, 0, 0)...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dec BatchData
+			require.Error(t, dec.UnmarshalBinary(tt.data))
+		})
+	}
+}
+
+func spanTestBatches(rng *rand.Rand, genesisTime uint64, l1 []eth.L1BlockRef) []*BatchData {
+	var batches []*BatchData
+	for i, epoch := range []int{0, 0, 1, 1, 2} {
+		batches = append(batches, &BatchData{BatchV1: BatchV1{
+			ParentHash:   testutils.RandomHash(rng),
+			EpochNum:     rollup.Epoch(l1[epoch].Number),
+			EpochHash:    l1[epoch].Hash,
+			Timestamp:    genesisTime + 2*uint64(i+1),
+			Transactions: []hexutil.Bytes{testutils.RandomData(rng, 20+i)},
+		}})
+	}
+	return batches
+}
+
+func TestChannelOutSpanBatch(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	l1 := L1Chain([]uint64{10, 20, 30})
+	batches := spanTestBatches(rng, 10, l1)
+
+	cout, err := NewChannelOut(Zlib)
+	require.NoError(t, err)
+	require.NoError(t, cout.UseSpanBatch(10, 2))
+	for _, batch := range batches {
+		_, err := cout.AddBatch(batch)
+		require.NoError(t, err)
+	}
+	require.Zero(t, cout.ReadyBytes(), "the span batch is only written when the channel is closed")
+
+	// The batch must follow the last one
+	_, err = cout.AddBatch(&BatchData{BatchV1: BatchV1{EpochNum: 2, Timestamp: 24}})
+	require.ErrorContains(t, err, "does not follow")
+	_, err = cout.AddBatch(&BatchData{BatchV1: BatchV1{EpochNum: 4, Timestamp: 22}})
+	require.ErrorContains(t, err, "does not follow")
+
+	require.NoError(t, cout.Close())
+	next, err := BatchReader(bytes.NewReader(cout.buf.Bytes()), l1[2])
+	require.NoError(t, err)
+	read, err := next()
+	require.NoError(t, err)
+	_, err = next()
+	require.ErrorIs(t, err, io.EOF)
+
+	enc, err := rlp.EncodeToBytes(read.Batch)
+	require.NoError(t, err)
+	require.Equal(t, len(enc), cout.InputBytes(), "the tracked input size must be exact")
+
+	span := read.Batch.SpanBatch
+	require.NotNil(t, span)
+	require.Equal(t, uint64(2), span.RelTimestamp)
+	require.Equal(t, batches[0].ParentHash[:20], span.ParentCheck[:])
+	require.Equal(t, l1[2].Hash[:20], span.L1OriginCheck[:])
+	require.Len(t, span.Blocks, len(batches))
+	for i, batch := range batches {
+		require.Equal(t, batch.EpochNum, span.Blocks[i].EpochNum)
+		require.Equal(t, batch.Transactions, span.Blocks[i].Transactions)
+	}
+
+	// A non-empty channel cannot switch to a span batch, and a reset channel is back to singular batches.
+	require.Error(t, cout.UseSpanBatch(10, 2))
+	require.NoError(t, cout.Reset())
+	_, err = cout.AddBatch(batches[0])
+	require.NoError(t, err)
+	require.Error(t, cout.UseSpanBatch(10, 2))
+}
+
+func TestChannelOutSpanBatchRLPLimit(t *testing.T) {
+	cout, err := NewChannelOut(Zlib)
+	require.NoError(t, err)
+	require.NoError(t, cout.UseSpanBatch(0, 2))
+	_, err = cout.AddBatch(&BatchData{BatchV1: BatchV1{
+		ParentHash:   common.Hash{},
+		Timestamp:    2,
+		Transactions: []hexutil.Bytes{make([]byte, MaxRLPBytesPerChannel)},
+	}})
+	require.ErrorIs(t, err, ErrTooManyRLPBytes)
+	require.Zero(t, cout.InputBytes())
+}
@@ -28,6 +28,7 @@ var (
 	ErrMissingScalar                 = errors.New("missing genesis system config scalar")
 	ErrMissingGasLimit               = errors.New("missing genesis system config gas limit")
 	ErrMissingBatchInboxAddress      = errors.New("missing batch inbox address")
+	ErrInvalidBatchInboxRotation     = errors.New("invalid batch inbox rotation")
 	ErrMissingDepositContractAddress = errors.New("missing deposit contract address")
 	ErrMissingL1ChainID              = errors.New("L1 chain ID must not be nil")
 	ErrMissingL2ChainID              = errors.New("L2 chain ID must not be nil")
@@ -74,10 +75,31 @@ type Config struct {
 
 	// L1 address that batches are sent to.
 	BatchInboxAddress common.Address `json:"batch_inbox_address"`
+	// BatchInboxRotations schedules the rotation of the batch inbox address, ordered by L1 block number.
+	// From the L1 block of a rotation on, the batches are only accepted at its address.
+	BatchInboxRotations []BatchInboxRotation `json:"batch_inbox_rotations,omitempty"`
 	// L1 Deposit Contract Address
 	DepositContractAddress common.Address `json:"deposit_contract_address"`
 	// L1 System Config Address
 	L1SystemConfigAddress common.Address `json:"l1_system_config_address"`
+
+	// SpanBatchTime sets the activation time of the span batches, which encode a range of L2 blocks
+	// into a single batch. Active if SpanBatchTime != nil && L1 inclusion block time >= *SpanBatchTime.
+	SpanBatchTime *uint64 `json:"span_batch_time,omitempty"`
+	// AltDATime sets the activation time of the alternative DA, with which the batcher txs carry a commitment
+	// to the frames posted to a DA layer instead of the frames themselves.
+	// Active if AltDATime != nil && L1 inclusion block time >= *AltDATime.
+	AltDATime *uint64 `json:"alt_da_time,omitempty"`
+	// CompressionAlgosTime sets the activation time of the brotli and zstd channel compression, negotiated by
+	// the channel version byte. Before it, the channels are only compressed with zlib.
+	// Active if CompressionAlgosTime != nil && L1 inclusion block time >= *CompressionAlgosTime.
+	CompressionAlgosTime *uint64 `json:"compression_algos_time,omitempty"`
+}
+
+// BatchInboxRotation is a rotation of the batch inbox address, activated at an L1 block number.
+type BatchInboxRotation struct {
+	L1Block uint64         `json:"l1_block"`
+	Address common.Address `json:"address"`
 }
 
 // ValidateL1Config checks L1 config variables for errors.
@@ -220,6 +242,16 @@ func (cfg *Config) Check() error {
 	if cfg.BatchInboxAddress == (common.Address{}) {
 		return ErrMissingBatchInboxAddress
 	}
+	prevL1Block := cfg.Genesis.L1.Number
+	for _, rotation := range cfg.BatchInboxRotations {
+		if rotation.L1Block <= prevL1Block {
+			return fmt.Errorf("%w: L1 block %d must be after the genesis and the previous rotation", ErrInvalidBatchInboxRotation, rotation.L1Block)
+		}
+		if rotation.Address == (common.Address{}) {
+			return fmt.Errorf("%w: missing address at L1 block %d", ErrInvalidBatchInboxRotation, rotation.L1Block)
+		}
+		prevL1Block = rotation.L1Block
+	}
 	if cfg.DepositContractAddress == (common.Address{}) {
 		return ErrMissingDepositContractAddress
 	}
@@ -241,6 +273,43 @@ func (cfg *Config) Check() error {
 	return nil
 }
 
+// IsSpanBatch returns true if the span batches are active at or past the given L1 timestamp.
+func (cfg *Config) IsSpanBatch(timestamp uint64) bool {
+	return cfg.SpanBatchTime != nil && timestamp >= *cfg.SpanBatchTime
+}
+
+// IsAltDA returns true if the alternative DA is active at or past the given L1 timestamp.
+func (cfg *Config) IsAltDA(timestamp uint64) bool {
+	return cfg.AltDATime != nil && timestamp >= *cfg.AltDATime
+}
+
+// IsCompressionAlgos returns true if the brotli and zstd channel compression is active at or past
+// the given L1 timestamp.
+func (cfg *Config) IsCompressionAlgos(timestamp uint64) bool {
+	return cfg.CompressionAlgosTime != nil && timestamp >= *cfg.CompressionAlgosTime
+}
+
+// BatchInboxAddressAt returns the batch inbox address active at the given L1 block number.
+func (cfg *Config) BatchInboxAddressAt(l1Block uint64) common.Address {
+	addr := cfg.BatchInboxAddress
+	for _, rotation := range cfg.BatchInboxRotations {
+		if l1Block < rotation.L1Block {
+			break
+		}
+		addr = rotation.Address
+	}
+	return addr
+}
+
+// BatchInboxAddresses returns all the batch inbox addresses, the genesis one first.
+func (cfg *Config) BatchInboxAddresses() []common.Address {
+	addrs := []common.Address{cfg.BatchInboxAddress}
+	for _, rotation := range cfg.BatchInboxRotations {
+		addrs = append(addrs, rotation.Address)
+	}
+	return addrs
+}
+
 func (cfg *Config) L1Signer() types.Signer {
 	return types.NewLondonSigner(cfg.L1ChainID)
 }
@@ -273,6 +342,14 @@ func (cfg *Config) Description(l2Chains map[string]string) string {
 	banner += fmt.Sprintf("  L2 starting time: %d ~ %s\n", cfg.Genesis.L2Time, fmtTime(cfg.Genesis.L2Time))
 	banner += fmt.Sprintf("  L2 block: %s %d\n", cfg.Genesis.L2.Hash, cfg.Genesis.L2.Number)
 	banner += fmt.Sprintf("  L1 block: %s %d\n", cfg.Genesis.L1.Hash, cfg.Genesis.L1.Number)
+	// Report the upgrade configuration
+	banner += "Post-Genesis upgrades:\n"
+	banner += fmt.Sprintf("  - Span batch: %s\n", fmtForkTimeOrUnset(cfg.SpanBatchTime))
+	banner += fmt.Sprintf("  - Alternative DA: %s\n", fmtForkTimeOrUnset(cfg.AltDATime))
+	banner += fmt.Sprintf("  - Compression algorithms: %s\n", fmtForkTimeOrUnset(cfg.CompressionAlgosTime))
+	for _, rotation := range cfg.BatchInboxRotations {
+		banner += fmt.Sprintf("  - Batch inbox rotation: %s @ L1 block %d\n", rotation.Address, rotation.L1Block)
+	}
 	return banner
 }
 
@@ -295,7 +372,9 @@ func (cfg *Config) LogDescription(log log.Logger, l2Chains map[string]string) {
 	log.Info("Rollup Config", "l2_chain_id", cfg.L2ChainID, "l2_network", networkL2, "l1_chain_id", cfg.L1ChainID,
 		"l1_network", networkL1, "l2_start_time", cfg.Genesis.L2Time, "l2_block_hash", cfg.Genesis.L2.Hash.String(),
 		"l2_block_number", cfg.Genesis.L2.Number, "l1_block_hash", cfg.Genesis.L1.Hash.String(),
-		"l1_block_number", cfg.Genesis.L1.Number)
+		"l1_block_number", cfg.Genesis.L1.Number, "span_batch_time", fmtForkTimeOrUnset(cfg.SpanBatchTime),
+		"alt_da_time", fmtForkTimeOrUnset(cfg.AltDATime),
+		"compression_algos_time", fmtForkTimeOrUnset(cfg.CompressionAlgosTime))
 }
 
 func fmtForkTimeOrUnset(v *uint64) string {
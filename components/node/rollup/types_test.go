@@ -156,6 +156,106 @@ func TestRandomConfigDescription(t *testing.T) {
 		require.Contains(t, out, "(unknown L1)")
 		require.Contains(t, out, "(unknown L2)")
 	})
+	t.Run("span batch", func(t *testing.T) {
+		config := randConfig()
+		require.Contains(t, config.Description(nil), "Span batch: (not configured)")
+		spanBatchTime := uint64(0)
+		config.SpanBatchTime = &spanBatchTime
+		require.Contains(t, config.Description(nil), "Span batch: @ genesis")
+	})
+	t.Run("alt da", func(t *testing.T) {
+		config := randConfig()
+		require.Contains(t, config.Description(nil), "Alternative DA: (not configured)")
+		altDATime := uint64(0)
+		config.AltDATime = &altDATime
+		require.Contains(t, config.Description(nil), "Alternative DA: @ genesis")
+	})
+}
+
+func TestIsSpanBatch(t *testing.T) {
+	config := randConfig()
+	require.False(t, config.IsSpanBatch(0))
+	spanBatchTime := uint64(100)
+	config.SpanBatchTime = &spanBatchTime
+	require.False(t, config.IsSpanBatch(99))
+	require.True(t, config.IsSpanBatch(100))
+	require.True(t, config.IsSpanBatch(101))
+}
+
+func TestIsAltDA(t *testing.T) {
+	config := randConfig()
+	require.False(t, config.IsAltDA(0))
+	altDATime := uint64(100)
+	config.AltDATime = &altDATime
+	require.False(t, config.IsAltDA(99))
+	require.True(t, config.IsAltDA(100))
+	require.True(t, config.IsAltDA(101))
+}
+
+func TestBatchInboxAddressAt(t *testing.T) {
+	config := randConfig()
+	genesisInbox := config.BatchInboxAddress
+	require.Equal(t, genesisInbox, config.BatchInboxAddressAt(0))
+	require.Equal(t, []common.Address{genesisInbox}, config.BatchInboxAddresses())
+
+	first, second := common.Address{0: 1}, common.Address{0: 2}
+	config.BatchInboxRotations = []BatchInboxRotation{
+		{L1Block: 500_000, Address: first},
+		{L1Block: 600_000, Address: second},
+	}
+	require.NoError(t, config.Check())
+	require.Equal(t, genesisInbox, config.BatchInboxAddressAt(499_999))
+	require.Equal(t, first, config.BatchInboxAddressAt(500_000))
+	require.Equal(t, first, config.BatchInboxAddressAt(599_999))
+	require.Equal(t, second, config.BatchInboxAddressAt(600_000))
+	require.Equal(t, []common.Address{genesisInbox, first, second}, config.BatchInboxAddresses())
+}
+
+func TestCheckBatchInboxRotations(t *testing.T) {
+	tests := []struct {
+		name      string
+		rotations []BatchInboxRotation
+	}{
+		{
+			name:      "BeforeGenesis",
+			rotations: []BatchInboxRotation{{L1Block: 424242, Address: common.Address{0: 1}}},
+		},
+		{
+			name: "Unordered",
+			rotations: []BatchInboxRotation{
+				{L1Block: 600_000, Address: common.Address{0: 1}},
+				{L1Block: 500_000, Address: common.Address{0: 2}},
+			},
+		},
+		{
+			name: "SameL1Block",
+			rotations: []BatchInboxRotation{
+				{L1Block: 500_000, Address: common.Address{0: 1}},
+				{L1Block: 500_000, Address: common.Address{0: 2}},
+			},
+		},
+		{
+			name:      "NoAddress",
+			rotations: []BatchInboxRotation{{L1Block: 500_000}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := randConfig()
+			cfg.BatchInboxRotations = test.rotations
+			require.ErrorIs(t, cfg.Check(), ErrInvalidBatchInboxRotation)
+		})
+	}
+}
+
+func TestIsCompressionAlgos(t *testing.T) {
+	config := randConfig()
+	require.False(t, config.IsCompressionAlgos(0))
+	compressionAlgosTime := uint64(100)
+	config.CompressionAlgosTime = &compressionAlgosTime
+	require.False(t, config.IsCompressionAlgos(99))
+	require.True(t, config.IsCompressionAlgos(100))
+	require.True(t, config.IsCompressionAlgos(101))
 }
 
 type mockL2Client struct {
@@ -20,6 +20,7 @@ import (
 	"github.com/kroma-network/kroma/components/node/rollup"
 	"github.com/kroma-network/kroma/components/node/rollup/driver"
 	"github.com/kroma-network/kroma/components/node/sources"
+	"github.com/kroma-network/kroma/utils/altda"
 	kpprof "github.com/kroma-network/kroma/utils/service/pprof"
 )
 
@@ -76,6 +77,10 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 			ListenAddr: ctx.String(flags.PprofAddrFlag.Name),
 			ListenPort: ctx.Int(flags.PprofPortFlag.Name),
 		},
+		AltDA: altda.CLIConfig{
+			ServerURL: ctx.String(flags.AltDAServerFlag.Name),
+			Timeout:   ctx.Duration(flags.AltDATimeoutFlag.Name),
+		},
 		P2P:                 p2pConfig,
 		P2PSigner:           p2pSignerSetup,
 		L1EpochPollInterval: ctx.Duration(flags.L1EpochPollIntervalFlag.Name),
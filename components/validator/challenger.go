@@ -24,6 +24,7 @@ import (
 	chal "github.com/kroma-network/kroma/components/validator/challenge"
 	"github.com/kroma-network/kroma/components/validator/metrics"
 	"github.com/kroma-network/kroma/utils"
+	"github.com/kroma-network/kroma/utils/service/txmgr"
 )
 
 var deletedOutputRoot = [32]byte{}
@@ -571,8 +572,10 @@ func (c *Challenger) handleChallenge(outputIndex *big.Int, asserter common.Addre
 	}
 }
 
+// submitChallengeTx sends the challenge tx ahead of the queued output submissions,
+// since the challenge has to progress before its deadline.
 func (c *Challenger) submitChallengeTx(tx *types.Transaction) error {
-	return c.cfg.TxManager.SendTransaction(c.ctx, tx).Err
+	return c.cfg.TxManager.SendTransactionWithPriority(c.ctx, tx, txmgr.TxPriorityHigh).Err
 }
 
 // HasEnoughDeposit checks if challenger has enough deposit to bond when creating challenge.
@@ -791,7 +794,7 @@ func (c *Challenger) CreateChallenge(ctx context.Context, outputRange *OutputRan
 		return nil, err
 	}
 
-	txOpts := utils.NewSimpleTxOpts(ctx, c.cfg.TxManager.From(), c.cfg.TxManager.Signer)
+	txOpts := utils.NewSimpleTxOpts(ctx, c.cfg.TxManager.From(), c.cfg.TxManager.SignerFn())
 	return c.colosseumContract.CreateChallenge(txOpts, outputIndex, l1BlockHash, l1BlockNumber, segments.Hashes)
 }
 
@@ -820,21 +823,21 @@ func (c *Challenger) Bisect(ctx context.Context, outputIndex *big.Int, challenge
 		return nil, err
 	}
 
-	txOpts := utils.NewSimpleTxOpts(ctx, c.cfg.TxManager.From(), c.cfg.TxManager.Signer)
+	txOpts := utils.NewSimpleTxOpts(ctx, c.cfg.TxManager.From(), c.cfg.TxManager.SignerFn())
 	return c.colosseumContract.Bisect(txOpts, outputIndex, challenger, position, nextSegments.Hashes)
 }
 
 func (c *Challenger) ChallengerTimeout(ctx context.Context, outputIndex *big.Int, challenger common.Address) (*types.Transaction, error) {
 	c.log.Info("crafting challenger timeout tx", "outputIndex", outputIndex, "challenger", challenger)
 
-	txOpts := utils.NewSimpleTxOpts(ctx, c.cfg.TxManager.From(), c.cfg.TxManager.Signer)
+	txOpts := utils.NewSimpleTxOpts(ctx, c.cfg.TxManager.From(), c.cfg.TxManager.SignerFn())
 	return c.colosseumContract.ChallengerTimeout(txOpts, outputIndex, challenger)
 }
 
 func (c *Challenger) CancelChallenge(ctx context.Context, outputIndex *big.Int) (*types.Transaction, error) {
 	c.log.Info("crafting cancel challenge tx", "outputIndex", outputIndex)
 
-	txOpts := utils.NewSimpleTxOpts(ctx, c.cfg.TxManager.From(), c.cfg.TxManager.Signer)
+	txOpts := utils.NewSimpleTxOpts(ctx, c.cfg.TxManager.From(), c.cfg.TxManager.SignerFn())
 	return c.colosseumContract.CancelChallenge(txOpts, outputIndex)
 }
 
@@ -884,7 +887,7 @@ func (c *Challenger) ProveFault(ctx context.Context, outputIndex *big.Int, chall
 		return nil, fmt.Errorf("failed to fetch proof and pair(fault position blockNumber: %d): %w", targetBlockNumber.Uint64(), err)
 	}
 
-	txOpts := utils.NewSimpleTxOpts(ctx, c.cfg.TxManager.From(), c.cfg.TxManager.Signer)
+	txOpts := utils.NewSimpleTxOpts(ctx, c.cfg.TxManager.From(), c.cfg.TxManager.SignerFn())
 	return c.colosseumContract.ProveFault(
 		txOpts,
 		outputIndex,
@@ -11,6 +11,7 @@ import (
 	"github.com/kroma-network/kroma/components/validator/cmd/balance"
 	"github.com/kroma-network/kroma/components/validator/flags"
 	klog "github.com/kroma-network/kroma/utils/service/log"
+	"github.com/kroma-network/kroma/utils/signer/client"
 )
 
 var (
@@ -59,6 +60,7 @@ func main() {
 			Usage:  "Attempt to unbond in ValidatorPool",
 			Action: balance.Unbond,
 		},
+		client.FailoverDrillCommand(flags.EnvVarPrefix),
 	}
 
 	err := app.Run(os.Args)
@@ -16,6 +16,7 @@ import (
 	"github.com/kroma-network/kroma/components/validator/flags"
 	"github.com/kroma-network/kroma/components/validator/metrics"
 	"github.com/kroma-network/kroma/utils"
+	khealth "github.com/kroma-network/kroma/utils/service/health"
 	klog "github.com/kroma-network/kroma/utils/service/log"
 	kmetrics "github.com/kroma-network/kroma/utils/service/metrics"
 	kpprof "github.com/kroma-network/kroma/utils/service/pprof"
@@ -59,7 +60,7 @@ func (c *Config) Check() error {
 // This also contains config options for auxiliary services.
 // It is transformed into a `Config` before the Validator is started.
 type CLIConfig struct {
-	// L1EthRpc is the Websocket provider URL for L1.
+	// L1EthRpc is the Websocket provider URL for L1, or a comma-separated list of them.
 	L1EthRpc string
 
 	// L2EthRpc is the HTTP provider URL for the L2 execution engine.
@@ -111,6 +112,7 @@ type CLIConfig struct {
 	LogConfig     klog.CLIConfig
 	MetricsConfig kmetrics.CLIConfig
 	PprofConfig   kpprof.CLIConfig
+	HealthConfig  khealth.CLIConfig
 }
 
 func (c CLIConfig) Check() error {
@@ -132,6 +134,9 @@ func (c CLIConfig) Check() error {
 	if err := c.PprofConfig.Check(); err != nil {
 		return err
 	}
+	if err := c.HealthConfig.Check(); err != nil {
+		return err
+	}
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
@@ -166,6 +171,7 @@ func NewCLIConfig(ctx *cli.Context) CLIConfig {
 		LogConfig:                       klog.ReadCLIConfig(ctx),
 		MetricsConfig:                   kmetrics.ReadCLIConfig(ctx),
 		PprofConfig:                     kpprof.ReadCLIConfig(ctx),
+		HealthConfig:                    khealth.ReadCLIConfig(ctx),
 	}
 }
 
@@ -194,6 +200,14 @@ func NewValidatorConfig(cfg CLIConfig, l log.Logger, m metrics.Metricer) (*Confi
 		return nil, err
 	}
 
+	cfg.TxMgrConfig.ContractLabels = map[common.Address]string{
+		l2ooAddress:      "l2_output_oracle",
+		colosseumAddress: "colosseum",
+		valPoolAddress:   "validator_pool",
+	}
+	if cfg.GuardianEnabled {
+		cfg.TxMgrConfig.ContractLabels[securityCouncilAddress] = "security_council"
+	}
 	txManager, err := txmgr.NewBufferedTxManager("validator", l, m, cfg.TxMgrConfig)
 	if err != nil {
 		return nil, err
@@ -213,7 +227,8 @@ func NewValidatorConfig(cfg CLIConfig, l log.Logger, m metrics.Metricer) (*Confi
 
 	// Connect to L1 and L2 providers. Perform these last since they are the most expensive.
 	ctx := context.Background()
-	l1Client, err := utils.DialEthClientWithTimeout(ctx, cfg.L1EthRpc)
+	// The tx manager fails over between all the L1 endpoints, while the reads go to the primary one.
+	l1Client, err := utils.DialEthClientWithTimeout(ctx, txmgr.PrimaryL1RPCURL(cfg.L1EthRpc))
 	if err != nil {
 		return nil, err
 	}
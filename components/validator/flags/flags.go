@@ -6,6 +6,7 @@ import (
 	"github.com/urfave/cli"
 
 	kservice "github.com/kroma-network/kroma/utils/service"
+	khealth "github.com/kroma-network/kroma/utils/service/health"
 	klog "github.com/kroma-network/kroma/utils/service/log"
 	kmetrics "github.com/kroma-network/kroma/utils/service/metrics"
 	kpprof "github.com/kroma-network/kroma/utils/service/pprof"
@@ -13,64 +14,64 @@ import (
 	"github.com/kroma-network/kroma/utils/service/txmgr"
 )
 
-const envVarPrefix = "VALIDATOR"
+const EnvVarPrefix = "VALIDATOR"
 
 var (
 	// Required Flags
 
 	L1EthRpcFlag = cli.StringFlag{
 		Name:     "l1-eth-rpc",
-		Usage:    "Websocket provider URL for L1",
+		Usage:    "Websocket provider URL for L1. A comma-separated list of URLs makes the tx manager fail over between them, with the first one being the primary",
 		Required: true,
-		EnvVar:   kservice.PrefixEnvVar(envVarPrefix, "L1_ETH_RPC"),
+		EnvVar:   kservice.PrefixEnvVar(EnvVarPrefix, "L1_ETH_RPC"),
 	}
 	L2EthRpcFlag = cli.StringFlag{
 		Name:     "l2-eth-rpc",
 		Usage:    "HTTP provider URL for L2",
 		Required: true,
-		EnvVar:   kservice.PrefixEnvVar(envVarPrefix, "L2_ETH_RPC"),
+		EnvVar:   kservice.PrefixEnvVar(EnvVarPrefix, "L2_ETH_RPC"),
 	}
 	RollupRpcFlag = cli.StringFlag{
 		Name:     "rollup-rpc",
 		Usage:    "HTTP provider URL for the rollup node",
 		Required: true,
-		EnvVar:   kservice.PrefixEnvVar(envVarPrefix, "ROLLUP_RPC"),
+		EnvVar:   kservice.PrefixEnvVar(EnvVarPrefix, "ROLLUP_RPC"),
 	}
 	L2OOAddressFlag = cli.StringFlag{
 		Name:     "l2oo-address",
 		Usage:    "Address of the L2OutputOracle contract",
 		Required: true,
-		EnvVar:   kservice.PrefixEnvVar(envVarPrefix, "L2OO_ADDRESS"),
+		EnvVar:   kservice.PrefixEnvVar(EnvVarPrefix, "L2OO_ADDRESS"),
 	}
 	ColosseumAddressFlag = cli.StringFlag{
 		Name:     "colosseum-address",
 		Usage:    "Address of the Colosseum contract",
 		Required: true,
-		EnvVar:   kservice.PrefixEnvVar(envVarPrefix, "COLOSSEUM_ADDRESS"),
+		EnvVar:   kservice.PrefixEnvVar(EnvVarPrefix, "COLOSSEUM_ADDRESS"),
 	}
 	ValPoolAddressFlag = cli.StringFlag{
 		Name:     "valpool-address",
 		Usage:    "Address of the ValidatorPool contract",
 		Required: true,
-		EnvVar:   kservice.PrefixEnvVar(envVarPrefix, "VALPOOL_ADDRESS"),
+		EnvVar:   kservice.PrefixEnvVar(EnvVarPrefix, "VALPOOL_ADDRESS"),
 	}
 	OutputSubmitterEnabledFlag = cli.BoolFlag{
 		Name:     "output-submitter.enabled",
 		Usage:    "Enable l2 output submitter",
-		EnvVar:   kservice.PrefixEnvVar(envVarPrefix, "OUTPUT_SUBMITTER_ENABLED"),
+		EnvVar:   kservice.PrefixEnvVar(EnvVarPrefix, "OUTPUT_SUBMITTER_ENABLED"),
 		Required: true,
 	}
 	ChallengerEnabledFlag = cli.BoolFlag{
 		Name:     "challenger.enabled",
 		Usage:    "Enable challenger",
-		EnvVar:   kservice.PrefixEnvVar(envVarPrefix, "CHALLENGER_ENABLED"),
+		EnvVar:   kservice.PrefixEnvVar(EnvVarPrefix, "CHALLENGER_ENABLED"),
 		Required: true,
 	}
 	ChallengerPollIntervalFlag = cli.DurationFlag{
 		Name:     "challenger.poll-interval",
 		Usage:    "Poll interval for challenge process",
 		Required: true,
-		EnvVar:   kservice.PrefixEnvVar(envVarPrefix, "CHALLENGER_POLL_INTERVAL"),
+		EnvVar:   kservice.PrefixEnvVar(EnvVarPrefix, "CHALLENGER_POLL_INTERVAL"),
 	}
 
 	// Optional flags
@@ -78,44 +79,44 @@ var (
 	AllowNonFinalizedFlag = cli.BoolFlag{
 		Name:   "allow-non-finalized",
 		Usage:  "Allow the validator to submit outputs for L2 blocks derived from non-finalized L1 blocks.",
-		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "ALLOW_NON_FINALIZED"),
+		EnvVar: kservice.PrefixEnvVar(EnvVarPrefix, "ALLOW_NON_FINALIZED"),
 	}
 	OutputSubmitterRetryIntervalFlag = cli.DurationFlag{
 		Name:   "output-submitter.retry-interval",
 		Usage:  "Retry interval for output submission process",
-		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "OUTPUT_SUBMITTER_RETRY_INTERVAL"),
+		EnvVar: kservice.PrefixEnvVar(EnvVarPrefix, "OUTPUT_SUBMITTER_RETRY_INTERVAL"),
 		Value:  time.Second * 1,
 	}
 	OutputSubmitterRoundBufferFlag = cli.Uint64Flag{
 		Name:   "output-submitter.round-buffer",
 		Usage:  "Number of blocks before each round to start trying submission",
-		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "OUTPUT_SUBMITTER_ROUND_BUFFER"),
+		EnvVar: kservice.PrefixEnvVar(EnvVarPrefix, "OUTPUT_SUBMITTER_ROUND_BUFFER"),
 		Value:  30,
 	}
 	OutputSubmitterAllowPublicRoundFlag = cli.BoolFlag{
 		Name:   "output-submitter.allow-public-round",
 		Usage:  "Allows l2 output submitter in public round",
-		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "OUTPUT_SUBMITTER_ALLOW_PUBLIC_ROUND"),
+		EnvVar: kservice.PrefixEnvVar(EnvVarPrefix, "OUTPUT_SUBMITTER_ALLOW_PUBLIC_ROUND"),
 	}
 	ProverRPCFlag = cli.StringFlag{
 		Name:   "prover-rpc-url",
 		Usage:  "jsonRPC URL for kroma-prover.",
-		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "PROVER_RPC"),
+		EnvVar: kservice.PrefixEnvVar(EnvVarPrefix, "PROVER_RPC"),
 	}
 	SecurityCouncilAddressFlag = cli.StringFlag{
 		Name:   "securitycouncil-address",
 		Usage:  "Address of the SecurityCouncil contract",
-		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "SECURITYCOUNCIL_ADDRESS"),
+		EnvVar: kservice.PrefixEnvVar(EnvVarPrefix, "SECURITYCOUNCIL_ADDRESS"),
 	}
 	GuardianEnabledFlag = cli.BoolFlag{
 		Name:   "guardian.enabled",
 		Usage:  "Enable guardian",
-		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "GUARDIAN_ENABLED"),
+		EnvVar: kservice.PrefixEnvVar(EnvVarPrefix, "GUARDIAN_ENABLED"),
 	}
 	FetchingProofTimeoutFlag = cli.DurationFlag{
 		Name:   "fetching-proof-timeout",
 		Usage:  "Duration we will wait to fetching proof",
-		EnvVar: kservice.PrefixEnvVar(envVarPrefix, "FETCHING_PROOF_TIMEOUT"),
+		EnvVar: kservice.PrefixEnvVar(EnvVarPrefix, "FETCHING_PROOF_TIMEOUT"),
 		Value:  time.Hour * 2,
 	}
 )
@@ -144,12 +145,13 @@ var optionalFlags = []cli.Flag{
 }
 
 func init() {
-	requiredFlags = append(requiredFlags, krpc.CLIFlags(envVarPrefix)...)
+	requiredFlags = append(requiredFlags, krpc.CLIFlags(EnvVarPrefix)...)
 
-	optionalFlags = append(optionalFlags, klog.CLIFlags(envVarPrefix)...)
-	optionalFlags = append(optionalFlags, kmetrics.CLIFlags(envVarPrefix)...)
-	optionalFlags = append(optionalFlags, kpprof.CLIFlags(envVarPrefix)...)
-	optionalFlags = append(optionalFlags, txmgr.CLIFlags(envVarPrefix)...)
+	optionalFlags = append(optionalFlags, klog.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, kmetrics.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, kpprof.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, khealth.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, txmgr.CLIFlags(EnvVarPrefix)...)
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
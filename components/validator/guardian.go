@@ -552,13 +552,13 @@ func (g *Guardian) ValidateL2Output(ctx context.Context, outputRoot eth.Bytes32,
 
 func (g *Guardian) ConfirmTransaction(ctx context.Context, transactionId *big.Int) (*types.Transaction, error) {
 	g.log.Info("crafting confirm tx", "transactionId", transactionId)
-	txOpts := utils.NewSimpleTxOpts(ctx, g.cfg.TxManager.From(), g.cfg.TxManager.Signer)
+	txOpts := utils.NewSimpleTxOpts(ctx, g.cfg.TxManager.From(), g.cfg.TxManager.SignerFn())
 	return g.securityCouncilContract.ConfirmTransaction(txOpts, transactionId)
 }
 
 func (g *Guardian) RequestDeletion(ctx context.Context, outputIndex *big.Int) (*types.Transaction, error) {
 	g.log.Info("crafting requestDeletion tx", "outputIndex", outputIndex)
-	txOpts := utils.NewSimpleTxOpts(ctx, g.cfg.TxManager.From(), g.cfg.TxManager.Signer)
+	txOpts := utils.NewSimpleTxOpts(ctx, g.cfg.TxManager.From(), g.cfg.TxManager.SignerFn())
 	return g.securityCouncilContract.RequestDeletion(txOpts, outputIndex, false)
 }
 
@@ -186,7 +186,12 @@ func (l *L2OutputSubmitter) doSubmitL2Output(ctx context.Context, nextBlockNumbe
 		return fmt.Errorf("failed to create submit l2 output transaction data: %w", err)
 	}
 
-	if txResponse := l.submitL2OutputTx(data); txResponse.Err != nil {
+	if txResponse := l.submitL2OutputTx(data, nextBlockNumber); txResponse.Err != nil {
+		if errors.Is(txResponse.Err, txmgr.ErrPreconditionFailed) {
+			// Another validator submitted the output first, so go to the next one immediately.
+			l.log.Info("L2output already submitted", "blockNumber", output.BlockRef.Number, "err", txResponse.Err)
+			return nil
+		}
 		return txResponse.Err
 	}
 
@@ -397,7 +402,8 @@ func SubmitL2OutputTxData(abi *abi.ABI, output *eth.OutputResponse) ([]byte, err
 }
 
 // submitL2OutputTx creates l2 output submit tx candidate and sends it to txCandidates channel to process validator's tx candidates in order.
-func (l *L2OutputSubmitter) submitL2OutputTx(data []byte) *txmgr.TxResponse {
+// The tx is no longer sent once the output at the block number is submitted by another validator.
+func (l *L2OutputSubmitter) submitL2OutputTx(data []byte, blockNumber *big.Int) *txmgr.TxResponse {
 	layout, err := bindings.GetStorageLayout("ValidatorPool")
 	if err != nil {
 		return &txmgr.TxResponse{
@@ -431,6 +437,18 @@ func (l *L2OutputSubmitter) submitL2OutputTx(data []byte) *txmgr.TxResponse {
 		To:         &l.cfg.L2OutputOracleAddr,
 		GasLimit:   0,
 		AccessList: accessList,
+		Precondition: func(ctx context.Context) error {
+			nextBlockNumber, err := l.l2ooContract.NextBlockNumber(utils.NewSimpleCallOpts(ctx))
+			if err != nil {
+				// The submission reverts anyway if the output is already submitted.
+				l.log.Warn("unable to get next block number", "err", err)
+				return nil
+			}
+			if nextBlockNumber.Cmp(blockNumber) != 0 {
+				return fmt.Errorf("next block number moved from %v to %v", blockNumber, nextBlockNumber)
+			}
+			return nil
+		},
 	})
 }
 
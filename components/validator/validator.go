@@ -6,7 +6,10 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/urfave/cli"
 
 	"github.com/kroma-network/kroma/bindings/bindings"
@@ -15,6 +18,7 @@ import (
 	"github.com/kroma-network/kroma/utils/monitoring"
 	klog "github.com/kroma-network/kroma/utils/service/log"
 	krpc "github.com/kroma-network/kroma/utils/service/rpc"
+	"github.com/kroma-network/kroma/utils/service/txmgr"
 )
 
 // Main is the entrypoint into the Validator. This method executes the
@@ -40,7 +44,15 @@ func Main(version string, cliCtx *cli.Context) error {
 
 	monitoring.MaybeStartPprof(ctx, cliCfg.PprofConfig, l)
 	monitoring.MaybeStartMetrics(ctx, cliCfg.MetricsConfig, l, m, validatorCfg.L1Client, validatorCfg.TxManager.From())
-	server, err := monitoring.StartRPC(cliCfg.RPCConfig, version, krpc.WithLogger(l))
+	monitoring.MaybeStartHealth(ctx, cliCfg.HealthConfig, l, validatorCfg.TxManager.Ready)
+	rpcOpts := []krpc.ServerOption{
+		krpc.WithLogger(l),
+		krpc.WithHealthzHandler(krpc.ReadyHealthzHandler(version, validatorCfg.TxManager.Ready)),
+	}
+	if validatorCfg.TxManager.EnableAdmin {
+		rpcOpts = append(rpcOpts, krpc.WithAPIs([]rpc.API{txmgr.NewAdminAPI(&validatorCfg.TxManager.SimpleTxManager)}))
+	}
+	server, err := monitoring.StartRPC(cliCfg.RPCConfig, version, rpcOpts...)
 	if err != nil {
 		return err
 	}
@@ -91,6 +103,10 @@ func NewValidator(ctx context.Context, cfg Config, l log.Logger, m metrics.Metri
 		return nil, err
 	}
 
+	if err := registerContractABIs(cfg); err != nil {
+		return nil, err
+	}
+
 	var err error
 	var l2os *L2OutputSubmitter
 	if cfg.OutputSubmitterEnabled {
@@ -129,6 +145,29 @@ func NewValidator(ctx context.Context, cfg Config, l log.Logger, m metrics.Metri
 	}, nil
 }
 
+// registerContractABIs registers the ABIs of the contracts called by the validator to the tx manager,
+// so that the reverts of the validator txs are decoded and attributed to the contracts.
+func registerContractABIs(cfg Config) error {
+	contracts := []struct {
+		name     string
+		addr     common.Address
+		metadata *bind.MetaData
+	}{
+		{"L2OutputOracle", cfg.L2OutputOracleAddr, bindings.L2OutputOracleMetaData},
+		{"Colosseum", cfg.ColosseumAddr, bindings.ColosseumMetaData},
+		{"SecurityCouncil", cfg.SecurityCouncilAddr, bindings.SecurityCouncilMetaData},
+		{"ValidatorPool", cfg.ValidatorPoolAddr, bindings.ValidatorPoolMetaData},
+	}
+	for _, contract := range contracts {
+		parsed, err := contract.metadata.GetAbi()
+		if err != nil {
+			return fmt.Errorf("failed to parse the ABI of %s: %w", contract.name, err)
+		}
+		cfg.TxManager.RegisterContractABI(contract.addr, contract.name, parsed)
+	}
+	return nil
+}
+
 func (v *Validator) Start() error {
 	v.ctx, v.cancel = context.WithCancel(context.Background())
 	v.l.Info("starting Validator", "outputSubmitter", v.cfg.OutputSubmitterEnabled, "challenger", v.cfg.ChallengerEnabled, "guardian", v.cfg.GuardianEnabled)
@@ -1,11 +1,15 @@
 package actions
 
 import (
+	"bytes"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 
+	"github.com/kroma-network/kroma/components/node/eth"
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
 	"github.com/kroma-network/kroma/components/node/testlog"
 	"github.com/kroma-network/kroma/e2e/e2eutils"
 )
@@ -51,3 +55,124 @@ func TestShapellaL1Fork(gt *testing.T) {
 	require.Equal(t, l1Block.Hash(), syncer.SyncStatus().SafeL2.L1Origin.Hash, "syncer synced L1 chain that includes shanghai headers")
 	require.Equal(t, proposer.SyncStatus().UnsafeL2, syncer.SyncStatus().UnsafeL2, "syncer and proposer agree")
 }
+
+func TestSpanBatchFork(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	offset := hexutil.Uint64(24)
+	dp.DeployConfig.L2GenesisSpanBatchTimeOffset = &offset
+
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	require.Equal(t, sd.L1Cfg.Timestamp+24, *sd.RollupCfg.SpanBatchTime)
+	log := testlog.Logger(t, log.LvlDebug)
+
+	_, _, miner, proposer, _, syncer, _, batcher := setupReorgTestActors(t, dp, sd, log)
+
+	// start nodes
+	proposer.ActL2PipelineFull(t)
+	syncer.ActL2PipelineFull(t)
+
+	// submitAndSync builds the L2 chain up to the L1 head, submits it in a single channel and syncs
+	// the syncer from it. It returns the batches of the channel.
+	submitAndSync := func() []*derive.BatchData {
+		proposer.ActL1HeadSignal(t)
+		proposer.ActBuildToL1Head(t)
+		miner.ActL1StartBlock(12)(t)
+		batcher.ActSubmitAll(t)
+		miner.ActL1IncludeTx(batcher.batcherAddr)(t)
+		miner.ActL1EndBlock(t)
+
+		syncer.ActL1HeadSignal(t)
+		syncer.ActL2PipelineFull(t)
+		require.Equal(t, proposer.SyncStatus().UnsafeL2, syncer.SyncStatus().SafeL2, "syncer derived the proposer chain")
+
+		signed := batcher.Signer().SignedTo(sd.RollupCfg.BatchInboxAddress, nil)
+		frames, err := derive.ParseFrames(signed[len(signed)-1].Data())
+		require.NoError(t, err)
+		require.Len(t, frames, 1)
+		next, err := derive.BatchReader(bytes.NewReader(frames[0].Data), eth.L1BlockRef{})
+		require.NoError(t, err)
+		var batches []*derive.BatchData
+		for batch, err := next(); err == nil; batch, err = next() {
+			batches = append(batches, batch.Batch)
+		}
+		require.NotEmpty(t, batches)
+		return batches
+	}
+
+	// The L2 blocks of L1 origins before the activation are submitted in singular batches.
+	miner.ActEmptyBlock(t)
+	require.False(t, sd.RollupCfg.IsSpanBatch(miner.l1Chain.CurrentBlock().Time), "not active yet")
+	for _, batch := range submitAndSync() {
+		require.Nil(t, batch.SpanBatch)
+	}
+
+	// Once the L2 blocks adopt L1 origins from the activation on, they are submitted in span batches.
+	for i := 0; ; i++ {
+		require.Less(t, i, 3, "the batcher must submit a span batch after the activation")
+		miner.ActEmptyBlock(t)
+		miner.ActEmptyBlock(t)
+		if batches := submitAndSync(); batches[0].SpanBatch != nil {
+			require.Len(t, batches, 1)
+			require.Greater(t, len(batches[0].SpanBatch.Blocks), 1)
+			break
+		}
+	}
+}
+
+func TestAltDAFork(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	offset := hexutil.Uint64(36)
+	dp.DeployConfig.L2GenesisAltDATimeOffset = &offset
+
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	require.Equal(t, sd.L1Cfg.Timestamp+36, *sd.RollupCfg.AltDATime)
+	log := testlog.Logger(t, log.LvlDebug)
+
+	miner, propEngine, proposer := setupProposerTest(t, sd, log)
+	_, syncer := setupSyncer(t, sd, log, miner.L1Client(t, sd.RollupCfg))
+	batcher := NewL2Batcher(log, sd.RollupCfg, &BatcherCfg{
+		MinL1TxSize: 0,
+		MaxL1TxSize: 128_000,
+		BatcherKey:  dp.Secrets.Batcher,
+		DAClient:    sd.DA,
+	}, proposer.RollupClient(), miner.EthClient(), propEngine.EthClient())
+
+	// start nodes
+	proposer.ActL2PipelineFull(t)
+	syncer.ActL2PipelineFull(t)
+
+	// submitAndSync builds the L2 chain up to the L1 head, submits it and syncs the syncer from it.
+	// It returns the data of the batcher tx.
+	submitAndSync := func() []byte {
+		proposer.ActL1HeadSignal(t)
+		proposer.ActBuildToL1Head(t)
+		miner.ActL1StartBlock(12)(t)
+		batcher.ActSubmitAll(t)
+		miner.ActL1IncludeTx(batcher.batcherAddr)(t)
+		miner.ActL1EndBlock(t)
+
+		syncer.ActL1HeadSignal(t)
+		syncer.ActL2PipelineFull(t)
+		require.Equal(t, proposer.SyncStatus().UnsafeL2, syncer.SyncStatus().SafeL2, "syncer derived the proposer chain")
+
+		signed := batcher.Signer().SignedTo(sd.RollupCfg.BatchInboxAddress, nil)
+		return signed[len(signed)-1].Data()
+	}
+
+	// The frames included before the activation are sent to L1.
+	miner.ActEmptyBlock(t)
+	data := submitAndSync()
+	require.False(t, sd.RollupCfg.IsAltDA(miner.l1Chain.CurrentBlock().Time), "not active yet")
+	require.Equal(t, byte(derive.DerivationVersion0), data[0])
+
+	// From the activation on, the frames are posted to the DA layer, and only their commitments to L1.
+	miner.ActEmptyBlock(t)
+	data = submitAndSync()
+	require.True(t, sd.RollupCfg.IsAltDA(miner.l1Chain.CurrentBlock().Time))
+	require.Equal(t, byte(derive.DerivationVersionAltDA), data[0])
+	frames, err := sd.DA.GetInput(t.Ctx(), data[1:])
+	require.NoError(t, err)
+	require.Equal(t, byte(derive.DerivationVersion0), frames[0])
+}
@@ -56,6 +56,7 @@ type ChannelOutIface interface {
 	ID() derive.ChannelID
 	Reset() error
 	AddBlock(block *types.Block) (uint64, error)
+	InputBytes() int
 	ReadyBytes() int
 	Flush() error
 	Close() error
@@ -167,6 +168,11 @@ func (co *GarbageChannelOut) AddBlock(block *types.Block) (uint64, error) {
 	return uint64(written), err
 }
 
+// InputBytes returns the total amount of RLP-encoded input bytes.
+func (co *GarbageChannelOut) InputBytes() int {
+	return co.rlpLength
+}
+
 // ReadyBytes returns the number of bytes that the channel out can immediately output into a frame.
 // Use `Flush` or `Close` to move data from the compression buffer into the ready buffer if more bytes
 // are needed. Add blocks may add to the ready buffer, but it is not guaranteed due to the compression stage.
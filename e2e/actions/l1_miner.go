@@ -165,6 +165,32 @@ func (s *L1Miner) ActL1EndBlock(t Testing) {
 	}
 }
 
+// ActL1RewindTo rewinds the L1 chain back to the given block number, dropping every block after it,
+// so that an alternative branch can be built on top of it.
+func (s *L1Miner) ActL1RewindTo(num uint64) Action {
+	return func(t Testing) {
+		head := s.l1Chain.CurrentHeader().Number.Uint64()
+		if num > head {
+			t.InvalidAction("cannot rewind L1 to block %d ahead of head %d", num, head)
+			return
+		}
+		s.ActL1ReorgDepth(head - num)(t)
+	}
+}
+
+// ActL1ReorgDepth drops the last depth L1 blocks, so that an alternative branch can be built on top of
+// the new head. Note that the blocks of the new branch must differ from the dropped ones
+// (e.g. by changing the fee recipient), otherwise the same blocks are simply re-inserted.
+func (s *L1Miner) ActL1ReorgDepth(depth uint64) Action {
+	return func(t Testing) {
+		if s.l1Building {
+			t.InvalidAction("cannot reorg L1 while building a block")
+			return
+		}
+		s.ActL1RewindDepth(depth)(t)
+	}
+}
+
 func (s *L1Miner) includeL1Block(t StatefulTesting, sender common.Address) {
 	s.ActL1StartBlock(12)(t)
 	s.ActL1IncludeTx(sender)(t)
@@ -176,6 +202,15 @@ func (s *L1Miner) ActEmptyBlock(t Testing) {
 	s.ActL1EndBlock(t)
 }
 
+// ActEmptyBlocks builds the given number of empty L1 blocks.
+func (s *L1Miner) ActEmptyBlocks(n uint64) Action {
+	return func(t Testing) {
+		for i := uint64(0); i < n; i++ {
+			s.ActEmptyBlock(t)
+		}
+	}
+}
+
 func (s *L1Miner) Close() error {
 	return s.L1Replica.Close()
 }
@@ -16,9 +16,11 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/require"
 
+	"github.com/kroma-network/kroma/components/batcher"
 	"github.com/kroma-network/kroma/components/node/eth"
 	"github.com/kroma-network/kroma/components/node/rollup"
 	"github.com/kroma-network/kroma/components/node/rollup/derive"
+	"github.com/kroma-network/kroma/e2e/e2eutils"
 )
 
 type SyncStatusAPI interface {
@@ -43,6 +45,23 @@ type BatcherCfg struct {
 	BatcherKey *ecdsa.PrivateKey
 
 	GarbageCfg *GarbageChannelCfg
+
+	// The target number of frames to split a channel into when submitting it with ActSubmitChannel.
+	// If zero, all the buffered L2 blocks are put into a single channel.
+	TargetNumFrames int
+	// Approximated compression ratio to assume when estimating the number of frames of a channel.
+	ApproxComprRatio float64
+	// The compression algorithm of the channels. Zlib if empty.
+	CompressionAlgo derive.CompressionAlgo
+	// The DA layer to post the frames to once the alternative DA is active, only sending their commitments to L1.
+	// The frames are always sent to L1 if nil.
+	DAClient batcher.DAClient
+}
+
+// InputThreshold calculates the input data threshold in bytes at which a channel is expected to be
+// compressed into the target number of frames.
+func (c *BatcherCfg) InputThreshold() uint64 {
+	return uint64(float64(c.TargetNumFrames) * float64(c.MaxL1TxSize-1) / c.ApproxComprRatio)
 }
 
 // L2Batcher buffers and submits L2 batches to L1.
@@ -59,14 +78,15 @@ type L2Batcher struct {
 	l2            BlocksAPI
 	l1            L1TxAPI
 
-	l1Signer types.Signer
-
 	l2ChannelOut     ChannelOutIface
 	l2Submitting     bool // when the channel out is being submitted, and not safe to write to without resetting
 	l2BufferedBlock  eth.BlockID
 	l2SubmittedBlock eth.BlockID
 	l2BatcherCfg     *BatcherCfg
 	batcherAddr      common.Address
+
+	// signer signs the batch txs, recording them.
+	signer *e2eutils.RecordingSigner
 }
 
 func NewL2Batcher(log log.Logger, rollupCfg *rollup.Config, batcherCfg *BatcherCfg, api SyncStatusAPI, l1 L1TxAPI, l2 BlocksAPI) *L2Batcher {
@@ -77,11 +97,16 @@ func NewL2Batcher(log log.Logger, rollupCfg *rollup.Config, batcherCfg *BatcherC
 		l1:            l1,
 		l2:            l2,
 		l2BatcherCfg:  batcherCfg,
-		l1Signer:      types.LatestSignerForChainID(rollupCfg.L1ChainID),
 		batcherAddr:   crypto.PubkeyToAddress(batcherCfg.BatcherKey.PublicKey),
+		signer:        e2eutils.NewRecordingSigner(batcherCfg.BatcherKey),
 	}
 }
 
+// Signer returns the signer of the batcher, which recorded the txs it signed.
+func (s *L2Batcher) Signer() *e2eutils.RecordingSigner {
+	return s.signer
+}
+
 // SubmittingData indicates if the actor is submitting buffer data.
 // All data must be submitted before it can safely continue buffering more L2 blocks.
 func (s *L2Batcher) SubmittingData() bool {
@@ -133,7 +158,16 @@ func (s *L2Batcher) Buffer(t Testing) error {
 		if s.l2BatcherCfg.GarbageCfg != nil {
 			ch, err = NewGarbageChannelOut(s.l2BatcherCfg.GarbageCfg)
 		} else {
-			ch, err = derive.NewChannelOut()
+			algo := s.l2BatcherCfg.CompressionAlgo
+			if algo == "" {
+				algo = derive.Zlib
+			}
+			var co *derive.ChannelOut
+			co, err = derive.NewChannelOut(algo)
+			if err == nil && s.spanBatchAt(t, s.l2BufferedBlock.Number+1) {
+				err = co.UseSpanBatch(s.rollupCfg.Genesis.L2Time, s.rollupCfg.BlockTime)
+			}
+			ch = co
 		}
 		require.NoError(t, err, "failed to create channel")
 		s.l2ChannelOut = ch
@@ -153,6 +187,19 @@ func (s *L2Batcher) Buffer(t Testing) error {
 	return nil
 }
 
+// spanBatchAt returns whether the channel starting with the L2 block of the number is made of a span
+// batch, which depends on the time of the L1 origin of the block, like in the batcher.
+func (s *L2Batcher) spanBatchAt(t Testing, number uint64) bool {
+	if s.rollupCfg.SpanBatchTime == nil {
+		return false
+	}
+	block, err := s.l2.BlockByNumber(t.Ctx(), new(big.Int).SetUint64(number))
+	require.NoError(t, err, "need l2 block %d to start a channel", number)
+	_, l1Info, err := derive.BlockToBatch(block)
+	require.NoError(t, err)
+	return s.rollupCfg.IsSpanBatch(l1Info.Time)
+}
+
 func (s *L2Batcher) ActL2ChannelClose(t Testing) {
 	// Don't run this action if there's no data to submit
 	if s.l2ChannelOut == nil {
@@ -181,6 +228,94 @@ func (s *L2Batcher) ActL2BatchSubmit(t Testing, txOpts ...func(tx *types.Dynamic
 		t.Fatalf("failed to output channel data to frame: %v", err)
 	}
 
+	s.sendBatchTx(t, s.altDAData(t, data.Bytes()), txOpts...)
+}
+
+// FrameOrder returns the order of frame numbers to submit the frames of a channel in.
+type FrameOrder func(numFrames int) []int
+
+// ReverseFrameOrder submits the frames of a channel from the last frame to the first one.
+func ReverseFrameOrder(numFrames int) []int {
+	order := make([]int, numFrames)
+	for i := range order {
+		order[i] = numFrames - 1 - i
+	}
+	return order
+}
+
+// ActSubmitChannel buffers L2 blocks into a new channel until the channel is expected to fill
+// the target number of frames, closes it and submits each of its frames in a separate L1 tx.
+// If frameOrder is not nil, the frames are submitted in the order it returns instead of the frame number order,
+// so that the channel can be submitted out of order.
+func (s *L2Batcher) ActSubmitChannel(t Testing, frameOrder FrameOrder) {
+	if s.l2ChannelOut != nil {
+		t.InvalidAction("need to submit the current channel first, cannot build a new channel")
+		return
+	}
+	stat, err := s.syncStatusAPI.SyncStatus(t.Ctx())
+	require.NoError(t, err)
+	for s.l2BufferedBlock.Number < stat.UnsafeL2.Number {
+		s.ActL2BatchBuffer(t)
+		if s.l2BatcherCfg.TargetNumFrames > 0 && uint64(s.l2ChannelOut.InputBytes()) >= s.l2BatcherCfg.InputThreshold() {
+			break
+		}
+	}
+	if s.l2ChannelOut == nil {
+		t.InvalidAction("need L2 blocks to buffer, cannot submit an empty channel")
+		return
+	}
+	s.ActL2ChannelClose(t)
+
+	var frames [][]byte
+	for {
+		data := new(bytes.Buffer)
+		data.WriteByte(derive.DerivationVersion0)
+		// subtract one, to account for the version byte
+		_, err := s.l2ChannelOut.OutputFrame(data, s.l2BatcherCfg.MaxL1TxSize-1)
+		if err != nil && err != io.EOF {
+			s.l2Submitting = false
+			t.Fatalf("failed to output channel data to frame: %v", err)
+		}
+		frames = append(frames, data.Bytes())
+		if err == io.EOF {
+			break
+		}
+	}
+	s.l2ChannelOut = nil
+	s.l2Submitting = false
+
+	frameNumbers := make([]int, len(frames))
+	for i := range frames {
+		frameNumbers[i] = i
+	}
+	order := frameNumbers
+	if frameOrder != nil {
+		order = frameOrder(len(frames))
+	}
+	require.ElementsMatch(t, frameNumbers, order, "frame order must contain every frame exactly once")
+	for _, i := range order {
+		s.sendBatchTx(t, s.altDAData(t, frames[i]))
+	}
+}
+
+// altDAData posts the frame data to the DA layer and returns the tx data carrying its commitment,
+// if the alternative DA is active at the pending L1 block. Otherwise, the frame data is returned as is.
+func (s *L2Batcher) altDAData(t Testing, data []byte) []byte {
+	if s.l2BatcherCfg.DAClient == nil {
+		return data
+	}
+	pendingHeader, err := s.l1.HeaderByNumber(t.Ctx(), big.NewInt(-1))
+	require.NoError(t, err, "need l1 pending header to check the alternative DA activation")
+	if !s.rollupCfg.IsAltDA(pendingHeader.Time) {
+		return data
+	}
+	comm, err := s.l2BatcherCfg.DAClient.SetInput(t.Ctx(), data)
+	require.NoError(t, err, "failed to post the frame data to the DA layer")
+	return append([]byte{derive.DerivationVersionAltDA}, comm...)
+}
+
+// sendBatchTx signs a batch tx with the given data and sends it to L1.
+func (s *L2Batcher) sendBatchTx(t Testing, data []byte, txOpts ...func(tx *types.DynamicFeeTx)) {
 	nonce, err := s.l1.PendingNonceAt(t.Ctx(), s.batcherAddr)
 	require.NoError(t, err, "need batcher nonce")
 
@@ -189,13 +324,14 @@ func (s *L2Batcher) ActL2BatchSubmit(t Testing, txOpts ...func(tx *types.Dynamic
 	require.NoError(t, err, "need l1 pending header for gas price estimation")
 	gasFeeCap := new(big.Int).Add(gasTipCap, new(big.Int).Mul(pendingHeader.BaseFee, big.NewInt(2)))
 
+	inbox := s.rollupCfg.BatchInboxAddressAt(pendingHeader.Number.Uint64())
 	rawTx := &types.DynamicFeeTx{
 		ChainID:   s.rollupCfg.L1ChainID,
 		Nonce:     nonce,
-		To:        &s.rollupCfg.BatchInboxAddress,
+		To:        &inbox,
 		GasTipCap: gasTipCap,
 		GasFeeCap: gasFeeCap,
-		Data:      data.Bytes(),
+		Data:      data,
 	}
 	for _, opt := range txOpts {
 		opt(rawTx)
@@ -204,7 +340,7 @@ func (s *L2Batcher) ActL2BatchSubmit(t Testing, txOpts ...func(tx *types.Dynamic
 	require.NoError(t, err, "need to compute intrinsic gas")
 	rawTx.Gas = gas
 
-	tx, err := types.SignNewTx(s.l2BatcherCfg.BatcherKey, s.l1Signer, rawTx)
+	tx, err := s.signer.SignerFn(s.rollupCfg.L1ChainID)(t.Ctx(), s.batcherAddr, types.NewTx(rawTx))
 	require.NoError(t, err, "need to sign tx")
 
 	err = s.l1.SendTransaction(t.Ctx(), tx)
@@ -267,31 +403,7 @@ func (s *L2Batcher) ActL2BatchSubmitGarbage(t Testing, kind GarbageKind) {
 		t.Fatalf("Unexpected garbage kind: %v", kind)
 	}
 
-	nonce, err := s.l1.PendingNonceAt(t.Ctx(), s.batcherAddr)
-	require.NoError(t, err, "need batcher nonce")
-
-	gasTipCap := big.NewInt(2 * params.GWei)
-	pendingHeader, err := s.l1.HeaderByNumber(t.Ctx(), big.NewInt(-1))
-	require.NoError(t, err, "need l1 pending header for gas price estimation")
-	gasFeeCap := new(big.Int).Add(gasTipCap, new(big.Int).Mul(pendingHeader.BaseFee, big.NewInt(2)))
-
-	rawTx := &types.DynamicFeeTx{
-		ChainID:   s.rollupCfg.L1ChainID,
-		Nonce:     nonce,
-		To:        &s.rollupCfg.BatchInboxAddress,
-		GasTipCap: gasTipCap,
-		GasFeeCap: gasFeeCap,
-		Data:      outputFrame,
-	}
-	gas, err := core.IntrinsicGas(rawTx.Data, nil, false, true, true, false)
-	require.NoError(t, err, "need to compute intrinsic gas")
-	rawTx.Gas = gas
-
-	tx, err := types.SignNewTx(s.l2BatcherCfg.BatcherKey, s.l1Signer, rawTx)
-	require.NoError(t, err, "need to sign tx")
-
-	err = s.l1.SendTransaction(t.Ctx(), tx)
-	require.NoError(t, err, "need to send tx")
+	s.sendBatchTx(t, outputFrame)
 }
 
 func (s *L2Batcher) ActBufferAll(t Testing) {
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
@@ -77,6 +78,9 @@ func TestBatcher(gt *testing.T) {
 	_, txs, err := l1Cl.InfoAndTxsByHash(t.Ctx(), bl.Hash())
 	require.NoError(t, err)
 	log.Info("bl", "txs", len(txs))
+	signed := batcher.Signer().SignedTo(sd.RollupCfg.BatchInboxAddress, nil)
+	require.Len(t, signed, 1)
+	require.Equal(t, signed[0].Hash(), txs[len(txs)-1].Hash())
 
 	// Now make enough L1 blocks that the syncer will have to derive a L2 block
 	// It will also eagerly derive the block from the batcher
@@ -98,6 +102,69 @@ func TestBatcher(gt *testing.T) {
 	require.NotNil(t, vTx)
 }
 
+func TestBatcherMultiFrameChannel(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	p := &e2eutils.TestParams{
+		MaxProposerDrift:   20, // larger than L1 block time we simulate in this test (12)
+		ProposerWindowSize: 24,
+		ChannelTimeout:     20,
+	}
+	dp := e2eutils.MakeDeployParams(t, p)
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LvlDebug)
+	miner, propEngine, proposer := setupProposerTest(t, sd, log)
+	syncEngine, syncer := setupSyncer(t, sd, log, miner.L1Client(t, sd.RollupCfg))
+
+	batcher := NewL2Batcher(log, sd.RollupCfg, &BatcherCfg{
+		MinL1TxSize:      0,
+		MaxL1TxSize:      100, // small frames, to force the channel to be split between multiple frames
+		BatcherKey:       dp.Secrets.Batcher,
+		TargetNumFrames:  3,
+		ApproxComprRatio: 1,
+	}, proposer.RollupClient(), miner.EthClient(), propEngine.EthClient())
+
+	proposer.ActL2PipelineFull(t)
+	syncer.ActL2PipelineFull(t)
+
+	// build L2 blocks spanning multiple L1 blocks
+	for i := 0; i < 3; i++ {
+		miner.ActEmptyBlock(t)
+		proposer.ActL1HeadSignal(t)
+		proposer.ActBuildToL1Head(t)
+	}
+
+	// submit a single channel, with the frames in reverse order
+	batcher.ActSubmitChannel(t, ReverseFrameOrder)
+	lastBlockInChannel := batcher.l2BufferedBlock
+	require.Less(t, lastBlockInChannel.Number, proposer.SyncStatus().UnsafeL2.Number, "channel must be closed at the target number of frames")
+
+	txs, _ := miner.eth.TxPool().ContentFrom(dp.Addresses.Batcher)
+	require.Greater(t, len(txs), 1, "channel must be split into multiple frames")
+
+	// include each frame in a separate L1 block
+	for i := range txs {
+		miner.ActL1StartBlock(12)(t)
+		miner.ActL1IncludeTx(dp.Addresses.Batcher)(t)
+		miner.ActL1EndBlock(t)
+
+		syncer.ActL1HeadSignal(t)
+		syncer.ActL2PipelineFull(t)
+		if i < len(txs)-1 {
+			require.Zero(t, syncer.SyncStatus().SafeL2.Number, "channel must not be read before all frames land")
+		}
+	}
+
+	// the syncer derives the L2 blocks once the first frame lands last
+	require.Equal(t, lastBlockInChannel, syncer.SyncStatus().SafeL2.ID())
+	for n := uint64(1); n <= lastBlockInChannel.Number; n++ {
+		expected, err := propEngine.EthClient().BlockByNumber(t.Ctx(), new(big.Int).SetUint64(n))
+		require.NoError(t, err)
+		actual, err := syncEngine.EthClient().BlockByNumber(t.Ctx(), new(big.Int).SetUint64(n))
+		require.NoError(t, err)
+		require.Equal(t, expected.Hash(), actual.Hash(), "syncer must derive the same L2 block %d", n)
+	}
+}
+
 func TestL2Finalization(gt *testing.T) {
 	t := NewDefaultTesting(gt)
 	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
@@ -341,6 +408,60 @@ func TestGarbageBatch(gt *testing.T) {
 	}
 }
 
+// TestBatcherCompressionAlgos tests that the blocks batched in channels of every compression
+// algorithm are derived by the syncer once the compression algorithms are activated, and that
+// the channels of the algorithms other than zlib are dropped before the activation.
+func TestBatcherCompressionAlgos(gt *testing.T) {
+	for _, activated := range []bool{true, false} {
+		for _, algo := range derive.CompressionAlgos {
+			activated, algo := activated, algo
+			name := string(algo)
+			if !activated {
+				name += "_before_activation"
+			}
+			gt.Run(name, func(gt *testing.T) {
+				t := NewDefaultTesting(gt)
+				dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+				if activated {
+					offset := hexutil.Uint64(0)
+					dp.DeployConfig.L2GenesisCompressionAlgosTimeOffset = &offset
+				}
+				sd := e2eutils.Setup(t, dp, defaultAlloc)
+				log := testlog.Logger(t, log.LvlError)
+				miner, engine, proposer := setupProposerTest(t, sd, log)
+				_, syncer := setupSyncer(t, sd, log, miner.L1Client(t, sd.RollupCfg))
+
+				batcher := NewL2Batcher(log, sd.RollupCfg, &BatcherCfg{
+					MinL1TxSize:     0,
+					MaxL1TxSize:     128_000,
+					BatcherKey:      dp.Secrets.Batcher,
+					CompressionAlgo: algo,
+				}, proposer.RollupClient(), miner.EthClient(), engine.EthClient())
+
+				// Build L2 blocks on top of an empty L1 block and batch them
+				miner.ActEmptyBlock(t)
+				proposer.ActL1HeadSignal(t)
+				proposer.ActL2PipelineFull(t)
+				proposer.ActBuildToL1Head(t)
+				batcher.ActSubmitAll(t)
+
+				miner.ActL1StartBlock(12)(t)
+				miner.ActL1IncludeTx(dp.Addresses.Batcher)(t)
+				miner.ActL1EndBlock(t)
+
+				syncer.ActL1HeadSignal(t)
+				syncer.ActL2PipelineFull(t)
+				if !activated && algo != derive.Zlib {
+					require.Equal(t, uint64(0), syncer.L2Safe().Number, "channel must be dropped before activation")
+					return
+				}
+				require.Equal(t, uint64(1), syncer.L2Safe().L1Origin.Number)
+				require.Equal(t, proposer.L2Unsafe().Hash, syncer.L2Safe().Hash)
+			})
+		}
+	}
+}
+
 func TestExtendedTimeWithoutL1Batches(gt *testing.T) {
 	t := NewDefaultTesting(gt)
 	p := &e2eutils.TestParams{
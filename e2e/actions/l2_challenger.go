@@ -65,6 +65,22 @@ func (v *L2Validator) ActBisect(t Testing, outputIndex *big.Int, challenger comm
 	return tx.Hash()
 }
 
+// ActRespondBisection bisects the segments of the challenge on the output at outputIndex, if it is the turn of
+// this validator, either as the asserter or as the given challenger.
+func (v *L2Validator) ActRespondBisection(t Testing, outputIndex *big.Int, challenger common.Address) common.Hash {
+	status, err := v.challenger.GetChallengeStatus(t.Ctx(), outputIndex, challenger)
+	require.NoError(t, err, "unable to get challenge status")
+
+	isAsserter := v.address != challenger
+	if isAsserter {
+		require.Equal(t, chal.StatusAsserterTurn, status, "not the asserter turn")
+	} else {
+		require.Equal(t, chal.StatusChallengerTurn, status, "not the challenger turn")
+	}
+
+	return v.ActBisect(t, outputIndex, challenger, isAsserter)
+}
+
 func (v *L2Validator) ActCancelChallenge(t Testing, outputIndex *big.Int) common.Hash {
 	tx, err := v.challenger.CancelChallenge(t.Ctx(), outputIndex)
 	require.NoError(t, err, "unable to create cancel challenge tx")
@@ -29,19 +29,22 @@ func TestChallenge(t *testing.T) {
 	// create challenge
 	rt.setupChallenge(rt.challenger1)
 
+	prevStatus := chal.StatusNone
 interaction:
 	for {
 		status, err := rt.colosseumContract.GetStatus(nil, rt.outputIndex, rt.challenger1.address)
 		require.NoError(rt.t, err)
+		require.NotEqual(rt.t, prevStatus, status, "challenge status must transition after each step")
+		prevStatus = status
 
 		switch status {
 		case chal.StatusChallengerTurn:
 			// call bisect by challenger
-			rt.txHash = rt.challenger1.ActBisect(rt.t, rt.outputIndex, rt.challenger1.address, false)
+			rt.txHash = rt.challenger1.ActRespondBisection(rt.t, rt.outputIndex, rt.challenger1.address)
 			rt.miner.includeL1Block(rt.t, rt.challenger1.address)
 		case chal.StatusAsserterTurn:
 			// call bisect by validator
-			rt.txHash = rt.validator.ActBisect(rt.t, rt.outputIndex, rt.challenger1.address, true)
+			rt.txHash = rt.validator.ActRespondBisection(rt.t, rt.outputIndex, rt.challenger1.address)
 			rt.miner.includeL1Block(rt.t, rt.validator.address)
 		case chal.StatusReadyToProve:
 			rt.txHash = rt.challenger1.ActProveFault(rt.t, rt.outputIndex, false)
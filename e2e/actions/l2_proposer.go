@@ -41,8 +41,8 @@ type L2Proposer struct {
 	mockL1OriginSelector *MockL1OriginSelector
 }
 
-func NewL2Proposer(t Testing, log log.Logger, l1 derive.L1Fetcher, eng L2API, cfg *rollup.Config, propConfDepth uint64) *L2Proposer {
-	syncer := NewL2Syncer(t, log, l1, eng, cfg)
+func NewL2Proposer(t Testing, log log.Logger, l1 derive.L1Fetcher, daFetcher derive.DAFetcher, eng L2API, cfg *rollup.Config, propConfDepth uint64) *L2Proposer {
+	syncer := NewL2Syncer(t, log, l1, daFetcher, eng, cfg)
 	attrBuilder := derive.NewFetchingAttributesBuilder(cfg, l1, eng)
 	propConfDepthL1 := driver.NewConfDepth(propConfDepth, syncer.l1State.L1Head, l1)
 	l1OriginSelector := &MockL1OriginSelector{
@@ -67,7 +67,7 @@ func setupProposerTest(t Testing, sd *e2eutils.SetupData, log log.Logger) (*L1Mi
 	l2Cl, err := sources.NewEngineClient(engine.RPCClient(), log, nil, sources.EngineClientDefaultConfig(sd.RollupCfg))
 	require.NoError(t, err)
 
-	proposer := NewL2Proposer(t, log, l1F, l2Cl, sd.RollupCfg, 0)
+	proposer := NewL2Proposer(t, log, l1F, sd.DA, l2Cl, sd.RollupCfg, 0)
 	return miner, engine, proposer
 }
 
@@ -154,8 +154,18 @@ func (rt *Runtime) bindChallengeContracts() {
 	require.NoError(rt.t, err)
 }
 
-// setupOutputSubmitted sets output submission by validator
-func (rt *Runtime) setupOutputSubmitted() {
+// setupHonestValidators sets up an honest validator for each of the given keys, which compete with each other
+// to submit outputs according to the round selection of the ValidatorPool.
+func (rt *Runtime) setupHonestValidators(pks ...*ecdsa.PrivateKey) []*L2Validator {
+	validators := make([]*L2Validator, len(pks))
+	for i, pk := range pks {
+		validators[i] = rt.honestValidator(pk)
+	}
+	return validators
+}
+
+// buildFinalizedL2Blocks builds L2 blocks and finalizes them along with the L1 blocks including the batches.
+func (rt *Runtime) buildFinalizedL2Blocks() {
 	// NOTE(chokobole): It is necessary to wait for one finalized (or safe if AllowNonFinalized
 	// config is set) block to pass after each submission interval before submitting the output
 	// root. For example, if the submission interval is set to 1800 blocks, the output root can
@@ -182,6 +192,11 @@ func (rt *Runtime) setupOutputSubmitted() {
 		rt.proposer.ActL1SafeSignal(rt.t)
 		rt.proposer.ActL1FinalizedSignal(rt.t)
 	}
+}
+
+// setupOutputSubmitted sets output submission by validator
+func (rt *Runtime) setupOutputSubmitted() {
+	rt.buildFinalizedL2Blocks()
 
 	// deposit bond for validator
 	rt.validator.ActDeposit(rt.t, defaultDepositAmount)
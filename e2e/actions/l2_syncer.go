@@ -57,9 +57,9 @@ type L2API interface {
 	GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error)
 }
 
-func NewL2Syncer(t Testing, log log.Logger, l1 derive.L1Fetcher, eng L2API, cfg *rollup.Config) *L2Syncer {
+func NewL2Syncer(t Testing, log log.Logger, l1 derive.L1Fetcher, daFetcher derive.DAFetcher, eng L2API, cfg *rollup.Config) *L2Syncer {
 	metrics := &testutils.TestDerivationMetrics{}
-	pipeline := derive.NewDerivationPipeline(log, cfg, l1, eng, metrics)
+	pipeline := derive.NewDerivationPipeline(log, cfg, l1, daFetcher, eng, metrics)
 	pipeline.Reset()
 
 	rollupNode := &L2Syncer{
@@ -156,6 +156,12 @@ func (s *L2Syncer) SyncStatus() *eth.SyncStatus {
 	}
 }
 
+// ProposerWindowSize returns the number of L1 blocks after which the L2 blocks of an epoch,
+// including the deposits of the epoch, are derived even if no batch is submitted for them.
+func (s *L2Syncer) ProposerWindowSize() uint64 {
+	return s.rollupCfg.ProposerWindowSize
+}
+
 func (s *L2Syncer) RollupClient() *sources.RollupClient {
 	return sources.NewRollupClient(s.RPCClient())
 }
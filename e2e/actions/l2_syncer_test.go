@@ -16,7 +16,7 @@ func setupSyncer(t Testing, sd *e2eutils.SetupData, log log.Logger, l1F derive.L
 	jwtPath := e2eutils.WriteDefaultJWT(t)
 	engine := NewL2Engine(t, log, sd.L2Cfg, sd.RollupCfg.Genesis.L1, jwtPath)
 	engCl := engine.EngineClient(t, sd.RollupCfg)
-	syncer := NewL2Syncer(t, log, l1F, engCl, sd.RollupCfg)
+	syncer := NewL2Syncer(t, log, l1F, sd.DA, engCl, sd.RollupCfg)
 	return engine, syncer
 }
 
@@ -4,12 +4,12 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
@@ -21,8 +21,8 @@ import (
 	"github.com/kroma-network/kroma/components/validator"
 	validatormetrics "github.com/kroma-network/kroma/components/validator/metrics"
 	"github.com/kroma-network/kroma/e2e/e2eutils"
-	kcrypto "github.com/kroma-network/kroma/utils/service/crypto"
 	"github.com/kroma-network/kroma/utils/service/txmgr"
+	txmetrics "github.com/kroma-network/kroma/utils/service/txmgr/metrics"
 )
 
 type ValidatorCfg struct {
@@ -46,16 +46,21 @@ type L2Validator struct {
 	valPoolContractAddr common.Address
 	lastTx              common.Hash
 	cfg                 *validator.Config
+
+	// txMgr sends the submissions of ActStartSubmitL2Output through the real send path,
+	// publishing to L1 via txBackend.
+	txMgr      *txmgr.SimpleTxManager
+	txBackend  *stallingBackend
+	submission *txmgrSubmission
+
+	// signer signs the txs of the validator, recording them.
+	signer *e2eutils.RecordingSigner
 }
 
 func NewL2Validator(t Testing, log log.Logger, cfg *ValidatorCfg, l1 *ethclient.Client, l2 *ethclient.Client, rollupCl *sources.RollupClient) *L2Validator {
-	signer := func(chainID *big.Int) kcrypto.SignerFn {
-		s := kcrypto.PrivateKeySignerFn(cfg.ValidatorKey, chainID)
-		return func(_ context.Context, addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
-			return s(addr, tx)
-		}
-	}
-	from := crypto.PubkeyToAddress(cfg.ValidatorKey.PublicKey)
+	recorder := e2eutils.NewRecordingSigner(cfg.ValidatorKey)
+	signer := recorder.SignerFn
+	from := recorder.Address()
 
 	chainID, err := l1.ChainID(t.Ctx())
 	require.NoError(t, err)
@@ -99,6 +104,20 @@ func NewL2Validator(t Testing, log log.Logger, cfg *ValidatorCfg, l1 *ethclient.
 	guardian, err := validator.NewGuardian(t.Ctx(), validatorCfg, log)
 	require.NoError(t, err)
 
+	txBackend := &stallingBackend{Client: l1}
+	txMgr := txmgr.NewSimpleTxManagerFromConfig("validator", log, &txmetrics.NoopTxMetrics{}, txmgr.Config{
+		Backend:                   txBackend,
+		ChainID:                   chainID,
+		ResubmissionTimeout:       100 * time.Millisecond,
+		TxNotInMempoolTimeout:     time.Minute,
+		NetworkTimeout:            time.Second,
+		ReceiptQueryInterval:      50 * time.Millisecond,
+		NumConfirmations:          1,
+		SafeAbortNonceTooLowCount: 3,
+		Signer:                    signer(chainID),
+		From:                      from,
+	})
+
 	return &L2Validator{
 		log:                 log,
 		l1:                  l1,
@@ -110,9 +129,17 @@ func NewL2Validator(t Testing, log log.Logger, cfg *ValidatorCfg, l1 *ethclient.
 		l2ooContractAddr:    cfg.OutputOracleAddr,
 		valPoolContractAddr: cfg.ValidatorPoolAddr,
 		cfg:                 &validatorCfg,
+		txMgr:               txMgr,
+		txBackend:           txBackend,
+		signer:              recorder,
 	}
 }
 
+// Signer returns the signer of the validator, which recorded the txs it signed.
+func (v *L2Validator) Signer() *e2eutils.RecordingSigner {
+	return v.signer
+}
+
 // sendTx reimplements creating & sending transactions because we need to do the final send as async in
 // the action tests while we do it synchronously in the real system.
 func (v *L2Validator) sendTx(t Testing, toAddr *common.Address, txValue *big.Int, data []byte) {
@@ -120,10 +147,6 @@ func (v *L2Validator) sendTx(t Testing, toAddr *common.Address, txValue *big.Int
 	pendingHeader, err := v.l1.HeaderByNumber(t.Ctx(), big.NewInt(-1))
 	require.NoError(t, err, "need l1 pending header for gas price estimation")
 	gasFeeCap := new(big.Int).Add(gasTipCap, new(big.Int).Mul(pendingHeader.BaseFee, big.NewInt(2)))
-	chainID, err := v.l1.ChainID(t.Ctx())
-	require.NoError(t, err)
-	nonce, err := v.l1.NonceAt(t.Ctx(), v.address, nil)
-	require.NoError(t, err)
 
 	gasLimit, err := v.l1.EstimateGas(t.Ctx(), ethereum.CallMsg{
 		From:      v.address,
@@ -135,6 +158,17 @@ func (v *L2Validator) sendTx(t Testing, toAddr *common.Address, txValue *big.Int
 	})
 	require.NoError(t, err)
 
+	v.sendTxWithGasLimit(t, toAddr, txValue, data, gasTipCap, gasFeeCap, gasLimit)
+}
+
+// sendTxWithGasLimit sends a transaction with the given gas limit without estimating it,
+// so that the transaction is included even if it is going to revert.
+func (v *L2Validator) sendTxWithGasLimit(t Testing, toAddr *common.Address, txValue *big.Int, data []byte, gasTipCap, gasFeeCap *big.Int, gasLimit uint64) {
+	chainID, err := v.l1.ChainID(t.Ctx())
+	require.NoError(t, err)
+	nonce, err := v.l1.NonceAt(t.Ctx(), v.address, nil)
+	require.NoError(t, err)
+
 	rawTx := &types.DynamicFeeTx{
 		Nonce:     nonce,
 		To:        toAddr,
@@ -146,7 +180,7 @@ func (v *L2Validator) sendTx(t Testing, toAddr *common.Address, txValue *big.Int
 		ChainID:   chainID,
 	}
 
-	tx, err := types.SignNewTx(v.privKey, types.LatestSignerForChainID(chainID), rawTx)
+	tx, err := v.signer.SignerFn(chainID)(t.Ctx(), v.address, types.NewTx(rawTx))
 	require.NoError(t, err, "need to sign tx")
 
 	err = v.l1.SendTransaction(t.Ctx(), tx)
@@ -163,6 +197,194 @@ func (v *L2Validator) CalculateWaitTime(t Testing) time.Duration {
 }
 
 func (v *L2Validator) ActSubmitL2Output(t Testing) {
+	txData := v.submitL2OutputTxData(t)
+
+	// Note: Use L1 instead of the output submitter's transaction manager because
+	// this is non-blocking while the txmgr is blocking & deadlocks the tests
+	v.sendTx(t, &v.l2ooContractAddr, common.Big0, txData)
+}
+
+// ActSubmitL2OutputWithGasLimit submits the next output with the given gas limit instead of estimating it.
+// It is used when competing validators submit the same output, since the gas estimation of the later one
+// fails against the pending state including the earlier submission.
+func (v *L2Validator) ActSubmitL2OutputWithGasLimit(t Testing, gasLimit uint64) {
+	txData := v.submitL2OutputTxData(t)
+
+	gasTipCap := big.NewInt(2 * params.GWei)
+	pendingHeader, err := v.l1.HeaderByNumber(t.Ctx(), big.NewInt(-1))
+	require.NoError(t, err, "need l1 pending header for gas price estimation")
+	gasFeeCap := new(big.Int).Add(gasTipCap, new(big.Int).Mul(pendingHeader.BaseFee, big.NewInt(2)))
+
+	v.sendTxWithGasLimit(t, &v.l2ooContractAddr, common.Big0, txData, gasTipCap, gasFeeCap, gasLimit)
+}
+
+// stallingBackend is the L1 backend of the validator txmgr, which can stall the publication of txs
+// to simulate txs stuck in a congested L1: while stalled, txs are reported as published but never reach L1.
+type stallingBackend struct {
+	*ethclient.Client
+
+	mu        sync.Mutex
+	stalled   bool
+	tipCap    *big.Int
+	attempts  []*types.Transaction
+	published int
+}
+
+func (b *stallingBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	b.mu.Lock()
+	tipCap := b.tipCap
+	b.mu.Unlock()
+	if tipCap != nil {
+		return tipCap, nil
+	}
+	return b.Client.SuggestGasTipCap(ctx)
+}
+
+func (b *stallingBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.attempts = append(b.attempts, tx)
+	if b.stalled {
+		return nil
+	}
+	if err := b.Client.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	b.published++
+	return nil
+}
+
+// txmgrSubmission is an output submission in flight through the txmgr.
+type txmgrSubmission struct {
+	cancel  context.CancelFunc
+	done    chan struct{}
+	receipt *types.Receipt
+	err     error
+}
+
+// ActStallL1Publication makes the txs sent by the txmgr get stuck until ActResumeL1Publication.
+func (v *L2Validator) ActStallL1Publication(t Testing) {
+	v.txBackend.mu.Lock()
+	defer v.txBackend.mu.Unlock()
+	v.txBackend.stalled = true
+}
+
+// ActResumeL1Publication makes the txs sent by the txmgr reach L1 again.
+func (v *L2Validator) ActResumeL1Publication(t Testing) {
+	v.txBackend.mu.Lock()
+	defer v.txBackend.mu.Unlock()
+	v.txBackend.stalled = false
+}
+
+// ActSetSuggestedGasTipCap makes the txmgr suggest the given gas tip cap, simulating the rising fee market
+// of a congested L1, so that the stuck txs are resubmitted with bumped fees.
+func (v *L2Validator) ActSetSuggestedGasTipCap(t Testing, tipCap *big.Int) {
+	v.txBackend.mu.Lock()
+	defer v.txBackend.mu.Unlock()
+	v.txBackend.tipCap = tipCap
+}
+
+// ActStartSubmitL2Output starts submitting the next output through the txmgr, which resubmits
+// the tx every ResubmissionTimeout, with a bumped fee if the fee market has risen, until it is confirmed.
+// The submission runs in the background, since it is blocked until the tx is included by the miner.
+func (v *L2Validator) ActStartSubmitL2Output(t Testing) {
+	if v.submission != nil {
+		select {
+		case <-v.submission.done:
+		default:
+			t.Fatalf("previous submission must be finished")
+		}
+	}
+	txData := v.submitL2OutputTxData(t)
+
+	v.txBackend.mu.Lock()
+	v.txBackend.attempts = nil
+	v.txBackend.published = 0
+	v.txBackend.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(t.Ctx())
+	s := &txmgrSubmission{cancel: cancel, done: make(chan struct{})}
+	v.submission = s
+	go func() {
+		defer close(s.done)
+		s.receipt, s.err = v.txMgr.Send(ctx, txmgr.TxCandidate{
+			TxData: txData,
+			To:     &v.l2ooContractAddr,
+		})
+	}()
+}
+
+// ActWaitSubmissionAttempts waits until the txmgr has made at least n attempts to publish the submission.
+func (v *L2Validator) ActWaitSubmissionAttempts(t Testing, n int) {
+	require.Eventually(t, func() bool {
+		return v.SubmissionAttempts() >= n
+	}, 10*time.Second, 10*time.Millisecond, "txmgr must attempt to publish the submission %d times", n)
+}
+
+// ActWaitSubmissionPublished waits until a submission tx reaches L1, so that it can be included by the miner.
+func (v *L2Validator) ActWaitSubmissionPublished(t Testing) {
+	require.Eventually(t, func() bool {
+		v.txBackend.mu.Lock()
+		defer v.txBackend.mu.Unlock()
+		return v.txBackend.published > 0
+	}, 10*time.Second, 10*time.Millisecond, "submission must be published to L1")
+}
+
+// ActWaitL2OutputSubmitted waits for the submission to be finished, either confirmed or aborted by the txmgr.
+func (v *L2Validator) ActWaitL2OutputSubmitted(t Testing) {
+	require.NotNil(t, v.submission, "no submission in flight")
+	select {
+	case <-v.submission.done:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("submission is not finished")
+	}
+	if v.submission.receipt != nil {
+		v.lastTx = v.submission.receipt.TxHash
+	}
+}
+
+// ActCancelL2OutputSubmission cancels the submission in flight and waits for the txmgr to give up.
+func (v *L2Validator) ActCancelL2OutputSubmission(t Testing) {
+	require.NotNil(t, v.submission, "no submission in flight")
+	v.submission.cancel()
+	v.ActWaitL2OutputSubmitted(t)
+}
+
+// SubmissionAttempts returns the number of attempts the txmgr made to publish the last submission.
+func (v *L2Validator) SubmissionAttempts() int {
+	v.txBackend.mu.Lock()
+	defer v.txBackend.mu.Unlock()
+	return len(v.txBackend.attempts)
+}
+
+// SubmissionResubmissions returns the number of times the last submission was resubmitted with a bumped fee.
+func (v *L2Validator) SubmissionResubmissions() int {
+	if attempts := v.SubmissionAttempts(); attempts > 0 {
+		return attempts - 1
+	}
+	return 0
+}
+
+// SubmissionAttempt returns the tx of the i-th attempt to publish the last submission.
+func (v *L2Validator) SubmissionAttempt(i int) *types.Transaction {
+	v.txBackend.mu.Lock()
+	defer v.txBackend.mu.Unlock()
+	return v.txBackend.attempts[i]
+}
+
+// SubmissionResult returns the receipt and the error of the finished submission.
+func (v *L2Validator) SubmissionResult(t Testing) (*types.Receipt, error) {
+	require.NotNil(t, v.submission, "no submission in flight")
+	select {
+	case <-v.submission.done:
+	default:
+		t.Fatalf("submission is not finished")
+	}
+	return v.submission.receipt, v.submission.err
+}
+
+func (v *L2Validator) submitL2OutputTxData(t Testing) []byte {
 	nextBlockNumber, err := v.l2os.FetchNextBlockNumber(t.Ctx())
 	require.NoError(t, err)
 
@@ -172,9 +394,7 @@ func (v *L2Validator) ActSubmitL2Output(t Testing) {
 	txData, err := validator.SubmitL2OutputTxData(v.l2os.L2ooAbi(), output)
 	require.NoError(t, err)
 
-	// Note: Use L1 instead of the output submitter's transaction manager because
-	// this is non-blocking while the txmgr is blocking & deadlocks the tests
-	v.sendTx(t, &v.l2ooContractAddr, common.Big0, txData)
+	return txData
 }
 
 func (v *L2Validator) LastSubmitL2OutputTx() common.Hash {
@@ -191,6 +411,47 @@ func (v *L2Validator) ActDeposit(t Testing, depositAmount uint64) {
 	v.sendTx(t, &v.valPoolContractAddr, new(big.Int).SetUint64(depositAmount), txData)
 }
 
+// ActUnbond unbonds the bonds of the finalized outputs in the ValidatorPool, which returns the bonded amount to
+// the deposit of the validator that submitted each output.
+func (v *L2Validator) ActUnbond(t Testing) {
+	valPoolABI, err := bindings.ValidatorPoolMetaData.GetAbi()
+	require.NoError(t, err)
+
+	txData, err := valPoolABI.Pack("unbond")
+	require.NoError(t, err)
+
+	v.sendTx(t, &v.valPoolContractAddr, common.Big0, txData)
+}
+
+// CallUnbond simulates unbonding against the latest L1 state without sending a tx.
+// It returns the revert error if the unbonding would be rejected.
+func (v *L2Validator) CallUnbond(t Testing) error {
+	valPoolABI, err := bindings.ValidatorPoolMetaData.GetAbi()
+	require.NoError(t, err)
+
+	txData, err := valPoolABI.Pack("unbond")
+	require.NoError(t, err)
+
+	_, err = v.l1.CallContract(t.Ctx(), ethereum.CallMsg{
+		From: v.address,
+		To:   &v.valPoolContractAddr,
+		Data: txData,
+	}, nil)
+	return err
+}
+
+// ActWithdrawBond withdraws the given amount from the deposit of the validator in the ValidatorPool,
+// sending the ETH back to the validator address.
+func (v *L2Validator) ActWithdrawBond(t Testing, amount uint64) {
+	valPoolABI, err := bindings.ValidatorPoolMetaData.GetAbi()
+	require.NoError(t, err)
+
+	txData, err := valPoolABI.Pack("withdraw", new(big.Int).SetUint64(amount))
+	require.NoError(t, err)
+
+	v.sendTx(t, &v.valPoolContractAddr, common.Big0, txData)
+}
+
 func (v *L2Validator) fetchOutput(t Testing, blockNumber *big.Int) *eth.OutputResponse {
 	output, err := v.l2os.FetchOutput(t.Ctx(), blockNumber)
 	require.NoError(t, err)
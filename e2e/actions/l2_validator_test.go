@@ -1,16 +1,23 @@
 package actions
 
 import (
+	"context"
+	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/require"
 
 	"github.com/kroma-network/kroma/bindings/bindings"
 	"github.com/kroma-network/kroma/components/node/eth"
 	"github.com/kroma-network/kroma/components/node/testlog"
 	"github.com/kroma-network/kroma/e2e/e2eutils"
+	"github.com/kroma-network/kroma/e2e/testdata"
+	"github.com/kroma-network/kroma/utils/service/txmgr"
 )
 
 func TestValidator(gt *testing.T) {
@@ -103,3 +110,457 @@ func TestValidator(gt *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, eth.Bytes32(outputOnL1.OutputRoot), outputComputed.OutputRoot, "output roots must match")
 }
+
+// TestValidatorAllowNonFinalized asserts that a validator with AllowNonFinalized submits outputs
+// rooted on safe but not finalized L2 blocks, while a strict validator waits for the finalization.
+func TestValidatorAllowNonFinalized(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LvlDebug)
+	miner, propEngine, proposer := setupProposerTest(t, sd, log)
+
+	rollupPropCl := proposer.RollupClient()
+	batcher := NewL2Batcher(log, sd.RollupCfg, &BatcherCfg{
+		MinL1TxSize: 0,
+		MaxL1TxSize: 128_000,
+		BatcherKey:  dp.Secrets.Batcher,
+	}, rollupPropCl, miner.EthClient(), propEngine.EthClient())
+
+	// Both validators share the trusted validator key, so that only the mode differs.
+	newValidator := func(allowNonFinalized bool) *L2Validator {
+		return NewL2Validator(t, log, &ValidatorCfg{
+			OutputOracleAddr:    sd.DeploymentsL1.L2OutputOracleProxy,
+			ValidatorPoolAddr:   sd.DeploymentsL1.ValidatorPoolProxy,
+			ColosseumAddr:       sd.DeploymentsL1.ColosseumProxy,
+			SecurityCouncilAddr: sd.DeploymentsL1.SecurityCouncilProxy,
+			ValidatorKey:        dp.Secrets.TrustedValidator,
+			AllowNonFinalized:   allowNonFinalized,
+		}, miner.EthClient(), propEngine.EthClient(), proposer.RollupClient())
+	}
+	nonFinalizedValidator := newValidator(true)
+	strictValidator := newValidator(false)
+
+	// make the L2 chain safe, but not finalized
+	for i := 0; i < 2; i++ {
+		miner.ActEmptyBlock(t)
+		proposer.ActL1HeadSignal(t)
+		proposer.ActL2PipelineFull(t)
+		proposer.ActBuildToL1Head(t)
+		batcher.ActSubmitAll(t)
+		miner.includeL1Block(t, dp.Addresses.Batcher)
+		miner.ActL1SafeNext(t)
+		miner.ActL1SafeNext(t)
+		proposer.ActL2PipelineFull(t)
+		proposer.ActL1SafeSignal(t)
+	}
+
+	// deposit bond for the validators
+	nonFinalizedValidator.ActDeposit(t, 1_000)
+	miner.includeL1Block(t, nonFinalizedValidator.address)
+
+	status := proposer.SyncStatus()
+	require.Equal(t, status.UnsafeL2, status.SafeL2)
+	require.Zero(t, status.FinalizedL2.Number, "L2 chain must not be finalized yet")
+
+	// only the validator allowing non-finalized blocks may submit the output
+	require.Positive(t, strictValidator.CalculateWaitTime(t), "strict validator must wait for finalization")
+	require.Zero(t, nonFinalizedValidator.CalculateWaitTime(t), "output on safe blocks must be submittable")
+	nonFinalizedValidator.ActSubmitL2Output(t)
+	miner.includeL1Block(t, nonFinalizedValidator.address)
+	miner.ActEmptyBlock(t)
+	receipt, err := miner.EthClient().TransactionReceipt(t.Ctx(), nonFinalizedValidator.LastSubmitL2OutputTx())
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status, "submission failed")
+	require.Positive(t, strictValidator.CalculateWaitTime(t), "strict validator must wait for finalization")
+
+	// once the L2 chain is finalized, the strict validator may submit the next output
+	for i := 0; i < 4; i++ {
+		miner.ActL1FinalizeNext(t)
+	}
+	proposer.ActL2PipelineFull(t)
+	proposer.ActL1FinalizedSignal(t)
+	require.Equal(t, status.SafeL2, proposer.SyncStatus().FinalizedL2)
+	require.Zero(t, strictValidator.CalculateWaitTime(t), "output on finalized blocks must be submittable")
+
+	strictValidator.ActSubmitL2Output(t)
+	miner.includeL1Block(t, strictValidator.address)
+	receipt, err = miner.EthClient().TransactionReceipt(t.Ctx(), strictValidator.LastSubmitL2OutputTx())
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status, "submission failed")
+}
+
+func TestValidatorL1Reorg(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LvlDebug)
+	miner, propEngine, proposer := setupProposerTest(t, sd, log)
+	miner.ActL1SetFeeRecipient(common.Address{'A'})
+
+	rollupPropCl := proposer.RollupClient()
+	batcher := NewL2Batcher(log, sd.RollupCfg, &BatcherCfg{
+		MinL1TxSize: 0,
+		MaxL1TxSize: 128_000,
+		BatcherKey:  dp.Secrets.Batcher,
+	}, rollupPropCl, miner.EthClient(), propEngine.EthClient())
+
+	// The validator is allowed to submit outputs rooted on safe blocks, since L1 blocks that can be
+	// reorged cannot be finalized.
+	validator := NewL2Validator(t, log, &ValidatorCfg{
+		OutputOracleAddr:    sd.DeploymentsL1.L2OutputOracleProxy,
+		ValidatorPoolAddr:   sd.DeploymentsL1.ValidatorPoolProxy,
+		ColosseumAddr:       sd.DeploymentsL1.ColosseumProxy,
+		SecurityCouncilAddr: sd.DeploymentsL1.SecurityCouncilProxy,
+		ValidatorKey:        dp.Secrets.TrustedValidator,
+		AllowNonFinalized:   true,
+	}, miner.EthClient(), propEngine.EthClient(), proposer.RollupClient())
+
+	// deposit bond for validator
+	validator.ActDeposit(t, 1_000)
+	miner.includeL1Block(t, validator.address)
+
+	// create L2 blocks, and reference the L1 head as origin
+	proposer.ActL1HeadSignal(t)
+	proposer.ActBuildToL1Head(t)
+
+	// submit all new L2 blocks and include the batch on L1
+	batcher.ActSubmitAll(t)
+	miner.ActL1StartBlock(12)(t)
+	miner.ActL1IncludeTx(dp.Addresses.Batcher)(t)
+	batchTx := miner.l1Transactions[0]
+	miner.ActL1EndBlock(t)
+
+	// derive the L2 chain from the batch
+	proposer.ActL1HeadSignal(t)
+	proposer.ActL2PipelineFull(t)
+	require.Equal(t, proposer.L2Unsafe(), proposer.L2Safe(), "proposer derives the batch")
+	require.Zero(t, validator.CalculateWaitTime(t), "validator can submit output rooted on the batch")
+
+	// orphan the L1 block that included the batch tx, and build a different L1 branch
+	orphaned := miner.l1Chain.CurrentBlock().Hash()
+	miner.ActL1ReorgDepth(1)(t)
+	miner.ActL1SetFeeRecipient(common.Address{'B'})
+	miner.ActEmptyBlock(t)
+	miner.ActEmptyBlock(t)
+
+	// the proposer re-derives, and the previously derived L2 chain is unsafe again
+	proposer.ActL1HeadSignal(t)
+	proposer.ActL2PipelineFull(t)
+	require.Less(t, proposer.L2Safe().Number, proposer.L2Unsafe().Number, "proposer rewinds safe head when L1 reorgs out batch")
+	require.NotZero(t, validator.CalculateWaitTime(t), "validator must not submit output rooted on the orphaned chain")
+
+	// replay the batch tx in a new L1 block
+	miner.ActL1StartBlock(12)(t)
+	// note: the geth tx pool reorgLoop is too slow, so the tx is re-inserted manually.
+	require.NoError(t, miner.eth.TxPool().AddLocal(batchTx))
+	miner.ActL1IncludeTx(dp.Addresses.Batcher)(t)
+	miner.ActL1EndBlock(t)
+
+	proposer.ActL1HeadSignal(t)
+	proposer.ActL2PipelineFull(t)
+	require.Equal(t, proposer.L2Unsafe(), proposer.L2Safe(), "proposer re-derives the replayed batch")
+	require.Zero(t, validator.CalculateWaitTime(t))
+
+	// the output to be submitted must be rooted on the canonical chain
+	nextBlockNumber, err := validator.l2os.FetchNextBlockNumber(t.Ctx())
+	require.NoError(t, err)
+	output := validator.fetchOutput(t, nextBlockNumber)
+	require.NotEqual(t, orphaned, output.Status.CurrentL1.Hash, "output must not be rooted on the orphaned chain")
+	canonical := miner.l1Chain.GetBlockByNumber(output.Status.CurrentL1.Number)
+	require.Equal(t, canonical.Hash(), output.Status.CurrentL1.Hash, "output must be rooted on the canonical chain")
+
+	validator.ActSubmitL2Output(t)
+	miner.includeL1Block(t, validator.address)
+	receipt, err := miner.EthClient().TransactionReceipt(t.Ctx(), validator.LastSubmitL2OutputTx())
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status, "submission failed")
+}
+
+func TestValidatorUnbond(gt *testing.T) {
+	rt := defaultRuntime(gt)
+	rt.setTargetInvalidBlockNumber(testdata.TargetBlockNumber)
+	rt.setupHonestValidator()
+	rt.bindChallengeContracts()
+	rt.setupOutputSubmitted()
+
+	outputCount, err := rt.outputOracleContract.NextOutputIndex(nil)
+	require.NoError(rt.t, err)
+	require.Positive(rt.t, outputCount.Uint64(), "outputs must be submitted")
+	maxUnbond, err := rt.valPoolContract.MAXUNBOND(nil)
+	require.NoError(rt.t, err)
+
+	bondAmount := rt.dp.DeployConfig.ValidatorPoolRequiredBondAmount.ToInt()
+	totalBond := new(big.Int).Mul(bondAmount, outputCount)
+	deposit := new(big.Int).SetUint64(defaultDepositAmount)
+
+	bal, err := rt.valPoolContract.BalanceOf(nil, rt.validator.address)
+	require.NoError(rt.t, err)
+	require.Equal(rt.t, new(big.Int).Sub(deposit, totalBond), bal, "bonds must be taken from the deposit")
+
+	// unbonding is rejected while the outputs are still challengeable
+	err = rt.validator.CallUnbond(rt.t)
+	require.ErrorContains(rt.t, err, "no bond that can be unbond")
+
+	// advance L1 past the bond lock period of every output
+	lastOutput, err := rt.outputOracleContract.GetL2Output(nil, new(big.Int).Sub(outputCount, common.Big1))
+	require.NoError(rt.t, err)
+	lastBond, err := rt.valPoolContract.GetBond(nil, new(big.Int).Sub(outputCount, common.Big1))
+	require.NoError(rt.t, err)
+	require.Equal(rt.t, lastOutput.Timestamp.Uint64()+rt.dp.DeployConfig.FinalizationPeriodSeconds, lastBond.ExpiresAt.Uint64())
+	rt.miner.ActL1StartBlock(rt.dp.DeployConfig.FinalizationPeriodSeconds)(rt.t)
+	rt.miner.ActL1EndBlock(rt.t)
+	require.NoError(rt.t, rt.validator.CallUnbond(rt.t))
+
+	// unbond the bonds of the finalized outputs, at most MAX_UNBOND bonds at a time
+	for unbonded := uint64(0); unbonded < outputCount.Uint64(); {
+		rt.validator.ActUnbond(rt.t)
+		rt.miner.includeL1Block(rt.t, rt.validator.address)
+		rt.receipt, err = rt.miner.EthClient().TransactionReceipt(rt.t.Ctx(), rt.validator.lastTx)
+		require.NoError(rt.t, err)
+		require.Equal(rt.t, types.ReceiptStatusSuccessful, rt.receipt.Status, "unbond failed")
+
+		unbonded += maxUnbond.Uint64()
+		if unbonded > outputCount.Uint64() {
+			unbonded = outputCount.Uint64()
+		}
+		bal, err = rt.valPoolContract.BalanceOf(nil, rt.validator.address)
+		require.NoError(rt.t, err)
+		returned := new(big.Int).Mul(bondAmount, new(big.Int).SetUint64(unbonded))
+		require.Equal(rt.t, new(big.Int).Add(new(big.Int).Sub(deposit, totalBond), returned), bal, "unbonded bonds must be returned to the deposit")
+	}
+	err = rt.validator.CallUnbond(rt.t)
+	require.ErrorContains(rt.t, err, "no bond that can be unbond")
+
+	for i := uint64(0); i < outputCount.Uint64(); i++ {
+		_, err := rt.valPoolContract.GetBond(nil, new(big.Int).SetUint64(i))
+		require.ErrorContains(rt.t, err, "the bond does not exist", "bond of output %d must be unbonded", i)
+	}
+	bal, err = rt.valPoolContract.BalanceOf(nil, rt.validator.address)
+	require.NoError(rt.t, err)
+	require.Equal(rt.t, deposit, bal, "bonds must be returned to the deposit")
+
+	// withdraw the whole deposit
+	poolBalBefore, err := rt.miner.EthClient().BalanceAt(rt.t.Ctx(), rt.sd.DeploymentsL1.ValidatorPoolProxy, nil)
+	require.NoError(rt.t, err)
+	valBalBefore, err := rt.miner.EthClient().BalanceAt(rt.t.Ctx(), rt.validator.address, nil)
+	require.NoError(rt.t, err)
+
+	rt.validator.ActWithdrawBond(rt.t, defaultDepositAmount)
+	rt.miner.includeL1Block(rt.t, rt.validator.address)
+	rt.receipt, err = rt.miner.EthClient().TransactionReceipt(rt.t.Ctx(), rt.validator.lastTx)
+	require.NoError(rt.t, err)
+	require.Equal(rt.t, types.ReceiptStatusSuccessful, rt.receipt.Status, "withdrawal failed")
+
+	bal, err = rt.valPoolContract.BalanceOf(nil, rt.validator.address)
+	require.NoError(rt.t, err)
+	require.Zero(rt.t, bal.Sign(), "deposit must be fully withdrawn")
+
+	poolBalAfter, err := rt.miner.EthClient().BalanceAt(rt.t.Ctx(), rt.sd.DeploymentsL1.ValidatorPoolProxy, nil)
+	require.NoError(rt.t, err)
+	require.Equal(rt.t, new(big.Int).Sub(poolBalBefore, deposit), poolBalAfter, "pool balance must decrease by the withdrawn amount")
+
+	valBalAfter, err := rt.miner.EthClient().BalanceAt(rt.t.Ctx(), rt.validator.address, nil)
+	require.NoError(rt.t, err)
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(rt.receipt.GasUsed), rt.receipt.EffectiveGasPrice)
+	expected := new(big.Int).Sub(new(big.Int).Add(valBalBefore, deposit), fee)
+	require.Equal(rt.t, expected, valBalAfter, "withdrawn ETH must return to the validator")
+}
+
+func TestMultipleValidatorsCompeteForOutput(gt *testing.T) {
+	rt := defaultRuntime(gt)
+	rt.setTargetInvalidBlockNumber(testdata.TargetBlockNumber)
+	rt.bindChallengeContracts()
+	validators := rt.setupHonestValidators(rt.dp.Secrets.TrustedValidator, rt.dp.Secrets.Challenger1)
+	trusted, other := validators[0], validators[1]
+
+	rt.buildFinalizedL2Blocks()
+
+	// deposit bond for every validator
+	for _, v := range validators {
+		v.ActDeposit(rt.t, defaultDepositAmount)
+		rt.miner.includeL1Block(rt.t, v.address)
+	}
+
+	// without any priority validator selected yet, only the trusted validator can submit
+	require.Zero(rt.t, trusted.CalculateWaitTime(rt.t))
+	require.Positive(rt.t, other.CalculateWaitTime(rt.t), "non-selected validator must wait for the public round")
+
+	trusted.ActSubmitL2Output(rt.t)
+	rt.miner.includeL1Block(rt.t, trusted.address)
+	receipt, err := rt.miner.EthClient().TransactionReceipt(rt.t.Ctx(), trusted.LastSubmitL2OutputTx())
+	require.NoError(rt.t, err)
+	require.Equal(rt.t, types.ReceiptStatusSuccessful, receipt.Status, "submission failed")
+
+	// the priority validator is selected when unbonding a finalized output,
+	// so finalize the submitted output and unbond it
+	rt.miner.ActL1StartBlock(rt.dp.DeployConfig.FinalizationPeriodSeconds)(rt.t)
+	rt.miner.ActL1EndBlock(rt.t)
+	trusted.ActUnbond(rt.t)
+	rt.miner.includeL1Block(rt.t, trusted.address)
+	receipt, err = rt.miner.EthClient().TransactionReceipt(rt.t.Ctx(), trusted.lastTx)
+	require.NoError(rt.t, err)
+	require.Equal(rt.t, types.ReceiptStatusSuccessful, receipt.Status, "unbond failed")
+
+	// the next output is far behind the L2 head, so its priority round has already passed
+	for _, v := range validators {
+		require.Zero(rt.t, v.CalculateWaitTime(rt.t), "every validator must be able to join the public round")
+	}
+	outputIndex, err := rt.outputOracleContract.NextOutputIndex(nil)
+	require.NoError(rt.t, err)
+
+	// both validators race to submit the same output in a single L1 block
+	for _, v := range validators {
+		v.ActSubmitL2OutputWithGasLimit(rt.t, 500_000)
+	}
+	rt.miner.ActL1StartBlock(12)(rt.t)
+	for _, v := range validators {
+		rt.miner.ActL1IncludeTx(v.address)(rt.t)
+	}
+	rt.miner.ActL1EndBlock(rt.t)
+
+	var winner *L2Validator
+	for _, v := range validators {
+		receipt, err := rt.miner.EthClient().TransactionReceipt(rt.t.Ctx(), v.LastSubmitL2OutputTx())
+		require.NoError(rt.t, err)
+		if receipt.Status == types.ReceiptStatusSuccessful {
+			require.Nil(rt.t, winner, "only one submission must succeed")
+			winner = v
+			continue
+		}
+
+		// replay the reverted submission to check the revert reason
+		tx, _, err := rt.miner.EthClient().TransactionByHash(rt.t.Ctx(), v.LastSubmitL2OutputTx())
+		require.NoError(rt.t, err)
+		_, err = rt.miner.EthClient().CallContract(rt.t.Ctx(), ethereum.CallMsg{
+			From: v.address,
+			To:   tx.To(),
+			Data: tx.Data(),
+		}, receipt.BlockNumber)
+		require.ErrorContains(rt.t, err, "block number must be equal to next expected block number")
+	}
+	require.Equal(rt.t, trusted, winner, "the first included submission must win")
+
+	output, err := rt.outputOracleContract.GetL2Output(nil, outputIndex)
+	require.NoError(rt.t, err)
+	require.Equal(rt.t, winner.address, output.Submitter)
+	nextOutputIndex, err := rt.outputOracleContract.NextOutputIndex(nil)
+	require.NoError(rt.t, err)
+	require.Equal(rt.t, new(big.Int).Add(outputIndex, common.Big1), nextOutputIndex, "the output must be submitted only once")
+}
+
+// setupValidatorSubmissionTest sets up a validator with a bond, ready to submit the next output.
+func setupValidatorSubmissionTest(t StatefulTesting) (*L1Miner, *L2Validator) {
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LvlDebug)
+	miner, propEngine, proposer := setupProposerTest(t, sd, log)
+
+	batcher := NewL2Batcher(log, sd.RollupCfg, &BatcherCfg{
+		MinL1TxSize: 0,
+		MaxL1TxSize: 128_000,
+		BatcherKey:  dp.Secrets.Batcher,
+	}, proposer.RollupClient(), miner.EthClient(), propEngine.EthClient())
+
+	validator := NewL2Validator(t, log, &ValidatorCfg{
+		OutputOracleAddr:    sd.DeploymentsL1.L2OutputOracleProxy,
+		ValidatorPoolAddr:   sd.DeploymentsL1.ValidatorPoolProxy,
+		ColosseumAddr:       sd.DeploymentsL1.ColosseumProxy,
+		SecurityCouncilAddr: sd.DeploymentsL1.SecurityCouncilProxy,
+		ValidatorKey:        dp.Secrets.TrustedValidator,
+		AllowNonFinalized:   true,
+	}, miner.EthClient(), propEngine.EthClient(), proposer.RollupClient())
+
+	for i := 0; i < 2; i++ {
+		miner.ActEmptyBlock(t)
+		proposer.ActL1HeadSignal(t)
+		proposer.ActL2PipelineFull(t)
+		proposer.ActBuildToL1Head(t)
+		batcher.ActSubmitAll(t)
+		miner.includeL1Block(t, dp.Addresses.Batcher)
+		miner.ActL1SafeNext(t)
+		miner.ActL1SafeNext(t)
+		proposer.ActL2PipelineFull(t)
+		proposer.ActL1SafeSignal(t)
+	}
+
+	validator.ActDeposit(t, 1_000)
+	miner.includeL1Block(t, validator.address)
+	miner.ActEmptyBlock(t)
+	require.Zero(t, validator.CalculateWaitTime(t), "output must be submittable")
+
+	return miner, validator
+}
+
+// TestValidatorSubmissionResubmittedWhenStuck asserts that an output submission stuck in a congested L1
+// is resubmitted by the txmgr every ResubmissionTimeout, and replaced with a bumped fee once the fee market rises.
+func TestValidatorSubmissionResubmittedWhenStuck(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	miner, validator := setupValidatorSubmissionTest(t)
+
+	// the first attempts get stuck
+	validator.ActStallL1Publication(t)
+	validator.ActStartSubmitL2Output(t)
+	validator.ActWaitSubmissionAttempts(t, 3)
+
+	// the fee market rises, and the next resubmission reaches L1 with a bumped fee
+	validator.ActSetSuggestedGasTipCap(t, big.NewInt(5*params.GWei))
+	validator.ActResumeL1Publication(t)
+	validator.ActWaitSubmissionPublished(t)
+	miner.includeL1Block(t, validator.address)
+	validator.ActWaitL2OutputSubmitted(t)
+
+	receipt, err := validator.SubmissionResult(t)
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status, "submission failed")
+	require.Equal(t, validator.LastSubmitL2OutputTx(), receipt.TxHash)
+	require.GreaterOrEqual(t, validator.SubmissionResubmissions(), 3)
+
+	// the confirmed tx replaced the stuck one with a bumped fee
+	first := validator.SubmissionAttempt(0)
+	tx, _, err := miner.EthClient().TransactionByHash(t.Ctx(), receipt.TxHash)
+	require.NoError(t, err)
+	require.Equal(t, first.Nonce(), tx.Nonce())
+	require.Equal(t, 1, tx.GasFeeCap().Cmp(first.GasFeeCap()), "fee cap must be bumped")
+	require.Equal(t, 1, tx.GasTipCap().Cmp(first.GasTipCap()), "tip cap must be bumped")
+}
+
+// TestValidatorSubmissionAbortedOnNonceTooLow asserts that an output submission is aborted
+// once SafeAbortNonceTooLowCount resubmissions are rejected, because another tx took its nonce.
+func TestValidatorSubmissionAbortedOnNonceTooLow(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	miner, validator := setupValidatorSubmissionTest(t)
+
+	validator.ActStallL1Publication(t)
+	validator.ActStartSubmitL2Output(t)
+	validator.ActWaitSubmissionAttempts(t, 1)
+
+	// another tx takes the nonce of the stuck submission
+	validator.ActDeposit(t, 1)
+	miner.includeL1Block(t, validator.address)
+
+	validator.ActResumeL1Publication(t)
+	validator.ActWaitL2OutputSubmitted(t)
+
+	_, err := validator.SubmissionResult(t)
+	var nonceErr *txmgr.NonceTooLowAbortError
+	require.ErrorAs(t, err, &nonceErr)
+	require.Equal(t, validator.SubmissionAttempt(0).Nonce(), nonceErr.Nonce)
+	require.GreaterOrEqual(t, nonceErr.Count, uint64(3))
+	require.GreaterOrEqual(t, validator.SubmissionResubmissions(), 3)
+}
+
+// TestValidatorSubmissionCancelled asserts that a stuck output submission is given up
+// when its context is cancelled.
+func TestValidatorSubmissionCancelled(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	_, validator := setupValidatorSubmissionTest(t)
+
+	validator.ActStallL1Publication(t)
+	validator.ActStartSubmitL2Output(t)
+	validator.ActWaitSubmissionAttempts(t, 2)
+	validator.ActCancelL2OutputSubmission(t)
+
+	_, err := validator.SubmissionResult(t)
+	require.ErrorIs(t, err, context.Canceled)
+}
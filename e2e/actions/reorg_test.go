@@ -353,6 +353,7 @@ func TestDeepReorg(gt *testing.T) {
 	}
 	alice := NewCrossLayerUser(log, dp.Secrets.Alice, rand.New(rand.NewSource(0xa57b)), sd.RollupCfg)
 	alice.L2.SetUserEnv(l2UserEnv)
+	alice.L2.CheckUserEnv(t, dp)
 
 	// Run one iteration of the L2 derivation pipeline
 	proposer.ActL1HeadSignal(t)
@@ -578,7 +579,7 @@ func TestRestartKromaGeth(gt *testing.T) {
 	engRpc := &rpcWrapper{propEng.RPCClient()}
 	l2Cl, err := sources.NewEngineClient(engRpc, log, nil, sources.EngineClientDefaultConfig(sd.RollupCfg))
 	require.NoError(t, err)
-	proposer := NewL2Proposer(t, log, l1F, l2Cl, sd.RollupCfg, 0)
+	proposer := NewL2Proposer(t, log, l1F, sd.DA, l2Cl, sd.RollupCfg, 0)
 
 	batcher := NewL2Batcher(log, sd.RollupCfg, &BatcherCfg{
 		MinL1TxSize: 0,
@@ -668,7 +669,7 @@ func TestConflictingL2Blocks(gt *testing.T) {
 	require.NoError(t, err)
 	l1F, err := sources.NewL1Client(miner.RPCClient(), log, nil, sources.L1ClientDefaultConfig(sd.RollupCfg, false, sources.RPCKindBasic))
 	require.NoError(t, err)
-	altProposer := NewL2Proposer(t, log, l1F, altPropEngCl, sd.RollupCfg, 0)
+	altProposer := NewL2Proposer(t, log, l1F, sd.DA, altPropEngCl, sd.RollupCfg, 0)
 	altBatcher := NewL2Batcher(log, sd.RollupCfg, &BatcherCfg{
 		MinL1TxSize: 0,
 		MaxL1TxSize: 128_000,
@@ -686,6 +687,7 @@ func TestConflictingL2Blocks(gt *testing.T) {
 	}
 	alice := NewCrossLayerUser(log, dp.Secrets.Alice, rand.New(rand.NewSource(1234)), sd.RollupCfg)
 	alice.L2.SetUserEnv(l2UserEnv)
+	alice.L2.CheckUserEnv(t, dp)
 
 	proposer.ActL2PipelineFull(t)
 	syncer.ActL2PipelineFull(t)
@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
@@ -141,13 +142,36 @@ func (s *BasicUser[B]) ActResetTxOpts(t Testing) {
 	}
 }
 
-func (s *BasicUser[B]) ActRandomTxToAddr(t Testing) {
-	i := s.rng.Intn(len(s.env.AddressCorpora))
-	var to *common.Address
-	if i > 0 { // 0 == nil
-		to = &s.env.AddressCorpora[i]
+// PickAddress deterministically picks an address of the corpora with the given seed.
+// The address at index 0 is reserved for nil, i.e. contract creation.
+func (env *BasicUserEnv[B]) PickAddress(seed int64) *common.Address {
+	i := rand.New(rand.NewSource(seed)).Intn(len(env.AddressCorpora))
+	if i == 0 {
+		return nil
 	}
-	s.txToAddr = to
+	return &env.AddressCorpora[i]
+}
+
+// ActRandomTxToAddr picks a random address to send the tx to.
+// The seed is logged, so that a failing selection can be reproduced with PickAddress.
+func (s *BasicUser[B]) ActRandomTxToAddr(t Testing) {
+	seed := s.rng.Int63()
+	s.txToAddr = s.env.PickAddress(seed)
+	s.log.Debug("picked random tx to address", "seed", seed, "to", s.txToAddr)
+}
+
+// CheckUserEnv checks that the user environment is wired to the deploy params: the address corpora
+// must be valid, and the signer must derive the address of the user from its signatures,
+// which is one of the secrets.
+func (s *BasicUser[B]) CheckUserEnv(t Testing, dp *e2eutils.DeployParams) {
+	require.NoError(t, e2eutils.CheckAddressCorpora(s.env.AddressCorpora, dp), "invalid address corpora")
+	require.Contains(t, dp.Secrets.Addresses().All(), s.address, "user account must be one of the secrets")
+
+	tx, err := s.signerFn(s.address, types.NewTx(&types.DynamicFeeTx{ChainID: s.env.Signer.ChainID()}))
+	require.NoError(t, err)
+	from, err := types.Sender(s.env.Signer, tx)
+	require.NoError(t, err)
+	require.Equal(t, s.address, from, "signer must derive the user address")
 }
 
 func (s *BasicUser[B]) ActSetTxCalldata(calldata []byte) Action {
@@ -308,13 +332,6 @@ func NewCrossLayerUser(log log.Logger, priv *ecdsa.PrivateKey, rng *rand.Rand, r
 }
 
 func (s *CrossLayerUser) ActDeposit(t Testing) {
-	isCreation := false
-	toAddr := common.Address{}
-	if s.L2.txToAddr == nil {
-		isCreation = true
-	} else {
-		toAddr = *s.L2.txToAddr
-	}
 	depositTransferValue := s.L2.TxValue()
 	depositGas := s.L2.txOpts.GasLimit
 	if s.L2.txOpts.GasLimit == 0 {
@@ -329,8 +346,33 @@ func (s *CrossLayerUser) ActDeposit(t Testing) {
 		require.NoError(t, err)
 		depositGas = gas
 	}
+	s.deposit(t, s.L2.txToAddr, depositTransferValue, depositGas, s.L2.txCallData)
+}
+
+// revertingInitCode is a contract creation code that reverts immediately: PUSH1 0x00, DUP1, REVERT.
+var revertingInitCode = []byte{byte(vm.PUSH1), 0x00, byte(vm.DUP1), byte(vm.REVERT)}
+
+// revertingDepositGas is the gas limit of the deposit crafted by ActDepositExpectL2Revert.
+// A reverting execution cannot be estimated, so a fixed gas limit is used instead.
+const revertingDepositGas = 100_000
+
+// ActDepositExpectL2Revert deposits a contract creation whose L2 execution reverts.
+// The L1 tx value is still minted on L2, so the ETH is credited to the depositor even though
+// the L2 deposit tx fails. Check it with ActCheckDepositStatus(true, false).
+func (s *CrossLayerUser) ActDepositExpectL2Revert(t Testing) {
+	s.deposit(t, nil, common.Big0, revertingDepositGas, revertingInitCode)
+}
+
+func (s *CrossLayerUser) deposit(t Testing, to *common.Address, value *big.Int, gasLimit uint64, data []byte) {
+	isCreation := false
+	toAddr := common.Address{}
+	if to == nil {
+		isCreation = true
+	} else {
+		toAddr = *to
+	}
 
-	tx, err := s.L1.env.Bindings.KromaPortal.DepositTransaction(&s.L1.txOpts, toAddr, depositTransferValue, depositGas, isCreation, s.L2.txCallData)
+	tx, err := s.L1.env.Bindings.KromaPortal.DepositTransaction(&s.L1.txOpts, toAddr, value, gasLimit, isCreation, data)
 	require.NoError(t, err, "failed to create deposit tx")
 
 	// Send the actual tx (since tx opts don't send by default)
@@ -345,6 +387,8 @@ func (s *CrossLayerUser) ActCheckDepositStatus(l1Success, l2Success bool) Action
 	}
 }
 
+// CheckDepositTx checks the receipts of the deposit on L1 and of the corresponding deposit tx on L2.
+// If the L2 deposit tx is expected to fail, it also checks that the mint is still credited on L2.
 func (s *CrossLayerUser) CheckDepositTx(t Testing, l1TxHash common.Hash, index int, l1Success, l2Success bool) {
 	depositReceipt := s.L1.CheckReceipt(t, l1Success, l1TxHash)
 	if depositReceipt == nil {
@@ -355,8 +399,26 @@ func (s *CrossLayerUser) CheckDepositTx(t Testing, l1TxHash common.Hash, index i
 		reconstructedDep, err := derive.UnmarshalDepositLogEvent(depositReceipt.Logs[index])
 		require.NoError(t, err, "Could not reconstruct L2 Deposit")
 		l2Tx := types.NewTx(reconstructedDep)
-		s.L2.CheckReceipt(t, l2Success, l2Tx.Hash())
+		l2Receipt := s.L2.CheckReceipt(t, l2Success, l2Tx.Hash())
+		if !l2Success {
+			s.checkDepositMinted(t, reconstructedDep, l2Receipt)
+		}
+	}
+}
+
+// checkDepositMinted checks that the mint of the failed deposit is credited to the depositor.
+// Deposits pay no L2 fees and a failed deposit transfers no value, so the balance of the depositor
+// must increase by exactly the mint, assuming the depositor sent no other tx in the same L2 block.
+func (s *CrossLayerUser) checkDepositMinted(t Testing, dep *types.DepositTx, l2Receipt *types.Receipt) {
+	mint := new(big.Int)
+	if dep.Mint != nil {
+		mint = dep.Mint
 	}
+	prevBalance, err := s.L2.env.EthCl.BalanceAt(t.Ctx(), dep.From, new(big.Int).Sub(l2Receipt.BlockNumber, common.Big1))
+	require.NoError(t, err)
+	balance, err := s.L2.env.EthCl.BalanceAt(t.Ctx(), dep.From, l2Receipt.BlockNumber)
+	require.NoError(t, err)
+	require.Equal(t, mint, new(big.Int).Sub(balance, prevBalance), "mint of the failed deposit must be credited")
 }
 
 func (s *CrossLayerUser) ActStartWithdrawal(t Testing) {
@@ -382,6 +444,12 @@ func (s *CrossLayerUser) Address() common.Address {
 	return s.L1.address
 }
 
+// CheckUserEnv checks the user environments of both layers.
+func (s *CrossLayerUser) CheckUserEnv(t Testing, dp *e2eutils.DeployParams) {
+	s.L1.CheckUserEnv(t, dp)
+	s.L2.CheckUserEnv(t, dp)
+}
+
 // ActCompleteWithdrawal creates a L1 proveWithdrawal tx for latest withdrawal.
 // The tx hash is remembered as the last L1 tx, to check as L1 actor.
 func (s *CrossLayerUser) ActProveWithdrawal(t Testing) {
@@ -481,6 +549,44 @@ func (s *CrossLayerUser) CompleteWithdrawal(t Testing, l2TxHash common.Hash) com
 		return common.Hash{}
 	}
 
+	tx := s.finalizeWithdrawalTx(t, l2OutputBlockNr, &s.L1.txOpts)
+
+	// Send the actual tx (since tx opts don't send by default)
+	err = s.L1.env.EthCl.SendTransaction(t.Ctx(), tx)
+	require.NoError(t, err, "must send finalize tx")
+	return tx.Hash()
+}
+
+// completeWithdrawalRevertGas is the gas limit of the finalization tx sent by ActCompleteWithdrawalExpectRevert.
+// A reverting tx cannot be estimated, so a fixed gas limit is used instead.
+const completeWithdrawalRevertGas = 500_000
+
+// ActCompleteWithdrawalExpectRevert creates a L1 withdrawal finalization tx for the latest withdrawal
+// before its finalization period has elapsed, and asserts that it reverts because the withdrawal is not finalized yet.
+// The tx is still sent, and remembered as the last L1 tx, so its failed receipt can be checked as L1 actor.
+func (s *CrossLayerUser) ActCompleteWithdrawalExpectRevert(t Testing) {
+	l2OutputBlockNr, err := s.L1.env.Bindings.L2OutputOracle.LatestBlockNumber(&bind.CallOpts{})
+	require.NoError(t, err)
+
+	txOpts := s.L1.txOpts
+	txOpts.GasLimit = completeWithdrawalRevertGas
+	tx := s.finalizeWithdrawalTx(t, l2OutputBlockNr, &txOpts)
+
+	_, err = s.L1.env.EthCl.CallContract(t.Ctx(), ethereum.CallMsg{
+		From: s.L1.address,
+		To:   tx.To(),
+		Data: tx.Data(),
+	}, nil)
+	require.ErrorContains(t, err, "finalization period has not elapsed", "withdrawal must not be finalized yet")
+
+	err = s.L1.env.EthCl.SendTransaction(t.Ctx(), tx)
+	require.NoError(t, err, "must send finalize tx")
+	s.L1.lastTxHash = tx.Hash()
+}
+
+// finalizeWithdrawalTx creates a L1 withdrawal finalization tx for the latest withdrawal, using the L2 output
+// at the given L2 block number. The tx is signed with the given tx opts, but not sent.
+func (s *CrossLayerUser) finalizeWithdrawalTx(t Testing, l2OutputBlockNr *big.Int, txOpts *bind.TransactOpts) *types.Transaction {
 	// We generate a proof for the latest L2 output, which shouldn't require archive-node data if it's recent enough.
 	// Note that for the `FinalizeWithdrawalTransaction` function, this proof isn't needed. We simply use some of the
 	// params for the `WithdrawalTransaction` type generated in the bindings.
@@ -494,7 +600,7 @@ func (s *CrossLayerUser) CompleteWithdrawal(t Testing, l2TxHash common.Hash) com
 
 	// Create the withdrawal tx
 	tx, err := s.L1.env.Bindings.KromaPortal.FinalizeWithdrawalTransaction(
-		&s.L1.txOpts,
+		txOpts,
 		bindings.TypesWithdrawalTransaction{
 			Nonce:    params.Nonce,
 			Sender:   params.Sender,
@@ -505,9 +611,5 @@ func (s *CrossLayerUser) CompleteWithdrawal(t Testing, l2TxHash common.Hash) com
 		},
 	)
 	require.NoError(t, err)
-
-	// Send the actual tx (since tx opts don't send by default)
-	err = s.L1.env.EthCl.SendTransaction(t.Ctx(), tx)
-	require.NoError(t, err, "must send finalize tx")
-	return tx.Hash()
+	return tx
 }
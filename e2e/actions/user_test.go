@@ -1,13 +1,17 @@
 package actions
 
 import (
+	"math/big"
 	"math/rand"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/require"
 
+	"github.com/kroma-network/kroma/components/node/rollup/derive"
 	"github.com/kroma-network/kroma/components/node/testlog"
 	"github.com/kroma-network/kroma/e2e/e2eutils"
 )
@@ -18,11 +22,14 @@ import (
 // - deposit on L1
 // - withdraw from L2
 // - prove tx on L1
-// - wait 1 week + 1 second
+// - fail to finalize withdrawal on L1 before the finalization period
+// - wait the finalization period
 // - finalize withdrawal on L1
 func TestCrossLayerUser(gt *testing.T) {
 	t := NewDefaultTesting(gt)
-	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	p := *defaultRollupTestParams
+	p.FinalizationPeriodSeconds = 36
+	dp := e2eutils.MakeDeployParams(t, &p)
 	sd := e2eutils.Setup(t, dp, defaultAlloc)
 	log := testlog.Logger(t, log.LvlDebug)
 
@@ -66,6 +73,8 @@ func TestCrossLayerUser(gt *testing.T) {
 	alice := NewCrossLayerUser(log, dp.Secrets.Alice, rand.New(rand.NewSource(1234)), sd.RollupCfg)
 	alice.L1.SetUserEnv(l1UserEnv)
 	alice.L2.SetUserEnv(l2UserEnv)
+	alice.CheckUserEnv(t, dp)
+	require.Equal(t, l1UserEnv.PickAddress(42), l1UserEnv.PickAddress(42), "random selection must be reproducible")
 
 	// Build at least one l2 block so we have an unsafe head with a deposit info tx (genesis block doesn't)
 	proposer.ActL2StartBlock(t)
@@ -164,12 +173,21 @@ func TestCrossLayerUser(gt *testing.T) {
 	miner.ActL1EndBlock(t)
 	// check withdrawal succeeded
 	alice.L1.ActCheckReceiptStatusOfLastTx(true)(t)
+	proveReceipt := alice.L1.LastTxReceipt(t)
+	proveBlock, err := miner.EthClient().HeaderByHash(t.Ctx(), proveReceipt.BlockHash)
+	require.NoError(t, err)
 
-	// A bit hacky- Mines an empty block with the time delta
-	// of the finalization period (12s) + 1 in order for the
-	// withdrawal to be finalized successfully.
-	miner.ActL1StartBlock(13)(t)
+	// the withdrawal cannot be completed before the finalization period elapses
+	alice.ActCompleteWithdrawalExpectRevert(t)
+	miner.ActL1StartBlock(12)(t)
+	miner.ActL1IncludeTx(alice.Address())(t)
 	miner.ActL1EndBlock(t)
+	alice.L1.ActCheckReceiptStatusOfLastTx(false)(t)
+
+	// wait until the finalization period of the proven withdrawal elapses
+	for miner.l1Chain.CurrentBlock().Time <= proveBlock.Time+p.FinalizationPeriodSeconds {
+		miner.ActEmptyBlock(t)
+	}
 
 	// make the L1 finalize withdrawal tx
 	alice.ActCompleteWithdrawal(t)
@@ -180,3 +198,128 @@ func TestCrossLayerUser(gt *testing.T) {
 	// check withdrawal succeeded
 	alice.L1.ActCheckReceiptStatusOfLastTx(true)(t)
 }
+
+// actExpireProposerWindow builds empty L1 blocks for the full proposer window without any batch submitted,
+// and lets the syncer derive the L2 chain, which force-includes the deposits of the expired epochs.
+func actExpireProposerWindow(t Testing, miner *L1Miner, syncer *L2Syncer) {
+	miner.ActEmptyBlocks(syncer.ProposerWindowSize())(t)
+	syncer.ActL1HeadSignal(t)
+	syncer.ActL2PipelineFull(t)
+}
+
+// TestDepositForceInclusion tests that a deposit is included in the L2 chain once the proposer window
+// of its L1 block expires, even if the proposer never sequences it.
+func TestDepositForceInclusion(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LvlDebug)
+
+	miner, propEngine, proposer := setupProposerTest(t, sd, log)
+	proposer.ActL2PipelineFull(t)
+
+	l1Cl := miner.EthClient()
+	l2Cl := propEngine.EthClient()
+	addresses := e2eutils.CollectAddresses(sd, dp)
+
+	alice := NewCrossLayerUser(log, dp.Secrets.Alice, rand.New(rand.NewSource(1234)), sd.RollupCfg)
+	alice.L1.SetUserEnv(&BasicUserEnv[*L1Bindings]{
+		EthCl:          l1Cl,
+		Signer:         types.LatestSigner(sd.L1Cfg.Config),
+		AddressCorpora: addresses,
+		Bindings:       NewL1Bindings(t, l1Cl, &sd.DeploymentsL1),
+	})
+	alice.L2.SetUserEnv(&BasicUserEnv[*L2Bindings]{
+		EthCl:          l2Cl,
+		Signer:         types.LatestSigner(sd.L2Cfg.Config),
+		AddressCorpora: addresses,
+		Bindings:       NewL2Bindings(t, l2Cl, propEngine.GethClient()),
+	})
+	alice.CheckUserEnv(t, dp)
+
+	// deposit on L1, while the proposer never sequences any L2 block
+	alice.L1.ActResetTxOpts(t)
+	alice.L2.ActResetTxOpts(t)
+	alice.L2.ActSetTxToAddr(&dp.Addresses.Bob)(t)
+	alice.ActDeposit(t)
+	miner.ActL1StartBlock(12)(t)
+	miner.ActL1IncludeTx(alice.Address())(t)
+	miner.ActL1EndBlock(t)
+	depositL1Block := miner.l1Chain.CurrentBlock()
+
+	// the deposit is not derived before the proposer window expires
+	miner.ActEmptyBlocks(proposer.ProposerWindowSize() - 1)(t)
+	proposer.ActL1HeadSignal(t)
+	proposer.ActL2PipelineFull(t)
+	require.Less(t, proposer.SyncStatus().SafeL2.L1Origin.Number, depositL1Block.Number.Uint64(), "epoch of the deposit must not be derived within the proposer window")
+
+	actExpireProposerWindow(t, miner, &proposer.L2Syncer)
+	alice.ActCheckDepositStatus(true, true)(t)
+
+	// the deposit must be included in the first L2 block of the epoch of its L1 block
+	receipt := alice.L2.CheckReceipt(t, true, depositL2TxHash(t, alice))
+	l2Block, err := l2Cl.BlockByHash(t.Ctx(), receipt.BlockHash)
+	require.NoError(t, err)
+	l1Info, err := derive.L1InfoDepositTxData(l2Block.Transactions()[0].Data())
+	require.NoError(t, err)
+	require.Equal(t, depositL1Block.Hash(), l1Info.BlockHash, "deposit must be included in the epoch of its L1 block")
+	parent, err := l2Cl.BlockByHash(t.Ctx(), l2Block.ParentHash())
+	require.NoError(t, err)
+	parentL1Info, err := derive.L1InfoDepositTxData(parent.Transactions()[0].Data())
+	require.NoError(t, err)
+	require.Less(t, parentL1Info.Number, l1Info.Number, "deposit must be included in the first L2 block of the epoch")
+	require.GreaterOrEqual(t, proposer.SyncStatus().SafeL2.Number, l2Block.NumberU64(), "deposit must be included in the safe L2 chain")
+}
+
+func depositL2TxHash(t Testing, user *CrossLayerUser) common.Hash {
+	depositReceipt := user.L1.CheckReceipt(t, true, user.lastL1DepositTxHash)
+	dep, err := derive.UnmarshalDepositLogEvent(depositReceipt.Logs[0])
+	require.NoError(t, err)
+	return types.NewTx(dep).Hash()
+}
+
+func TestDepositExpectL2Revert(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LvlDebug)
+
+	miner, propEngine, proposer := setupProposerTest(t, sd, log)
+	proposer.ActL2PipelineFull(t)
+
+	l1Cl := miner.EthClient()
+	l2Cl := propEngine.EthClient()
+	addresses := e2eutils.CollectAddresses(sd, dp)
+
+	alice := NewCrossLayerUser(log, dp.Secrets.Alice, rand.New(rand.NewSource(1234)), sd.RollupCfg)
+	alice.L1.SetUserEnv(&BasicUserEnv[*L1Bindings]{
+		EthCl:          l1Cl,
+		Signer:         types.LatestSigner(sd.L1Cfg.Config),
+		AddressCorpora: addresses,
+		Bindings:       NewL1Bindings(t, l1Cl, &sd.DeploymentsL1),
+	})
+	alice.L2.SetUserEnv(&BasicUserEnv[*L2Bindings]{
+		EthCl:          l2Cl,
+		Signer:         types.LatestSigner(sd.L2Cfg.Config),
+		AddressCorpora: addresses,
+		Bindings:       NewL2Bindings(t, l2Cl, propEngine.GethClient()),
+	})
+	alice.CheckUserEnv(t, dp)
+
+	// deposit with a mint, whose L2 execution reverts
+	alice.L1.ActResetTxOpts(t)
+	alice.L1.ActSetTxValue(big.NewInt(params.Ether))(t)
+	alice.ActDepositExpectL2Revert(t)
+	miner.ActL1StartBlock(12)(t)
+	miner.ActL1IncludeTx(alice.Address())(t)
+	miner.ActL1EndBlock(t)
+
+	proposer.ActL1HeadSignal(t)
+	for proposer.SyncStatus().UnsafeL2.L1Origin.Number < miner.l1Chain.CurrentBlock().Number.Uint64() {
+		proposer.ActL2StartBlock(t)
+		proposer.ActL2EndBlock(t)
+	}
+
+	// the L2 deposit tx fails, but the mint is still credited
+	alice.ActCheckDepositStatus(true, false)(t)
+}
@@ -2,6 +2,8 @@ package e2eutils
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"sort"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -14,6 +16,10 @@ import (
 func collectAllocAddrs(alloc core.GenesisAlloc) []common.Address {
 	var out []common.Address
 	for addr := range alloc {
+		// the zero address is not a meaningful counterparty
+		if addr == (common.Address{}) {
+			continue
+		}
 		out = append(out, addr)
 	}
 	// make output deterministic
@@ -29,16 +35,14 @@ func CollectAddresses(sd *SetupData, dp *DeployParams) (out []common.Address) {
 	// This should be seeded with:
 	//  - reserve 0 for selecting nil (contract creation)
 	out = append(out, common.Address{})
-	//  - zero address
-	out = append(out, common.Address{})
 	//  - addresses of signing accounts
 	out = append(out, dp.Addresses.All()...)
 	// prefunded L1/L2 accounts for testing
 	out = append(out, collectAllocAddrs(sd.L1Cfg.Alloc)...)
 	out = append(out, collectAllocAddrs(sd.L2Cfg.Alloc)...)
 
-	//  - addresses of system contracts
-	out = append(out,
+	//  - addresses of system contracts, except for the unset ones
+	for _, addr := range []common.Address{
 		sd.L1Cfg.Coinbase,
 		sd.L2Cfg.Coinbase,
 		dp.Addresses.ProposerP2P,
@@ -46,9 +50,13 @@ func CollectAddresses(sd *SetupData, dp *DeployParams) (out []common.Address) {
 		sd.RollupCfg.BatchInboxAddress,
 		sd.RollupCfg.Genesis.SystemConfig.BatcherAddr,
 		sd.RollupCfg.DepositContractAddress,
-	)
-	//  - precompiles
-	for i := 0; i <= 0xff; i++ {
+	} {
+		if addr != (common.Address{}) {
+			out = append(out, addr)
+		}
+	}
+	//  - precompiles, except for the zero address
+	for i := 1; i <= 0xff; i++ {
 		out = append(out, common.Address{19: byte(i)})
 	}
 	//  - masked L2 version of all the original addrs
@@ -64,3 +72,29 @@ func CollectAddresses(sd *SetupData, dp *DeployParams) (out []common.Address) {
 	}
 	return out
 }
+
+// CheckAddressCorpora checks that the addresses collected by CollectAddresses are meaningful counterparties:
+// the corpora must not be empty, must not contain the zero address except for the reserved index 0,
+// and must contain the addresses derived from all the secrets of the deploy params.
+func CheckAddressCorpora(addrs []common.Address, dp *DeployParams) error {
+	if len(addrs) <= 1 {
+		return errors.New("address corpora is empty")
+	}
+	known := make(map[common.Address]struct{}, len(addrs))
+	for i, addr := range addrs[1:] {
+		if addr == (common.Address{}) {
+			return fmt.Errorf("address corpora contains the zero address at index %d", i+1)
+		}
+		known[addr] = struct{}{}
+	}
+	derived := dp.Secrets.Addresses().All()
+	for i, addr := range dp.Addresses.All() {
+		if addr != derived[i] {
+			return fmt.Errorf("address %s does not match %s derived from the secrets", addr, derived[i])
+		}
+		if _, ok := known[addr]; !ok {
+			return fmt.Errorf("address corpora does not contain %s derived from the secrets", addr)
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package e2eutils
 import (
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
 
@@ -18,4 +19,11 @@ func TestCollectAddresses(t *testing.T) {
 	addrs := CollectAddresses(sd, dp)
 	require.NotEmpty(t, addrs)
 	require.Contains(t, addrs, dp.Addresses.Batcher)
+	require.NoError(t, CheckAddressCorpora(addrs, dp))
+
+	require.ErrorContains(t, CheckAddressCorpora(addrs[:1], dp), "empty")
+	withZero := append([]common.Address{}, addrs...)
+	withZero[3] = common.Address{}
+	require.ErrorContains(t, CheckAddressCorpora(withZero, dp), "zero address at index 3")
+	require.ErrorContains(t, CheckAddressCorpora(addrs[:2], dp), "does not contain")
 }
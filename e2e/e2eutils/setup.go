@@ -14,6 +14,7 @@ import (
 	"github.com/kroma-network/kroma/bindings/predeploys"
 	"github.com/kroma-network/kroma/components/node/eth"
 	"github.com/kroma-network/kroma/components/node/rollup"
+	"github.com/kroma-network/kroma/utils/altda"
 	genesis2 "github.com/kroma-network/kroma/utils/chain-ops/genesis"
 )
 
@@ -47,6 +48,9 @@ type TestParams struct {
 	ProposerWindowSize uint64
 	ChannelTimeout     uint64
 	L1BlockTime        uint64
+	// FinalizationPeriodSeconds is the finalization window of the L2 outputs and the withdrawals.
+	// If 0, the default of 10 seconds is used.
+	FinalizationPeriodSeconds uint64
 }
 
 func MakeDeployParams(t require.TestingT, tp *TestParams) *DeployParams {
@@ -54,6 +58,10 @@ func MakeDeployParams(t require.TestingT, tp *TestParams) *DeployParams {
 	secrets, err := mnemonicCfg.Secrets()
 	require.NoError(t, err)
 	addresses := secrets.Addresses()
+	finalizationPeriodSeconds := tp.FinalizationPeriodSeconds
+	if finalizationPeriodSeconds == 0 {
+		finalizationPeriodSeconds = 10
+	}
 	deployConfig := &genesis2.DeployConfig{
 		L1ChainID:   900,
 		L2ChainID:   901,
@@ -86,7 +94,7 @@ func MakeDeployParams(t require.TestingT, tp *TestParams) *DeployParams {
 		L1GenesisBlockGasUsed:       0,
 		L1GenesisBlockParentHash:    common.Hash{},
 		L1GenesisBlockBaseFeePerGas: uint64ToBig(1000_000_000), // 1 gwei
-		FinalizationPeriodSeconds:   10,
+		FinalizationPeriodSeconds:   finalizationPeriodSeconds,
 
 		L2GenesisBlockNonce:         0,
 		L2GenesisBlockGasLimit:      30_000_000,
@@ -169,6 +177,8 @@ type SetupData struct {
 	L2Cfg         *core.Genesis
 	RollupCfg     *rollup.Config
 	DeploymentsL1 DeploymentsL1
+	// DA is the in-memory alternative DA layer shared by the actors.
+	DA *altda.MemStore
 }
 
 // AllocParams defines genesis allocations to apply on top of the genesis generated by deploy parameters.
@@ -240,6 +250,9 @@ func Setup(t require.TestingT, deployParams *DeployParams, alloc *AllocParams) *
 		BatchInboxAddress:      deployConf.BatchInboxAddress,
 		DepositContractAddress: predeploys.DevKromaPortalAddr,
 		L1SystemConfigAddress:  predeploys.DevSystemConfigAddr,
+		SpanBatchTime:          deployConf.SpanBatchTime(uint64(deployConf.L1GenesisBlockTimestamp)),
+		AltDATime:              deployConf.AltDATime(uint64(deployConf.L1GenesisBlockTimestamp)),
+		CompressionAlgosTime:   deployConf.CompressionAlgosTime(uint64(deployConf.L1GenesisBlockTimestamp)),
 	}
 
 	deploymentsL1 := DeploymentsL1{
@@ -261,6 +274,7 @@ func Setup(t require.TestingT, deployParams *DeployParams, alloc *AllocParams) *
 		L2Cfg:         l2Genesis,
 		RollupCfg:     rollupCfg,
 		DeploymentsL1: deploymentsL1,
+		DA:            altda.NewMemStore(),
 	}
 }
 
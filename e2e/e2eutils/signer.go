@@ -0,0 +1,80 @@
+package e2eutils
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	kcrypto "github.com/kroma-network/kroma/utils/service/crypto"
+)
+
+// RecordingSigner signs with a local key and records every signed tx, so that the tests can assert
+// exactly what the batcher or the validator signed. The signatures are deterministic (RFC 6979),
+// so the same tx is always signed into the same hash.
+type RecordingSigner struct {
+	key  *ecdsa.PrivateKey
+	from common.Address
+
+	mu     sync.Mutex
+	signed []*types.Transaction
+}
+
+func NewRecordingSigner(key *ecdsa.PrivateKey) *RecordingSigner {
+	return &RecordingSigner{key: key, from: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+// Address returns the address of the key.
+func (s *RecordingSigner) Address() common.Address {
+	return s.from
+}
+
+// SignerFn returns the signer of the txs of the chain, recording each of them.
+func (s *RecordingSigner) SignerFn(chainID *big.Int) kcrypto.SignerFn {
+	signer := types.LatestSignerForChainID(chainID)
+	return func(_ context.Context, address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if address != s.from {
+			return nil, fmt.Errorf("attempting to sign for %s, expected %s", address, s.from)
+		}
+		signed, err := types.SignTx(tx, signer, s.key)
+		if err != nil {
+			return nil, err
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.signed = append(s.signed, signed)
+		return signed, nil
+	}
+}
+
+// Signed returns the signed txs, in their signing order.
+func (s *RecordingSigner) Signed() []*types.Transaction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*types.Transaction(nil), s.signed...)
+}
+
+// SignedTo returns the signed txs to the address whose calldata starts with the prefix, e.g. a
+// method selector, in their signing order.
+func (s *RecordingSigner) SignedTo(to common.Address, prefix []byte) []*types.Transaction {
+	var txs []*types.Transaction
+	for _, tx := range s.Signed() {
+		if tx.To() != nil && *tx.To() == to && bytes.HasPrefix(tx.Data(), prefix) {
+			txs = append(txs, tx)
+		}
+	}
+	return txs
+}
+
+// Reset forgets the signed txs.
+func (s *RecordingSigner) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.signed = nil
+}
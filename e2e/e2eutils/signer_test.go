@@ -0,0 +1,55 @@
+package e2eutils
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordingSigner(t *testing.T) {
+	key, err := crypto.HexToECDSA("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	require.NoError(t, err)
+	chainID := big.NewInt(900)
+	to := common.HexToAddress("0xff00000000000000000000000000000000000000")
+	newTx := func(nonce uint64, data []byte) *types.Transaction {
+		return types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: nonce, To: &to, Gas: 21000, Data: data})
+	}
+
+	s := NewRecordingSigner(key)
+	signerFn := s.SignerFn(chainID)
+	first, err := signerFn(context.Background(), s.Address(), newTx(0, []byte{1, 2}))
+	require.NoError(t, err)
+	second, err := signerFn(context.Background(), s.Address(), newTx(1, []byte{3}))
+	require.NoError(t, err)
+	_, err = signerFn(context.Background(), common.Address{1}, newTx(2, nil))
+	require.ErrorContains(t, err, "attempting to sign")
+
+	// The signatures are deterministic.
+	again, err := NewRecordingSigner(key).SignerFn(chainID)(context.Background(), s.Address(), newTx(0, []byte{1, 2}))
+	require.NoError(t, err)
+	require.Equal(t, first.Hash(), again.Hash())
+
+	from, err := types.Sender(types.LatestSignerForChainID(chainID), first)
+	require.NoError(t, err)
+	require.Equal(t, s.Address(), from)
+
+	require.Equal(t, []common.Hash{first.Hash(), second.Hash()}, hashes(s.Signed()))
+	require.Equal(t, []common.Hash{first.Hash()}, hashes(s.SignedTo(to, []byte{1})))
+	require.Empty(t, s.SignedTo(common.Address{}, nil))
+
+	s.Reset()
+	require.Empty(t, s.Signed())
+}
+
+func hashes(txs []*types.Transaction) []common.Hash {
+	var hs []common.Hash
+	for _, tx := range txs {
+		hs = append(hs, tx.Hash())
+	}
+	return hs
+}
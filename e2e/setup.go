@@ -422,6 +422,9 @@ func (cfg SystemConfig) Start(_opts ...SystemConfigOption) (*System, error) {
 			BatchInboxAddress:      cfg.DeployConfig.BatchInboxAddress,
 			DepositContractAddress: predeploys.DevKromaPortalAddr,
 			L1SystemConfigAddress:  predeploys.DevSystemConfigAddr,
+			SpanBatchTime:          cfg.DeployConfig.SpanBatchTime(uint64(cfg.DeployConfig.L1GenesisBlockTimestamp)),
+			AltDATime:              cfg.DeployConfig.AltDATime(uint64(cfg.DeployConfig.L1GenesisBlockTimestamp)),
+			CompressionAlgosTime:   cfg.DeployConfig.CompressionAlgosTime(uint64(cfg.DeployConfig.L1GenesisBlockTimestamp)),
 		}
 	}
 	defaultConfig := makeRollupConfig()
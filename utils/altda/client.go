@@ -0,0 +1,130 @@
+package altda
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+var ErrNotFound = errors.New("data not found")
+
+// CLIConfig is the configuration of the DA server, an HTTP service storing the data on an
+// alternative DA layer (e.g. Celestia or EigenDA) keyed by their commitment.
+type CLIConfig struct {
+	// ServerURL is the URL of the DA server. The alternative DA is disabled if empty.
+	ServerURL string
+	// Timeout is the timeout of the requests to the DA server.
+	Timeout time.Duration
+}
+
+func (c CLIConfig) Enabled() bool {
+	return c.ServerURL != ""
+}
+
+func (c CLIConfig) Check() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if _, err := url.ParseRequestURI(c.ServerURL); err != nil {
+		return fmt.Errorf("invalid DA server URL: %w", err)
+	}
+	return nil
+}
+
+// Client posts and fetches the data to and from a DA server:
+//
+//	PUT <server>/put/<commitment> stores the request body under the commitment,
+//	GET <server>/get/<commitment> returns the data stored under the commitment, 404 if none,
+//
+// with the commitments hex-encoded with a 0x prefix.
+type Client struct {
+	url        string
+	httpClient *http.Client
+}
+
+func NewClient(cfg CLIConfig) *Client {
+	return &Client{
+		url:        cfg.ServerURL,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+// GetInput fetches the data of the commitment and verifies it against the commitment.
+func (c *Client) GetInput(ctx context.Context, comm Commitment) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/get/%s", c.url, comm), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, comm)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get %s: status %s", comm, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := comm.Verify(data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// SetInput posts the data to the DA server and returns its commitment.
+func (c *Client) SetInput(ctx context.Context, data []byte) (Commitment, error) {
+	comm := Keccak256Commitment(data)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, fmt.Sprintf("%s/put/%s", c.url, comm), bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to put %s: status %s", comm, resp.Status)
+	}
+	return comm, nil
+}
+
+// MemStore is an in-memory DA layer, for testing.
+type MemStore struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func NewMemStore() *MemStore {
+	return &MemStore{data: make(map[string][]byte)}
+}
+
+func (s *MemStore) GetInput(_ context.Context, comm Commitment) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.data[string(comm)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, comm)
+	}
+	return data, nil
+}
+
+func (s *MemStore) SetInput(_ context.Context, data []byte) (Commitment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	comm := Keccak256Commitment(data)
+	s.data[string(comm)] = data
+	return comm, nil
+}
@@ -0,0 +1,93 @@
+package altda
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitment(t *testing.T) {
+	data := []byte("frames")
+	comm := Keccak256Commitment(data)
+	require.Len(t, comm, 33)
+	require.Equal(t, Keccak256CommitmentType, comm[0])
+	require.NoError(t, comm.Verify(data))
+	require.ErrorIs(t, comm.Verify([]byte("other frames")), ErrCommitmentMismatch)
+
+	dec, err := DecodeCommitment(comm)
+	require.NoError(t, err)
+	require.Equal(t, comm, dec)
+	for _, b := range [][]byte{nil, {0x01}, comm[:32], append(comm, 0x00)} {
+		_, err := DecodeCommitment(b)
+		require.ErrorIs(t, err, ErrInvalidCommitment)
+	}
+}
+
+// newTestServer returns a DA server storing the data in memory. If corrupt is set, the data it
+// returns does not match the commitments.
+func newTestServer(t *testing.T, corrupt bool) *httptest.Server {
+	store := make(map[string][]byte)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/put/"):
+			data, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			store[strings.TrimPrefix(r.URL.Path, "/put/")] = data
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/get/"):
+			data, ok := store[strings.TrimPrefix(r.URL.Path, "/get/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if corrupt {
+				data = append(data, 0x00)
+			}
+			_, _ = w.Write(data)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	srv := newTestServer(t, false)
+	client := NewClient(CLIConfig{ServerURL: srv.URL, Timeout: time.Second})
+
+	data := []byte("frames")
+	comm, err := client.SetInput(ctx, data)
+	require.NoError(t, err)
+	require.Equal(t, Keccak256Commitment(data), comm)
+	got, err := client.GetInput(ctx, comm)
+	require.NoError(t, err)
+	require.Equal(t, data, got)
+
+	_, err = client.GetInput(ctx, Keccak256Commitment([]byte("other frames")))
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestClientCorruptData(t *testing.T) {
+	ctx := context.Background()
+	srv := newTestServer(t, true)
+	client := NewClient(CLIConfig{ServerURL: srv.URL, Timeout: time.Second})
+
+	comm, err := client.SetInput(ctx, []byte("frames"))
+	require.NoError(t, err)
+	_, err = client.GetInput(ctx, comm)
+	require.ErrorIs(t, err, ErrCommitmentMismatch)
+}
+
+func TestCLIConfigCheck(t *testing.T) {
+	require.NoError(t, CLIConfig{}.Check())
+	require.False(t, CLIConfig{}.Enabled())
+	require.NoError(t, CLIConfig{ServerURL: "http://localhost:3100"}.Check())
+	require.Error(t, CLIConfig{ServerURL: "localhost"}.Check())
+}
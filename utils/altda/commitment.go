@@ -0,0 +1,53 @@
+package altda
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Keccak256CommitmentType is the type of the commitments to the keccak256 hash of the data.
+const Keccak256CommitmentType byte = 0x00
+
+var (
+	ErrInvalidCommitment  = errors.New("invalid commitment")
+	ErrCommitmentMismatch = errors.New("data does not match the commitment")
+)
+
+// Commitment is a commitment to the data posted to a DA layer: a type byte followed by the
+// commitment of the type. Only the keccak256 commitments are supported, which are verified
+// against the data, so that the DA layer does not need to be trusted for its integrity.
+type Commitment []byte
+
+// Keccak256Commitment returns the keccak256 commitment to the data.
+func Keccak256Commitment(data []byte) Commitment {
+	return append(Commitment{Keccak256CommitmentType}, crypto.Keccak256(data)...)
+}
+
+// DecodeCommitment decodes the commitment, returning an error if it is not a keccak256 commitment.
+func DecodeCommitment(b []byte) (Commitment, error) {
+	if len(b) == 0 {
+		return nil, fmt.Errorf("%w: empty", ErrInvalidCommitment)
+	}
+	if b[0] != Keccak256CommitmentType {
+		return nil, fmt.Errorf("%w: unknown type %d", ErrInvalidCommitment, b[0])
+	}
+	if len(b) != 1+32 {
+		return nil, fmt.Errorf("%w: keccak256 commitment of length %d", ErrInvalidCommitment, len(b))
+	}
+	return Commitment(b), nil
+}
+
+// Verify returns ErrCommitmentMismatch if the data does not match the commitment.
+func (c Commitment) Verify(data []byte) error {
+	if string(Keccak256Commitment(data)) != string(c) {
+		return ErrCommitmentMismatch
+	}
+	return nil
+}
+
+func (c Commitment) String() string {
+	return hexutil.Encode(c)
+}
@@ -73,6 +73,16 @@ type DeployConfig struct {
 	L2GenesisBlockParentHash    common.Hash    `json:"l2GenesisBlockParentHash"`
 	L2GenesisBlockBaseFeePerGas *hexutil.Big   `json:"l2GenesisBlockBaseFeePerGas"`
 
+	// L2GenesisSpanBatchTimeOffset is the number of seconds after genesis at which the span batches
+	// activate. Span batches are never activated if nil.
+	L2GenesisSpanBatchTimeOffset *hexutil.Uint64 `json:"l2GenesisSpanBatchTimeOffset,omitempty"`
+	// L2GenesisAltDATimeOffset is the number of seconds after genesis at which the alternative DA
+	// activates. The alternative DA is never activated if nil.
+	L2GenesisAltDATimeOffset *hexutil.Uint64 `json:"l2GenesisAltDATimeOffset,omitempty"`
+	// L2GenesisCompressionAlgosTimeOffset is the number of seconds after genesis at which the brotli and
+	// zstd channel compression activates. The channels are only compressed with zlib if nil.
+	L2GenesisCompressionAlgosTimeOffset *hexutil.Uint64 `json:"l2GenesisCompressionAlgosTimeOffset,omitempty"`
+
 	ColosseumCreationPeriodSeconds uint64      `json:"colosseumCreationPeriodSeconds"`
 	ColosseumBisectionTimeout      uint64      `json:"colosseumBisectionTimeout"`
 	ColosseumProvingTimeout        uint64      `json:"colosseumProvingTimeout"`
@@ -385,9 +395,39 @@ func (d *DeployConfig) RollupConfig(l1StartBlock *types.Block, l2GenesisBlockHas
 		BatchInboxAddress:      d.BatchInboxAddress,
 		DepositContractAddress: d.KromaPortalProxy,
 		L1SystemConfigAddress:  d.SystemConfigProxy,
+		SpanBatchTime:          d.SpanBatchTime(l1StartBlock.Time()),
+		AltDATime:              d.AltDATime(l1StartBlock.Time()),
+		CompressionAlgosTime:   d.CompressionAlgosTime(l1StartBlock.Time()),
 	}, nil
 }
 
+// SpanBatchTime returns the activation time of the span batches, given the genesis time.
+func (d *DeployConfig) SpanBatchTime(genesisTime uint64) *uint64 {
+	return forkTime(genesisTime, d.L2GenesisSpanBatchTimeOffset)
+}
+
+// AltDATime returns the activation time of the alternative DA, given the genesis time.
+func (d *DeployConfig) AltDATime(genesisTime uint64) *uint64 {
+	return forkTime(genesisTime, d.L2GenesisAltDATimeOffset)
+}
+
+// CompressionAlgosTime returns the activation time of the brotli and zstd channel compression,
+// given the genesis time.
+func (d *DeployConfig) CompressionAlgosTime(genesisTime uint64) *uint64 {
+	return forkTime(genesisTime, d.L2GenesisCompressionAlgosTimeOffset)
+}
+
+func forkTime(genesisTime uint64, offset *hexutil.Uint64) *uint64 {
+	if offset == nil {
+		return nil
+	}
+	v := uint64(0)
+	if *offset > 0 {
+		v = genesisTime + uint64(*offset)
+	}
+	return &v
+}
+
 // NewDeployConfig reads a config file given a path on the filesystem.
 func NewDeployConfig(path string) (*DeployConfig, error) {
 	file, err := os.ReadFile(path)
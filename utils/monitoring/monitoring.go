@@ -3,11 +3,15 @@ package monitoring
 import (
 	"context"
 	"fmt"
+	"net"
+	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 
+	kservice "github.com/kroma-network/kroma/utils/service"
+	"github.com/kroma-network/kroma/utils/service/health"
 	"github.com/kroma-network/kroma/utils/service/metrics"
 	"github.com/kroma-network/kroma/utils/service/pprof"
 	krpc "github.com/kroma-network/kroma/utils/service/rpc"
@@ -30,6 +34,19 @@ func MaybeStartPprof(ctx context.Context, cfg pprof.CLIConfig, l log.Logger) {
 	}
 }
 
+// MaybeStartHealth serves the health endpoints until ctx is cancelled, with ready as the readiness check.
+func MaybeStartHealth(ctx context.Context, cfg health.CLIConfig, l log.Logger, ready func() error) {
+	if cfg.Enabled {
+		l.Info("starting health server", "addr", cfg.ListenAddr, "port", cfg.ListenPort)
+		addr := net.JoinHostPort(cfg.ListenAddr, strconv.Itoa(cfg.ListenPort))
+		go func() {
+			if err := kservice.NewHealthServer(addr, ready).Run(ctx, nil); err != nil {
+				l.Error("failed to start health server", "err", err)
+			}
+		}()
+	}
+}
+
 // NOTE(pangssu): MaybeStartMetrics requires cancelable context to stop http server
 func MaybeStartMetrics(ctx context.Context, cfg metrics.CLIConfig, l log.Logger, m metricer, l1 *ethclient.Client, wallet common.Address) {
 	if cfg.Enabled {
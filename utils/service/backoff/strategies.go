@@ -61,3 +61,32 @@ func Fixed(dur time.Duration) Strategy {
 		Dur: dur,
 	}
 }
+
+// FullJitterStrategy performs exponential backoff with full jitter. The backoff
+// function is randBetween(0, min(f.Initial * 2^attempt, f.Max)).
+type FullJitterStrategy struct {
+	// Initial is the upper bound of the wait time for the first retry attempt.
+	Initial time.Duration
+
+	// Max is the maximum amount of time to wait between attempts.
+	Max time.Duration
+}
+
+func (f *FullJitterStrategy) Duration(attempt int) time.Duration {
+	ceil := f.Max
+	// Avoid overflowing the shift for the large attempts.
+	if attempt < 62 && f.Initial < f.Max>>attempt {
+		ceil = f.Initial << attempt
+	}
+	if ceil <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(ceil)))
+}
+
+func FullJitter(initial time.Duration, max time.Duration) Strategy {
+	return &FullJitterStrategy{
+		Initial: initial,
+		Max:     max,
+	}
+}
@@ -19,3 +19,17 @@ func TestExponential(t *testing.T) {
 		require.Equal(t, time.Millisecond*time.Duration(dur*1000), strategy.Duration(i))
 	}
 }
+
+func TestFullJitter(t *testing.T) {
+	strategy := FullJitter(100*time.Millisecond, time.Second)
+
+	ceils := []time.Duration{100, 200, 400, 800, 1000, 1000}
+	for i, ceil := range ceils {
+		for j := 0; j < 100; j++ {
+			dur := strategy.Duration(i)
+			require.GreaterOrEqual(t, dur, time.Duration(0))
+			require.Less(t, dur, ceil*time.Millisecond)
+		}
+	}
+	require.Less(t, strategy.Duration(100), time.Second)
+}
@@ -0,0 +1,110 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	hdwallet "github.com/ethereum-optimism/go-ethereum-hdwallet"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/tyler-smith/go-bip39"
+	"github.com/tyler-smith/go-bip39/wordlists"
+	"golang.org/x/text/unicode/norm"
+)
+
+// DefaultMnemonicWordlist is the wordlist of the mnemonics, unless another one is selected.
+const DefaultMnemonicWordlist = "english"
+
+var mnemonicWordlists = map[string][]string{
+	"english":             wordlists.English,
+	"chinese-simplified":  wordlists.ChineseSimplified,
+	"chinese-traditional": wordlists.ChineseTraditional,
+	"czech":               wordlists.Czech,
+	"french":              wordlists.French,
+	"italian":             wordlists.Italian,
+	"japanese":            wordlists.Japanese,
+	"korean":              wordlists.Korean,
+	"spanish":             wordlists.Spanish,
+}
+
+// MnemonicWordlists returns the names of the supported BIP-39 wordlists.
+func MnemonicWordlists() []string {
+	names := make([]string, 0, len(mnemonicWordlists))
+	for name := range mnemonicWordlists {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CheckMnemonicWordlist checks that the wordlist is supported. An empty name selects the default one.
+func CheckMnemonicWordlist(wordlist string) error {
+	if _, ok := mnemonicWordlists[mnemonicWordlistName(wordlist)]; !ok {
+		return fmt.Errorf("unknown mnemonic wordlist %s, expected one of %s", wordlist, strings.Join(MnemonicWordlists(), ", "))
+	}
+	return nil
+}
+
+func mnemonicWordlistName(wordlist string) string {
+	if wordlist == "" {
+		return DefaultMnemonicWordlist
+	}
+	return strings.ToLower(wordlist)
+}
+
+// MnemonicPrivateKey derives the private key at the HD path from the BIP-39 mnemonic of the wordlist,
+// hardened with the passphrase, if any. The mnemonic and the passphrase are NFKD normalized as BIP-39
+// requires, so that e.g. the Japanese mnemonics may be separated by ideographic spaces.
+func MnemonicPrivateKey(mnemonic, passphrase, wordlist, hdPath string) (*ecdsa.PrivateKey, error) {
+	words := strings.Fields(norm.NFKD.String(mnemonic))
+	if err := checkMnemonic(words, mnemonicWordlistName(wordlist)); err != nil {
+		return nil, err
+	}
+	seed := bip39.NewSeed(strings.Join(words, " "), norm.NFKD.String(passphrase))
+	wallet, err := hdwallet.NewFromSeed(seed)
+	if err != nil {
+		return nil, err
+	}
+	return wallet.PrivateKey(accounts.Account{
+		URL: accounts.URL{
+			Path: hdPath,
+		},
+	})
+}
+
+// checkMnemonic checks that the words are in the wordlist and that their checksum is valid.
+// It does not use the validation of the bip39 package, whose wordlist is global.
+func checkMnemonic(words []string, wordlist string) error {
+	list, ok := mnemonicWordlists[wordlist]
+	if !ok {
+		return CheckMnemonicWordlist(wordlist)
+	}
+	if len(words) < 12 || len(words) > 24 || len(words)%3 != 0 {
+		return fmt.Errorf("invalid mnemonic length %d, expected 12, 15, 18, 21 or 24 words", len(words))
+	}
+	index := make(map[string]int64, len(list))
+	for i, word := range list {
+		index[norm.NFKD.String(word)] = int64(i)
+	}
+
+	// Each word encodes 11 bits: the entropy followed by its checksum, one bit per 32 bits of entropy.
+	bits := new(big.Int)
+	for i, word := range words {
+		n, ok := index[word]
+		if !ok {
+			return fmt.Errorf("word %d of the mnemonic is not in the %s wordlist", i+1, wordlist)
+		}
+		bits.Lsh(bits, 11).Or(bits, big.NewInt(n))
+	}
+	checksumBits := uint(len(words) * 11 / 33)
+	checksum := new(big.Int).And(bits, big.NewInt(1<<checksumBits-1))
+	entropy := new(big.Int).Rsh(bits, checksumBits).FillBytes(make([]byte, checksumBits*4))
+	hash := sha256.Sum256(entropy)
+	if uint64(hash[0]>>(8-checksumBits)) != checksum.Uint64() {
+		return fmt.Errorf("invalid mnemonic checksum for the %s wordlist", wordlist)
+	}
+	return nil
+}
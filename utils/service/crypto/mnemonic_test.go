@@ -0,0 +1,70 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"testing"
+
+	hdwallet "github.com/ethereum-optimism/go-ethereum-hdwallet"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+const testHDPath = "m/44'/60'/0'/0/0"
+
+func seedPrivateKey(t *testing.T, seed string) *ecdsa.PrivateKey {
+	wallet, err := hdwallet.NewFromSeed(common.FromHex(seed))
+	require.NoError(t, err)
+	key, err := wallet.PrivateKey(accounts.Account{URL: accounts.URL{Path: testHDPath}})
+	require.NoError(t, err)
+	return key
+}
+
+func TestMnemonicPrivateKey(t *testing.T) {
+	// The seeds are of the BIP-39 test vectors.
+	tests := []struct {
+		name       string
+		mnemonic   string
+		passphrase string
+		wordlist   string
+		seed       string
+	}{
+		{
+			name:       "english",
+			mnemonic:   "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			passphrase: "TREZOR",
+			seed:       "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			name:       "japanese",
+			mnemonic:   "あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あおぞら",
+			passphrase: "㍍ガバヴァぱばぐゞちぢ十人十色",
+			wordlist:   "japanese",
+			seed:       "a262d6fb6122ecf45be09c50492b31f92e9beb7d9a845987a02cefda57a15f9c467a17872029a9e92299b5cbdf306e3a0ee620245cbd508959b6cb7ca637bd55",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := MnemonicPrivateKey(tt.mnemonic, tt.passphrase, tt.wordlist, testHDPath)
+			require.NoError(t, err)
+			require.Equal(t, seedPrivateKey(t, tt.seed).D, key.D)
+		})
+	}
+
+	// Without a passphrase, the well-known test mnemonic derives the well-known address.
+	key, err := MnemonicPrivateKey("test test test test test test test test test test test junk", "", "", testHDPath)
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"), crypto.PubkeyToAddress(key.PublicKey))
+}
+
+func TestMnemonicPrivateKeyInvalid(t *testing.T) {
+	_, err := MnemonicPrivateKey("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", "", "", testHDPath)
+	require.ErrorContains(t, err, "checksum")
+	_, err = MnemonicPrivateKey("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "", "japanese", testHDPath)
+	require.ErrorContains(t, err, "not in the japanese wordlist")
+	_, err = MnemonicPrivateKey("abandon about", "", "", testHDPath)
+	require.ErrorContains(t, err, "invalid mnemonic length")
+	_, err = MnemonicPrivateKey("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "", "klingon", testHDPath)
+	require.ErrorContains(t, err, "unknown mnemonic wordlist")
+}
@@ -7,17 +7,19 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"os"
 	"strings"
 
-	hdwallet "github.com/ethereum-optimism/go-ethereum-hdwallet"
-	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 
 	ksigner "github.com/kroma-network/kroma/utils/signer/client"
+	khardware "github.com/kroma-network/kroma/utils/signer/hardware"
+	kkms "github.com/kroma-network/kroma/utils/signer/kms"
 )
 
 func PrivateKeySignerFn(key *ecdsa.PrivateKey, chainID *big.Int) bind.SignerFn {
@@ -42,19 +44,80 @@ type SignerFn func(context.Context, common.Address, *types.Transaction) (*types.
 // SignerFactory creates a SignerFn that is bound to a specific ChainID
 type SignerFactory func(chainID *big.Int) SignerFn
 
-// SignerFactoryFromConfig considers three ways that signers are created & then creates single factory from those config options.
-// It can either take a remote signer (via ksigner.CLIConfig) or it can be provided either a mnemonic + derivation path or a private key.
-// It prefers the remote signer, to the mnemonic or private key (only one of which can be provided).
-func SignerFactoryFromConfig(l log.Logger, privateKey, mnemonic, hdPath string, signerConfig ksigner.CLIConfig) (SignerFactory, common.Address, error) {
+// SignerFactoryFromConfig considers eight ways that signers are created & then creates single factory from those config options.
+// It can either take a remote signer (via ksigner.CLIConfig), an AWS KMS key (via kkms.CLIConfig),
+// a Google Cloud KMS key (via kkms.CLIConfig, if the signer backend is gcpkms),
+// an Azure Key Vault key (via kkms.CLIConfig, if the signer backend is azurekv),
+// a hardware wallet + derivation path (if the signer backend is ledger or trezor)
+// or it can be provided either a mnemonic + derivation path, a private key or an encrypted keystore file + password file.
+// The mnemonic may be of any supported BIP-39 wordlist, and hardened with a passphrase.
+// It prefers the remote signer, to the mnemonic, private key or keystore (only one of which can be provided).
+// The KMS keys and the hardware wallets cannot be provided along with any other key source.
+// The returned signer client is the one of the remote signer, which must be closed once unused. It is nil for
+// the other key sources.
+func SignerFactoryFromConfig(l log.Logger, privateKey, mnemonic, mnemonicPassphrase, mnemonicWordlist, hdPath, keystorePath, keystorePasswordFile string, signerConfig ksigner.CLIConfig, kmsConfig kkms.CLIConfig) (SignerFactory, common.Address, *ksigner.SignerClient, error) {
 	var signer SignerFactory
 	var fromAddress common.Address
-	if signerConfig.Enabled() {
+	var remote *ksigner.SignerClient
+	if signerConfig.Backend.IsHardware() {
+		if kmsConfig.Enabled() || kmsConfig.GCPKeyName != "" || signerConfig.Enabled() || privateKey != "" || mnemonic != "" || keystorePath != "" {
+			return nil, common.Address{}, nil, errors.New("cannot specify both a hardware wallet and another key source")
+		}
+		hwSigner, err := khardware.NewSigner(string(signerConfig.Backend), hdPath)
+		if err != nil {
+			l.Error("Unable to create hardware wallet Signer", "error", err)
+			return nil, common.Address{}, nil, fmt.Errorf("failed to create the hardware wallet signer: %w", err)
+		}
+		fromAddress = hwSigner.Address()
+		l.Info("Signing with hardware wallet, each transaction must be confirmed on the device", "wallet", signerConfig.Backend, "address", fromAddress)
+		signer = kmsSignerFactory(fromAddress, hwSigner)
+	} else if signerConfig.Backend == ksigner.BackendGCPKMS {
+		if kmsConfig.GCPKeyName == "" {
+			return nil, common.Address{}, nil, errors.New("must provide a gcp kms key name with the gcpkms signer backend")
+		}
+		if kmsConfig.Enabled() || signerConfig.Enabled() || privateKey != "" || mnemonic != "" || keystorePath != "" {
+			return nil, common.Address{}, nil, errors.New("cannot specify both a gcp kms key and another key source")
+		}
+		gcpSigner, err := kkms.NewGCPSignerFromConfig(context.Background(), kmsConfig)
+		if err != nil {
+			l.Error("Unable to create GCP KMS Signer", "error", err)
+			return nil, common.Address{}, nil, fmt.Errorf("failed to create the gcp kms signer: %w", err)
+		}
+		fromAddress = gcpSigner.Address()
+		signer = kmsSignerFactory(fromAddress, gcpSigner)
+	} else if signerConfig.Backend == ksigner.BackendAzureKV {
+		if kmsConfig.AzureKeyID == "" {
+			return nil, common.Address{}, nil, errors.New("must provide an azure key id with the azurekv signer backend")
+		}
+		if kmsConfig.Enabled() || signerConfig.Enabled() || privateKey != "" || mnemonic != "" || keystorePath != "" {
+			return nil, common.Address{}, nil, errors.New("cannot specify both an azure key and another key source")
+		}
+		azureSigner, err := kkms.NewAzureSignerFromConfig(context.Background(), kmsConfig)
+		if err != nil {
+			l.Error("Unable to create Azure Key Vault Signer", "error", err)
+			return nil, common.Address{}, nil, fmt.Errorf("failed to create the azure key vault signer: %w", err)
+		}
+		fromAddress = azureSigner.Address()
+		signer = kmsSignerFactory(fromAddress, azureSigner)
+	} else if kmsConfig.Enabled() {
+		if signerConfig.Enabled() || privateKey != "" || mnemonic != "" || keystorePath != "" {
+			return nil, common.Address{}, nil, errors.New("cannot specify both a kms key and another key source")
+		}
+		kmsSigner, err := kkms.NewSignerFromConfig(context.Background(), kmsConfig)
+		if err != nil {
+			l.Error("Unable to create KMS Signer", "error", err)
+			return nil, common.Address{}, nil, fmt.Errorf("failed to create the kms signer: %w", err)
+		}
+		fromAddress = kmsSigner.Address()
+		signer = kmsSignerFactory(fromAddress, kmsSigner)
+	} else if signerConfig.Enabled() {
 		signerClient, err := ksigner.NewSignerClientFromConfig(l, signerConfig)
 		if err != nil {
 			l.Error("Unable to create Signer Client", "error", err)
-			return nil, common.Address{}, fmt.Errorf("failed to create the signer client: %w", err)
+			return nil, common.Address{}, nil, fmt.Errorf("failed to create the signer client: %w", err)
 		}
 		fromAddress = common.HexToAddress(signerConfig.Address)
+		remote = signerClient
 		signer = func(chainID *big.Int) SignerFn {
 			return func(ctx context.Context, address common.Address, tx *types.Transaction) (*types.Transaction, error) {
 				if !bytes.Equal(address[:], fromAddress[:]) {
@@ -68,27 +131,25 @@ func SignerFactoryFromConfig(l log.Logger, privateKey, mnemonic, hdPath string,
 		var err error
 
 		if privateKey != "" && mnemonic != "" {
-			return nil, common.Address{}, errors.New("cannot specify both a private key and a mnemonic")
+			return nil, common.Address{}, nil, errors.New("cannot specify both a private key and a mnemonic")
 		}
-		if privateKey == "" {
-			// Parse l2output wallet private key and L2OO contract address.
-			wallet, err := hdwallet.NewFromMnemonic(mnemonic)
+		if keystorePath != "" && (privateKey != "" || mnemonic != "") {
+			return nil, common.Address{}, nil, errors.New("cannot specify both a keystore and a private key or mnemonic")
+		}
+		if keystorePath != "" {
+			privKey, err = decryptKeystore(keystorePath, keystorePasswordFile)
 			if err != nil {
-				return nil, common.Address{}, fmt.Errorf("failed to parse mnemonic: %w", err)
+				return nil, common.Address{}, nil, err
 			}
-
-			privKey, err = wallet.PrivateKey(accounts.Account{
-				URL: accounts.URL{
-					Path: hdPath,
-				},
-			})
+		} else if privateKey == "" {
+			privKey, err = MnemonicPrivateKey(mnemonic, mnemonicPassphrase, mnemonicWordlist, hdPath)
 			if err != nil {
-				return nil, common.Address{}, fmt.Errorf("failed to create a wallet: %w", err)
+				return nil, common.Address{}, nil, fmt.Errorf("failed to derive the key from the mnemonic: %w", err)
 			}
 		} else {
 			privKey, err = crypto.HexToECDSA(strings.TrimPrefix(privateKey, "0x"))
 			if err != nil {
-				return nil, common.Address{}, fmt.Errorf("failed to parse the private key: %w", err)
+				return nil, common.Address{}, nil, fmt.Errorf("failed to parse the private key: %w", err)
 			}
 		}
 		fromAddress = crypto.PubkeyToAddress(privKey.PublicKey)
@@ -100,5 +161,39 @@ func SignerFactoryFromConfig(l log.Logger, privateKey, mnemonic, hdPath string,
 		}
 	}
 
-	return signer, fromAddress, nil
+	return signer, fromAddress, remote, nil
+}
+
+// kmsSigner signs the transactions with a key held by a KMS or a hardware wallet.
+type kmsSigner interface {
+	SignTransaction(ctx context.Context, chainID *big.Int, tx *types.Transaction) (*types.Transaction, error)
+}
+
+func kmsSignerFactory(fromAddress common.Address, s kmsSigner) SignerFactory {
+	return func(chainID *big.Int) SignerFn {
+		return func(ctx context.Context, address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != fromAddress {
+				return nil, fmt.Errorf("attempting to sign for %s, expected %s: ", address, fromAddress)
+			}
+			return s.SignTransaction(ctx, chainID, tx)
+		}
+	}
+}
+
+// decryptKeystore decrypts the web3 keystore JSON file with the password held by the password file.
+// The trailing line break of the password file is ignored.
+func decryptKeystore(keystorePath, passwordFile string) (*ecdsa.PrivateKey, error) {
+	keyJSON, err := os.ReadFile(keystorePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the keystore file: %w", err)
+	}
+	password, err := os.ReadFile(passwordFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the keystore password file: %w", err)
+	}
+	key, err := keystore.DecryptKey(keyJSON, strings.TrimRight(string(password), "\r\n"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the keystore: %w", err)
+	}
+	return key.PrivateKey, nil
 }
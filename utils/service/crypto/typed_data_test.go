@@ -0,0 +1,36 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignTypedData(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {{Name: "name", Type: "string"}, {Name: "chainId", Type: "uint256"}},
+			"Vote":         {{Name: "proposalId", Type: "uint256"}, {Name: "support", Type: "bool"}},
+		},
+		PrimaryType: "Vote",
+		Domain:      apitypes.TypedDataDomain{Name: "Kroma", ChainId: math.NewHexOrDecimal256(900)},
+		Message:     apitypes.TypedDataMessage{"proposalId": "1", "support": true},
+	}
+
+	signature, err := SignTypedData(key, typedData)
+	require.NoError(t, err)
+	require.Contains(t, []byte{27, 28}, signature[crypto.RecoveryIDOffset])
+	signer, err := RecoverTypedDataSigner(typedData, signature)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer)
+
+	typedData.Message["support"] = false
+	signer, err = RecoverTypedDataSigner(typedData, signature)
+	require.NoError(t, err)
+	require.NotEqual(t, crypto.PubkeyToAddress(key.PublicKey), signer)
+}
@@ -0,0 +1,64 @@
+package health
+
+import (
+	"errors"
+	"math"
+
+	"github.com/urfave/cli"
+
+	kservice "github.com/kroma-network/kroma/utils/service"
+)
+
+const (
+	EnabledFlagName    = "health.enabled"
+	ListenAddrFlagName = "health.addr"
+	PortFlagName       = "health.port"
+)
+
+func CLIFlags(envPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.BoolFlag{
+			Name:   EnabledFlagName,
+			Usage:  "Enable the health server, serving the liveness probe /healthz and the readiness probe /readyz",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "HEALTH_ENABLED"),
+		},
+		cli.StringFlag{
+			Name:   ListenAddrFlagName,
+			Usage:  "Health server listening address",
+			Value:  "0.0.0.0",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "HEALTH_ADDR"),
+		},
+		cli.IntFlag{
+			Name:   PortFlagName,
+			Usage:  "Health server listening port",
+			Value:  8081,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "HEALTH_PORT"),
+		},
+	}
+}
+
+type CLIConfig struct {
+	Enabled    bool
+	ListenAddr string
+	ListenPort int
+}
+
+func (m CLIConfig) Check() error {
+	if !m.Enabled {
+		return nil
+	}
+
+	if m.ListenPort < 0 || m.ListenPort > math.MaxUint16 {
+		return errors.New("invalid health port")
+	}
+
+	return nil
+}
+
+func ReadCLIConfig(ctx *cli.Context) CLIConfig {
+	return CLIConfig{
+		Enabled:    ctx.GlobalBool(EnabledFlagName),
+		ListenAddr: ctx.GlobalString(ListenAddrFlagName),
+		ListenPort: ctx.GlobalInt(PortFlagName),
+	}
+}
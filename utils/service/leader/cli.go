@@ -0,0 +1,91 @@
+package leader
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	kservice "github.com/kroma-network/kroma/utils/service"
+)
+
+const (
+	ConsulURLFlagName = "leader.consul-url"
+	KeyFlagName       = "leader.key"
+	TTLFlagName       = "leader.ttl"
+	IDFlagName        = "leader.id"
+)
+
+// minConsulTTL is the minimum TTL of a Consul session.
+const minConsulTTL = 10 * time.Second
+
+func CLIFlags(envPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:   ConsulURLFlagName,
+			Usage:  "HTTP URL of the Consul agent holding the leader lock. If set, only the instance holding the lock is active, and a standby instance takes over once the lock expires. If empty, the leader election is disabled",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "LEADER_CONSUL_URL"),
+		},
+		cli.StringFlag{
+			Name:   KeyFlagName,
+			Usage:  "Consul key of the leader lock, shared by all the instances",
+			Value:  strings.ToLower(envPrefix) + "/leader",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "LEADER_KEY"),
+		},
+		cli.DurationFlag{
+			Name:   TTLFlagName,
+			Usage:  "TTL of the leader lock. A leader which fails to renew the lock steps down after half of it, and a standby takes over once it expires",
+			Value:  15 * time.Second,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "LEADER_TTL"),
+		},
+		cli.StringFlag{
+			Name:   IDFlagName,
+			Usage:  "ID of the instance, stored in the leader lock while it holds it. Defaults to the hostname",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "LEADER_ID"),
+		},
+	}
+}
+
+type CLIConfig struct {
+	ConsulURL string
+	Key       string
+	TTL       time.Duration
+	ID        string
+}
+
+func (c CLIConfig) Enabled() bool {
+	return c.ConsulURL != ""
+}
+
+func (c CLIConfig) Check() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if _, err := url.ParseRequestURI(c.ConsulURL); err != nil {
+		return fmt.Errorf("invalid Consul URL: %w", err)
+	}
+	if c.Key == "" {
+		return errors.New("the leader lock key must not be empty")
+	}
+	if c.TTL < minConsulTTL {
+		return fmt.Errorf("the leader lock TTL must be at least %s", minConsulTTL)
+	}
+	return nil
+}
+
+func ReadCLIConfig(ctx *cli.Context) CLIConfig {
+	id := ctx.GlobalString(IDFlagName)
+	if id == "" {
+		id, _ = os.Hostname()
+	}
+	return CLIConfig{
+		ConsulURL: ctx.GlobalString(ConsulURLFlagName),
+		Key:       ctx.GlobalString(KeyFlagName),
+		TTL:       ctx.GlobalDuration(TTLFlagName),
+		ID:        id,
+	}
+}
@@ -0,0 +1,98 @@
+package leader
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/utils/service/clock"
+)
+
+// Elector elects the leader among the instances of a service sharing a Lock: the instance holding
+// the lock is the leader. The leader renews the lock every third of the TTL, and steps down once
+// half of the TTL elapsed since its last renewal, so that it is no longer active by the time the
+// lock expires and a standby instance takes over.
+type Elector struct {
+	lock  Lock
+	ttl   time.Duration
+	clock clock.Clock
+	log   log.Logger
+
+	mu sync.Mutex
+	// leaderUntil is the time until which this instance is the leader, zero if it is not.
+	leaderUntil time.Time
+}
+
+func NewElector(lock Lock, ttl time.Duration, clk clock.Clock, l log.Logger) *Elector {
+	return &Elector{
+		lock:  lock,
+		ttl:   ttl,
+		clock: clk,
+		log:   l,
+	}
+}
+
+// Run campaigns for the leadership until ctx is done, and releases the lock then.
+func (e *Elector) Run(ctx context.Context) {
+	ticker := e.clock.NewTicker(e.ttl / 3)
+	defer ticker.Stop()
+	for {
+		e.campaign(ctx)
+		select {
+		case <-ticker.Ch():
+		case <-ctx.Done():
+			e.stepDown()
+			rctx, cancel := context.WithTimeout(context.Background(), e.ttl/3)
+			defer cancel()
+			if err := e.lock.Release(rctx); err != nil {
+				e.log.Warn("Failed to release the leader lock", "err", err)
+			}
+			return
+		}
+	}
+}
+
+// IsLeader returns whether this instance is the leader.
+func (e *Elector) IsLeader() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.clock.Now().Before(e.leaderUntil)
+}
+
+// campaign tries to acquire or renew the lock once. On failure, the leadership lasts until
+// half of the TTL elapsed since the last renewal.
+func (e *Elector) campaign(ctx context.Context) {
+	start := e.clock.Now()
+	ctx, cancel := context.WithTimeout(ctx, e.ttl/3)
+	defer cancel()
+	held, err := e.lock.Acquire(ctx)
+	if err != nil {
+		e.log.Warn("Failed to acquire the leader lock", "err", err, "leader", e.IsLeader())
+		return
+	}
+
+	wasLeader := e.IsLeader()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if held {
+		e.leaderUntil = start.Add(e.ttl / 2)
+		if !wasLeader {
+			e.log.Info("Became the leader")
+		}
+	} else {
+		e.leaderUntil = time.Time{}
+		if wasLeader {
+			e.log.Warn("Lost the leadership")
+		}
+	}
+}
+
+func (e *Elector) stepDown() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.leaderUntil = time.Time{}
+}
@@ -0,0 +1,172 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/utils/service/clock"
+)
+
+// fakeConsul serves the session and KV lock endpoints of the Consul HTTP API used by ConsulLock.
+type fakeConsul struct {
+	mu       sync.Mutex
+	sessions map[string]bool
+	holders  map[string]string // key -> session
+	values   map[string]string
+	next     int
+}
+
+func newFakeConsul(t *testing.T) (*fakeConsul, *httptest.Server) {
+	c := &fakeConsul{sessions: make(map[string]bool), holders: make(map[string]string), values: make(map[string]string)}
+	srv := httptest.NewServer(http.HandlerFunc(c.serve))
+	t.Cleanup(srv.Close)
+	return c, srv
+}
+
+func (c *fakeConsul) serve(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	body, _ := io.ReadAll(r.Body)
+	path := r.URL.Path
+	switch {
+	case path == "/v1/session/create":
+		c.next++
+		id := fmt.Sprintf("session-%d", c.next)
+		c.sessions[id] = true
+		_, _ = fmt.Fprintf(w, `{"ID":%q}`, id)
+	case strings.HasPrefix(path, "/v1/session/renew/"):
+		if !c.sessions[strings.TrimPrefix(path, "/v1/session/renew/")] {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case strings.HasPrefix(path, "/v1/session/destroy/"):
+		c.expire(strings.TrimPrefix(path, "/v1/session/destroy/"))
+	case strings.HasPrefix(path, "/v1/kv/"):
+		key := strings.TrimPrefix(path, "/v1/kv/")
+		if session := r.URL.Query().Get("acquire"); session != "" {
+			holder, held := c.holders[key]
+			ok := c.sessions[session] && (!held || holder == session)
+			if ok {
+				c.holders[key] = session
+				c.values[key] = string(body)
+			}
+			_, _ = fmt.Fprint(w, ok)
+		} else if session := r.URL.Query().Get("release"); session != "" {
+			ok := c.holders[key] == session
+			if ok {
+				delete(c.holders, key)
+			}
+			_, _ = fmt.Fprint(w, ok)
+		}
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+// expire invalidates the session, deleting the keys it holds. It must be called with the lock held.
+func (c *fakeConsul) expire(session string) {
+	delete(c.sessions, session)
+	for key, holder := range c.holders {
+		if holder == session {
+			delete(c.holders, key)
+			delete(c.values, key)
+		}
+	}
+}
+
+func TestConsulLock(t *testing.T) {
+	ctx := context.Background()
+	consul, srv := newFakeConsul(t)
+	cfg := CLIConfig{ConsulURL: srv.URL, Key: "kroma_batcher/leader", TTL: 15 * time.Second}
+	primaryCfg, standbyCfg := cfg, cfg
+	primaryCfg.ID, standbyCfg.ID = "primary", "standby"
+	primary, standby := NewConsulLock(primaryCfg), NewConsulLock(standbyCfg)
+
+	held, err := primary.Acquire(ctx)
+	require.NoError(t, err)
+	require.True(t, held)
+	require.Equal(t, "primary", consul.values[cfg.Key])
+	held, err = standby.Acquire(ctx)
+	require.NoError(t, err)
+	require.False(t, held)
+	// Renewing keeps the lock.
+	held, err = primary.Acquire(ctx)
+	require.NoError(t, err)
+	require.True(t, held)
+
+	// The standby takes over once the session of the primary expires.
+	consul.mu.Lock()
+	consul.expire(primary.session)
+	consul.mu.Unlock()
+	held, err = standby.Acquire(ctx)
+	require.NoError(t, err)
+	require.True(t, held)
+	require.Equal(t, "standby", consul.values[cfg.Key])
+	held, err = primary.Acquire(ctx)
+	require.NoError(t, err)
+	require.False(t, held, "Expected the primary to create a new session, without getting the lock back")
+
+	// The primary gets the lock back once the standby releases it.
+	require.NoError(t, standby.Release(ctx))
+	require.Empty(t, standby.session)
+	held, err = primary.Acquire(ctx)
+	require.NoError(t, err)
+	require.True(t, held)
+}
+
+type fakeLock struct {
+	held bool
+	err  error
+}
+
+func (l *fakeLock) Acquire(context.Context) (bool, error) { return l.held, l.err }
+func (l *fakeLock) Release(context.Context) error         { return nil }
+
+func TestElector(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewDeterministicClock(time.Unix(1000, 0))
+	lock := &fakeLock{}
+	e := NewElector(lock, 15*time.Second, clk, testlog.Logger(t, log.LvlCrit))
+
+	e.campaign(ctx)
+	require.False(t, e.IsLeader())
+
+	lock.held = true
+	e.campaign(ctx)
+	require.True(t, e.IsLeader())
+
+	// A leader failing to renew the lock steps down after half of the TTL.
+	lock.err = errors.New("consul unreachable")
+	clk.AdvanceTime(5 * time.Second)
+	e.campaign(ctx)
+	require.True(t, e.IsLeader())
+	clk.AdvanceTime(3 * time.Second)
+	require.False(t, e.IsLeader())
+
+	lock.err = nil
+	e.campaign(ctx)
+	require.True(t, e.IsLeader())
+	lock.held = false
+	e.campaign(ctx)
+	require.False(t, e.IsLeader())
+}
+
+func TestCLIConfigCheck(t *testing.T) {
+	require.NoError(t, CLIConfig{}.Check())
+	require.False(t, CLIConfig{}.Enabled())
+	cfg := CLIConfig{ConsulURL: "http://localhost:8500", Key: "kroma_batcher/leader", TTL: 15 * time.Second}
+	require.NoError(t, cfg.Check())
+	cfg.TTL = time.Second
+	require.Error(t, cfg.Check())
+}
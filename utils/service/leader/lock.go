@@ -0,0 +1,144 @@
+package leader
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Lock is a lock shared between the instances of a service, held for a TTL unless renewed,
+// so that it is released once the instance holding it dies.
+type Lock interface {
+	// Acquire tries to acquire the lock, or to renew it if it is already held, and returns
+	// whether it is held.
+	Acquire(ctx context.Context) (bool, error)
+	// Release releases the lock, if it is held.
+	Release(ctx context.Context) error
+}
+
+var errSessionNotFound = errors.New("session not found")
+
+// ConsulLock is a Lock held by a Consul session with the TTL, through the Consul HTTP API.
+// The session is renewed on each Acquire, and the key is deleted once the session expires.
+type ConsulLock struct {
+	url        string
+	key        string
+	id         string
+	ttl        time.Duration
+	httpClient *http.Client
+
+	mu      sync.Mutex
+	session string
+}
+
+func NewConsulLock(cfg CLIConfig) *ConsulLock {
+	return &ConsulLock{
+		url:        strings.TrimSuffix(cfg.ConsulURL, "/"),
+		key:        strings.TrimPrefix(cfg.Key, "/"),
+		id:         cfg.ID,
+		ttl:        cfg.TTL,
+		httpClient: &http.Client{},
+	}
+}
+
+func (l *ConsulLock) Acquire(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.session != "" {
+		err := l.do(ctx, "/v1/session/renew/"+l.session, nil, nil)
+		if errors.Is(err, errSessionNotFound) {
+			l.session = ""
+		} else if err != nil {
+			return false, fmt.Errorf("failed to renew the session: %w", err)
+		}
+	}
+	if l.session == "" {
+		var resp struct{ ID string }
+		err := l.do(ctx, "/v1/session/create", map[string]string{
+			"Name":      l.key,
+			"TTL":       l.ttl.String(),
+			"Behavior":  "delete",
+			"LockDelay": "0s",
+		}, &resp)
+		if err != nil {
+			return false, fmt.Errorf("failed to create a session: %w", err)
+		}
+		l.session = resp.ID
+	}
+
+	var held bool
+	if err := l.do(ctx, l.keyPath("acquire"), l.id, &held); err != nil {
+		return false, fmt.Errorf("failed to acquire the lock: %w", err)
+	}
+	return held, nil
+}
+
+func (l *ConsulLock) Release(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.session == "" {
+		return nil
+	}
+	var released bool
+	if err := l.do(ctx, l.keyPath("release"), nil, &released); err != nil {
+		return fmt.Errorf("failed to release the lock: %w", err)
+	}
+	if err := l.do(ctx, "/v1/session/destroy/"+l.session, nil, nil); err != nil {
+		return fmt.Errorf("failed to destroy the session: %w", err)
+	}
+	l.session = ""
+	return nil
+}
+
+func (l *ConsulLock) keyPath(op string) string {
+	return fmt.Sprintf("/v1/kv/%s?%s=%s", l.key, op, url.QueryEscape(l.session))
+}
+
+// do sends a PUT request with the JSON body, or the raw body if it is a string, and decodes
+// the JSON response into out, if not nil.
+func (l *ConsulLock) do(ctx context.Context, path string, body any, out any) error {
+	var data []byte
+	switch body := body.(type) {
+	case nil:
+	case string:
+		data = []byte(body)
+	default:
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, l.url+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	resp, err := l.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respData, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return errSessionNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %s: %s", resp.Status, strings.TrimSpace(string(respData)))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(respData, out)
+}
@@ -221,6 +221,19 @@ func defaultHealthzHandler(appVersion string) http.HandlerFunc {
 	}
 }
 
+// ReadyHealthzHandler returns the healthz handler responding with 503 and the error while ready fails,
+// e.g. while the tx manager pauses the sends, and like the default handler otherwise.
+func ReadyHealthzHandler(appVersion string, ready func() error) http.HandlerFunc {
+	healthy := defaultHealthzHandler(appVersion)
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := ready(); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		healthy(w, r)
+	}
+}
+
 type healthzAPI struct {
 	appVersion string
 }
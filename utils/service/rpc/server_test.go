@@ -1,10 +1,12 @@
 package rpc
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"math/rand"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/rpc"
@@ -59,3 +61,19 @@ func TestBaseServer(t *testing.T) {
 		require.Equal(t, 4, res)
 	})
 }
+
+func TestReadyHealthzHandler(t *testing.T) {
+	var readyErr error
+	handler := ReadyHealthzHandler("test", func() error { return readyErr })
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "{\"version\":\"test\"}\n", rec.Body.String())
+
+	readyErr = errors.New("paused")
+	rec = httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	require.Contains(t, rec.Body.String(), "paused")
+}
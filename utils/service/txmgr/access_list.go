@@ -0,0 +1,81 @@
+package txmgr
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// AccessListCreator is implemented by the backends supporting eth_createAccessList.
+// It returns the access list of the call, the gas used with it, and the error of the call, if any.
+type AccessListCreator interface {
+	CreateAccessList(ctx context.Context, msg ethereum.CallMsg) (*types.AccessList, uint64, string, error)
+}
+
+// accessListClient is an ethclient.Client that also supports eth_createAccessList.
+type accessListClient struct {
+	*ethclient.Client
+	geth *gethclient.Client
+	rpc  *rpc.Client
+}
+
+func newAccessListClient(client *rpc.Client) *accessListClient {
+	return &accessListClient{Client: ethclient.NewClient(client), geth: gethclient.New(client), rpc: client}
+}
+
+func (c *accessListClient) CreateAccessList(ctx context.Context, msg ethereum.CallMsg) (*types.AccessList, uint64, string, error) {
+	return c.geth.CreateAccessList(ctx, msg)
+}
+
+// TransactionReceipts queries the receipts of the txs in a single JSON-RPC batch.
+func (c *accessListClient) TransactionReceipts(ctx context.Context, hashes []common.Hash) ([]*types.Receipt, []error, error) {
+	receipts := make([]*types.Receipt, len(hashes))
+	batch := make([]rpc.BatchElem, len(hashes))
+	for i, hash := range hashes {
+		batch[i] = rpc.BatchElem{Method: "eth_getTransactionReceipt", Args: []interface{}{hash}, Result: &receipts[i]}
+	}
+	if err := c.rpc.BatchCallContext(ctx, batch); err != nil {
+		return nil, nil, err
+	}
+	errs := make([]error, len(hashes))
+	for i, elem := range batch {
+		errs[i] = elem.Error
+		if errs[i] == nil && receipts[i] == nil {
+			errs[i] = ethereum.NotFound
+		}
+	}
+	return receipts, errs, nil
+}
+
+// createAccessList queries the backend for the access list of the call. It returns nil if the backend
+// does not support it or the query fails, since the access list only saves gas and is never required.
+func (m *SimpleTxManager) createAccessList(ctx context.Context, msg ethereum.CallMsg) types.AccessList {
+	creator, ok := m.backend.(AccessListCreator)
+	if !ok {
+		m.l.Debug("backend does not support eth_createAccessList")
+		return nil
+	}
+	// The access list is queried once, without retrying, since the tx can be sent without it.
+	cCtx, cancel := context.WithTimeout(ctx, m.NetworkTimeout)
+	defer cancel()
+	accessList, gasUsed, callErr, err := creator.CreateAccessList(cCtx, msg)
+	if err != nil {
+		m.metr.RPCError()
+		m.l.Warn("failed to create the access list, sending without it", "err", err)
+		return nil
+	}
+	if callErr != "" {
+		m.l.Warn("call fails while creating the access list, sending without it", "err", callErr)
+		return nil
+	}
+	if accessList == nil {
+		return nil
+	}
+	m.l.Debug("created access list", "addresses", len(*accessList), "gas_used", gasUsed)
+	return *accessList
+}
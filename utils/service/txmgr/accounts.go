@@ -0,0 +1,212 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	kcrypto "github.com/kroma-network/kroma/utils/service/crypto"
+	"github.com/kroma-network/kroma/utils/service/txmgr/metrics"
+)
+
+// ErrUnknownAccount is returned by SwitchAccount if the account is neither the primary account nor a standby one.
+var ErrUnknownAccount = errors.New("unknown account")
+
+// Account is a signer/from pair that the tx manager sends txs from.
+type Account struct {
+	Signer kcrypto.SignerFn
+	From   common.Address
+}
+
+// accountPool rotates the sends between the primary account of the tx manager and its ExtraAccounts.
+// Each account has up to limit sends in flight; a send goes to the first account with room,
+// so that the extra accounts are only used while the primary one is busy.
+type accountPool struct {
+	// extra are the tx managers of the extra accounts. The index 0 of the pool is the primary account.
+	extra []*SimpleTxManager
+	limit int
+
+	mu       sync.Mutex
+	inflight []int
+	// freed is closed and replaced whenever a send is released.
+	freed chan struct{}
+}
+
+func newAccountPool(extra []*SimpleTxManager, maxPendingTxs uint64) *accountPool {
+	limit := int(maxPendingTxs)
+	if limit < 1 {
+		limit = 1
+	}
+	return &accountPool{
+		extra:    extra,
+		limit:    limit,
+		inflight: make([]int, len(extra)+1),
+		freed:    make(chan struct{}),
+	}
+}
+
+// size returns the number of accounts of the pool.
+func (p *accountPool) size() int {
+	return len(p.inflight)
+}
+
+// acquire returns the index of the first account with room for a send, waiting until ctx is done
+// if all the accounts are busy.
+func (p *accountPool) acquire(ctx context.Context) (int, error) {
+	for {
+		p.mu.Lock()
+		for i, n := range p.inflight {
+			if n < p.limit {
+				p.inflight[i]++
+				p.mu.Unlock()
+				return i, nil
+			}
+		}
+		freed := p.freed
+		p.mu.Unlock()
+
+		select {
+		case <-freed:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+}
+
+// release frees the send of the account taken by acquire.
+func (p *accountPool) release(i int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.inflight[i]--
+	close(p.freed)
+	p.freed = make(chan struct{})
+}
+
+// standbyAccounts holds the tx managers of the StandbyAccounts, and the account the new sends go from.
+type standbyAccounts struct {
+	managers []*SimpleTxManager
+	// active is the index of the account the new txs are sent from, 0 being the primary account
+	// and i the standby account i-1.
+	active atomic.Int32
+}
+
+// newAccountManagers returns the tx managers of the given extra or standby accounts of the config. They share
+// the head tracker, the receipt batcher, the circuit breaker, the fee budget, the jitter source and the background work
+// of the primary tx manager, and record their txs each in its own state file.
+func newAccountManagers(name string, l log.Logger, m metrics.TxMetricer, conf Config, primary *SimpleTxManager, accounts []Account) []*SimpleTxManager {
+	managers := make([]*SimpleTxManager, 0, len(accounts))
+	for _, account := range accounts {
+		accountConf := conf
+		accountConf.Signer = account.Signer
+		accountConf.From = account.From
+		accountConf.ExtraAccounts = nil
+		accountConf.StandbyAccounts = nil
+		if conf.StatePath != "" {
+			accountConf.StatePath = conf.StatePath + "." + account.From.Hex()
+		}
+		manager := NewSimpleTxManagerFromConfig(name, l, m, accountConf)
+		manager.heads = primary.heads
+		manager.receipts = primary.receipts
+		manager.circuit = primary.circuit
+		manager.budget = primary.budget
+		manager.rng = primary.rng
+		manager.bg = primary.bg
+		managers = append(managers, manager)
+	}
+	return managers
+}
+
+// accountManager returns the tx manager of the account of the given pool index.
+func (m *SimpleTxManager) accountManager(i int) *SimpleTxManager {
+	if i == 0 {
+		return m
+	}
+	return m.accounts.extra[i-1]
+}
+
+// secondaryAccountManagers returns the tx managers of the extra and the standby accounts, if any.
+func (m *SimpleTxManager) secondaryAccountManagers() []*SimpleTxManager {
+	var managers []*SimpleTxManager
+	if m.accounts != nil {
+		managers = append(managers, m.accounts.extra...)
+	}
+	if m.standby != nil {
+		managers = append(managers, m.standby.managers...)
+	}
+	return managers
+}
+
+// sender returns the tx manager of the account the new txs are sent from: the primary account,
+// or the standby account switched to with SwitchAccount.
+func (m *SimpleTxManager) sender() *SimpleTxManager {
+	if m.standby == nil {
+		return m
+	}
+	if i := m.standby.active.Load(); i > 0 {
+		return m.standby.managers[i-1]
+	}
+	return m
+}
+
+// SignerFn returns the signer of the account the new txs are sent from.
+func (m *SimpleTxManager) SignerFn() kcrypto.SignerFn {
+	return m.sender().Config.Signer
+}
+
+// SwitchAccount makes the new txs be sent from the account, the primary account or one of the
+// StandbyAccounts, e.g. to rotate the key of a compromised primary account without a restart.
+// The sends in flight continue from their account.
+func (m *SimpleTxManager) SwitchAccount(from common.Address) error {
+	if m.standby == nil {
+		if from != m.Config.From {
+			return fmt.Errorf("%w: %v", ErrUnknownAccount, from)
+		}
+		return nil
+	}
+	index := -1
+	if from == m.Config.From {
+		index = 0
+	}
+	for i, am := range m.standby.managers {
+		if am.Config.From == from {
+			index = i + 1
+		}
+	}
+	if index < 0 {
+		return fmt.Errorf("%w: %v", ErrUnknownAccount, from)
+	}
+	if prev := m.standby.active.Swap(int32(index)); int(prev) != index {
+		m.l.Warn("switched the sending account", "from", from)
+	}
+	return nil
+}
+
+// SwitchToNextAccount makes the new txs be sent from the standby account following the current one
+// in the order of the StandbyAccounts, and returns it.
+func (m *SimpleTxManager) SwitchToNextAccount() (common.Address, error) {
+	if m.standby == nil {
+		return common.Address{}, errors.New("no standby account configured")
+	}
+	next := int(m.standby.active.Load())
+	if next >= len(m.standby.managers) {
+		return common.Address{}, errors.New("no standby account left")
+	}
+	from := m.standby.managers[next].Config.From
+	return from, m.SwitchAccount(from)
+}
+
+// maxConcurrentSends returns the number of sends that may be in flight at once over all the accounts.
+func (m *SimpleTxManager) maxConcurrentSends() int {
+	if m.accounts != nil {
+		return m.accounts.size() * m.accounts.limit
+	}
+	if m.MaxPendingTxs > 1 {
+		return int(m.MaxPendingTxs)
+	}
+	return 1
+}
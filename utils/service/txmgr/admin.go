@@ -0,0 +1,55 @@
+package txmgr
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// adminAPI is the txmgr namespace of the admin RPC API, to inspect and manage the txs in flight.
+type adminAPI struct {
+	m *SimpleTxManager
+}
+
+// NewAdminAPI returns the txmgr namespace of the admin RPC API of the tx manager, serving
+// txmgr_pending, txmgr_bump, txmgr_drop and txmgr_switchAccount.
+func NewAdminAPI(m *SimpleTxManager) rpc.API {
+	return rpc.API{
+		Namespace: "txmgr",
+		Service:   &adminAPI{m: m},
+	}
+}
+
+// Pending returns the txs in flight of all the accounts.
+func (a *adminAPI) Pending(_ context.Context) []PendingTx {
+	return a.m.PendingTxs()
+}
+
+// Bump resubmits the tx in flight at the nonce at once with bumped fees.
+// The account defaults to the account the new txs are sent from.
+func (a *adminAPI) Bump(_ context.Context, nonce hexutil.Uint64, from *common.Address) error {
+	return a.m.BumpPending(a.account(from), uint64(nonce))
+}
+
+// Drop gives up the tx in flight at the nonce. The account defaults to the account the new txs are sent from.
+func (a *adminAPI) Drop(_ context.Context, nonce hexutil.Uint64, from *common.Address) error {
+	return a.m.DropPending(a.account(from), uint64(nonce))
+}
+
+// SwitchAccount makes the new txs be sent from the account, the primary account or a standby one,
+// and returns it. The account defaults to the standby account following the current one.
+func (a *adminAPI) SwitchAccount(_ context.Context, from *common.Address) (common.Address, error) {
+	if from == nil {
+		return a.m.SwitchToNextAccount()
+	}
+	return *from, a.m.SwitchAccount(*from)
+}
+
+func (a *adminAPI) account(from *common.Address) common.Address {
+	if from == nil {
+		return a.m.From()
+	}
+	return *from
+}
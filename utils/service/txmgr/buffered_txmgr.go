@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
@@ -12,10 +13,44 @@ import (
 	"github.com/kroma-network/kroma/utils/service/txmgr/metrics"
 )
 
+// BufferPolicy is the policy of the buffered txmgr when the queue of transaction requests is full.
+type BufferPolicy string
+
+const (
+	// BufferPolicyBlock blocks the caller until there is room in the queue.
+	BufferPolicyBlock BufferPolicy = "block"
+	// BufferPolicyDropOldest drops the oldest queued request of the lowest priority to make room for the new one.
+	// If all the queued requests have a higher priority than the new one, the new one is dropped instead.
+	// The dropped request gets ErrTxDropped.
+	BufferPolicyDropOldest BufferPolicy = "drop-oldest"
+	// BufferPolicyRejectNew rejects the new request with ErrTxBufferFull.
+	BufferPolicyRejectNew BufferPolicy = "reject-new"
+)
+
+// Check returns an error if the policy is unknown. The empty policy is accepted as BufferPolicyRejectNew.
+func (p BufferPolicy) Check() error {
+	switch p {
+	case "", BufferPolicyBlock, BufferPolicyDropOldest, BufferPolicyRejectNew:
+		return nil
+	default:
+		return fmt.Errorf("unknown buffer policy: %s", p)
+	}
+}
+
+var (
+	// ErrTxBufferFull is the error returned when the request is rejected because the tx buffer is full.
+	ErrTxBufferFull = errors.New("tx buffer is full")
+	// ErrTxDropped is the error returned when the request is dropped from the full tx buffer to make room for a new one.
+	ErrTxDropped = errors.New("tx dropped from the full tx buffer")
+	// ErrTxManagerStopped is the error returned when the request is submitted to a stopped buffered tx manager,
+	// or when it is still queued at the end of the drain.
+	ErrTxManagerStopped = errors.New("buffered tx manager is stopped")
+)
+
 type BufferedTxManager struct {
 	SimpleTxManager // directly embed
 	wg              sync.WaitGroup
-	txRequestChan   chan *TxRequest
+	queue           *txQueue
 	ctx             context.Context
 	cancel          context.CancelFunc
 }
@@ -43,72 +78,131 @@ func NewBufferedTxManager(name string, l log.Logger, m metrics.TxMetricer, cfg C
 }
 
 func (m *BufferedTxManager) Start(ctx context.Context) error {
-	m.txRequestChan = make(chan *TxRequest, m.Config.TxBufferSize)
+	if err := m.SimpleTxManager.Start(ctx); err != nil {
+		return err
+	}
+	m.queue = newTxQueue(m.Config.TxBufferSize, m.Config.BufferPolicy)
 	m.ctx, m.cancel = context.WithCancel(ctx)
 	m.wg.Add(1)
 	go m.listen(m.ctx)
 	return nil
 }
 
+// Stop makes the new requests fail with ErrTxManagerStopped, and keeps sending the queued requests
+// until the queue is empty or DrainTimeout passes. The sends still in flight are then aborted; their
+// txs are resumed after a restart if StatePath is set. The requests still queued are recorded by dropQueued.
 func (m *BufferedTxManager) Stop() error {
+	m.queue.Close()
+	if m.DrainTimeout > 0 {
+		drained := make(chan struct{})
+		go func() {
+			m.wg.Wait()
+			close(drained)
+		}()
+		select {
+		case <-drained:
+		case <-time.After(m.DrainTimeout):
+			m.l.Warn("drain timeout exceeded, aborting the transaction requests", "queued", m.queue.Len())
+		}
+	}
 	m.cancel()
 	m.wg.Wait()
-	close(m.txRequestChan)
-	return nil
+	m.Close()
+	return m.dropQueued()
 }
 
+// listen sends the queued requests, up to MaxPendingTxs per account at once. A request is popped only
+// once a send is free, so that the requests queued meanwhile are still ordered by priority.
+// If MulticallAddress is set, the aggregatable requests queued within MulticallWindow of each other
+// are sent in a single Multicall3 tx.
 func (m *BufferedTxManager) listen(ctx context.Context) {
 	defer m.wg.Done()
+	sends := make(chan struct{}, m.maxConcurrentSends())
 	for {
 		select {
-		case txRequest := <-m.txRequestChan:
-			txReceipt, err := m.Send(txRequest.ctx, *txRequest.txCandidate)
+		case sends <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		txRequest, err := m.queue.Pop(ctx)
+		if err != nil {
+			return
+		}
+		m.wg.Add(1)
+		go func() {
+			defer m.wg.Done()
+			defer func() { <-sends }()
+			if m.multicallEnabled() && txRequest.txCandidate.aggregatable() {
+				if txRequests := m.collectAggregatable(ctx, txRequest); len(txRequests) > 1 {
+					m.sendAggregated(ctx, txRequests)
+					return
+				}
+			}
+			// The send is aborted once the drain is over.
+			sendCtx, cancelSend := context.WithCancel(txRequest.ctx)
+			defer cancelSend()
+			go func() {
+				select {
+				case <-ctx.Done():
+					cancelSend()
+				case <-sendCtx.Done():
+				}
+			}()
+			txReceipt, err := m.Send(sendCtx, *txRequest.txCandidate)
 			if err != nil {
 				m.l.Error("failed to send transaction in buffered tx manager", "err", err)
 			}
 			txRequest.responseChan <- &TxResponse{txReceipt, err}
-		case <-ctx.Done():
-			return
-		}
+		}()
 	}
 }
 
-func (m *BufferedTxManager) submitTransaction(ctx context.Context, txCandidate *TxCandidate) *TxResponse {
-	responseChan := make(chan *TxResponse)
-	defer close(responseChan)
-
+func (m *BufferedTxManager) submitTransaction(ctx context.Context, txCandidate *TxCandidate, priority TxPriority) *TxResponse {
+	// The response channel is buffered, so that the response is never blocked
+	// even if the requester has already given up waiting for it.
 	txRequest := &TxRequest{
 		ctx:          ctx,
 		txCandidate:  txCandidate,
-		responseChan: responseChan,
+		responseChan: make(chan *TxResponse, 1),
 	}
-	if !m.tryEnqueue(txRequest) {
+	if err := m.queue.Push(m.ctx, txRequest, priority); err != nil {
 		return &TxResponse{
-			nil, errors.New("submit transaction failed in tryEnqueue"),
+			nil, fmt.Errorf("submit transaction failed to enqueue: %w", err),
 		}
 	}
 	return txRequest.waitForResponse()
 }
 
 func (m *BufferedTxManager) SendTxCandidate(ctx context.Context, txCandidate *TxCandidate) *TxResponse {
-	return m.submitTransaction(ctx, txCandidate)
+	return m.SendWithPriority(ctx, txCandidate, TxPriorityNormal)
+}
+
+// SendWithPriority queues the tx candidate with the given priority. The queued requests of higher
+// priority are sent first, e.g. the challenge txs take precedence over the routine output submissions.
+func (m *BufferedTxManager) SendWithPriority(ctx context.Context, txCandidate *TxCandidate, priority TxPriority) *TxResponse {
+	return m.submitTransaction(ctx, txCandidate, priority)
 }
 
 func (m *BufferedTxManager) SendTransaction(ctx context.Context, tx *types.Transaction) *TxResponse {
-	return m.SendTxCandidate(ctx, &TxCandidate{
+	return m.SendTransactionWithPriority(ctx, tx, TxPriorityNormal)
+}
+
+// SendTransactionWithPriority is SendTransaction with the priority of SendWithPriority.
+func (m *BufferedTxManager) SendTransactionWithPriority(ctx context.Context, tx *types.Transaction, priority TxPriority) *TxResponse {
+	return m.SendWithPriority(ctx, &TxCandidate{
 		TxData:   tx.Data(),
 		To:       tx.To(),
 		GasLimit: 0,
-	})
+	}, priority)
 }
 
-func (m *BufferedTxManager) tryEnqueue(txRequest *TxRequest) bool {
-	select {
-	case m.txRequestChan <- txRequest:
-		return true
-	default:
-		return false
-	}
+// QueueDepth returns the number of transaction requests waiting in the queue.
+// It can be used to observe the backpressure.
+func (m *BufferedTxManager) QueueDepth() int {
+	return m.queue.Len()
 }
 
 func (r *TxRequest) waitForResponse() *TxResponse {
@@ -0,0 +1,443 @@
+package txmgr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// newSaturatedBufferedTxManager creates a BufferedTxManager without a listener,
+// so the queued tx requests are never consumed and the buffer saturates.
+func newSaturatedBufferedTxManager(t *testing.T, size uint64, policy BufferPolicy) *BufferedTxManager {
+	cfg := configWithNumConfs(1)
+	cfg.TxBufferSize = size
+	cfg.BufferPolicy = policy
+	h := newTestHarnessWithConfig(t, cfg)
+
+	m := &BufferedTxManager{
+		SimpleTxManager: *h.mgr,
+		queue:           newTxQueue(size, policy),
+	}
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+	t.Cleanup(m.cancel)
+	return m
+}
+
+// sendAsync submits a tx candidate in the background and returns the channel of its response.
+func sendAsync(ctx context.Context, m *BufferedTxManager) <-chan *TxResponse {
+	resCh := make(chan *TxResponse, 1)
+	go func() {
+		resCh <- m.SendTxCandidate(ctx, &TxCandidate{})
+	}()
+	return resCh
+}
+
+// consume pops the next queued request and responds to it, as the listener does.
+func consume(t *testing.T, m *BufferedTxManager) *TxCandidate {
+	req, err := m.queue.Pop(m.ctx)
+	require.NoError(t, err)
+	req.responseChan <- &TxResponse{}
+	return req.txCandidate
+}
+
+func requireQueueDepth(t *testing.T, m *BufferedTxManager, depth int) {
+	require.Eventually(t, func() bool {
+		return m.QueueDepth() == depth
+	}, time.Second, 5*time.Millisecond)
+}
+
+// TestBufferedTxManagerRejectNew asserts that the new requests are rejected with
+// ErrTxBufferFull while the buffer is full.
+func TestBufferedTxManagerRejectNew(t *testing.T) {
+	t.Parallel()
+
+	m := newSaturatedBufferedTxManager(t, 2, BufferPolicyRejectNew)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	rejected := 0
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res := m.SendTxCandidate(ctx, &TxCandidate{})
+			mu.Lock()
+			defer mu.Unlock()
+			if errors.Is(res.Err, ErrTxBufferFull) {
+				rejected++
+			}
+		}()
+	}
+
+	requireQueueDepth(t, m, 2)
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return rejected == 3
+	}, time.Second, 5*time.Millisecond)
+
+	// The queued requests give up once their context is cancelled.
+	cancel()
+	wg.Wait()
+	require.Equal(t, 3, rejected)
+}
+
+// TestBufferedTxManagerUnbuffered asserts that a buffer of size 0 hands the requests over
+// to a waiting listener, like an unbuffered channel, and rejects them otherwise.
+func TestBufferedTxManagerUnbuffered(t *testing.T) {
+	t.Parallel()
+
+	m := newSaturatedBufferedTxManager(t, 0, BufferPolicyRejectNew)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res := m.SendTxCandidate(ctx, &TxCandidate{})
+	require.ErrorIs(t, res.Err, ErrTxBufferFull)
+
+	consumed := make(chan *TxCandidate, 1)
+	go func() {
+		consumed <- consume(t, m)
+	}()
+	require.Eventually(t, func() bool {
+		m.queue.mu.Lock()
+		defer m.queue.mu.Unlock()
+		return m.queue.poppers == 1
+	}, time.Second, 5*time.Millisecond)
+
+	candidate := &TxCandidate{TxData: []byte{1}}
+	res = m.SendTxCandidate(ctx, candidate)
+	require.NoError(t, res.Err)
+	require.Equal(t, candidate, <-consumed)
+}
+
+// TestBufferedTxManagerDropOldest asserts that the oldest request is dropped with
+// ErrTxDropped to make room for the new one.
+func TestBufferedTxManagerDropOldest(t *testing.T) {
+	t.Parallel()
+
+	m := newSaturatedBufferedTxManager(t, 2, BufferPolicyDropOldest)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	first := sendAsync(ctx, m)
+	requireQueueDepth(t, m, 1)
+	second := sendAsync(ctx, m)
+	requireQueueDepth(t, m, 2)
+
+	third := sendAsync(ctx, m)
+	res := <-first
+	require.ErrorIs(t, res.Err, ErrTxDropped)
+	requireQueueDepth(t, m, 2)
+
+	// Saturate the buffer concurrently: every request is either queued or dropped.
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- m.SendTxCandidate(ctx, &TxCandidate{}).Err
+		}()
+	}
+	for _, resCh := range []<-chan *TxResponse{second, third} {
+		res := <-resCh
+		require.ErrorIs(t, res.Err, ErrTxDropped)
+	}
+	requireQueueDepth(t, m, 2)
+
+	// Consume the remaining two requests, so that all the waiting callers return.
+	for i := 0; i < 2; i++ {
+		consume(t, m)
+	}
+	wg.Wait()
+	close(errs)
+
+	dropped := 0
+	for err := range errs {
+		if err != nil {
+			require.ErrorIs(t, err, ErrTxDropped)
+			dropped++
+		}
+	}
+	require.Equal(t, 8, dropped)
+}
+
+// TestBufferedTxManagerBlock asserts that the new requests are blocked until there is
+// room in the buffer, or until their context is cancelled.
+func TestBufferedTxManagerBlock(t *testing.T) {
+	t.Parallel()
+
+	m := newSaturatedBufferedTxManager(t, 1, BufferPolicyBlock)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	first := sendAsync(ctx, m)
+	requireQueueDepth(t, m, 1)
+
+	// The second request blocks while the buffer is full.
+	second := sendAsync(ctx, m)
+	select {
+	case res := <-second:
+		t.Fatalf("request must be blocked, but got response: %v", res)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Once the first request is consumed, the second one is queued.
+	consume(t, m)
+	require.NoError(t, (<-first).Err)
+	requireQueueDepth(t, m, 1)
+
+	// A blocked request gives up when its context is cancelled.
+	blockedCtx, blockedCancel := context.WithCancel(context.Background())
+	blocked := sendAsync(blockedCtx, m)
+	blockedCancel()
+	require.ErrorIs(t, (<-blocked).Err, context.Canceled)
+	requireQueueDepth(t, m, 1)
+
+	consume(t, m)
+	require.NoError(t, (<-second).Err)
+}
+
+// TestBufferedTxManagerPriority asserts that the queued requests of higher priority are sent first,
+// and that the full queue drops the least important requests.
+func TestBufferedTxManagerPriority(t *testing.T) {
+	t.Parallel()
+
+	m := newSaturatedBufferedTxManager(t, 3, BufferPolicyDropOldest)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sendWithPriority := func(gasLimit uint64, priority TxPriority) <-chan *TxResponse {
+		resCh := make(chan *TxResponse, 1)
+		go func() {
+			resCh <- m.SendWithPriority(ctx, &TxCandidate{GasLimit: gasLimit}, priority)
+		}()
+		return resCh
+	}
+	oldest := sendWithPriority(1, TxPriorityNormal)
+	requireQueueDepth(t, m, 1)
+	normal := sendWithPriority(2, TxPriorityNormal)
+	requireQueueDepth(t, m, 2)
+	high := sendWithPriority(3, TxPriorityHigh)
+	requireQueueDepth(t, m, 3)
+
+	// The oldest request of the lowest priority makes room for the high priority request.
+	higher := sendWithPriority(4, TxPriorityHigh)
+	require.ErrorIs(t, (<-oldest).Err, ErrTxDropped)
+	requireQueueDepth(t, m, 3)
+
+	require.Equal(t, uint64(3), consume(t, m).GasLimit)
+	require.Equal(t, uint64(4), consume(t, m).GasLimit)
+	require.NoError(t, (<-high).Err)
+	require.NoError(t, (<-higher).Err)
+
+	// A normal request never displaces the high priority ones.
+	high = sendWithPriority(5, TxPriorityHigh)
+	requireQueueDepth(t, m, 2)
+	higher = sendWithPriority(6, TxPriorityHigh)
+	requireQueueDepth(t, m, 3)
+	highest := sendWithPriority(7, TxPriorityHigh)
+	require.ErrorIs(t, (<-normal).Err, ErrTxDropped)
+	requireQueueDepth(t, m, 3)
+	require.ErrorIs(t, m.SendWithPriority(ctx, &TxCandidate{}, TxPriorityNormal).Err, ErrTxDropped)
+
+	for i, resCh := range []<-chan *TxResponse{high, higher, highest} {
+		require.Equal(t, uint64(5+i), consume(t, m).GasLimit)
+		require.NoError(t, (<-resCh).Err)
+	}
+}
+
+// TestBufferedTxManagerMulticall asserts that the aggregatable requests queued within the multicall
+// window are sent in a single Multicall3 tx, and that the other requests are sent on their own.
+func TestBufferedTxManagerMulticall(t *testing.T) {
+	t.Parallel()
+
+	multicallAddr := common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+	cfg := configWithNumConfs(1)
+	cfg.NetworkTimeout = time.Second
+	cfg.TxBufferSize = 10
+	cfg.MulticallAddress = multicallAddr
+	cfg.MulticallWindow = 200 * time.Millisecond
+	h := newTestHarnessWithConfig(t, cfg)
+	h.backend.receiptStatus = types.ReceiptStatusSuccessful
+
+	var mu sync.Mutex
+	var published []*types.Transaction
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		mu.Lock()
+		published = append(published, tx)
+		mu.Unlock()
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+
+	m := &BufferedTxManager{SimpleTxManager: *h.mgr}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, m.Start(ctx))
+	defer m.Stop()
+
+	send := func(to common.Address, aggregatable bool) <-chan *TxResponse {
+		resCh := make(chan *TxResponse, 1)
+		go func() {
+			resCh <- m.SendTxCandidate(ctx, &TxCandidate{
+				To:           &to,
+				TxData:       to.Bytes(),
+				GasLimit:     100_000,
+				Aggregatable: aggregatable,
+			})
+		}()
+		return resCh
+	}
+	var aggregated []<-chan *TxResponse
+	for i := 1; i <= 3; i++ {
+		aggregated = append(aggregated, send(common.BigToAddress(big.NewInt(int64(i))), true))
+	}
+	single := send(common.BigToAddress(big.NewInt(4)), false)
+
+	var receipt *types.Receipt
+	for _, resCh := range aggregated {
+		res := <-resCh
+		require.NoError(t, res.Err)
+		if receipt == nil {
+			receipt = res.Receipt
+		}
+		require.Equal(t, receipt.TxHash, res.Receipt.TxHash)
+	}
+	res := <-single
+	require.NoError(t, res.Err)
+	require.NotEqual(t, receipt.TxHash, res.Receipt.TxHash)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, published, 2)
+	for _, tx := range published {
+		if tx.Hash() != receipt.TxHash {
+			continue
+		}
+		require.Equal(t, multicallAddr, *tx.To())
+		require.Equal(t, uint64(300_000), tx.Gas())
+		method := multicall3.Methods["aggregate3Value"]
+		args, err := method.Inputs.Unpack(tx.Data()[4:])
+		require.NoError(t, err)
+		calls := args[0].([]struct {
+			Target       common.Address `json:"target"`
+			AllowFailure bool           `json:"allowFailure"`
+			Value        *big.Int       `json:"value"`
+			CallData     []byte         `json:"callData"`
+		})
+		var targets []common.Address
+		for _, call := range calls {
+			targets = append(targets, call.Target)
+			require.False(t, call.AllowFailure)
+		}
+		require.ElementsMatch(t, []common.Address{
+			common.BigToAddress(big.NewInt(1)),
+			common.BigToAddress(big.NewInt(2)),
+			common.BigToAddress(big.NewInt(3)),
+		}, targets)
+	}
+}
+
+// newDrainingBufferedTxManager starts a BufferedTxManager whose txs are mined once mine is closed.
+func newDrainingBufferedTxManager(t *testing.T, drainTimeout time.Duration, statePath string) (*BufferedTxManager, chan struct{}) {
+	cfg := configWithNumConfs(1)
+	cfg.NetworkTimeout = time.Second
+	cfg.TxBufferSize = 10
+	cfg.DrainTimeout = drainTimeout
+	cfg.StatePath = statePath
+	h := newTestHarnessWithConfig(t, cfg)
+	h.backend.receiptStatus = types.ReceiptStatusSuccessful
+
+	mine := make(chan struct{})
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		go func() {
+			<-mine
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap())
+		}()
+		return nil
+	})
+
+	m := &BufferedTxManager{SimpleTxManager: *h.mgr}
+	require.NoError(t, m.Start(context.Background()))
+	return m, mine
+}
+
+// TestBufferedTxManagerDrain asserts that a stopped buffered tx manager rejects the new requests,
+// and sends the queued ones before returning.
+func TestBufferedTxManagerDrain(t *testing.T) {
+	t.Parallel()
+
+	m, mine := newDrainingBufferedTxManager(t, 10*time.Second, "")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var resChs []<-chan *TxResponse
+	for i := 0; i < 3; i++ {
+		resChs = append(resChs, sendAsync(ctx, m))
+	}
+	requireQueueDepth(t, m, 2)
+
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- m.Stop()
+	}()
+	require.Eventually(t, func() bool {
+		return errors.Is(m.SendTxCandidate(ctx, &TxCandidate{}).Err, ErrTxManagerStopped)
+	}, time.Second, 5*time.Millisecond)
+
+	close(mine)
+	for _, resCh := range resChs {
+		require.NoError(t, (<-resCh).Err)
+	}
+	require.NoError(t, <-stopped)
+}
+
+// TestBufferedTxManagerDrainTimeout asserts that the requests still queued at the end of the drain
+// get ErrTxManagerStopped and are recorded to the unsent file.
+func TestBufferedTxManagerDrainTimeout(t *testing.T) {
+	t.Parallel()
+
+	statePath := filepath.Join(t.TempDir(), "txs.json")
+	m, _ := newDrainingBufferedTxManager(t, 100*time.Millisecond, statePath)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	inFlight := sendAsync(ctx, m)
+	requireQueueDepth(t, m, 0)
+	to := common.Address{1}
+	resCh := make(chan *TxResponse, 1)
+	go func() {
+		resCh <- m.SendWithPriority(ctx, &TxCandidate{To: &to, TxData: []byte{2}, GasLimit: 100_000}, TxPriorityHigh)
+	}()
+	requireQueueDepth(t, m, 1)
+
+	require.NoError(t, m.Stop())
+	require.ErrorIs(t, (<-inFlight).Err, context.Canceled)
+	require.ErrorIs(t, (<-resCh).Err, ErrTxManagerStopped)
+
+	data, err := os.ReadFile(statePath + unsentSuffix)
+	require.NoError(t, err)
+	var unsent []UnsentTxRequest
+	require.NoError(t, json.Unmarshal(data, &unsent))
+	require.Equal(t, []UnsentTxRequest{{
+		Priority: TxPriorityHigh,
+		To:       &to,
+		Data:     []byte{2},
+		GasLimit: 100_000,
+	}}, unsent)
+}
@@ -0,0 +1,154 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/utils/service/clock"
+	"github.com/kroma-network/kroma/utils/service/txmgr/metrics"
+)
+
+// ErrCircuitOpen is the error returned by Ready while the new sends are paused because the L1 backend
+// keeps failing.
+var ErrCircuitOpen = errors.New("L1 backend circuit breaker is open")
+
+// ErrSignerUnhealthy is returned by Ready while the remote signer is unreachable.
+var ErrSignerUnhealthy = errors.New("remote signer is unreachable")
+
+// circuitBreaker pauses the new sends once the L1 backend failed threshold times in a row, so that
+// a failing endpoint is not hammered and the buffered requests are not drained into failures.
+// While open, the waiting sends probe the backend every probeInterval, and any successful call
+// closes the circuit again. Only the endpoint errors count, see isEndpointError.
+type circuitBreaker struct {
+	threshold     uint64
+	probeInterval time.Duration
+	clock         clock.Clock
+	metr          metrics.TxMetricer
+	l             log.Logger
+
+	mu        sync.Mutex
+	failures  uint64
+	lastProbe time.Time
+	// closed is closed once the circuit closes again. It is nil while the circuit is closed.
+	closed chan struct{}
+}
+
+func newCircuitBreaker(threshold uint64, probeInterval time.Duration, clock clock.Clock, metr metrics.TxMetricer, l log.Logger) *circuitBreaker {
+	return &circuitBreaker{
+		threshold:     threshold,
+		probeInterval: probeInterval,
+		clock:         clock,
+		metr:          metr,
+		l:             l,
+	}
+}
+
+// record records the result of a call to the backend.
+func (b *circuitBreaker) record(err error) {
+	if err != nil && !isEndpointError(err) {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		b.failures = 0
+		if b.closed != nil {
+			b.l.Info("L1 backend recovered, resuming the sends")
+			close(b.closed)
+			b.closed = nil
+			b.metr.RecordCircuitOpen(false)
+		}
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold && b.closed == nil {
+		b.l.Error("L1 backend keeps failing, pausing the sends", "failures", b.failures, "err", err)
+		b.closed = make(chan struct{})
+		// The first probe is due after probeInterval.
+		b.lastProbe = b.clock.Now()
+		b.metr.RecordCircuitOpen(true)
+	}
+}
+
+// isOpen returns whether the new sends are paused.
+func (b *circuitBreaker) isOpen() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.closed != nil
+}
+
+// wait waits until the circuit is closed or ctx is done. While waiting, it probes the backend
+// with probe every probeInterval; the concurrent waiters share the probes.
+func (b *circuitBreaker) wait(ctx context.Context, probe func(ctx context.Context) error) error {
+	for {
+		b.mu.Lock()
+		closed := b.closed
+		b.mu.Unlock()
+		if closed == nil {
+			return nil
+		}
+
+		select {
+		case <-closed:
+			return nil
+		case <-b.clock.After(b.probeInterval):
+			b.probe(ctx, probe)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// probe calls probe unless another waiter did within probeInterval, and records the result.
+func (b *circuitBreaker) probe(ctx context.Context, probe func(ctx context.Context) error) {
+	b.mu.Lock()
+	if b.clock.Now().Sub(b.lastProbe) < b.probeInterval {
+		b.mu.Unlock()
+		return
+	}
+	b.lastProbe = b.clock.Now()
+	b.mu.Unlock()
+
+	err := probe(ctx)
+	if ctx.Err() == nil {
+		b.record(err)
+	}
+}
+
+// recordBackend records the result of a call to the backend to the circuit breaker, if any.
+// The failures caused by the cancellation of ctx are not the backend's fault, so they are ignored.
+func (m *SimpleTxManager) recordBackend(ctx context.Context, err error) {
+	if m.circuit != nil && ctx.Err() == nil {
+		m.circuit.record(err)
+	}
+}
+
+// waitCircuit waits until the circuit breaker, if any, lets the new sends through.
+func (m *SimpleTxManager) waitCircuit(ctx context.Context) error {
+	if m.circuit == nil {
+		return nil
+	}
+	return m.circuit.wait(ctx, func(ctx context.Context) error {
+		cCtx, cancel := context.WithTimeout(ctx, m.NetworkTimeout)
+		defer cancel()
+		_, err := m.backend.BlockNumber(cCtx)
+		return err
+	})
+}
+
+// Ready returns ErrCircuitOpen while the new sends are paused because the L1 backend keeps failing,
+// ErrSignerUnhealthy while the remote signer is unreachable, and nil otherwise. It can back the
+// readiness check of the health endpoint of the service.
+func (m *SimpleTxManager) Ready() error {
+	if m.circuit != nil && m.circuit.isOpen() {
+		return ErrCircuitOpen
+	}
+	if m.SignerHealthy != nil && !m.SignerHealthy() {
+		return ErrSignerUnhealthy
+	}
+	return nil
+}
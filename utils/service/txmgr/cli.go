@@ -5,36 +5,106 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"math/rand"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/urfave/cli"
 
 	kservice "github.com/kroma-network/kroma/utils/service"
+	"github.com/kroma-network/kroma/utils/service/backoff"
+	"github.com/kroma-network/kroma/utils/service/clock"
 	kcrypto "github.com/kroma-network/kroma/utils/service/crypto"
 	"github.com/kroma-network/kroma/utils/signer/client"
+	"github.com/kroma-network/kroma/utils/signer/kms"
 )
 
 const (
 	// Duplicated L1 RPC flag
 	L1RPCFlagName = "l1-eth-rpc"
-	// Key Management Flags (also have signer client flags)
-	MnemonicFlagName   = "mnemonic"
-	HDPathFlagName     = "hd-path"
-	PrivateKeyFlagName = "private-key"
+	// Key Management Flags (also have signer client and kms flags)
+	MnemonicFlagName             = "mnemonic"
+	MnemonicPassphraseFlagName   = "mnemonic-passphrase"
+	MnemonicWordlistFlagName     = "mnemonic-wordlist"
+	HDPathFlagName               = "hd-path"
+	PrivateKeyFlagName           = "private-key"
+	KeystorePathFlagName         = "keystore-path"
+	KeystorePasswordFileFlagName = "keystore-password-file"
+	StandbyPrivateKeysFlagName   = "standby-private-keys"
+	StandbyKeystorePathsFlagName = "standby-keystore-paths"
 	// TxMgr Flags (new + legacy + some shared flags)
-	NumConfirmationsFlagName          = "num-confirmations"
-	SafeAbortNonceTooLowCountFlagName = "safe-abort-nonce-too-low-count"
-	ResubmissionTimeoutFlagName       = "resubmission-timeout"
-	NetworkTimeoutFlagName            = "network-timeout"
-	TxSendTimeoutFlagName             = "txmgr.send-timeout"
-	TxNotInMempoolTimeoutFlagName     = "txmgr.not-in-mempool-timeout"
-	ReceiptQueryIntervalFlagName      = "txmgr.receipt-query-interval"
-	BufferSizeFlagName                = "txmgr.buffer-size"
+	NumConfirmationsFlagName            = "txmgr.num-confirmations"
+	ConfirmationModeFlagName            = "txmgr.confirmation-mode"
+	SafeAbortNonceTooLowCountFlagName   = "txmgr.safe-abort-nonce-too-low-count"
+	ResubmissionTimeoutFlagName         = "txmgr.resubmission-timeout"
+	ResubmissionTimeoutJitterFlagName   = "txmgr.resubmission-timeout-jitter"
+	NetworkTimeoutFlagName              = "txmgr.network-timeout"
+	TxSendTimeoutFlagName               = "txmgr.send-timeout"
+	TxNotInMempoolTimeoutFlagName       = "txmgr.not-in-mempool-timeout"
+	StuckTxBumpsFlagName                = "txmgr.stuck-tx-bumps"
+	StuckTxDurationFlagName             = "txmgr.stuck-tx-duration"
+	ReorgWatchDepthFlagName             = "txmgr.reorg-watch-depth"
+	ReceiptQueryIntervalFlagName        = "txmgr.receipt-query-interval"
+	BufferSizeFlagName                  = "txmgr.buffer-size"
+	GasLimitBufferPercentFlagName       = "txmgr.gas-limit-buffer-percent"
+	BufferPolicyFlagName                = "txmgr.buffer-policy"
+	DrainTimeoutFlagName                = "txmgr.drain-timeout"
+	MaxPendingTxsFlagName               = "txmgr.max-pending-txs"
+	NetworkRetryInitialFlagName         = "txmgr.network-retry-initial"
+	NetworkRetryMaxFlagName             = "txmgr.network-retry-max"
+	NetworkRetryAttemptsFlagName        = "txmgr.network-retry-attempts"
+	DryRunFlagName                      = "txmgr.dry-run"
+	FeeBumpPercentFlagName              = "txmgr.fee-bump-percent"
+	FeeLimitMultiplierFlagName          = "txmgr.fee-limit-multiplier"
+	StatePathFlagName                   = "txmgr.state-path"
+	EnableAdminFlagName                 = "txmgr.enable-admin"
+	BatchReceiptsFlagName               = "txmgr.batch-receipts"
+	MaxGasPriceFlagName                 = "txmgr.max-gas-price"
+	DailyFeeBudgetFlagName              = "txmgr.daily-fee-budget-eth"
+	GasPriceStrategyFlagName            = "txmgr.gas-price-strategy"
+	GasPricePercentileFlagName          = "txmgr.gas-price-percentile"
+	FixedGasTipCapFlagName              = "txmgr.fixed-gas-tip-cap"
+	GasOracleURLFlagName                = "txmgr.gas-oracle-url"
+	SimulateBeforeSendFlagName          = "txmgr.simulate-before-send"
+	CreateAccessListFlagName            = "txmgr.create-access-list"
+	LegacyTxsFlagName                   = "txmgr.legacy-txs"
+	PrivateTxRPCFlagName                = "txmgr.private-tx-rpc"
+	PrivateTxFallbackDelayFlagName      = "txmgr.private-tx-fallback-delay"
+	RebroadcastIntervalFlagName         = "txmgr.rebroadcast-interval"
+	MulticallAddressFlagName            = "txmgr.multicall-address"
+	MulticallWindowFlagName             = "txmgr.multicall-window"
+	L1ChainIDFlagName                   = "txmgr.l1-chain-id"
+	MaxSendsPerSecondFlagName           = "txmgr.max-sends-per-second"
+	CircuitBreakerThresholdFlagName     = "txmgr.circuit-breaker-threshold"
+	CircuitBreakerProbeIntervalFlagName = "txmgr.circuit-breaker-probe-interval"
+	// Deprecated legacy TxMgr Flags
+	LegacyNumConfirmationsFlagName          = "num-confirmations"
+	LegacySafeAbortNonceTooLowCountFlagName = "safe-abort-nonce-too-low-count"
+	LegacyResubmissionTimeoutFlagName       = "resubmission-timeout"
+	LegacyNetworkTimeoutFlagName            = "network-timeout"
 )
 
+// deprecatedFlags lists the deprecated legacy flags with their canonical replacements.
+// The deprecated flags are still honored, unless the replacements are set as well.
+var deprecatedFlags = []struct {
+	deprecated string
+	canonical  string
+}{
+	{LegacyNumConfirmationsFlagName, NumConfirmationsFlagName},
+	{LegacySafeAbortNonceTooLowCountFlagName, SafeAbortNonceTooLowCountFlagName},
+	{LegacyResubmissionTimeoutFlagName, ResubmissionTimeoutFlagName},
+	{LegacyNetworkTimeoutFlagName, NetworkTimeoutFlagName},
+}
+
+func deprecatedUsage(canonical string) string {
+	return fmt.Sprintf("Deprecated: use --%s instead", canonical)
+}
+
 func CLIFlags(envPrefix string) []cli.Flag {
 	return append([]cli.Flag{
 		cli.StringFlag{
@@ -42,9 +112,20 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			Usage:  "The mnemonic used to derive the wallets for either the service",
 			EnvVar: kservice.PrefixEnvVar(envPrefix, "MNEMONIC"),
 		},
+		cli.StringFlag{
+			Name:   MnemonicPassphraseFlagName,
+			Usage:  "The BIP-39 passphrase hardening the seed of the mnemonic. The mnemonic flag must also be set.",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "MNEMONIC_PASSPHRASE"),
+		},
+		cli.StringFlag{
+			Name:   MnemonicWordlistFlagName,
+			Usage:  "The BIP-39 wordlist of the mnemonic: " + strings.Join(kcrypto.MnemonicWordlists(), ", "),
+			Value:  kcrypto.DefaultMnemonicWordlist,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "MNEMONIC_WORDLIST"),
+		},
 		cli.StringFlag{
 			Name:   HDPathFlagName,
-			Usage:  "The HD path used to derive the wallet from the mnemonic. The mnemonic flag must also be set.",
+			Usage:  "The HD path used to derive the wallet from the mnemonic, or of the key of the hardware wallet signer backend. The mnemonic flag or a hardware wallet signer backend must also be set.",
 			EnvVar: kservice.PrefixEnvVar(envPrefix, "HD_PATH"),
 		},
 		cli.StringFlag{
@@ -52,30 +133,104 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			Usage:  "The private key to use with the service. Must not be used with mnemonic.",
 			EnvVar: kservice.PrefixEnvVar(envPrefix, "PRIVATE_KEY"),
 		},
+		cli.StringFlag{
+			Name:   KeystorePathFlagName,
+			Usage:  "Path of the encrypted web3 keystore JSON file of the key to use with the service. Must not be used with mnemonic or private key.",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "KEYSTORE_PATH"),
+		},
+		cli.StringFlag{
+			Name:   KeystorePasswordFileFlagName,
+			Usage:  "Path of the file holding the password of the keystore files. The keystore path or standby keystore paths flag must also be set.",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "KEYSTORE_PASSWORD_FILE"),
+		},
+		cli.StringFlag{
+			Name:   StandbyPrivateKeysFlagName,
+			Usage:  "Comma-separated private keys of the standby accounts, which the txs can be switched to by the txmgr_switchAccount admin API, e.g. to rotate a compromised key",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "STANDBY_PRIVATE_KEYS"),
+		},
+		cli.StringFlag{
+			Name:   StandbyKeystorePathsFlagName,
+			Usage:  "Comma-separated paths of the keystore files of the standby accounts, decrypted with the keystore password file. They come before the standby private keys in the order of the standby accounts.",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "STANDBY_KEYSTORE_PATHS"),
+		},
 		cli.Uint64Flag{
 			Name:   NumConfirmationsFlagName,
 			Usage:  "Number of confirmations which we will wait after sending a transaction",
 			Value:  10,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_NUM_CONFIRMATIONS"),
+		},
+		cli.Uint64Flag{
+			Name:   LegacyNumConfirmationsFlagName,
+			Usage:  deprecatedUsage(NumConfirmationsFlagName),
+			Value:  10,
 			EnvVar: kservice.PrefixEnvVar(envPrefix, "NUM_CONFIRMATIONS"),
 		},
+		cli.StringFlag{
+			Name:   ConfirmationModeFlagName,
+			Usage:  "How a mined transaction is confirmed: blocks, once it has num-confirmations blocks, or safe or finalized, once its block is tagged so by the L1 node",
+			Value:  string(ConfirmationModeBlocks),
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_CONFIRMATION_MODE"),
+		},
 		cli.Uint64Flag{
 			Name:   SafeAbortNonceTooLowCountFlagName,
 			Usage:  "Number of ErrNonceTooLow observations required to give up on a tx at a particular nonce without receiving confirmation",
 			Value:  3,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_SAFE_ABORT_NONCE_TOO_LOW_COUNT"),
+		},
+		cli.Uint64Flag{
+			Name:   LegacySafeAbortNonceTooLowCountFlagName,
+			Usage:  deprecatedUsage(SafeAbortNonceTooLowCountFlagName),
+			Value:  3,
 			EnvVar: kservice.PrefixEnvVar(envPrefix, "SAFE_ABORT_NONCE_TOO_LOW_COUNT"),
 		},
 		cli.DurationFlag{
 			Name:   ResubmissionTimeoutFlagName,
 			Usage:  "Duration we will wait before resubmitting a transaction to L1",
 			Value:  48 * time.Second,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_RESUBMISSION_TIMEOUT"),
+		},
+		cli.DurationFlag{
+			Name:   LegacyResubmissionTimeoutFlagName,
+			Usage:  deprecatedUsage(ResubmissionTimeoutFlagName),
+			Value:  48 * time.Second,
 			EnvVar: kservice.PrefixEnvVar(envPrefix, "RESUBMISSION_TIMEOUT"),
 		},
+		cli.Float64Flag{
+			Name:   ResubmissionTimeoutJitterFlagName,
+			Usage:  "Fraction (0-1) by which each resubmission timeout is randomized. If 0 it is disabled.",
+			Value:  0,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_RESUBMISSION_TIMEOUT_JITTER"),
+		},
 		cli.DurationFlag{
 			Name:   NetworkTimeoutFlagName,
 			Usage:  "Timeout for all network operations",
 			Value:  2 * time.Second,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_NETWORK_TIMEOUT"),
+		},
+		cli.DurationFlag{
+			Name:   LegacyNetworkTimeoutFlagName,
+			Usage:  deprecatedUsage(NetworkTimeoutFlagName),
+			Value:  2 * time.Second,
 			EnvVar: kservice.PrefixEnvVar(envPrefix, "NETWORK_TIMEOUT"),
 		},
+		cli.DurationFlag{
+			Name:   NetworkRetryInitialFlagName,
+			Usage:  "Upper bound of the backoff before the first retry of a failed network operation. The backoff is randomized with full jitter.",
+			Value:  500 * time.Millisecond,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_NETWORK_RETRY_INITIAL"),
+		},
+		cli.DurationFlag{
+			Name:   NetworkRetryMaxFlagName,
+			Usage:  "Maximum backoff between the retries of a failed network operation",
+			Value:  10 * time.Second,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_NETWORK_RETRY_MAX"),
+		},
+		cli.IntFlag{
+			Name:   NetworkRetryAttemptsFlagName,
+			Usage:  "Number of attempts of a network operation before giving up. If 0 or 1, failed network operations are not retried.",
+			Value:  3,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_NETWORK_RETRY_ATTEMPTS"),
+		},
 		cli.DurationFlag{
 			Name:   TxSendTimeoutFlagName,
 			Usage:  "Timeout for sending transactions. If 0 it is disabled.",
@@ -88,9 +243,31 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			Value:  2 * time.Minute,
 			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_TX_NOT_IN_MEMPOOL_TIMEOUT"),
 		},
+		cli.BoolFlag{
+			Name:   BatchReceiptsFlagName,
+			Usage:  "Query the receipts of the pending transactions in a single JSON-RPC batch per poll interval rather than one request per transaction",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_BATCH_RECEIPTS"),
+		},
+		cli.Uint64Flag{
+			Name:   StuckTxBumpsFlagName,
+			Usage:  "Number of fee bumps after which a transaction is reported as stuck. If 0, the bumps are not watched.",
+			Value:  10,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_STUCK_TX_BUMPS"),
+		},
+		cli.DurationFlag{
+			Name:   StuckTxDurationFlagName,
+			Usage:  "Duration after which a transaction still pending is reported as stuck. If 0, the duration is not watched.",
+			Value:  10 * time.Minute,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_STUCK_TX_DURATION"),
+		},
+		cli.Uint64Flag{
+			Name:   ReorgWatchDepthFlagName,
+			Usage:  "Number of confirmations up to which the block of a confirmed transaction is watched for reorgs. If 0, the confirmed transactions are not watched.",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_REORG_WATCH_DEPTH"),
+		},
 		cli.DurationFlag{
 			Name:   ReceiptQueryIntervalFlagName,
-			Usage:  "Frequency to poll for receipts",
+			Usage:  "Frequency to poll for receipts. If the L1 RPC is a websocket endpoint, the receipts are checked on each new head instead",
 			Value:  12 * time.Second,
 			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_RECEIPT_QUERY_INTERVAL"),
 		},
@@ -100,109 +277,594 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			Value:  10,
 			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_BUFFER_SIZE"),
 		},
-	}, client.CLIFlags(envPrefix)...)
+		cli.Uint64Flag{
+			Name:   MaxPendingTxsFlagName,
+			Usage:  "Maximum number of transactions in flight at once, each at its own nonce and resubmitted independently. If 1, the transactions are sent one at a time.",
+			Value:  1,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_MAX_PENDING_TXS"),
+		},
+		cli.StringFlag{
+			Name:   BufferPolicyFlagName,
+			Usage:  "Policy of the buffered txmgr when the tx buffer is full: block, drop-oldest or reject-new",
+			Value:  string(BufferPolicyRejectNew),
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_BUFFER_POLICY"),
+		},
+		cli.DurationFlag{
+			Name:   DrainTimeoutFlagName,
+			Usage:  "Time the buffered txmgr keeps sending the queued transaction requests on shutdown. The requests still queued afterwards are recorded next to the state path, if set.",
+			Value:  30 * time.Second,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_DRAIN_TIMEOUT"),
+		},
+		cli.Uint64Flag{
+			Name:   GasLimitBufferPercentFlagName,
+			Usage:  "Percentage added on top of the estimated gas limit of the transactions without an explicit gas limit",
+			Value:  0,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_GAS_LIMIT_BUFFER_PERCENT"),
+		},
+		cli.Uint64Flag{
+			Name:   FeeBumpPercentFlagName,
+			Usage:  "Minimum percentage by which the tip and fee caps are bumped on resubmission. Must be at least 10, as required by geth to replace a tx.",
+			Value:  DefaultFeeBumpPercent,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_FEE_BUMP_PERCENT"),
+		},
+		cli.Uint64Flag{
+			Name:   FeeLimitMultiplierFlagName,
+			Usage:  "Maximum multiple of the suggested tip and fee caps that a bumped tx may pay. If 0 it is disabled.",
+			Value:  5,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_FEE_LIMIT_MULTIPLIER"),
+		},
+		cli.Float64Flag{
+			Name:   MaxGasPriceFlagName,
+			Usage:  "Maximum L1 gas price in gwei. The transactions are held while the basefee plus the tip exceeds it, and their fee caps never exceed it. If 0 it is disabled.",
+			Value:  0,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_MAX_GAS_PRICE"),
+		},
+		cli.Float64Flag{
+			Name:   DailyFeeBudgetFlagName,
+			Usage:  "Maximum L1 fee in ETH that the confirmed transactions may spend within the last 24 hours. The new transactions are refused once it is spent. If 0 it is disabled.",
+			Value:  0,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_DAILY_FEE_BUDGET_ETH"),
+		},
+		cli.StringFlag{
+			Name:   GasPriceStrategyFlagName,
+			Usage:  "Strategy suggesting the tip cap of the transactions: node (eth_maxPriorityFeePerGas), fee-history (percentile of the tips of the latest blocks), fixed or oracle",
+			Value:  string(GasPriceStrategyNode),
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_GAS_PRICE_STRATEGY"),
+		},
+		cli.Float64Flag{
+			Name:   GasPricePercentileFlagName,
+			Usage:  "Percentile of the tips paid in the latest blocks suggested by the fee-history gas price strategy",
+			Value:  50,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_GAS_PRICE_PERCENTILE"),
+		},
+		cli.Float64Flag{
+			Name:   FixedGasTipCapFlagName,
+			Usage:  "Tip cap in gwei suggested by the fixed gas price strategy",
+			Value:  0,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_FIXED_GAS_TIP_CAP"),
+		},
+		cli.StringFlag{
+			Name:   GasOracleURLFlagName,
+			Usage:  "URL of the gas oracle queried by the oracle gas price strategy. It must answer a JSON object whose maxPriorityFeePerGas field is the tip cap in gwei.",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_GAS_ORACLE_URL"),
+		},
+		cli.BoolFlag{
+			Name:   DryRunFlagName,
+			Usage:  "Build and sign the transactions without publishing them to L1, to verify the key, address and chain ID wiring",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_DRY_RUN"),
+		},
+		cli.BoolFlag{
+			Name:   SimulateBeforeSendFlagName,
+			Usage:  "Call the transactions against the pending block before publishing them, and return the revert to the caller instead of publishing a transaction that would revert",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_SIMULATE_BEFORE_SEND"),
+		},
+		cli.BoolFlag{
+			Name:   CreateAccessListFlagName,
+			Usage:  "Attach the access list returned by eth_createAccessList to the transactions without an access list, to reduce the gas of the repeated calls into the same contracts",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_CREATE_ACCESS_LIST"),
+		},
+		cli.BoolFlag{
+			Name:   LegacyTxsFlagName,
+			Usage:  "Send legacy transactions priced by gasPrice instead of EIP-1559 transactions. It is enabled automatically if the L1 blocks have no basefee.",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_LEGACY_TXS"),
+		},
+		cli.StringFlag{
+			Name:   PrivateTxRPCFlagName,
+			Usage:  "RPC URL of a private tx relay, like an MEV-protect endpoint, to publish the transactions to instead of the public mempool. If empty, the transactions are published publicly.",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_PRIVATE_TX_RPC"),
+		},
+		cli.DurationFlag{
+			Name:   PrivateTxFallbackDelayFlagName,
+			Usage:  "Delay after which a transaction not yet mined through the private tx relay is published to the public mempool. If 0, it never falls back.",
+			Value:  2 * time.Minute,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_PRIVATE_TX_FALLBACK_DELAY"),
+		},
+		cli.DurationFlag{
+			Name:   RebroadcastIntervalFlagName,
+			Usage:  "Interval at which the pending transaction is republished without a fee bump to every L1 RPC endpoint, in case it was evicted from the mempool or not propagated. If 0, it is only republished on the fee bumps.",
+			Value:  0,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_REBROADCAST_INTERVAL"),
+		},
+		cli.StringFlag{
+			Name:   MulticallAddressFlagName,
+			Usage:  "Address of the Multicall3 contract that the aggregatable transactions requested within the multicall window are aggregated into. If empty, the transactions are not aggregated. Only used by the buffered txmgr.",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_MULTICALL_ADDRESS"),
+		},
+		cli.DurationFlag{
+			Name:   MulticallWindowFlagName,
+			Usage:  "Time window during which the aggregatable transaction requests are collected into a single Multicall3 transaction",
+			Value:  2 * time.Second,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_MULTICALL_WINDOW"),
+		},
+		cli.Uint64Flag{
+			Name:   L1ChainIDFlagName,
+			Usage:  "Chain ID of L1. If set, the L1 RPC endpoints are not dialed until the first use, so that the service starts while they are unavailable. If 0, it is fetched from L1 at startup.",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_L1_CHAIN_ID"),
+		},
+		cli.Float64Flag{
+			Name:   MaxSendsPerSecondFlagName,
+			Usage:  "Maximum number of requests per second sent to each L1 RPC endpoint, with bursts of up to one second of requests. The requests above it wait for their turn. If 0 it is disabled.",
+			Value:  0,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_MAX_SENDS_PER_SECOND"),
+		},
+		cli.Uint64Flag{
+			Name:   CircuitBreakerThresholdFlagName,
+			Usage:  "Number of consecutive L1 RPC failures after which the new transactions are paused until the L1 RPC recovers. If 0, the new transactions are never paused.",
+			Value:  0,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_CIRCUIT_BREAKER_THRESHOLD"),
+		},
+		cli.DurationFlag{
+			Name:   CircuitBreakerProbeIntervalFlagName,
+			Usage:  "Interval at which the L1 RPC is probed while the new transactions are paused",
+			Value:  10 * time.Second,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_CIRCUIT_BREAKER_PROBE_INTERVAL"),
+		},
+		cli.StringFlag{
+			Name:   StatePathFlagName,
+			Usage:  "Path of the file recording the signed but unconfirmed transactions, which are resumed after a restart. If empty, they are not persisted.",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_STATE_PATH"),
+		},
+		cli.BoolFlag{
+			Name:   EnableAdminFlagName,
+			Usage:  "Serve the txmgr admin API (txmgr_pending, txmgr_bump, txmgr_drop, txmgr_switchAccount) on the RPC server, to inspect, bump and drop the transactions in flight and switch to a standby account",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "TXMGR_ENABLE_ADMIN"),
+		},
+	}, append(client.CLIFlags(envPrefix), kms.CLIFlags(envPrefix)...)...)
 }
 
 type CLIConfig struct {
-	L1RPCURL                  string
-	Mnemonic                  string
-	HDPath                    string
-	PrivateKey                string
-	SignerCLIConfig           client.CLIConfig
-	NumConfirmations          uint64
-	SafeAbortNonceTooLowCount uint64
-	TxBufferSize              uint64
-	ResubmissionTimeout       time.Duration
-	ReceiptQueryInterval      time.Duration
-	NetworkTimeout            time.Duration
-	TxSendTimeout             time.Duration
-	TxNotInMempoolTimeout     time.Duration
+	L1RPCURL                    string
+	Mnemonic                    string
+	MnemonicPassphrase          string
+	MnemonicWordlist            string
+	HDPath                      string
+	PrivateKey                  string
+	KeystorePath                string
+	KeystorePasswordFile        string
+	StandbyPrivateKeys          []string
+	StandbyKeystorePaths        []string
+	SignerCLIConfig             client.CLIConfig
+	KMSConfig                   kms.CLIConfig
+	NumConfirmations            uint64
+	ConfirmationMode            ConfirmationMode
+	SafeAbortNonceTooLowCount   uint64
+	TxBufferSize                uint64
+	BufferPolicy                BufferPolicy
+	DrainTimeout                time.Duration
+	MaxPendingTxs               uint64
+	GasLimitBufferPercent       uint64
+	FeeBumpPercent              uint64
+	FeeLimitMultiplier          uint64
+	MaxGasPriceGwei             float64
+	DailyFeeBudgetEth           float64
+	GasPriceStrategy            GasPriceStrategy
+	GasPricePercentile          float64
+	FixedGasTipCapGwei          float64
+	GasOracleURL                string
+	ResubmissionTimeout         time.Duration
+	ResubmissionTimeoutJitter   float64
+	ReceiptQueryInterval        time.Duration
+	BatchReceipts               bool
+	NetworkTimeout              time.Duration
+	NetworkRetryInitial         time.Duration
+	NetworkRetryMax             time.Duration
+	NetworkRetryAttempts        int
+	TxSendTimeout               time.Duration
+	TxNotInMempoolTimeout       time.Duration
+	StuckTxBumps                uint64
+	StuckTxDuration             time.Duration
+	ReorgWatchDepth             uint64
+	DryRun                      bool
+	SimulateBeforeSend          bool
+	CreateAccessList            bool
+	LegacyTxs                   bool
+	PrivateTxRPCURL             string
+	PrivateTxFallbackDelay      time.Duration
+	RebroadcastInterval         time.Duration
+	MulticallAddress            string
+	MulticallWindow             time.Duration
+	L1ChainID                   uint64
+	MaxSendsPerSecond           float64
+	CircuitBreakerThreshold     uint64
+	CircuitBreakerProbeInterval time.Duration
+	StatePath                   string
+	EnableAdmin                 bool
+	// ContractLabels are the metric labels of the known contracts the txs are sent to.
+	// It has no flag: it is set by the services, which know the addresses of their contracts.
+	ContractLabels map[common.Address]string
 }
 
 func (m CLIConfig) Check() error {
-	if m.L1RPCURL == "" {
+	if len(SplitL1RPCURLs(m.L1RPCURL)) == 0 {
 		return errors.New("must provide a L1 RPC url")
 	}
+	return m.checkSettings()
+}
+
+// checkSettings checks the config, except the L1 RPC URL, which is not used with an external backend.
+func (m CLIConfig) checkSettings() error {
 	if m.NumConfirmations == 0 {
 		return errors.New("NumConfirmations must not be 0")
 	}
 	if m.NetworkTimeout == 0 {
 		return errors.New("must provide NetworkTimeout")
 	}
+	if m.NetworkRetryAttempts < 0 {
+		return errors.New("NetworkRetryAttempts must not be negative")
+	}
+	if m.NetworkRetryMax < m.NetworkRetryInitial {
+		return errors.New("NetworkRetryMax must not be less than NetworkRetryInitial")
+	}
 	if m.ResubmissionTimeout == 0 {
 		return errors.New("must provide ResubmissionTimeout")
 	}
+	if m.FeeBumpPercent != 0 && m.FeeBumpPercent < minFeeBumpPercent {
+		return fmt.Errorf("FeeBumpPercent must be at least %d", minFeeBumpPercent)
+	}
+	if m.MaxGasPriceGwei < 0 {
+		return errors.New("MaxGasPriceGwei must not be negative")
+	}
+	if m.DailyFeeBudgetEth < 0 {
+		return errors.New("DailyFeeBudgetEth must not be negative")
+	}
+	if err := m.GasPriceStrategy.Check(); err != nil {
+		return err
+	}
+	if m.GasPriceStrategy == GasPriceStrategyFeeHistory && (m.GasPricePercentile < 0 || m.GasPricePercentile > 100) {
+		return errors.New("GasPricePercentile must be between 0 and 100")
+	}
+	if m.GasPriceStrategy == GasPriceStrategyFixed && m.FixedGasTipCapGwei < 0 {
+		return errors.New("FixedGasTipCapGwei must not be negative")
+	}
+	if m.GasPriceStrategy == GasPriceStrategyOracle && m.GasOracleURL == "" {
+		return errors.New("must provide GasOracleURL for the oracle gas price strategy")
+	}
+	if m.ResubmissionTimeoutJitter < 0 || m.ResubmissionTimeoutJitter > 1 {
+		return errors.New("ResubmissionTimeoutJitter must be between 0 and 1")
+	}
 	if m.ReceiptQueryInterval == 0 {
 		return errors.New("must provide ReceiptQueryInterval")
 	}
 	if m.TxNotInMempoolTimeout == 0 {
 		return errors.New("must provide TxNotInMempoolTimeout")
 	}
+	if err := m.ConfirmationMode.Check(); err != nil {
+		return err
+	}
+	if m.ReorgWatchDepth != 0 && m.ReorgWatchDepth <= m.NumConfirmations {
+		return errors.New("ReorgWatchDepth must be more than NumConfirmations")
+	}
+	if m.CircuitBreakerThreshold != 0 && m.CircuitBreakerProbeInterval == 0 {
+		return errors.New("must provide CircuitBreakerProbeInterval if CircuitBreakerThreshold is set")
+	}
+	if m.MulticallAddress != "" && !common.IsHexAddress(m.MulticallAddress) {
+		return fmt.Errorf("invalid MulticallAddress: %s", m.MulticallAddress)
+	}
+	if m.MaxSendsPerSecond < 0 {
+		return errors.New("MaxSendsPerSecond must not be negative")
+	}
 	if m.SafeAbortNonceTooLowCount == 0 {
 		return errors.New("SafeAbortNonceTooLowCount must not be 0")
 	}
+	if err := m.BufferPolicy.Check(); err != nil {
+		return err
+	}
+	if m.MnemonicPassphrase != "" && m.Mnemonic == "" {
+		return errors.New("the mnemonic passphrase requires a mnemonic")
+	}
+	if err := kcrypto.CheckMnemonicWordlist(m.MnemonicWordlist); err != nil {
+		return err
+	}
+	if (m.KeystorePath == "" && len(m.StandbyKeystorePaths) == 0) != (m.KeystorePasswordFile == "") {
+		return errors.New("keystore path and keystore password file must both be set or not set")
+	}
 	if err := m.SignerCLIConfig.Check(); err != nil {
 		return err
 	}
+	if m.SignerCLIConfig.Backend.IsHardware() && !m.LegacyTxs {
+		return fmt.Errorf("the %s signer backend only signs legacy txs, LegacyTxs must be set", m.SignerCLIConfig.Backend)
+	}
+	if err := m.KMSConfig.Check(); err != nil {
+		return err
+	}
 	return nil
 }
 
 func ReadCLIConfig(ctx *cli.Context) CLIConfig {
+	return readCLIConfig(ctx, log.Root())
+}
+
+func readCLIConfig(ctx *cli.Context, l log.Logger) CLIConfig {
+	names := migrateFlags(ctx, l)
 	return CLIConfig{
-		L1RPCURL:                  ctx.GlobalString(L1RPCFlagName),
-		Mnemonic:                  ctx.GlobalString(MnemonicFlagName),
-		HDPath:                    ctx.GlobalString(HDPathFlagName),
-		PrivateKey:                ctx.GlobalString(PrivateKeyFlagName),
-		SignerCLIConfig:           client.ReadCLIConfig(ctx),
-		NumConfirmations:          ctx.GlobalUint64(NumConfirmationsFlagName),
-		SafeAbortNonceTooLowCount: ctx.GlobalUint64(SafeAbortNonceTooLowCountFlagName),
-		ResubmissionTimeout:       ctx.GlobalDuration(ResubmissionTimeoutFlagName),
-		ReceiptQueryInterval:      ctx.GlobalDuration(ReceiptQueryIntervalFlagName),
-		NetworkTimeout:            ctx.GlobalDuration(NetworkTimeoutFlagName),
-		TxSendTimeout:             ctx.GlobalDuration(TxSendTimeoutFlagName),
-		TxNotInMempoolTimeout:     ctx.GlobalDuration(TxNotInMempoolTimeoutFlagName),
-		TxBufferSize:              ctx.GlobalUint64(BufferSizeFlagName),
+		L1RPCURL:                    ctx.GlobalString(L1RPCFlagName),
+		Mnemonic:                    ctx.GlobalString(MnemonicFlagName),
+		MnemonicPassphrase:          ctx.GlobalString(MnemonicPassphraseFlagName),
+		MnemonicWordlist:            ctx.GlobalString(MnemonicWordlistFlagName),
+		HDPath:                      ctx.GlobalString(HDPathFlagName),
+		PrivateKey:                  ctx.GlobalString(PrivateKeyFlagName),
+		KeystorePath:                ctx.GlobalString(KeystorePathFlagName),
+		KeystorePasswordFile:        ctx.GlobalString(KeystorePasswordFileFlagName),
+		StandbyPrivateKeys:          splitList(ctx.GlobalString(StandbyPrivateKeysFlagName)),
+		StandbyKeystorePaths:        splitList(ctx.GlobalString(StandbyKeystorePathsFlagName)),
+		SignerCLIConfig:             client.ReadCLIConfig(ctx),
+		KMSConfig:                   kms.ReadCLIConfig(ctx),
+		NumConfirmations:            ctx.GlobalUint64(names[NumConfirmationsFlagName]),
+		ConfirmationMode:            ConfirmationMode(ctx.GlobalString(ConfirmationModeFlagName)),
+		SafeAbortNonceTooLowCount:   ctx.GlobalUint64(names[SafeAbortNonceTooLowCountFlagName]),
+		ResubmissionTimeout:         ctx.GlobalDuration(names[ResubmissionTimeoutFlagName]),
+		ResubmissionTimeoutJitter:   ctx.GlobalFloat64(ResubmissionTimeoutJitterFlagName),
+		ReceiptQueryInterval:        ctx.GlobalDuration(ReceiptQueryIntervalFlagName),
+		BatchReceipts:               ctx.GlobalBool(BatchReceiptsFlagName),
+		NetworkTimeout:              ctx.GlobalDuration(names[NetworkTimeoutFlagName]),
+		NetworkRetryInitial:         ctx.GlobalDuration(NetworkRetryInitialFlagName),
+		NetworkRetryMax:             ctx.GlobalDuration(NetworkRetryMaxFlagName),
+		NetworkRetryAttempts:        ctx.GlobalInt(NetworkRetryAttemptsFlagName),
+		TxSendTimeout:               ctx.GlobalDuration(TxSendTimeoutFlagName),
+		TxNotInMempoolTimeout:       ctx.GlobalDuration(TxNotInMempoolTimeoutFlagName),
+		StuckTxBumps:                ctx.GlobalUint64(StuckTxBumpsFlagName),
+		StuckTxDuration:             ctx.GlobalDuration(StuckTxDurationFlagName),
+		ReorgWatchDepth:             ctx.GlobalUint64(ReorgWatchDepthFlagName),
+		TxBufferSize:                ctx.GlobalUint64(BufferSizeFlagName),
+		BufferPolicy:                BufferPolicy(ctx.GlobalString(BufferPolicyFlagName)),
+		DrainTimeout:                ctx.GlobalDuration(DrainTimeoutFlagName),
+		MaxPendingTxs:               ctx.GlobalUint64(MaxPendingTxsFlagName),
+		GasLimitBufferPercent:       ctx.GlobalUint64(GasLimitBufferPercentFlagName),
+		FeeBumpPercent:              ctx.GlobalUint64(FeeBumpPercentFlagName),
+		FeeLimitMultiplier:          ctx.GlobalUint64(FeeLimitMultiplierFlagName),
+		MaxGasPriceGwei:             ctx.GlobalFloat64(MaxGasPriceFlagName),
+		DailyFeeBudgetEth:           ctx.GlobalFloat64(DailyFeeBudgetFlagName),
+		GasPriceStrategy:            GasPriceStrategy(ctx.GlobalString(GasPriceStrategyFlagName)),
+		GasPricePercentile:          ctx.GlobalFloat64(GasPricePercentileFlagName),
+		FixedGasTipCapGwei:          ctx.GlobalFloat64(FixedGasTipCapFlagName),
+		GasOracleURL:                ctx.GlobalString(GasOracleURLFlagName),
+		DryRun:                      ctx.GlobalBool(DryRunFlagName),
+		SimulateBeforeSend:          ctx.GlobalBool(SimulateBeforeSendFlagName),
+		CreateAccessList:            ctx.GlobalBool(CreateAccessListFlagName),
+		LegacyTxs:                   ctx.GlobalBool(LegacyTxsFlagName),
+		PrivateTxRPCURL:             ctx.GlobalString(PrivateTxRPCFlagName),
+		PrivateTxFallbackDelay:      ctx.GlobalDuration(PrivateTxFallbackDelayFlagName),
+		RebroadcastInterval:         ctx.GlobalDuration(RebroadcastIntervalFlagName),
+		MulticallAddress:            ctx.GlobalString(MulticallAddressFlagName),
+		MulticallWindow:             ctx.GlobalDuration(MulticallWindowFlagName),
+		L1ChainID:                   ctx.GlobalUint64(L1ChainIDFlagName),
+		MaxSendsPerSecond:           ctx.GlobalFloat64(MaxSendsPerSecondFlagName),
+		CircuitBreakerThreshold:     ctx.GlobalUint64(CircuitBreakerThresholdFlagName),
+		CircuitBreakerProbeInterval: ctx.GlobalDuration(CircuitBreakerProbeIntervalFlagName),
+		StatePath:                   ctx.GlobalString(StatePathFlagName),
+		EnableAdmin:                 ctx.GlobalBool(EnableAdminFlagName),
+	}
+}
+
+// migrateFlags returns the names of the flags to read the values of the canonical flags from.
+// If a deprecated flag is set, it warns naming the replacement and honors the value,
+// unless the canonical flag is set as well, in which case the canonical flag wins.
+func migrateFlags(ctx *cli.Context, l log.Logger) map[string]string {
+	names := make(map[string]string, len(deprecatedFlags))
+	for _, f := range deprecatedFlags {
+		names[f.canonical] = f.canonical
+		if !ctx.GlobalIsSet(f.deprecated) {
+			continue
+		}
+		if ctx.GlobalIsSet(f.canonical) {
+			l.Warn("Ignoring deprecated flag in favor of its replacement", "flag", f.deprecated, "replacement", f.canonical)
+			continue
+		}
+		l.Warn("Flag is deprecated, use its replacement instead", "flag", f.deprecated, "replacement", f.canonical)
+		names[f.canonical] = f.deprecated
 	}
+	return names
 }
 
+// NewConfig dials L1 and returns the Config of the CLIConfig. The chain ID is fetched from L1, unless
+// L1ChainID is set, in which case the L1 endpoints are only dialed on their first use, or by Start.
 func NewConfig(cfg CLIConfig, l log.Logger) (Config, error) {
 	if err := cfg.Check(); err != nil {
 		return Config{}, fmt.Errorf("invalid config: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.NetworkTimeout)
-	defer cancel()
-	l1, err := ethclient.DialContext(ctx, cfg.L1RPCURL)
+	l1, err := dialL1(cfg, l)
 	if err != nil {
-		return Config{}, fmt.Errorf("could not dial eth client: %w", err)
+		return Config{}, err
 	}
 
-	ctx, cancel = context.WithTimeout(context.Background(), cfg.NetworkTimeout)
-	defer cancel()
-	chainID, err := l1.ChainID(ctx)
+	if cfg.L1ChainID != 0 {
+		return NewConfigFromBackend(cfg, l, l1, new(big.Int).SetUint64(cfg.L1ChainID))
+	}
+	var chainID *big.Int
+	retryCfg := Config{
+		NetworkRetryInitial:  cfg.NetworkRetryInitial,
+		NetworkRetryMax:      cfg.NetworkRetryMax,
+		NetworkRetryAttempts: cfg.NetworkRetryAttempts,
+	}
+	err = backoff.Do(retryCfg.networkRetryAttempts(), retryCfg.networkRetryStrategy(), func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.NetworkTimeout)
+		defer cancel()
+		chainID, err = l1.ChainID(ctx)
+		return err
+	})
 	if err != nil {
 		return Config{}, fmt.Errorf("could not dial fetch L1 chain ID: %w", err)
 	}
+	return NewConfigFromBackend(cfg, l, l1, chainID)
+}
+
+// NewConfigFromBackend returns the Config of the CLIConfig with the given backend and L1 chain ID,
+// without dialing L1, e.g. for the tests or the backends built by the caller. The L1 RPC URL is ignored.
+func NewConfigFromBackend(cfg CLIConfig, l log.Logger, backend ETHBackend, chainID *big.Int) (Config, error) {
+	if err := cfg.checkSettings(); err != nil {
+		return Config{}, fmt.Errorf("invalid config: %w", err)
+	}
 
-	signerFactory, from, err := kcrypto.SignerFactoryFromConfig(l, cfg.PrivateKey, cfg.Mnemonic, cfg.HDPath, cfg.SignerCLIConfig)
+	gasPricer, err := gasPricerFromConfig(cfg, backend)
+	if err != nil {
+		return Config{}, fmt.Errorf("could not init gas pricer: %w", err)
+	}
+
+	var privateTx TxPublisher
+	if cfg.PrivateTxRPCURL != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.NetworkTimeout)
+		defer cancel()
+		privateTx, err = ethclient.DialContext(ctx, cfg.PrivateTxRPCURL)
+		if err != nil {
+			return Config{}, fmt.Errorf("could not dial private tx relay: %w", err)
+		}
+	}
+
+	signerFactory, from, signerClient, err := kcrypto.SignerFactoryFromConfig(l, cfg.PrivateKey, cfg.Mnemonic, cfg.MnemonicPassphrase, cfg.MnemonicWordlist, cfg.HDPath, cfg.KeystorePath, cfg.KeystorePasswordFile, cfg.SignerCLIConfig, cfg.KMSConfig)
 	if err != nil {
 		return Config{}, fmt.Errorf("could not init signer: %w", err)
 	}
+	var signerHealthy func() bool
+	var signerClose func()
+	if signerClient != nil {
+		signerHealthy, signerClose = signerClient.Healthy, signerClient.Close
+	}
+	standbyAccounts, err := standbyAccountsFromConfig(cfg, l, chainID)
+	if err != nil {
+		if signerClose != nil {
+			signerClose()
+		}
+		return Config{}, err
+	}
+
+	conf := Config{
+		Backend:                     backend,
+		ResubmissionTimeout:         cfg.ResubmissionTimeout,
+		ResubmissionTimeoutJitter:   cfg.ResubmissionTimeoutJitter,
+		ChainID:                     chainID,
+		TxSendTimeout:               cfg.TxSendTimeout,
+		TxNotInMempoolTimeout:       cfg.TxNotInMempoolTimeout,
+		StuckTxBumps:                cfg.StuckTxBumps,
+		StuckTxDuration:             cfg.StuckTxDuration,
+		ReorgWatchDepth:             cfg.ReorgWatchDepth,
+		NetworkTimeout:              cfg.NetworkTimeout,
+		NetworkRetryInitial:         cfg.NetworkRetryInitial,
+		NetworkRetryMax:             cfg.NetworkRetryMax,
+		NetworkRetryAttempts:        cfg.NetworkRetryAttempts,
+		ReceiptQueryInterval:        cfg.ReceiptQueryInterval,
+		BatchReceipts:               cfg.BatchReceipts,
+		NumConfirmations:            cfg.NumConfirmations,
+		ConfirmationMode:            cfg.ConfirmationMode,
+		SafeAbortNonceTooLowCount:   cfg.SafeAbortNonceTooLowCount,
+		TxBufferSize:                cfg.TxBufferSize,
+		BufferPolicy:                cfg.BufferPolicy,
+		DrainTimeout:                cfg.DrainTimeout,
+		MaxPendingTxs:               cfg.MaxPendingTxs,
+		GasLimitBufferPercent:       cfg.GasLimitBufferPercent,
+		FeeBumpPercent:              cfg.FeeBumpPercent,
+		FeeLimitMultiplier:          cfg.FeeLimitMultiplier,
+		MaxGasPrice:                 gweiToWei(cfg.MaxGasPriceGwei),
+		DailyFeeBudget:              ethToWei(cfg.DailyFeeBudgetEth),
+		GasPricer:                   gasPricer,
+		DryRun:                      cfg.DryRun,
+		SimulateBeforeSend:          cfg.SimulateBeforeSend,
+		CreateAccessList:            cfg.CreateAccessList,
+		LegacyTxs:                   cfg.LegacyTxs,
+		PrivateTxPublisher:          privateTx,
+		PrivateTxFallbackDelay:      cfg.PrivateTxFallbackDelay,
+		RebroadcastInterval:         cfg.RebroadcastInterval,
+		MulticallAddress:            common.HexToAddress(cfg.MulticallAddress),
+		MulticallWindow:             cfg.MulticallWindow,
+		CircuitBreakerThreshold:     cfg.CircuitBreakerThreshold,
+		CircuitBreakerProbeInterval: cfg.CircuitBreakerProbeInterval,
+		StatePath:                   cfg.StatePath,
+		EnableAdmin:                 cfg.EnableAdmin,
+		ContractLabels:              cfg.ContractLabels,
+		Signer:                      signerFactory(chainID),
+		From:                        from,
+		SignerHealthy:               signerHealthy,
+		SignerClose:                 signerClose,
+		StandbyAccounts:             standbyAccounts,
+	}
+	if err := conf.Check(); err != nil {
+		if signerClose != nil {
+			signerClose()
+		}
+		return Config{}, fmt.Errorf("invalid config: %w", err)
+	}
+	return conf, nil
+}
 
-	return Config{
-		Backend:                   l1,
-		ResubmissionTimeout:       cfg.ResubmissionTimeout,
-		ChainID:                   chainID,
-		TxSendTimeout:             cfg.TxSendTimeout,
-		TxNotInMempoolTimeout:     cfg.TxNotInMempoolTimeout,
-		NetworkTimeout:            cfg.NetworkTimeout,
-		ReceiptQueryInterval:      cfg.ReceiptQueryInterval,
-		NumConfirmations:          cfg.NumConfirmations,
-		SafeAbortNonceTooLowCount: cfg.SafeAbortNonceTooLowCount,
-		TxBufferSize:              cfg.TxBufferSize,
-		Signer:                    signerFactory(chainID),
-		From:                      from,
-	}, nil
+// standbyAccountsFromConfig returns the standby accounts of the keystore files, then of the private keys.
+func standbyAccountsFromConfig(cfg CLIConfig, l log.Logger, chainID *big.Int) ([]Account, error) {
+	var accounts []Account
+	add := func(privateKey, keystorePath string) error {
+		signerFactory, from, _, err := kcrypto.SignerFactoryFromConfig(l, privateKey, "", "", "", "", keystorePath, cfg.KeystorePasswordFile, client.CLIConfig{}, kms.CLIConfig{})
+		if err != nil {
+			return fmt.Errorf("could not init standby signer: %w", err)
+		}
+		accounts = append(accounts, Account{Signer: signerFactory(chainID), From: from})
+		return nil
+	}
+	for _, path := range cfg.StandbyKeystorePaths {
+		if err := add("", path); err != nil {
+			return nil, err
+		}
+	}
+	for _, key := range cfg.StandbyPrivateKeys {
+		if err := add(key, ""); err != nil {
+			return nil, err
+		}
+	}
+	return accounts, nil
+}
+
+// l1Backend is the backend of the tx manager, which can also be queried for the chain ID.
+type l1Backend interface {
+	ETHBackend
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
+// dialL1 dials the comma-separated L1 RPC endpoints. If several are given, they are wrapped
+// in a FailoverBackend with the first one being active. If L1ChainID is set, the endpoints are
+// dialed lazily. If MaxSendsPerSecond is set, the requests to each endpoint are rate limited.
+func dialL1(cfg CLIConfig, l log.Logger) (l1Backend, error) {
+	urls := SplitL1RPCURLs(cfg.L1RPCURL)
+	endpoints := make([]ETHBackend, 0, len(urls))
+	for _, url := range urls {
+		var endpoint rpcEndpoint
+		if cfg.L1ChainID != 0 {
+			endpoint = newLazyClient(url, cfg)
+		} else {
+			ctx, cancel := context.WithTimeout(context.Background(), cfg.NetworkTimeout)
+			client, err := rpc.DialContext(ctx, url)
+			cancel()
+			if err != nil {
+				return nil, fmt.Errorf("could not dial eth client: %w", err)
+			}
+			endpoint = newAccessListClient(client)
+		}
+		if cfg.MaxSendsPerSecond > 0 {
+			endpoint = newRateLimitedClient(endpoint, cfg.MaxSendsPerSecond)
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	if len(endpoints) == 1 {
+		return endpoints[0].(l1Backend), nil
+	}
+	l.Info("failing over between L1 endpoints", "count", len(endpoints))
+	return NewFailoverBackend(l, endpoints, cfg.NetworkTimeout), nil
 }
 
 // Config houses parameters for altering the behavior of a SimpleTxManager.
@@ -214,6 +876,15 @@ type Config struct {
 	// attempted.
 	ResubmissionTimeout time.Duration
 
+	// ResubmissionTimeoutJitter is the fraction in [0, 1] by which each
+	// resubmission interval is randomized, i.e. every interval is drawn from
+	// [timeout*(1-jitter), timeout*(1+jitter)]. Zero disables the jitter.
+	ResubmissionTimeoutJitter float64
+
+	// ResubmissionJitterSource is the random source the jitter is drawn from, e.g. a
+	// seeded source in tests. It defaults to a source seeded with the current time.
+	ResubmissionJitterSource rand.Source
+
 	// ChainID is the chain ID of the L1 chain.
 	ChainID *big.Int
 
@@ -225,19 +896,55 @@ type Config struct {
 	// make it to the mempool. If the tx is in the mempool, TxSendTimeout is used instead.
 	TxNotInMempoolTimeout time.Duration
 
+	// StuckTxBumps is the number of fee bumps after which a tx is reported as stuck to the
+	// handler registered with OnStuckTx and to the metrics. If 0, the bumps are not watched.
+	StuckTxBumps uint64
+
+	// StuckTxDuration is the duration since the first publication after which a tx still pending
+	// is reported as stuck. If 0, the duration is not watched.
+	StuckTxDuration time.Duration
+
+	// ReorgWatchDepth is the number of confirmations up to which the block of a confirmed tx is watched
+	// after Send returned. If the tx is reorged out meanwhile, it is reported to the handler registered
+	// with OnReorg and to the metrics. If 0, the confirmed txs are not watched.
+	ReorgWatchDepth uint64
+
 	// NetworkTimeout is the allowed duration for a single network request.
 	// This is intended to be used for network requests that can be replayed.
 	NetworkTimeout time.Duration
 
+	// NetworkRetryInitial is the upper bound of the backoff before the first retry of
+	// a failed replayable network request. The bound doubles on every retry, up to NetworkRetryMax,
+	// and the actual backoff is drawn uniformly below the bound (full jitter).
+	NetworkRetryInitial time.Duration
+
+	// NetworkRetryMax is the maximum backoff between the retries of a failed replayable network request.
+	NetworkRetryMax time.Duration
+
+	// NetworkRetryAttempts is the number of attempts of a replayable network request before giving up.
+	// If 0 or 1, failed network requests are not retried.
+	NetworkRetryAttempts int
+
 	// RequireQueryInterval is the interval at which the tx manager will
 	// query the backend to check for confirmations after a tx at a
-	// specific gas price has been published.
+	// specific gas price has been published. If the backend supports new head
+	// subscriptions, the confirmations are checked on each new head instead, and
+	// the interval only bounds the age of the cached head when polling.
 	ReceiptQueryInterval time.Duration
 
+	// BatchReceipts makes the in-flight txs poll their receipts together, at the multiples of
+	// ReceiptQueryInterval, in a single JSON-RPC batch. It is ignored if the Backend does not support batches.
+	BatchReceipts bool
+
 	// NumConfirmations specifies how many blocks are need to consider a
 	// transaction confirmed.
 	NumConfirmations uint64
 
+	// ConfirmationMode specifies how a mined tx is confirmed. In the safe and finalized modes, the tx
+	// is confirmed once its block is tagged so by the Backend, and NumConfirmations only paces the
+	// TxIncluded statuses. If empty, ConfirmationModeBlocks is used.
+	ConfirmationMode ConfirmationMode
+
 	// SafeAbortNonceTooLowCount specifies how many ErrNonceTooLow observations
 	// are required to give up on a tx at a particular nonce without receiving
 	// confirmation.
@@ -247,7 +954,215 @@ type Config struct {
 	// Only used by buffered txmgr.
 	TxBufferSize uint64
 
+	// BufferPolicy specifies what to do when the queue of transaction requests is full.
+	// Only used by buffered txmgr. If empty, BufferPolicyRejectNew is used.
+	BufferPolicy BufferPolicy
+
+	// DrainTimeout is the time during which the buffered txmgr keeps sending the queued requests
+	// once stopped. The requests still queued afterwards get ErrTxManagerStopped, and are recorded
+	// to the file at StatePath suffixed by ".unsent", if StatePath is set. If 0, the queued requests
+	// are not sent once stopped.
+	DrainTimeout time.Duration
+
+	// MaxPendingTxs is the maximum number of txs in flight at once. If it is more than 1, the nonces
+	// are reserved locally, so that the concurrent sends publish and bump their txs each at its own
+	// nonce, and the buffered txmgr sends up to MaxPendingTxs queued requests concurrently.
+	// If it is at most 1, the sends are serialized. With ExtraAccounts, the limit applies to each account.
+	MaxPendingTxs uint64
+
+	// GasLimitBufferPercent is the percentage added on top of the estimated gas limit,
+	// since a bare estimate may be too tight for calls touching cold storage.
+	// It is not applied to the transactions with an explicit gas limit.
+	GasLimitBufferPercent uint64
+
+	// FeeBumpPercent is the minimum percentage by which the tip and fee caps are bumped on resubmission.
+	// If 0, DefaultFeeBumpPercent is used.
+	FeeBumpPercent uint64
+
+	// FeeLimitMultiplier caps the bumped tip and fee caps at this multiple of the suggested ones,
+	// so that the fees do not run away while a tx is stuck. If 0, the fees are not capped.
+	FeeLimitMultiplier uint64
+
+	// MaxGasPrice is the ceiling of the L1 gas price in wei. While the basefee plus the suggested tip
+	// exceeds it, the new txs are held and the fees of the pending txs are not bumped.
+	// The gas fee caps never exceed it. If nil or 0, the gas price is unbounded.
+	MaxGasPrice *big.Int
+
+	// DailyFeeBudget is the maximum fee in wei that the confirmed txs may spend within the last 24 hours.
+	// Once it is spent, Send refuses the new txs with ErrFeeBudgetExhausted until the spends age out.
+	// The txs in flight, and the cancellations and replacements, are not refused. If nil or 0, the fees are unbounded.
+	DailyFeeBudget *big.Int
+
+	// GasPricer suggests the tip cap of the new and bumped txs. If nil, the tip cap suggested by
+	// the Backend is used.
+	GasPricer GasPricer
+
+	// DryRun makes the tx manager build and sign the transactions without publishing them.
+	// The signed tx is logged and called against the backend to surface the revert, and
+	// a successful receipt marked by SimulatedBlockHash is returned instead of the L1 receipt.
+	DryRun bool
+
+	// SimulateBeforeSend makes the tx manager call each new tx against the pending block before
+	// publishing it. If the call reverts, Send returns a RevertError instead of publishing the tx.
+	// The resubmissions of a published tx are not simulated again.
+	SimulateBeforeSend bool
+
+	// CreateAccessList makes the tx manager attach the access list returned by eth_createAccessList
+	// to the new txs whose candidate has no access list, before estimating their gas.
+	// If the backend does not support it or the query fails, the tx is sent without an access list.
+	CreateAccessList bool
+
+	// LegacyTxs makes the tx manager send legacy txs priced by gasPrice, for the chains without EIP-1559.
+	// The gas price is taken from the GasPricer, if set, or from the eth_gasPrice of the Backend, and
+	// bumped like the fee cap of the dynamic fee txs. It is also enabled for the txs crafted while
+	// the L1 head has no basefee.
+	LegacyTxs bool
+
+	// PrivateTxPublisher is the private tx relay, like an MEV-protect endpoint, that the txs are
+	// published to instead of the public mempool, so that they cannot be frontrun.
+	// The receipts are still queried from the Backend. If nil, the txs are published publicly.
+	PrivateTxPublisher TxPublisher
+
+	// PrivateTxFallbackDelay is the delay since the first publication after which the resubmissions of
+	// a tx not yet mined are published to the public mempool. If 0, the txs never fall back.
+	PrivateTxFallbackDelay time.Duration
+
+	// RebroadcastInterval is the interval at which the pending tx is republished without a fee bump,
+	// to every endpoint if the Backend is a TxBroadcaster, so that a tx evicted from the mempool or not
+	// propagated does not stall until the next bump. If 0, the tx is only republished on the bumps.
+	RebroadcastInterval time.Duration
+
+	// MulticallAddress is the address of the Multicall3 contract that the buffered txmgr aggregates
+	// the Aggregatable candidates into. If zero, the candidates are not aggregated.
+	MulticallAddress common.Address
+
+	// MulticallWindow is the time that the buffered txmgr waits after popping an Aggregatable candidate
+	// for the other aggregatable candidates to aggregate with it.
+	MulticallWindow time.Duration
+
+	// CircuitBreakerThreshold is the number of consecutive failures of the Backend, i.e. transport errors
+	// and timeouts, after which the new sends are paused until a call succeeds again. The sends in flight
+	// go on. If 0, the new sends are never paused.
+	CircuitBreakerThreshold uint64
+
+	// CircuitBreakerProbeInterval is the interval at which the Backend is probed while the new sends are paused.
+	CircuitBreakerProbeInterval time.Duration
+
+	// StatePath is the path of the file recording the signed but unconfirmed txs.
+	// On the first send after a restart, the recorded txs are resumed before any new tx is crafted,
+	// so that their nonces are not reused. If empty, the txs are not persisted.
+	StatePath string
+
+	// EnableAdmin makes the services serve the admin RPC API of the tx manager returned by NewAdminAPI.
+	EnableAdmin bool
+
+	// ContractLabels are the labels of the known contracts, e.g. "l2_output_oracle", under which the gas
+	// used by the confirmed txs is recorded. The txs to the other addresses are recorded as "other",
+	// so that the cardinality of the metrics is bounded.
+	ContractLabels map[common.Address]string
+
 	// Signer is used to sign transactions when the gas price is increased.
 	Signer kcrypto.SignerFn
 	From   common.Address
+
+	// SignerHealthy reports whether the remote signer is reachable, for Ready. It is nil with a local key.
+	SignerHealthy func() bool
+	// SignerClose stops the background work of the remote signer client on Close. It is nil with a local key.
+	SignerClose func()
+
+	// ExtraAccounts are the accounts the sends rotate to while the From account has MaxPendingTxs
+	// sends in flight (one if MaxPendingTxs is at most 1), so that a high-frequency service is not
+	// serialized behind a single nonce stream. Each account has its own nonces, and its own state file
+	// at StatePath suffixed by its address. The recipients of the txs must accept any of the accounts.
+	// Cancel and Replace only act on the From account.
+	ExtraAccounts []Account
+
+	// StandbyAccounts are the accounts the txs can be switched to with SwitchAccount, in their order,
+	// e.g. when the From account is compromised or its key is rotated. Each account has its own nonces,
+	// and its own state file at StatePath suffixed by its address. While a standby account is active,
+	// the ExtraAccounts are not used, and Cancel and Replace act on the standby account.
+	StandbyAccounts []Account
+
+	// Clock is the clock of the timers and tickers of the sends. If nil, the system clock is used.
+	// Tests can set a clock.DeterministicClock to drive the resubmissions and the confirmations.
+	Clock clock.Clock
+}
+
+// Check returns an error if the config is incomplete, e.g. when it is built without NewConfig.
+func (c Config) Check() error {
+	if c.Backend == nil {
+		return errors.New("must provide the Backend")
+	}
+	if c.ChainID == nil {
+		return errors.New("must provide the ChainID")
+	}
+	if c.Signer == nil {
+		return errors.New("must provide the Signer")
+	}
+	if c.NumConfirmations == 0 {
+		return errors.New("NumConfirmations must not be 0")
+	}
+	if c.NetworkTimeout == 0 {
+		return errors.New("must provide NetworkTimeout")
+	}
+	if c.ResubmissionTimeout == 0 {
+		return errors.New("must provide ResubmissionTimeout")
+	}
+	if c.ReceiptQueryInterval == 0 {
+		return errors.New("must provide ReceiptQueryInterval")
+	}
+	if c.TxNotInMempoolTimeout == 0 {
+		return errors.New("must provide TxNotInMempoolTimeout")
+	}
+	if c.SafeAbortNonceTooLowCount == 0 {
+		return errors.New("SafeAbortNonceTooLowCount must not be 0")
+	}
+	return nil
+}
+
+// gweiToWei converts the gas price in gwei to wei. It returns nil for 0.
+func gweiToWei(gwei float64) *big.Int {
+	if gwei == 0 {
+		return nil
+	}
+	wei, _ := new(big.Float).Mul(big.NewFloat(gwei), big.NewFloat(params.GWei)).Int(nil)
+	return wei
+}
+
+// ethToWei converts the amount in ETH to wei. It returns nil for 0.
+func ethToWei(eth float64) *big.Int {
+	if eth == 0 {
+		return nil
+	}
+	wei, _ := new(big.Float).Mul(big.NewFloat(eth), big.NewFloat(params.Ether)).Int(nil)
+	return wei
+}
+
+// splitList splits the comma-separated list, dropping the empty items.
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (c Config) feeBumpPercent() uint64 {
+	if c.FeeBumpPercent == 0 {
+		return DefaultFeeBumpPercent
+	}
+	return c.FeeBumpPercent
+}
+
+func (c Config) networkRetryAttempts() int {
+	if c.NetworkRetryAttempts < 1 {
+		return 1
+	}
+	return c.NetworkRetryAttempts
+}
+
+func (c Config) networkRetryStrategy() backoff.Strategy {
+	return backoff.FullJitter(c.NetworkRetryInitial, c.NetworkRetryMax)
 }
@@ -0,0 +1,251 @@
+package txmgr
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+
+	kcrypto "github.com/kroma-network/kroma/utils/service/crypto"
+	"github.com/kroma-network/kroma/utils/service/txmgr/metrics"
+)
+
+// parseCLIConfig parses the args with the txmgr flags, and returns the resulting config
+// with the deprecated flags named by the warnings.
+func parseCLIConfig(t *testing.T, args ...string) (CLIConfig, []string) {
+	var warned []string
+	l := log.New()
+	l.SetHandler(log.FuncHandler(func(r *log.Record) error {
+		if r.Lvl != log.LvlWarn {
+			return nil
+		}
+		for i := 0; i+1 < len(r.Ctx); i += 2 {
+			if r.Ctx[i] == "flag" {
+				warned = append(warned, r.Ctx[i+1].(string))
+			}
+		}
+		return nil
+	}))
+
+	var cfg CLIConfig
+	app := cli.NewApp()
+	app.Flags = CLIFlags("TXMGR_CLI_TEST")
+	app.Action = func(ctx *cli.Context) error {
+		cfg = readCLIConfig(ctx, l)
+		return nil
+	}
+	require.NoError(t, app.Run(append([]string{"txmgr"}, args...)))
+	return cfg, warned
+}
+
+func TestMigrateFlags(t *testing.T) {
+	tests := []struct {
+		name                string
+		args                []string
+		numConfirmations    uint64
+		resubmissionTimeout time.Duration
+		warned              []string
+	}{
+		{
+			name:                "defaults",
+			numConfirmations:    10,
+			resubmissionTimeout: 48 * time.Second,
+		},
+		{
+			name:                "canonical",
+			args:                []string{"--txmgr.num-confirmations=2", "--txmgr.resubmission-timeout=5s"},
+			numConfirmations:    2,
+			resubmissionTimeout: 5 * time.Second,
+		},
+		{
+			name:                "deprecated",
+			args:                []string{"--num-confirmations=3", "--resubmission-timeout=6s"},
+			numConfirmations:    3,
+			resubmissionTimeout: 6 * time.Second,
+			warned:              []string{LegacyNumConfirmationsFlagName, LegacyResubmissionTimeoutFlagName},
+		},
+		{
+			name:                "both",
+			args:                []string{"--num-confirmations=3", "--txmgr.num-confirmations=2", "--resubmission-timeout=6s"},
+			numConfirmations:    2,
+			resubmissionTimeout: 6 * time.Second,
+			warned:              []string{LegacyNumConfirmationsFlagName, LegacyResubmissionTimeoutFlagName},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, warned := parseCLIConfig(t, tt.args...)
+			require.Equal(t, tt.numConfirmations, cfg.NumConfirmations)
+			require.Equal(t, tt.resubmissionTimeout, cfg.ResubmissionTimeout)
+			require.Equal(t, tt.warned, warned)
+		})
+	}
+}
+
+const testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+// TestNewConfigFromBackend asserts that the config is built with the given backend and chain ID,
+// without an L1 RPC URL.
+func TestNewConfigFromBackend(t *testing.T) {
+	cfg, _ := parseCLIConfig(t, "--private-key="+testPrivateKey)
+	backend := newMockBackend(newGasPricer(1))
+	conf, err := NewConfigFromBackend(cfg, log.New(), backend, big.NewInt(900))
+	require.NoError(t, err)
+	require.Equal(t, backend, conf.Backend)
+	require.Equal(t, big.NewInt(900), conf.ChainID)
+	require.NotNil(t, conf.Signer)
+	require.Equal(t, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", conf.From.Hex())
+
+	cfg.NumConfirmations = 0
+	_, err = NewConfigFromBackend(cfg, log.New(), backend, big.NewInt(900))
+	require.ErrorContains(t, err, "NumConfirmations")
+	_, err = NewConfigFromBackend(cfg, log.New(), nil, nil)
+	require.Error(t, err)
+}
+
+// TestNewConfigKeystore asserts that the signing key is decrypted from the keystore file.
+func TestNewConfigKeystore(t *testing.T) {
+	key, err := crypto.HexToECDSA(testPrivateKey)
+	require.NoError(t, err)
+	keyJSON, err := keystore.EncryptKey(&keystore.Key{
+		Address:    crypto.PubkeyToAddress(key.PublicKey),
+		PrivateKey: key,
+	}, "password", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	keystorePath := filepath.Join(dir, "keystore.json")
+	passwordPath := filepath.Join(dir, "password")
+	require.NoError(t, os.WriteFile(keystorePath, keyJSON, 0o600))
+	require.NoError(t, os.WriteFile(passwordPath, []byte("password\n"), 0o600))
+
+	cfg, _ := parseCLIConfig(t,
+		"--keystore-path="+keystorePath,
+		"--keystore-password-file="+passwordPath,
+	)
+	conf, err := NewConfigFromBackend(cfg, log.New(), newMockBackend(newGasPricer(1)), big.NewInt(900))
+	require.NoError(t, err)
+	require.Equal(t, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", conf.From.Hex())
+
+	cfg.PrivateKey = testPrivateKey
+	_, err = NewConfigFromBackend(cfg, log.New(), newMockBackend(newGasPricer(1)), big.NewInt(900))
+	require.ErrorContains(t, err, "keystore")
+
+	cfg.PrivateKey = ""
+	cfg.KeystorePasswordFile = ""
+	require.ErrorContains(t, cfg.checkSettings(), "keystore password file")
+}
+
+func TestNewConfigStandbyAccounts(t *testing.T) {
+	standbyKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	cfg, _ := parseCLIConfig(t,
+		"--private-key="+testPrivateKey,
+		"--standby-private-keys="+hex.EncodeToString(crypto.FromECDSA(standbyKey))+",",
+	)
+	conf, err := NewConfigFromBackend(cfg, log.New(), newMockBackend(newGasPricer(1)), big.NewInt(900))
+	require.NoError(t, err)
+	require.Len(t, conf.StandbyAccounts, 1)
+	require.Equal(t, crypto.PubkeyToAddress(standbyKey.PublicKey), conf.StandbyAccounts[0].From)
+
+	cfg.StandbyKeystorePaths = []string{"keystore.json"}
+	require.ErrorContains(t, cfg.checkSettings(), "keystore password file")
+}
+
+func TestNewConfigMnemonicPassphrase(t *testing.T) {
+	cfg, _ := parseCLIConfig(t,
+		"--mnemonic=abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"--mnemonic-passphrase=TREZOR",
+		"--hd-path=m/44'/60'/0'/0/0",
+	)
+	require.Equal(t, kcrypto.DefaultMnemonicWordlist, cfg.MnemonicWordlist)
+	conf, err := NewConfigFromBackend(cfg, log.New(), newMockBackend(newGasPricer(1)), big.NewInt(900))
+	require.NoError(t, err)
+	unhardened, err := kcrypto.MnemonicPrivateKey(cfg.Mnemonic, "", "", cfg.HDPath)
+	require.NoError(t, err)
+	require.NotEqual(t, crypto.PubkeyToAddress(unhardened.PublicKey), conf.From)
+
+	cfg.MnemonicWordlist = "klingon"
+	require.ErrorContains(t, cfg.checkSettings(), "unknown mnemonic wordlist")
+	cfg.MnemonicWordlist = "japanese"
+	_, err = NewConfigFromBackend(cfg, log.New(), newMockBackend(newGasPricer(1)), big.NewInt(900))
+	require.ErrorContains(t, err, "japanese wordlist")
+
+	cfg.Mnemonic = ""
+	cfg.PrivateKey = testPrivateKey
+	require.ErrorContains(t, cfg.checkSettings(), "requires a mnemonic")
+}
+
+// TestNewConfigLazyL1 asserts that L1 is not dialed by NewConfig if the L1 chain ID is given,
+// but by Start.
+func TestNewConfigLazyL1(t *testing.T) {
+	cfg, _ := parseCLIConfig(t,
+		"--private-key="+testPrivateKey,
+		"--txmgr.l1-chain-id=900",
+		"--txmgr.network-timeout=100ms",
+	)
+	cfg.L1RPCURL = "ws://127.0.0.1:1"
+	conf, err := NewConfig(cfg, log.New())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(900), conf.ChainID)
+
+	m := NewSimpleTxManagerFromConfig("TEST", log.New(), &metrics.NoopTxMetrics{}, conf)
+	require.ErrorContains(t, m.Start(context.Background()), "failed to connect to L1")
+}
+
+// TestNewConfigRateLimitedL1 asserts that the requests to the L1 endpoint wait for the rate limit,
+// and fail without reaching the endpoint if their context ends first.
+func TestNewConfigRateLimitedL1(t *testing.T) {
+	cfg, _ := parseCLIConfig(t,
+		"--private-key="+testPrivateKey,
+		"--txmgr.l1-chain-id=900",
+		"--txmgr.max-sends-per-second=1",
+	)
+	cfg.L1RPCURL = "ws://127.0.0.1:1"
+	conf, err := NewConfig(cfg, log.New())
+	require.NoError(t, err)
+	client, ok := conf.Backend.(*rateLimitedClient)
+	require.True(t, ok)
+	require.True(t, client.limiter.Allow())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = client.BlockNumber(ctx)
+	require.ErrorContains(t, err, "rate")
+	require.NotContains(t, err.Error(), "could not dial")
+
+	cfg.MaxSendsPerSecond = -1
+	require.ErrorContains(t, cfg.Check(), "MaxSendsPerSecond")
+}
+
+func TestTxManagerFactory(t *testing.T) {
+	cfg, _ := parseCLIConfig(t,
+		"--private-key="+testPrivateKey,
+		"--txmgr.l1-chain-id=900",
+		"--txmgr.multicall-address=0xcA11bde05977b3631167028862bE2a173976CA11",
+		"--txmgr.network-timeout=100ms",
+	)
+	cfg.L1RPCURL = "ws://127.0.0.1:1"
+	f, err := NewTxManagerFactory(cfg, log.New())
+	require.NoError(t, err)
+
+	l1, err := f.L1("l1", &metrics.NoopTxMetrics{})
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(900), l1.ChainID)
+	require.True(t, l1.multicallEnabled())
+
+	l2, err := f.Chain("l2", &metrics.NoopTxMetrics{}, ChainConfig{RPCURL: "ws://127.0.0.1:2", ChainID: 901})
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(901), l2.ChainID)
+	require.Equal(t, l1.From(), l2.From())
+	require.False(t, l2.multicallEnabled())
+	require.NotSame(t, l1.backend, l2.backend)
+}
@@ -0,0 +1,59 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ConfirmationMode is the way the tx manager decides that a mined tx is confirmed.
+type ConfirmationMode string
+
+const (
+	// ConfirmationModeBlocks confirms a tx once it has NumConfirmations blocks on top of it, counting its own.
+	ConfirmationModeBlocks ConfirmationMode = "blocks"
+	// ConfirmationModeSafe confirms a tx once its block is tagged safe by the L1 node.
+	ConfirmationModeSafe ConfirmationMode = "safe"
+	// ConfirmationModeFinalized confirms a tx once its block is tagged finalized by the L1 node.
+	ConfirmationModeFinalized ConfirmationMode = "finalized"
+)
+
+// Check returns an error if the mode is unknown. The empty mode is accepted as ConfirmationModeBlocks.
+func (c ConfirmationMode) Check() error {
+	switch c {
+	case "", ConfirmationModeBlocks, ConfirmationModeSafe, ConfirmationModeFinalized:
+		return nil
+	default:
+		return fmt.Errorf("unknown confirmation mode: %s", c)
+	}
+}
+
+// tag returns the block tag confirming the txs, or false if the txs are confirmed by counting blocks.
+func (c ConfirmationMode) tag() (rpc.BlockNumber, bool) {
+	switch c {
+	case ConfirmationModeSafe:
+		return rpc.SafeBlockNumber, true
+	case ConfirmationModeFinalized:
+		return rpc.FinalizedBlockNumber, true
+	default:
+		return 0, false
+	}
+}
+
+// taggedBlockNumber returns the number of the L1 block of the tag.
+func (m *SimpleTxManager) taggedBlockNumber(ctx context.Context, tag rpc.BlockNumber) (uint64, error) {
+	header, err := retryNetwork(ctx, m, "get the tagged block", func(ctx context.Context) (*types.Header, error) {
+		return m.backend.HeaderByNumber(ctx, big.NewInt(tag.Int64()))
+	})
+	if err != nil {
+		return 0, err
+	}
+	if header.Number == nil {
+		return 0, errors.New("the tagged block has no number")
+	}
+	return header.Number.Uint64(), nil
+}
@@ -0,0 +1,83 @@
+package txmgr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// unsentSuffix is the suffix of the StatePath of the file recording the requests not sent before
+// the buffered tx manager stopped.
+const unsentSuffix = ".unsent"
+
+// UnsentTxRequest is a tx request still queued when the buffered tx manager stopped, as recorded
+// to the file at StatePath suffixed by ".unsent". The requests are not resubmitted after a restart,
+// since the services rebuild their txs from the chain state; the file lets the operator review them.
+type UnsentTxRequest struct {
+	Priority TxPriority      `json:"priority"`
+	To       *common.Address `json:"to"`
+	Data     hexutil.Bytes   `json:"data"`
+	Value    *hexutil.Big    `json:"value,omitempty"`
+	GasLimit hexutil.Uint64  `json:"gasLimit"`
+}
+
+// dropQueued responds ErrTxManagerStopped to the requests still queued, and appends them to the
+// unsent file if StatePath is set, so that they are not dropped silently.
+func (m *BufferedTxManager) dropQueued() error {
+	items := m.queue.Drain()
+	if len(items) == 0 {
+		return nil
+	}
+	unsent := make([]UnsentTxRequest, 0, len(items))
+	for _, item := range items {
+		candidate := item.txRequest.txCandidate
+		m.l.Warn("dropping the unsent transaction request", "to", candidate.To, "priority", item.priority)
+		unsent = append(unsent, UnsentTxRequest{
+			Priority: item.priority,
+			To:       candidate.To,
+			Data:     candidate.TxData,
+			Value:    (*hexutil.Big)(candidate.Value),
+			GasLimit: hexutil.Uint64(candidate.GasLimit),
+		})
+		item.txRequest.responseChan <- &TxResponse{Err: ErrTxManagerStopped}
+	}
+	if m.StatePath == "" {
+		return nil
+	}
+	path := m.StatePath + unsentSuffix
+	if err := appendUnsent(path, unsent); err != nil {
+		return fmt.Errorf("failed to record the unsent transaction requests: %w", err)
+	}
+	m.l.Warn("recorded the unsent transaction requests", "count", len(unsent), "path", path)
+	return nil
+}
+
+// appendUnsent appends the requests to the unsent file, keeping the requests recorded by the previous runs.
+func appendUnsent(path string, unsent []UnsentTxRequest) error {
+	var recorded []UnsentTxRequest
+	data, err := os.ReadFile(path)
+	if err == nil {
+		if err := json.Unmarshal(data, &recorded); err != nil {
+			return fmt.Errorf("failed to decode %s: %w", path, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	data, err = json.MarshalIndent(append(recorded, unsent...), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,52 @@
+package txmgr
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SimulatedBlockHash is the block hash of the receipts synthesized in the dry-run mode.
+var SimulatedBlockHash = crypto.Keccak256Hash([]byte("txmgr.simulated"))
+
+// IsSimulated returns whether the receipt was synthesized in the dry-run mode
+// rather than returned by L1.
+func IsSimulated(receipt *types.Receipt) bool {
+	return receipt != nil && receipt.BlockHash == SimulatedBlockHash
+}
+
+// simulate handles the signed tx in the dry-run mode. It logs the tx, and calls it against the backend
+// to surface the revert if the backend supports eth_call, see callTx. The tx is never published.
+// It returns a successful receipt marked by SimulatedBlockHash, with the current head as the block number.
+func (m *SimpleTxManager) simulate(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	rawTx, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the tx: %w", err)
+	}
+	m.l.Info("dry-run: skipped publishing tx", "hash", tx.Hash(), "nonce", tx.Nonce(), "to", tx.To(),
+		"gasLimit", tx.Gas(), "gasTipCap", tx.GasTipCap(), "gasFeeCap", tx.GasFeeCap(), "rlp", hexutil.Encode(rawTx))
+
+	if err := m.callTx(ctx, tx); err != nil {
+		return nil, fmt.Errorf("dry-run: tx %s would fail: %w", tx.Hash(), err)
+	}
+
+	head, err := retryNetwork(ctx, m, "get block number", m.headNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &types.Receipt{
+		Type:              tx.Type(),
+		Status:            types.ReceiptStatusSuccessful,
+		CumulativeGasUsed: tx.Gas(),
+		GasUsed:           tx.Gas(),
+		TxHash:            tx.Hash(),
+		EffectiveGasPrice: tx.GasFeeCap(),
+		BlockHash:         SimulatedBlockHash,
+		BlockNumber:       new(big.Int).SetUint64(head),
+		Logs:              []*types.Log{},
+	}, nil
+}
@@ -0,0 +1,58 @@
+package txmgr
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/utils/service/txmgr/metrics"
+)
+
+// ChainConfig is the chain-specific config of a tx manager built by a TxManagerFactory for a chain other than L1.
+type ChainConfig struct {
+	// RPCURL is the RPC endpoint of the chain, or a comma-separated list of endpoints to fail over between.
+	RPCURL string
+	// ChainID is the chain ID. If set, the endpoints are dialed lazily. If 0, it is fetched from the endpoint.
+	ChainID uint64
+	// StatePath is the path of the file recording the unconfirmed txs. If empty, the txs are not persisted.
+	StatePath string
+}
+
+// TxManagerFactory builds the tx managers of several chains, e.g. L1 and L2, from a single CLIConfig,
+// so that they share the signer and the settings of the txmgr flags. The settings specific to L1,
+// i.e. the L1 RPC URL and chain ID, the state path, the private tx relay, the Multicall3 address,
+// the gas oracle and the contract labels, only apply to the tx manager of L1.
+type TxManagerFactory struct {
+	cfg CLIConfig
+	l   log.Logger
+}
+
+// NewTxManagerFactory returns the TxManagerFactory of the CLIConfig.
+func NewTxManagerFactory(cfg CLIConfig, l log.Logger) (*TxManagerFactory, error) {
+	if err := cfg.checkSettings(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	return &TxManagerFactory{cfg: cfg, l: l}, nil
+}
+
+// L1 returns the tx manager of L1, like NewSimpleTxManager.
+func (f *TxManagerFactory) L1(name string, m metrics.TxMetricer) (*SimpleTxManager, error) {
+	return NewSimpleTxManager(name, f.l, m, f.cfg)
+}
+
+// Chain returns the tx manager of the chain of the ChainConfig. The metrics should be
+// distinct from those of the other chains.
+func (f *TxManagerFactory) Chain(name string, m metrics.TxMetricer, chain ChainConfig) (*SimpleTxManager, error) {
+	cfg := f.cfg
+	cfg.L1RPCURL = chain.RPCURL
+	cfg.L1ChainID = chain.ChainID
+	cfg.StatePath = chain.StatePath
+	cfg.PrivateTxRPCURL = ""
+	cfg.MulticallAddress = ""
+	if cfg.GasPriceStrategy == GasPriceStrategyOracle {
+		cfg.GasPriceStrategy = GasPriceStrategyNode
+		cfg.GasOracleURL = ""
+	}
+	cfg.ContractLabels = nil
+	return NewSimpleTxManager(name, f.l.New("chain", name), m, cfg)
+}
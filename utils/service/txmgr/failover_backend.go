@@ -0,0 +1,369 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// SplitL1RPCURLs splits the comma-separated list of L1 RPC endpoints given to --l1-eth-rpc.
+// The first endpoint is the primary one.
+func SplitL1RPCURLs(urls string) []string {
+	var out []string
+	for _, url := range strings.Split(urls, ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			out = append(out, url)
+		}
+	}
+	return out
+}
+
+// PrimaryL1RPCURL returns the primary endpoint of the comma-separated list of L1 RPC endpoints,
+// for the clients that do not fail over.
+func PrimaryL1RPCURL(urls string) string {
+	if split := SplitL1RPCURLs(urls); len(split) > 0 {
+		return split[0]
+	}
+	return ""
+}
+
+// FailoverBackend is an ETHBackend over several L1 endpoints. The calls go to the active endpoint.
+// When it fails with a transport error or times out, the next endpoint becomes active, and the txs
+// published but not yet seen mined are rebroadcast to it. The error is still returned, so that
+// the retry of the caller goes to the new endpoint.
+//
+// The JSON-RPC errors, like nonce too low, are answers of a healthy endpoint and never fail over.
+// The pending txs are tracked per sender, so that the backend can serve the txs of several accounts.
+type FailoverBackend struct {
+	endpoints []ETHBackend
+	// rebroadcastTimeout bounds the rebroadcast of each pending tx.
+	rebroadcastTimeout time.Duration
+	l                  log.Logger
+
+	mu     sync.Mutex
+	active int
+	// pending maps the sender and nonce to the latest tx published at the nonce, until the tx is
+	// seen mined or the nonce is taken.
+	pending map[pendingKey]*types.Transaction
+}
+
+type pendingKey struct {
+	from  common.Address
+	nonce uint64
+}
+
+// newPendingKey returns the key of the tx. The sender of a tx that cannot be recovered is the zero address.
+func newPendingKey(tx *types.Transaction) pendingKey {
+	from, _ := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	return pendingKey{from: from, nonce: tx.Nonce()}
+}
+
+func NewFailoverBackend(l log.Logger, endpoints []ETHBackend, rebroadcastTimeout time.Duration) *FailoverBackend {
+	return &FailoverBackend{
+		endpoints:          endpoints,
+		rebroadcastTimeout: rebroadcastTimeout,
+		l:                  l,
+		pending:            make(map[pendingKey]*types.Transaction),
+	}
+}
+
+// Active returns the index of the active endpoint.
+func (b *FailoverBackend) Active() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.active
+}
+
+func (b *FailoverBackend) endpoint() (int, ETHBackend) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.active, b.endpoints[b.active]
+}
+
+// failoverCall calls fn with the active endpoint, and fails over if the endpoint is unhealthy.
+func failoverCall[T any](b *FailoverBackend, fn func(ETHBackend) (T, error)) (T, error) {
+	idx, endpoint := b.endpoint()
+	result, err := fn(endpoint)
+	if isEndpointError(err) {
+		b.failover(idx, err)
+	}
+	return result, err
+}
+
+// errUnsupportedByEndpoint is returned by the optional methods that the endpoint does not implement.
+var errUnsupportedByEndpoint = errors.New("not supported by the L1 endpoint")
+
+// isEndpointError returns whether the error means that the endpoint is unhealthy,
+// rather than being an answer of the endpoint or the cancellation by the caller.
+func isEndpointError(err error) bool {
+	if err == nil || errors.Is(err, ethereum.NotFound) || errors.Is(err, context.Canceled) ||
+		errors.Is(err, errUnsupportedByEndpoint) {
+		return false
+	}
+	var rpcErr rpc.Error
+	return !errors.As(err, &rpcErr)
+}
+
+// failover makes the endpoint after the failed one active, and rebroadcasts the pending txs to it.
+// If another caller has already failed over from the failed endpoint, it does nothing.
+func (b *FailoverBackend) failover(failed int, err error) {
+	b.mu.Lock()
+	if len(b.endpoints) < 2 || b.active != failed {
+		b.mu.Unlock()
+		return
+	}
+	b.active = (failed + 1) % len(b.endpoints)
+	endpoint := b.endpoints[b.active]
+	pending := make([]*types.Transaction, 0, len(b.pending))
+	for _, tx := range b.pending {
+		pending = append(pending, tx)
+	}
+	b.mu.Unlock()
+
+	b.l.Warn("L1 endpoint failed, failing over", "failed", failed, "active", (failed+1)%len(b.endpoints),
+		"pending", len(pending), "err", err)
+	for _, tx := range pending {
+		ctx, cancel := context.WithTimeout(context.Background(), b.rebroadcastTimeout)
+		if err := endpoint.SendTransaction(ctx, tx); err != nil {
+			b.l.Debug("failed to rebroadcast pending tx", "hash", tx.Hash(), "nonce", tx.Nonce(), "err", err)
+		}
+		cancel()
+	}
+}
+
+func (b *FailoverBackend) BlockNumber(ctx context.Context) (uint64, error) {
+	return failoverCall(b, func(e ETHBackend) (uint64, error) {
+		return e.BlockNumber(ctx)
+	})
+}
+
+func (b *FailoverBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	receipt, err := failoverCall(b, func(e ETHBackend) (*types.Receipt, error) {
+		return e.TransactionReceipt(ctx, txHash)
+	})
+	if err == nil && receipt != nil {
+		b.forgetMined(txHash)
+	}
+	return receipt, err
+}
+
+// TransactionReceipts queries the active endpoint for the receipts in a single batch, if it supports it.
+func (b *FailoverBackend) TransactionReceipts(ctx context.Context, hashes []common.Hash) ([]*types.Receipt, []error, error) {
+	result, err := failoverCall(b, func(e ETHBackend) (receiptsResult, error) {
+		fetcher, ok := e.(ReceiptsFetcher)
+		if !ok {
+			return receiptsResult{}, fmt.Errorf("batched eth_getTransactionReceipt is %w", errUnsupportedByEndpoint)
+		}
+		receipts, errs, err := fetcher.TransactionReceipts(ctx, hashes)
+		return receiptsResult{receipts: receipts, errs: errs}, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, receipt := range result.receipts {
+		if result.errs[i] == nil && receipt != nil {
+			b.forgetMined(hashes[i])
+		}
+	}
+	return result.receipts, result.errs, nil
+}
+
+// forgetMined stops tracking the tx seen mined.
+func (b *FailoverBackend) forgetMined(txHash common.Hash) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for key, tx := range b.pending {
+		if tx.Hash() == txHash {
+			delete(b.pending, key)
+		}
+	}
+}
+
+// SendTransaction publishes the tx to the active endpoint, and records it to be rebroadcast on failover.
+// The tx is recorded even if the publication fails, since a failed endpoint may have published it anyway.
+func (b *FailoverBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	key := newPendingKey(tx)
+	b.mu.Lock()
+	b.pending[key] = tx
+	b.mu.Unlock()
+	_, err := failoverCall(b, func(e ETHBackend) (struct{}, error) {
+		return struct{}{}, e.SendTransaction(ctx, tx)
+	})
+	return err
+}
+
+// BroadcastTransaction publishes the tx to every endpoint, and records it like SendTransaction.
+// It does not fail over. It returns nil if any endpoint accepted the tx, and the error of the active
+// endpoint otherwise.
+func (b *FailoverBackend) BroadcastTransaction(ctx context.Context, tx *types.Transaction) error {
+	key := newPendingKey(tx)
+	b.mu.Lock()
+	b.pending[key] = tx
+	active := b.active
+	b.mu.Unlock()
+
+	var activeErr error
+	accepted := false
+	for i, endpoint := range b.endpoints {
+		err := endpoint.SendTransaction(ctx, tx)
+		if err == nil {
+			accepted = true
+		} else if i == active {
+			activeErr = err
+		}
+	}
+	if accepted {
+		return nil
+	}
+	return activeErr
+}
+
+func (b *FailoverBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return failoverCall(b, func(e ETHBackend) (*types.Header, error) {
+		return e.HeaderByNumber(ctx, number)
+	})
+}
+
+func (b *FailoverBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return failoverCall(b, func(e ETHBackend) (*big.Int, error) {
+		return e.SuggestGasTipCap(ctx)
+	})
+}
+
+// Connect connects the endpoints dialed lazily. It fails only if none of them connects,
+// since the calls fail over from the others.
+func (b *FailoverBackend) Connect(ctx context.Context) error {
+	var err error
+	for _, e := range b.endpoints {
+		connector, ok := e.(Connector)
+		if !ok {
+			return nil
+		}
+		if err = connector.Connect(ctx); err == nil {
+			return nil
+		}
+		b.l.Warn("failed to connect the L1 endpoint", "err", err)
+	}
+	return err
+}
+
+// SuggestGasPrice queries the active endpoint for the legacy gas price, if it supports it.
+func (b *FailoverBackend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return failoverCall(b, func(e ETHBackend) (*big.Int, error) {
+		suggester, ok := e.(GasPriceSuggester)
+		if !ok {
+			return nil, fmt.Errorf("eth_gasPrice is %w", errUnsupportedByEndpoint)
+		}
+		return suggester.SuggestGasPrice(ctx)
+	})
+}
+
+// NonceAt returns the account nonce, and forgets the pending txs of the account at the nonces already taken.
+func (b *FailoverBackend) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	nonce, err := failoverCall(b, func(e ETHBackend) (uint64, error) {
+		return e.NonceAt(ctx, account, blockNumber)
+	})
+	if err == nil {
+		b.mu.Lock()
+		for key := range b.pending {
+			if key.from == account && key.nonce < nonce {
+				delete(b.pending, key)
+			}
+		}
+		b.mu.Unlock()
+	}
+	return nonce, err
+}
+
+func (b *FailoverBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return failoverCall(b, func(e ETHBackend) (uint64, error) {
+		return e.PendingNonceAt(ctx, account)
+	})
+}
+
+func (b *FailoverBackend) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return failoverCall(b, func(e ETHBackend) (uint64, error) {
+		return e.EstimateGas(ctx, msg)
+	})
+}
+
+// ChainID returns the chain ID of the active endpoint, if it supports the query.
+func (b *FailoverBackend) ChainID(ctx context.Context) (*big.Int, error) {
+	return failoverCall(b, func(e ETHBackend) (*big.Int, error) {
+		chain, ok := e.(interface {
+			ChainID(ctx context.Context) (*big.Int, error)
+		})
+		if !ok {
+			return nil, fmt.Errorf("chain ID is %w", errUnsupportedByEndpoint)
+		}
+		return chain.ChainID(ctx)
+	})
+}
+
+// CallContract calls the active endpoint, if it supports eth_call, so that the dry-run mode works
+// with the failover.
+func (b *FailoverBackend) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return failoverCall(b, func(e ETHBackend) ([]byte, error) {
+		caller, ok := e.(ethereum.ContractCaller)
+		if !ok {
+			return nil, fmt.Errorf("eth_call is %w", errUnsupportedByEndpoint)
+		}
+		return caller.CallContract(ctx, msg, blockNumber)
+	})
+}
+
+// PendingCallContract calls the active endpoint against the pending state, if it supports it.
+func (b *FailoverBackend) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	return failoverCall(b, func(e ETHBackend) ([]byte, error) {
+		caller, ok := e.(ethereum.PendingContractCaller)
+		if !ok {
+			return nil, fmt.Errorf("pending eth_call is %w", errUnsupportedByEndpoint)
+		}
+		return caller.PendingCallContract(ctx, msg)
+	})
+}
+
+// FeeHistory queries the active endpoint for the fee history, if it supports it.
+func (b *FailoverBackend) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	return failoverCall(b, func(e ETHBackend) (*ethereum.FeeHistory, error) {
+		reader, ok := e.(FeeHistoryReader)
+		if !ok {
+			return nil, fmt.Errorf("eth_feeHistory is %w", errUnsupportedByEndpoint)
+		}
+		return reader.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+	})
+}
+
+// CreateAccessList queries the active endpoint for the access list of the call, if it supports it.
+func (b *FailoverBackend) CreateAccessList(ctx context.Context, msg ethereum.CallMsg) (*types.AccessList, uint64, string, error) {
+	result, err := failoverCall(b, func(e ETHBackend) (accessListResult, error) {
+		creator, ok := e.(AccessListCreator)
+		if !ok {
+			return accessListResult{}, fmt.Errorf("eth_createAccessList is %w", errUnsupportedByEndpoint)
+		}
+		accessList, gasUsed, callErr, err := creator.CreateAccessList(ctx, msg)
+		return accessListResult{accessList, gasUsed, callErr}, err
+	})
+	return result.accessList, result.gasUsed, result.callErr, err
+}
+
+type accessListResult struct {
+	accessList *types.AccessList
+	gasUsed    uint64
+	callErr    string
+}
+
+type receiptsResult struct {
+	receipts []*types.Receipt
+	errs     []error
+}
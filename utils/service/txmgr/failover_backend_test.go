@@ -0,0 +1,190 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/testlog"
+)
+
+// rpcError is a JSON-RPC error answered by a healthy endpoint.
+type rpcError struct{ msg string }
+
+func (e rpcError) Error() string  { return e.msg }
+func (e rpcError) ErrorCode() int { return -32000 }
+
+// fakeEndpoint is an L1 endpoint failing all the calls with err, if set.
+type fakeEndpoint struct {
+	mu   sync.Mutex
+	err  error
+	sent []common.Hash
+	// mined is the receipt of the mined tx, if any.
+	mined *types.Receipt
+}
+
+func (e *fakeEndpoint) setErr(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.err = err
+}
+
+func (e *fakeEndpoint) getErr() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.err
+}
+
+func (e *fakeEndpoint) sentTxs() []common.Hash {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]common.Hash(nil), e.sent...)
+}
+
+func (e *fakeEndpoint) BlockNumber(context.Context) (uint64, error) {
+	return 1, e.getErr()
+}
+
+func (e *fakeEndpoint) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	if err := e.getErr(); err != nil {
+		return nil, err
+	}
+	if e.mined != nil && e.mined.TxHash == txHash {
+		return e.mined, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+func (e *fakeEndpoint) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.err != nil {
+		return e.err
+	}
+	e.sent = append(e.sent, tx.Hash())
+	return nil
+}
+
+func (e *fakeEndpoint) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	return &types.Header{BaseFee: common.Big1}, e.getErr()
+}
+
+func (e *fakeEndpoint) SuggestGasTipCap(context.Context) (*big.Int, error) {
+	return common.Big1, e.getErr()
+}
+
+func (e *fakeEndpoint) NonceAt(context.Context, common.Address, *big.Int) (uint64, error) {
+	return 0, e.getErr()
+}
+
+func (e *fakeEndpoint) PendingNonceAt(context.Context, common.Address) (uint64, error) {
+	return 0, e.getErr()
+}
+
+func (e *fakeEndpoint) EstimateGas(context.Context, ethereum.CallMsg) (uint64, error) {
+	return 21_000, e.getErr()
+}
+
+func TestSplitL1RPCURLs(t *testing.T) {
+	require.Equal(t, []string{"http://a", "ws://b"}, SplitL1RPCURLs(" http://a, ,ws://b,"))
+	require.Equal(t, "http://a", PrimaryL1RPCURL("http://a,ws://b"))
+	require.Empty(t, SplitL1RPCURLs(""))
+	require.Empty(t, PrimaryL1RPCURL(" , "))
+}
+
+func TestFailoverBackend(t *testing.T) {
+	primary, secondary := &fakeEndpoint{}, &fakeEndpoint{}
+	b := NewFailoverBackend(testlog.Logger(t, log.LvlCrit), []ETHBackend{primary, secondary}, time.Second)
+	ctx := context.Background()
+
+	tx := types.NewTx(&types.DynamicFeeTx{Nonce: 1})
+	require.NoError(t, b.SendTransaction(ctx, tx))
+	require.Equal(t, []common.Hash{tx.Hash()}, primary.sentTxs())
+
+	// The answers of a healthy endpoint do not fail over.
+	_, err := b.TransactionReceipt(ctx, tx.Hash())
+	require.ErrorIs(t, err, ethereum.NotFound)
+	primary.setErr(rpcError{"nonce too low"})
+	_, err = b.BlockNumber(ctx)
+	require.Error(t, err)
+	require.Equal(t, 0, b.Active())
+
+	// The transport errors fail over, and the pending tx is rebroadcast to the new endpoint.
+	primary.setErr(errors.New("connection refused"))
+	_, err = b.BlockNumber(ctx)
+	require.Error(t, err)
+	require.Equal(t, 1, b.Active())
+	require.Equal(t, []common.Hash{tx.Hash()}, secondary.sentTxs())
+	_, err = b.BlockNumber(ctx)
+	require.NoError(t, err)
+
+	// The mined tx is no longer rebroadcast.
+	secondary.mined = &types.Receipt{TxHash: tx.Hash()}
+	_, err = b.TransactionReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	primary.setErr(nil)
+	secondary.setErr(context.DeadlineExceeded)
+	_, err = b.SuggestGasTipCap(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, 0, b.Active(), "must wrap around to the primary endpoint")
+	require.Equal(t, []common.Hash{tx.Hash()}, primary.sentTxs())
+
+	// The cancellation by the caller does not fail over.
+	primary.setErr(context.Canceled)
+	_, err = b.NonceAt(ctx, common.Address{}, nil)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 0, b.Active())
+}
+
+// TestTxMgrFailsOverL1Endpoints asserts that the tx manager keeps sending a tx through
+// the secondary endpoint once the primary one fails.
+func TestTxMgrFailsOverL1Endpoints(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+	h.backend.receiptStatus = types.ReceiptStatusSuccessful
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+	primary := &fakeEndpoint{err: errors.New("connection refused")}
+	h.mgr.backend = NewFailoverBackend(testlog.Logger(t, log.LvlCrit), []ETHBackend{primary, h.backend}, time.Second)
+	h.mgr.NetworkRetryAttempts = 2
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.Send(ctx, h.createTxCandidate())
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	require.Empty(t, primary.sentTxs())
+}
+
+func TestFailoverBackendBroadcast(t *testing.T) {
+	primary, secondary := &fakeEndpoint{}, &fakeEndpoint{}
+	b := NewFailoverBackend(testlog.Logger(t, log.LvlCrit), []ETHBackend{primary, secondary}, time.Second)
+	ctx := context.Background()
+
+	tx := types.NewTx(&types.DynamicFeeTx{Nonce: 1})
+	require.NoError(t, b.BroadcastTransaction(ctx, tx))
+	require.Equal(t, []common.Hash{tx.Hash()}, primary.sentTxs())
+	require.Equal(t, []common.Hash{tx.Hash()}, secondary.sentTxs())
+
+	// The broadcast succeeds if any endpoint accepts the tx, and never fails over.
+	primary.setErr(errors.New("connection refused"))
+	require.NoError(t, b.BroadcastTransaction(ctx, tx))
+	require.Equal(t, 0, b.Active())
+
+	secondary.setErr(rpcError{"already known"})
+	require.ErrorContains(t, b.BroadcastTransaction(ctx, tx), "connection refused")
+	require.Equal(t, 0, b.Active())
+}
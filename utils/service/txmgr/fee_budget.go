@@ -0,0 +1,98 @@
+package txmgr
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/kroma-network/kroma/utils/service/txmgr/metrics"
+)
+
+// ErrFeeBudgetExhausted is the error returned by Send while the fees spent within the budget window
+// reach DailyFeeBudget.
+var ErrFeeBudgetExhausted = errors.New("fee budget exhausted")
+
+// feeBudgetWindow is the window of DailyFeeBudget.
+const feeBudgetWindow = 24 * time.Hour
+
+// feeSpend is the fee spent by a confirmed tx.
+type feeSpend struct {
+	at  time.Time
+	fee *big.Int
+}
+
+// feeBudget tracks the fees spent by the confirmed txs within a sliding window, so that the new sends
+// are refused once the budget is spent. The sends in flight go on, so the budget may be overshot
+// by the fees of the txs already sent.
+type feeBudget struct {
+	budget *big.Int
+	window time.Duration
+	metr   metrics.TxMetricer
+
+	mu     sync.Mutex
+	spends []feeSpend
+	spent  *big.Int
+}
+
+func newFeeBudget(budget *big.Int, window time.Duration, metr metrics.TxMetricer) *feeBudget {
+	return &feeBudget{
+		budget: budget,
+		window: window,
+		metr:   metr,
+		spent:  new(big.Int),
+	}
+}
+
+// record records the fee spent at the given time.
+func (b *feeBudget) record(at time.Time, fee *big.Int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.spends = append(b.spends, feeSpend{at: at, fee: fee})
+	b.spent.Add(b.spent, fee)
+	b.update(at)
+}
+
+// check returns ErrFeeBudgetExhausted if the fees spent within the window before now reach the budget.
+func (b *feeBudget) check(now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.update(now) {
+		return fmt.Errorf("%w: spent %v wei of %v wei within %v", ErrFeeBudgetExhausted, b.spent, b.budget, b.window)
+	}
+	return nil
+}
+
+// update forgets the spends out of the window before now, records the metric, and returns whether
+// the budget is exhausted. It must be called with the lock held.
+func (b *feeBudget) update(now time.Time) bool {
+	expired := 0
+	for expired < len(b.spends) && now.Sub(b.spends[expired].at) >= b.window {
+		b.spent.Sub(b.spent, b.spends[expired].fee)
+		expired++
+	}
+	b.spends = b.spends[expired:]
+	exhausted := b.spent.Cmp(b.budget) >= 0
+	b.metr.RecordFeeBudgetExhausted(exhausted)
+	return exhausted
+}
+
+// recordFee records the fee of the confirmed tx to the fee budget, if any.
+func (m *SimpleTxManager) recordFee(receipt *types.Receipt) {
+	if m.budget == nil || receipt.EffectiveGasPrice == nil {
+		return
+	}
+	fee := new(big.Int).Mul(receipt.EffectiveGasPrice, new(big.Int).SetUint64(receipt.GasUsed))
+	m.budget.record(m.clock().Now(), fee)
+}
+
+// checkFeeBudget returns ErrFeeBudgetExhausted if the fee budget, if any, is exhausted.
+func (m *SimpleTxManager) checkFeeBudget() error {
+	if m.budget == nil {
+		return nil
+	}
+	return m.budget.check(m.clock().Now())
+}
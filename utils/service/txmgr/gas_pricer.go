@@ -0,0 +1,162 @@
+package txmgr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+)
+
+// GasPricer suggests the gas tip cap of the new and bumped txs.
+// The basefee is always taken from the L1 head.
+type GasPricer interface {
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+}
+
+// GasPriceStrategy is the strategy of the GasPricer built from the CLI config.
+type GasPriceStrategy string
+
+const (
+	// GasPriceStrategyNode takes the tip cap suggested by the L1 node, i.e. eth_maxPriorityFeePerGas.
+	GasPriceStrategyNode GasPriceStrategy = "node"
+	// GasPriceStrategyFeeHistory takes the median of the given percentile of the tips paid in
+	// the latest blocks, queried with eth_feeHistory.
+	GasPriceStrategyFeeHistory GasPriceStrategy = "fee-history"
+	// GasPriceStrategyFixed always takes the configured tip cap.
+	GasPriceStrategyFixed GasPriceStrategy = "fixed"
+	// GasPriceStrategyOracle takes the tip cap served by an external gas oracle.
+	GasPriceStrategyOracle GasPriceStrategy = "oracle"
+)
+
+// Check returns an error if the strategy is unknown. The empty strategy is accepted as GasPriceStrategyNode.
+func (s GasPriceStrategy) Check() error {
+	switch s {
+	case "", GasPriceStrategyNode, GasPriceStrategyFeeHistory, GasPriceStrategyFixed, GasPriceStrategyOracle:
+		return nil
+	default:
+		return fmt.Errorf("unknown gas price strategy: %s", s)
+	}
+}
+
+// feeHistoryBlocks is the number of the latest blocks whose tips are considered by the fee history strategy.
+const feeHistoryBlocks = 10
+
+// FeeHistoryReader is implemented by the backends supporting eth_feeHistory.
+type FeeHistoryReader interface {
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+}
+
+// GasPriceSuggester is implemented by the backends supporting eth_gasPrice, which prices the legacy txs.
+type GasPriceSuggester interface {
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+}
+
+// FeeHistoryGasPricer suggests the median of the given percentile of the tips paid in the latest blocks,
+// so that the suggestion follows the actual inclusion market rather than the heuristic of the node.
+type FeeHistoryGasPricer struct {
+	backend    FeeHistoryReader
+	percentile float64
+}
+
+func NewFeeHistoryGasPricer(backend FeeHistoryReader, percentile float64) *FeeHistoryGasPricer {
+	return &FeeHistoryGasPricer{backend: backend, percentile: percentile}
+}
+
+func (p *FeeHistoryGasPricer) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	history, err := p.backend.FeeHistory(ctx, feeHistoryBlocks, nil, []float64{p.percentile})
+	if err != nil {
+		return nil, err
+	}
+	tips := make([]*big.Int, 0, len(history.Reward))
+	for _, rewards := range history.Reward {
+		if len(rewards) > 0 && rewards[0] != nil {
+			tips = append(tips, rewards[0])
+		}
+	}
+	if len(tips) == 0 {
+		return nil, errors.New("fee history has no rewards")
+	}
+	sort.Slice(tips, func(i, j int) bool { return tips[i].Cmp(tips[j]) < 0 })
+	return new(big.Int).Set(tips[len(tips)/2]), nil
+}
+
+// FixedGasPricer always suggests the same tip cap.
+type FixedGasPricer struct {
+	tipCap *big.Int
+}
+
+func NewFixedGasPricer(tipCap *big.Int) *FixedGasPricer {
+	return &FixedGasPricer{tipCap: tipCap}
+}
+
+func (p *FixedGasPricer) SuggestGasTipCap(context.Context) (*big.Int, error) {
+	return new(big.Int).Set(p.tipCap), nil
+}
+
+// OracleGasPricer suggests the tip cap served by an external gas oracle. The oracle is queried
+// with a GET request, and must answer a JSON object whose maxPriorityFeePerGas field is the tip cap in gwei.
+type OracleGasPricer struct {
+	url    string
+	client *http.Client
+}
+
+func NewOracleGasPricer(url string) *OracleGasPricer {
+	return &OracleGasPricer{url: url, client: &http.Client{}}
+}
+
+func (p *OracleGasPricer) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the gas oracle request: %w", err)
+	}
+	res, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("gas oracle responded with status %d", res.StatusCode)
+	}
+
+	var resp struct {
+		MaxPriorityFeePerGas *float64 `json:"maxPriorityFeePerGas"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to decode the gas oracle response: %w", err)
+	}
+	if resp.MaxPriorityFeePerGas == nil || *resp.MaxPriorityFeePerGas < 0 {
+		return nil, errors.New("gas oracle responded without a valid maxPriorityFeePerGas")
+	}
+	if tipCap := gweiToWei(*resp.MaxPriorityFeePerGas); tipCap != nil {
+		return tipCap, nil
+	}
+	return new(big.Int), nil
+}
+
+// gasPricerFromConfig builds the GasPricer of the strategy of the CLI config. The node strategy returns nil,
+// in which case the tip cap is suggested by the Backend.
+func gasPricerFromConfig(cfg CLIConfig, l1 ETHBackend) (GasPricer, error) {
+	switch cfg.GasPriceStrategy {
+	case GasPriceStrategyFeeHistory:
+		reader, ok := l1.(FeeHistoryReader)
+		if !ok {
+			return nil, errors.New("L1 backend does not support eth_feeHistory")
+		}
+		return NewFeeHistoryGasPricer(reader, cfg.GasPricePercentile), nil
+	case GasPriceStrategyFixed:
+		tipCap := gweiToWei(cfg.FixedGasTipCapGwei)
+		if tipCap == nil {
+			tipCap = new(big.Int)
+		}
+		return NewFixedGasPricer(tipCap), nil
+	case GasPriceStrategyOracle:
+		return NewOracleGasPricer(cfg.GasOracleURL), nil
+	default:
+		return nil, nil
+	}
+}
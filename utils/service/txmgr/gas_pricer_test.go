@@ -0,0 +1,81 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/stretchr/testify/require"
+)
+
+type feeHistoryReader struct {
+	rewards     [][]*big.Int
+	err         error
+	percentiles []float64
+}
+
+func (r *feeHistoryReader) FeeHistory(_ context.Context, _ uint64, _ *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	r.percentiles = rewardPercentiles
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &ethereum.FeeHistory{Reward: r.rewards}, nil
+}
+
+func TestFeeHistoryGasPricer(t *testing.T) {
+	reader := &feeHistoryReader{rewards: [][]*big.Int{
+		{big.NewInt(30)}, {big.NewInt(10)}, {}, {big.NewInt(20)}, {big.NewInt(50)},
+	}}
+	tip, err := NewFeeHistoryGasPricer(reader, 60).SuggestGasTipCap(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(30), tip)
+	require.Equal(t, []float64{60}, reader.percentiles)
+
+	reader.rewards = nil
+	_, err = NewFeeHistoryGasPricer(reader, 60).SuggestGasTipCap(context.Background())
+	require.Error(t, err)
+
+	reader.err = errors.New("method not found")
+	_, err = NewFeeHistoryGasPricer(reader, 60).SuggestGasTipCap(context.Background())
+	require.ErrorIs(t, err, reader.err)
+}
+
+func TestOracleGasPricer(t *testing.T) {
+	body := `{"maxPriorityFeePerGas": 1.5}`
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+	pricer := NewOracleGasPricer(srv.URL)
+
+	tip, err := pricer.SuggestGasTipCap(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1_500_000_000), tip)
+
+	body = `{"maxPriorityFeePerGas": 0}`
+	tip, err = pricer.SuggestGasTipCap(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 0, tip.Sign())
+
+	body = `{"gasPrice": 1}`
+	_, err = pricer.SuggestGasTipCap(context.Background())
+	require.Error(t, err)
+
+	status = http.StatusInternalServerError
+	_, err = pricer.SuggestGasTipCap(context.Background())
+	require.Error(t, err)
+}
+
+func TestGasPriceStrategyCheck(t *testing.T) {
+	for _, s := range []GasPriceStrategy{"", GasPriceStrategyNode, GasPriceStrategyFeeHistory, GasPriceStrategyFixed, GasPriceStrategyOracle} {
+		require.NoError(t, s.Check())
+	}
+	require.Error(t, GasPriceStrategy("median").Check())
+}
@@ -0,0 +1,132 @@
+package txmgr
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/kroma-network/kroma/utils/service/clock"
+)
+
+// headSubscriber is implemented by the backends supporting new head subscriptions, like ethclient.Client
+// connected over websocket.
+type headSubscriber interface {
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+}
+
+// headTracker caches the block number of the L1 head, so that the confirmation checks of all
+// the in-flight txs share a single source instead of querying the backend for each tx.
+// If the backend supports new head subscriptions, the cache is kept up to date by the notifications,
+// which also wake up the receipt checks. Otherwise, or if the subscription fails, the head is polled
+// once the cache is older than maxAge.
+type headTracker struct {
+	backend ETHBackend
+	maxAge  time.Duration
+	clock   clock.Clock
+	l       log.Logger
+
+	mu         sync.Mutex
+	head       uint64
+	updatedAt  time.Time
+	subscribed bool
+	// newHead is closed and replaced on each new head while the subscription is live, and nil otherwise.
+	newHead chan struct{}
+}
+
+func newHeadTracker(backend ETHBackend, maxAge time.Duration, clock clock.Clock, l log.Logger) *headTracker {
+	return &headTracker{
+		backend: backend,
+		maxAge:  maxAge,
+		clock:   clock,
+		l:       l,
+	}
+}
+
+// BlockNumber returns the block number of the L1 head, which is at most maxAge old.
+func (h *headTracker) BlockNumber(ctx context.Context) (uint64, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.subscribed {
+		h.subscribe()
+	}
+	if !h.updatedAt.IsZero() && h.clock.Now().Sub(h.updatedAt) < h.maxAge {
+		return h.head, nil
+	}
+
+	// Concurrent callers wait for this request instead of issuing their own.
+	head, err := h.backend.BlockNumber(ctx)
+	if err != nil {
+		return 0, err
+	}
+	h.update(head)
+	return head, nil
+}
+
+// NewHead returns a channel closed on the next new head notification, or when the subscription fails.
+// It returns nil if the backend does not support new head subscriptions or the subscription has failed,
+// in which case the caller must poll.
+func (h *headTracker) NewHead() <-chan struct{} {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.subscribed {
+		h.subscribe()
+	}
+	if h.newHead == nil {
+		return nil
+	}
+	return h.newHead
+}
+
+func (h *headTracker) update(head uint64) {
+	h.head = head
+	h.updatedAt = h.clock.Now()
+}
+
+// subscribe subscribes to the new heads if the backend supports it. It must be called with the lock held.
+// If the subscription fails later, it is retried on the next BlockNumber call.
+func (h *headTracker) subscribe() {
+	subscriber, ok := h.backend.(headSubscriber)
+	if !ok {
+		// Never retry the subscription.
+		h.subscribed = true
+		return
+	}
+	headCh := make(chan *types.Header, 1)
+	sub, err := subscriber.SubscribeNewHead(context.Background(), headCh)
+	if err != nil {
+		h.l.Info("new head subscription is not available, polling the head instead", "err", err)
+		h.subscribed = true
+		return
+	}
+	h.subscribed = true
+	h.newHead = make(chan struct{})
+
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case header := <-headCh:
+				h.mu.Lock()
+				h.update(header.Number.Uint64())
+				close(h.newHead)
+				h.newHead = make(chan struct{})
+				h.mu.Unlock()
+			case err := <-sub.Err():
+				h.l.Warn("new head subscription failed, polling the head instead", "err", err)
+				h.mu.Lock()
+				h.subscribed = false
+				// Wake up the waiters, so that they fall back to polling.
+				close(h.newHead)
+				h.newHead = nil
+				h.mu.Unlock()
+				return
+			}
+		}
+	}()
+}
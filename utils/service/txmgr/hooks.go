@@ -0,0 +1,73 @@
+package txmgr
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TxHook is called at the lifecycle events of the txs, e.g. to log them for audit, gate them behind
+// an external approval, or alert on them. The hooks added with AddHook are called in order.
+// Except BeforeSign, the hooks are called from the send loop, so they must not block.
+// Embed NoopTxHook to implement only some of the events.
+type TxHook interface {
+	// BeforeSign is called with the unsigned tx before it is signed, including the resubmissions and
+	// the fills of the nonce gaps. If it returns an error, the tx is not signed: the send fails with
+	// the error, or the tx is resubmitted without a bump. It may block until ctx is done.
+	BeforeSign(ctx context.Context, tx *types.Transaction) error
+	// AfterBroadcast is called once the tx, or one of its resubmissions, is accepted to the mempool.
+	AfterBroadcast(tx *types.Transaction)
+	// OnBump is called when the fees of the tx are bumped, with the tx replaced by the bumped one.
+	OnBump(replaced, bumped *types.Transaction)
+	// OnConfirmed is called with the receipt of the tx once it has NumConfirmations confirmations,
+	// whatever its status.
+	OnConfirmed(receipt *types.Receipt)
+}
+
+// NoopTxHook is a TxHook doing nothing, to be embedded by the hooks of some of the events.
+type NoopTxHook struct{}
+
+func (NoopTxHook) BeforeSign(context.Context, *types.Transaction) error { return nil }
+func (NoopTxHook) AfterBroadcast(*types.Transaction)                    {}
+func (NoopTxHook) OnBump(*types.Transaction, *types.Transaction)        {}
+func (NoopTxHook) OnConfirmed(*types.Receipt)                           {}
+
+// AddHook adds the hook to the chain of the hooks called at the lifecycle events of the txs.
+// It must be called before any tx is sent.
+func (m *SimpleTxManager) AddHook(hook TxHook) {
+	m.hooks = append(m.hooks, hook)
+	for _, am := range m.secondaryAccountManagers() {
+		am.AddHook(hook)
+	}
+}
+
+// signTx calls the BeforeSign hooks, then signs the tx, bounding the signing by NetworkTimeout.
+func (m *SimpleTxManager) signTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	for _, hook := range m.hooks {
+		if err := hook.BeforeSign(ctx, tx); err != nil {
+			return nil, fmt.Errorf("tx rejected before signing: %w", err)
+		}
+	}
+	cCtx, cancel := context.WithTimeout(ctx, m.NetworkTimeout)
+	defer cancel()
+	return m.Signer(cCtx, m.Config.From, tx)
+}
+
+func (m *SimpleTxManager) afterBroadcast(tx *types.Transaction) {
+	for _, hook := range m.hooks {
+		hook.AfterBroadcast(tx)
+	}
+}
+
+func (m *SimpleTxManager) onBump(replaced, bumped *types.Transaction) {
+	for _, hook := range m.hooks {
+		hook.OnBump(replaced, bumped)
+	}
+}
+
+func (m *SimpleTxManager) onConfirmed(receipt *types.Receipt) {
+	for _, hook := range m.hooks {
+		hook.OnConfirmed(receipt)
+	}
+}
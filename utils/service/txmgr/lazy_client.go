@@ -0,0 +1,177 @@
+package txmgr
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/kroma-network/kroma/utils/service/backoff"
+)
+
+// Connector is implemented by the backends dialed lazily, which can be connected ahead of their first use.
+type Connector interface {
+	Connect(ctx context.Context) error
+}
+
+// lazyClient is an L1 endpoint dialed on its first use or by Connect, retrying with the network
+// retry backoff, so that the tx manager can be constructed while the endpoint is not available yet.
+type lazyClient struct {
+	url     string
+	timeout time.Duration
+	retry   Config
+
+	mu     sync.Mutex
+	client *accessListClient
+}
+
+func newLazyClient(url string, cfg CLIConfig) *lazyClient {
+	return &lazyClient{
+		url:     url,
+		timeout: cfg.NetworkTimeout,
+		retry: Config{
+			NetworkRetryInitial:  cfg.NetworkRetryInitial,
+			NetworkRetryMax:      cfg.NetworkRetryMax,
+			NetworkRetryAttempts: cfg.NetworkRetryAttempts,
+		},
+	}
+}
+
+// Connect dials the endpoint, unless it is already connected.
+func (c *lazyClient) Connect(ctx context.Context) error {
+	_, err := c.dial(ctx)
+	return err
+}
+
+func (c *lazyClient) dial(ctx context.Context) (*accessListClient, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.client != nil {
+		return c.client, nil
+	}
+	err := backoff.DoCtx(ctx, c.retry.networkRetryAttempts(), c.retry.networkRetryStrategy(), func() error {
+		dCtx, cancel := context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+		client, err := rpc.DialContext(dCtx, c.url)
+		if err != nil {
+			return err
+		}
+		c.client = newAccessListClient(client)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not dial eth client: %w", err)
+	}
+	return c.client, nil
+}
+
+// lazyCall calls the endpoint, dialing it first if needed.
+func lazyCall[T any](ctx context.Context, c *lazyClient, call func(client *accessListClient) (T, error)) (T, error) {
+	client, err := c.dial(ctx)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return call(client)
+}
+
+func (c *lazyClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return lazyCall(ctx, c, func(client *accessListClient) (uint64, error) {
+		return client.BlockNumber(ctx)
+	})
+}
+
+func (c *lazyClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return lazyCall(ctx, c, func(client *accessListClient) (*types.Receipt, error) {
+		return client.TransactionReceipt(ctx, txHash)
+	})
+}
+
+func (c *lazyClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	_, err := lazyCall(ctx, c, func(client *accessListClient) (struct{}, error) {
+		return struct{}{}, client.SendTransaction(ctx, tx)
+	})
+	return err
+}
+
+func (c *lazyClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return lazyCall(ctx, c, func(client *accessListClient) (*types.Header, error) {
+		return client.HeaderByNumber(ctx, number)
+	})
+}
+
+func (c *lazyClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return lazyCall(ctx, c, func(client *accessListClient) (*big.Int, error) {
+		return client.SuggestGasTipCap(ctx)
+	})
+}
+
+func (c *lazyClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return lazyCall(ctx, c, func(client *accessListClient) (*big.Int, error) {
+		return client.SuggestGasPrice(ctx)
+	})
+}
+
+func (c *lazyClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return lazyCall(ctx, c, func(client *accessListClient) (uint64, error) {
+		return client.NonceAt(ctx, account, blockNumber)
+	})
+}
+
+func (c *lazyClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return lazyCall(ctx, c, func(client *accessListClient) (uint64, error) {
+		return client.PendingNonceAt(ctx, account)
+	})
+}
+
+func (c *lazyClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return lazyCall(ctx, c, func(client *accessListClient) (uint64, error) {
+		return client.EstimateGas(ctx, msg)
+	})
+}
+
+func (c *lazyClient) ChainID(ctx context.Context) (*big.Int, error) {
+	return lazyCall(ctx, c, func(client *accessListClient) (*big.Int, error) {
+		return client.ChainID(ctx)
+	})
+}
+
+func (c *lazyClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return lazyCall(ctx, c, func(client *accessListClient) ([]byte, error) {
+		return client.CallContract(ctx, msg, blockNumber)
+	})
+}
+
+func (c *lazyClient) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	return lazyCall(ctx, c, func(client *accessListClient) ([]byte, error) {
+		return client.PendingCallContract(ctx, msg)
+	})
+}
+
+func (c *lazyClient) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	return lazyCall(ctx, c, func(client *accessListClient) (*ethereum.FeeHistory, error) {
+		return client.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+	})
+}
+
+func (c *lazyClient) TransactionReceipts(ctx context.Context, hashes []common.Hash) ([]*types.Receipt, []error, error) {
+	result, err := lazyCall(ctx, c, func(client *accessListClient) (receiptsResult, error) {
+		receipts, errs, err := client.TransactionReceipts(ctx, hashes)
+		return receiptsResult{receipts: receipts, errs: errs}, err
+	})
+	return result.receipts, result.errs, err
+}
+
+func (c *lazyClient) CreateAccessList(ctx context.Context, msg ethereum.CallMsg) (*types.AccessList, uint64, string, error) {
+	result, err := lazyCall(ctx, c, func(client *accessListClient) (accessListResult, error) {
+		accessList, gasUsed, callErr, err := client.CreateAccessList(ctx, msg)
+		return accessListResult{accessList: accessList, gasUsed: gasUsed, callErr: callErr}, err
+	})
+	return result.accessList, result.gasUsed, result.callErr, err
+}
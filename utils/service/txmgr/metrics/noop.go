@@ -4,9 +4,17 @@ import "github.com/ethereum/go-ethereum/core/types"
 
 type NoopTxMetrics struct{}
 
-func (*NoopTxMetrics) RecordNonce(uint64)                {}
-func (*NoopTxMetrics) RecordGasBumpCount(int)            {}
-func (*NoopTxMetrics) RecordTxConfirmationLatency(int64) {}
-func (*NoopTxMetrics) TxConfirmed(*types.Receipt)        {}
-func (*NoopTxMetrics) TxPublished(string)                {}
-func (*NoopTxMetrics) RPCError()                         {}
+func (*NoopTxMetrics) RecordNonce(uint64)                       {}
+func (*NoopTxMetrics) RecordGasBumpCount(int)                   {}
+func (*NoopTxMetrics) TxBumped()                                {}
+func (*NoopTxMetrics) RecordTxConfirmationLatency(int64)        {}
+func (*NoopTxMetrics) TxConfirmed(*types.Receipt)               {}
+func (*NoopTxMetrics) TxPublished(string)                       {}
+func (*NoopTxMetrics) RPCError()                                {}
+func (*NoopTxMetrics) RecordGasPriceHeld(bool)                  {}
+func (*NoopTxMetrics) TxStuck()                                 {}
+func (*NoopTxMetrics) RecordCircuitOpen(bool)                   {}
+func (*NoopTxMetrics) RecordFeeBudgetExhausted(bool)            {}
+func (*NoopTxMetrics) NonceGapRepaired()                        {}
+func (*NoopTxMetrics) TxReorged()                               {}
+func (*NoopTxMetrics) RecordContractGas(string, *types.Receipt) {}
@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"math/big"
+
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/prometheus/client_golang/prometheus"
@@ -10,11 +12,19 @@ import (
 
 type TxMetricer interface {
 	RecordGasBumpCount(int)
+	TxBumped()
 	RecordTxConfirmationLatency(int64)
 	RecordNonce(uint64)
 	TxConfirmed(*types.Receipt)
 	TxPublished(string)
 	RPCError()
+	RecordGasPriceHeld(bool)
+	TxStuck()
+	RecordCircuitOpen(bool)
+	RecordFeeBudgetExhausted(bool)
+	NonceGapRepaired()
+	TxReorged()
+	RecordContractGas(contract string, receipt *types.Receipt)
 }
 
 type TxMetrics struct {
@@ -26,8 +36,25 @@ type TxMetrics struct {
 	publishEvent       metrics.Event
 	confirmEvent       metrics.EventVec
 	rpcError           prometheus.Counter
+	gasPriceHeld       prometheus.Gauge
+	publishAttempts    prometheus.Counter
+	nonceTooLow        prometheus.Counter
+	feeBumps           prometheus.Counter
+	confirmLatency     prometheus.Histogram
+	feeSpent           prometheus.Counter
+	stuckTxs           prometheus.Counter
+	circuitOpen        prometheus.Gauge
+	feeBudgetExhausted prometheus.Gauge
+	nonceGapRepairs    prometheus.Counter
+	reorgedTxs         prometheus.Counter
+	contractGasUsed    *prometheus.CounterVec
+	contractGasPrice   *prometheus.GaugeVec
+	contractFeeSpent   *prometheus.CounterVec
 }
 
+// NonceTooLowError is the sanitized error string of the nonce too low publish errors.
+const NonceTooLowError = "nonce_to_low"
+
 func receiptStatusString(receipt *types.Receipt) string {
 	switch receipt.Status {
 	case types.ReceiptStatusSuccessful:
@@ -81,6 +108,91 @@ func MakeTxMetrics(ns string, factory metrics.Factory) TxMetrics {
 			Help:      "Temporary: Count of RPC errors (like timeouts) that have occurred",
 			Subsystem: "txmgr",
 		}),
+		gasPriceHeld: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "gas_price_held",
+			Help:      "1 if a transaction is held because the L1 gas price exceeds the max gas price, 0 otherwise",
+			Subsystem: "txmgr",
+		}),
+		publishAttempts: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "tx_publish_attempt_count",
+			Help:      "Count of transaction publish attempts, including the failed ones",
+			Subsystem: "txmgr",
+		}),
+		nonceTooLow: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "tx_nonce_too_low_count",
+			Help:      "Count of transaction publish attempts rejected with nonce too low",
+			Subsystem: "txmgr",
+		}),
+		feeBumps: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "tx_fee_bump_count",
+			Help:      "Count of transaction resubmissions with bumped fees",
+			Subsystem: "txmgr",
+		}),
+		confirmLatency: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "tx_confirmed_latency_seconds",
+			Buckets:   []float64{1, 2, 4, 8, 12, 16, 24, 36, 48, 60, 90, 120, 180, 300, 600, 1200},
+			Help:      "Histogram of the latency between the publication and the confirmation of transactions in seconds",
+			Subsystem: "txmgr",
+		}),
+		feeSpent: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "tx_fee_spent_eth",
+			Help:      "Cumulative L1 fee spent by the confirmed transactions in ETH",
+			Subsystem: "txmgr",
+		}),
+		stuckTxs: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "tx_stuck_count",
+			Help:      "Count of transactions detected as stuck, i.e. resubmitted or pending for too long",
+			Subsystem: "txmgr",
+		}),
+		circuitOpen: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "circuit_open",
+			Help:      "1 if the new transactions are paused because the L1 backend keeps failing, 0 otherwise",
+			Subsystem: "txmgr",
+		}),
+		feeBudgetExhausted: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "fee_budget_exhausted",
+			Help:      "1 if the new transactions are refused because the daily fee budget is spent, 0 otherwise",
+			Subsystem: "txmgr",
+		}),
+		nonceGapRepairs: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "nonce_gap_repairs",
+			Help:      "Count of the nonce gaps below a pending transaction filled with a self-transfer",
+			Subsystem: "txmgr",
+		}),
+		reorgedTxs: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "tx_reorged_count",
+			Help:      "Count of the confirmed transactions reorged out of L1",
+			Subsystem: "txmgr",
+		}),
+		contractGasUsed: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "tx_gas_used_total",
+			Help:      "Cumulative gas used by the confirmed transactions, by destination contract",
+			Subsystem: "txmgr",
+		}, []string{"contract"}),
+		contractGasPrice: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "tx_effective_gas_price_gwei",
+			Help:      "Effective gas price of the last confirmed transaction in GWEI, by destination contract",
+			Subsystem: "txmgr",
+		}, []string{"contract"}),
+		contractFeeSpent: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "tx_contract_fee_spent_eth",
+			Help:      "Cumulative L1 fee spent by the confirmed transactions in ETH, by destination contract",
+			Subsystem: "txmgr",
+		}, []string{"contract"}),
 	}
 }
 
@@ -92,17 +204,29 @@ func (t *TxMetrics) RecordNonce(nonce uint64) {
 func (t *TxMetrics) TxConfirmed(receipt *types.Receipt) {
 	t.confirmEvent.Record(receiptStatusString(receipt))
 	t.TxL1GasFee.Set(float64(receipt.EffectiveGasPrice.Uint64() * receipt.GasUsed / params.GWei))
+	fee := new(big.Int).Mul(receipt.EffectiveGasPrice, new(big.Int).SetUint64(receipt.GasUsed))
+	feeEth, _ := new(big.Float).Quo(new(big.Float).SetInt(fee), big.NewFloat(params.Ether)).Float64()
+	t.feeSpent.Add(feeEth)
 }
 
 func (t *TxMetrics) RecordGasBumpCount(times int) {
 	t.TxGasBump.Set(float64(times))
 }
 
+func (t *TxMetrics) TxBumped() {
+	t.feeBumps.Inc()
+}
+
 func (t *TxMetrics) RecordTxConfirmationLatency(latency int64) {
 	t.LatencyConfirmedTx.Set(float64(latency))
+	t.confirmLatency.Observe(float64(latency) / 1000)
 }
 
 func (t *TxMetrics) TxPublished(errString string) {
+	t.publishAttempts.Inc()
+	if errString == NonceTooLowError {
+		t.nonceTooLow.Inc()
+	}
 	if errString != "" {
 		t.txPublishError.WithLabelValues(errString).Inc()
 	} else {
@@ -113,3 +237,53 @@ func (t *TxMetrics) TxPublished(errString string) {
 func (t *TxMetrics) RPCError() {
 	t.rpcError.Inc()
 }
+
+func (t *TxMetrics) RecordGasPriceHeld(held bool) {
+	if held {
+		t.gasPriceHeld.Set(1)
+	} else {
+		t.gasPriceHeld.Set(0)
+	}
+}
+
+func (t *TxMetrics) TxStuck() {
+	t.stuckTxs.Inc()
+}
+
+func (t *TxMetrics) RecordCircuitOpen(open bool) {
+	if open {
+		t.circuitOpen.Set(1)
+	} else {
+		t.circuitOpen.Set(0)
+	}
+}
+
+func (t *TxMetrics) RecordFeeBudgetExhausted(exhausted bool) {
+	if exhausted {
+		t.feeBudgetExhausted.Set(1)
+	} else {
+		t.feeBudgetExhausted.Set(0)
+	}
+}
+
+func (t *TxMetrics) NonceGapRepaired() {
+	t.nonceGapRepairs.Inc()
+}
+
+func (t *TxMetrics) TxReorged() {
+	t.reorgedTxs.Inc()
+}
+
+// RecordContractGas records the gas used and the effective gas price of the confirmed transaction
+// under the label of its destination contract.
+func (t *TxMetrics) RecordContractGas(contract string, receipt *types.Receipt) {
+	t.contractGasUsed.WithLabelValues(contract).Add(float64(receipt.GasUsed))
+	if receipt.EffectiveGasPrice == nil {
+		return
+	}
+	price, _ := new(big.Float).Quo(new(big.Float).SetInt(receipt.EffectiveGasPrice), big.NewFloat(params.GWei)).Float64()
+	t.contractGasPrice.WithLabelValues(contract).Set(price)
+	fee := new(big.Int).Mul(receipt.EffectiveGasPrice, new(big.Int).SetUint64(receipt.GasUsed))
+	feeEth, _ := new(big.Float).Quo(new(big.Float).SetInt(fee), big.NewFloat(params.Ether)).Float64()
+	t.contractFeeSpent.WithLabelValues(contract).Add(feeEth)
+}
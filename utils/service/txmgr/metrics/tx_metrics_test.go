@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/utils/service/metrics"
+)
+
+func TestTxMetrics(t *testing.T) {
+	m := MakeTxMetrics("test", metrics.With(prometheus.NewRegistry()))
+
+	m.TxPublished("")
+	m.TxPublished(NonceTooLowError)
+	m.TxPublished("tx_underpriced")
+	require.Equal(t, 3.0, testutil.ToFloat64(m.publishAttempts))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.nonceTooLow))
+
+	m.TxBumped()
+	m.TxBumped()
+	require.Equal(t, 2.0, testutil.ToFloat64(m.feeBumps))
+
+	m.TxStuck()
+	require.Equal(t, 1.0, testutil.ToFloat64(m.stuckTxs))
+
+	m.RecordCircuitOpen(true)
+	require.Equal(t, 1.0, testutil.ToFloat64(m.circuitOpen))
+	m.RecordCircuitOpen(false)
+	require.Equal(t, 0.0, testutil.ToFloat64(m.circuitOpen))
+
+	m.RecordFeeBudgetExhausted(true)
+	require.Equal(t, 1.0, testutil.ToFloat64(m.feeBudgetExhausted))
+	m.RecordFeeBudgetExhausted(false)
+	require.Equal(t, 0.0, testutil.ToFloat64(m.feeBudgetExhausted))
+
+	m.NonceGapRepaired()
+	require.Equal(t, 1.0, testutil.ToFloat64(m.nonceGapRepairs))
+
+	m.TxReorged()
+	require.Equal(t, 1.0, testutil.ToFloat64(m.reorgedTxs))
+
+	m.RecordTxConfirmationLatency(1500)
+	require.Equal(t, 1, testutil.CollectAndCount(m.confirmLatency))
+
+	receipt := &types.Receipt{
+		Status:            types.ReceiptStatusSuccessful,
+		GasUsed:           100_000,
+		EffectiveGasPrice: big.NewInt(20 * params.GWei),
+	}
+	m.TxConfirmed(receipt)
+	m.TxConfirmed(receipt)
+	require.InDelta(t, 0.004, testutil.ToFloat64(m.feeSpent), 1e-12)
+
+	m.RecordContractGas("l2_output_oracle", receipt)
+	m.RecordContractGas("l2_output_oracle", receipt)
+	m.RecordContractGas("other", receipt)
+	require.Equal(t, 200_000.0, testutil.ToFloat64(m.contractGasUsed.WithLabelValues("l2_output_oracle")))
+	require.Equal(t, 20.0, testutil.ToFloat64(m.contractGasPrice.WithLabelValues("l2_output_oracle")))
+	require.InDelta(t, 0.002, testutil.ToFloat64(m.contractFeeSpent.WithLabelValues("other")), 1e-12)
+}
@@ -19,6 +19,20 @@ type TxManager struct {
 	mock.Mock
 }
 
+// Cancel provides a mock function with given fields: ctx, nonce
+func (_m *TxManager) Cancel(ctx context.Context, nonce uint64) error {
+	ret := _m.Called(ctx, nonce)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, nonce)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // From provides a mock function with given fields:
 func (_m *TxManager) From() common.Address {
 	ret := _m.Called()
@@ -35,6 +49,32 @@ func (_m *TxManager) From() common.Address {
 	return r0
 }
 
+// Replace provides a mock function with given fields: ctx, nonce, candidate
+func (_m *TxManager) Replace(ctx context.Context, nonce uint64, candidate txmgr.TxCandidate) (*types.Receipt, error) {
+	ret := _m.Called(ctx, nonce, candidate)
+
+	var r0 *types.Receipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, txmgr.TxCandidate) (*types.Receipt, error)); ok {
+		return rf(ctx, nonce, candidate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, txmgr.TxCandidate) *types.Receipt); ok {
+		r0 = rf(ctx, nonce, candidate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, txmgr.TxCandidate) error); ok {
+		r1 = rf(ctx, nonce, candidate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Send provides a mock function with given fields: ctx, candidate
 func (_m *TxManager) Send(ctx context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
 	ret := _m.Called(ctx, candidate)
@@ -61,6 +101,11 @@ func (_m *TxManager) Send(ctx context.Context, candidate txmgr.TxCandidate) (*ty
 	return r0, r1
 }
 
+// SendAsync provides a mock function with given fields: ctx, candidate, onStatus
+func (_m *TxManager) SendAsync(ctx context.Context, candidate txmgr.TxCandidate, onStatus func(txmgr.TxStatus)) {
+	_m.Called(ctx, candidate, onStatus)
+}
+
 type mockConstructorTestingTNewTxManager interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,122 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// maxMulticallCalls is the maximum number of the calls aggregated into a single Multicall3 tx.
+const maxMulticallCalls = 50
+
+// multicall3ABI is the ABI of the aggregate3Value function of Multicall3.
+const multicall3ABI = `[{"inputs":[{"components":[{"internalType":"address","name":"target","type":"address"},{"internalType":"bool","name":"allowFailure","type":"bool"},{"internalType":"uint256","name":"value","type":"uint256"},{"internalType":"bytes","name":"callData","type":"bytes"}],"internalType":"struct Multicall3.Call3Value[]","name":"calls","type":"tuple[]"}],"name":"aggregate3Value","outputs":[{"components":[{"internalType":"bool","name":"success","type":"bool"},{"internalType":"bytes","name":"returnData","type":"bytes"}],"internalType":"struct Multicall3.Result[]","name":"returnData","type":"tuple[]"}],"stateMutability":"payable","type":"function"}]`
+
+var multicall3 = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(multicall3ABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// call3Value is the Call3Value struct of Multicall3.
+type call3Value struct {
+	Target       common.Address
+	AllowFailure bool
+	Value        *big.Int
+	CallData     []byte
+}
+
+// multicallEnabled returns whether the aggregatable candidates are aggregated into Multicall3 txs.
+func (m *SimpleTxManager) multicallEnabled() bool {
+	return m.MulticallAddress != (common.Address{})
+}
+
+// multicallCandidate returns the candidate of the Multicall3 tx making all the calls of the candidates.
+// None of the calls may fail, so that a failing call reverts the tx like it would revert its own tx.
+// The gas limit is the sum of the gas limits of the candidates if they are all given, or estimated.
+// The deadline is the earliest deadline of the candidates, and the precondition fails if any of theirs fails.
+func (m *SimpleTxManager) multicallCandidate(candidates []TxCandidate) (TxCandidate, error) {
+	calls := make([]call3Value, len(candidates))
+	value := new(big.Int)
+	var gasLimit uint64
+	var estimate bool
+	var deadline time.Time
+	var preconditions []Precondition
+	for i, candidate := range candidates {
+		if candidate.To == nil {
+			return TxCandidate{}, errors.New("contract creation cannot be aggregated")
+		}
+		callValue := candidate.Value
+		if callValue == nil {
+			callValue = new(big.Int)
+		}
+		calls[i] = call3Value{Target: *candidate.To, Value: callValue, CallData: candidate.TxData}
+		value.Add(value, callValue)
+		gasLimit += candidate.GasLimit
+		estimate = estimate || candidate.GasLimit == 0
+		if !candidate.Deadline.IsZero() && (deadline.IsZero() || candidate.Deadline.Before(deadline)) {
+			deadline = candidate.Deadline
+		}
+		if candidate.Precondition != nil {
+			preconditions = append(preconditions, candidate.Precondition)
+		}
+	}
+	data, err := multicall3.Pack("aggregate3Value", calls)
+	if err != nil {
+		return TxCandidate{}, fmt.Errorf("failed to pack the multicall: %w", err)
+	}
+	if estimate {
+		gasLimit = 0
+	}
+	to := m.MulticallAddress
+	return TxCandidate{
+		TxData:       data,
+		To:           &to,
+		GasLimit:     gasLimit,
+		Value:        value,
+		Deadline:     deadline,
+		Precondition: allPreconditions(preconditions),
+	}, nil
+}
+
+// sendAggregated sends the requests in a single Multicall3 tx, and responds the receipt or the error
+// of the tx to all of them. The tx is sent until ctx is done, regardless of the contexts of the requests,
+// since the requests share it.
+func (m *BufferedTxManager) sendAggregated(ctx context.Context, txRequests []*TxRequest) {
+	candidates := make([]TxCandidate, len(txRequests))
+	for i, txRequest := range txRequests {
+		candidates[i] = *txRequest.txCandidate
+	}
+	response := &TxResponse{}
+	candidate, err := m.multicallCandidate(candidates)
+	if err != nil {
+		response.Err = err
+	} else {
+		m.l.Info("aggregating tx requests into a multicall", "calls", len(txRequests))
+		response.Receipt, response.Err = m.Send(ctx, candidate)
+	}
+	if response.Err != nil {
+		m.l.Error("failed to send multicall transaction in buffered tx manager", "calls", len(txRequests), "err", response.Err)
+	}
+	for _, txRequest := range txRequests {
+		txRequest.responseChan <- response
+	}
+}
+
+// collectAggregatable waits for MulticallWindow, then takes the aggregatable requests queued meanwhile
+// to be aggregated with the given one.
+func (m *BufferedTxManager) collectAggregatable(ctx context.Context, txRequest *TxRequest) []*TxRequest {
+	select {
+	case <-m.clock().After(m.MulticallWindow):
+	case <-ctx.Done():
+	}
+	return append([]*TxRequest{txRequest}, m.queue.PopAggregatable(maxMulticallCalls-1)...)
+}
@@ -0,0 +1,157 @@
+package txmgr
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// nonceTracker reserves the nonces of the concurrent sends, so that up to MaxPendingTxs txs
+// of the sender are in flight at once, each with its own nonce and bump loop.
+type nonceTracker struct {
+	// slots holds a token for each send in flight.
+	slots chan struct{}
+
+	mu sync.Mutex
+	// next is the nonce to reserve next. It is nil until fetched, and after a reset.
+	next *uint64
+	// inflight counts the txs in flight at each nonce, including the fills of the nonce gaps.
+	inflight map[uint64]int
+}
+
+func newNonceTracker(maxPendingTxs uint64) *nonceTracker {
+	return &nonceTracker{
+		slots:    make(chan struct{}, maxPendingTxs),
+		inflight: make(map[uint64]int),
+	}
+}
+
+// acquire waits for a free slot until ctx is done.
+func (t *nonceTracker) acquire(ctx context.Context) error {
+	select {
+	case t.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees the slot taken by acquire.
+func (t *nonceTracker) release() {
+	<-t.slots
+}
+
+// reserve returns the next nonce and increments it. The nonce is fetched with fetch when unknown;
+// from the pending state if other txs are in flight, so that their nonces are not reused,
+// and from the latest block otherwise, so that the stuck txs of a previous run are replaced.
+func (t *nonceTracker) reserve(ctx context.Context, fetch func(ctx context.Context, pending bool) (uint64, error)) (uint64, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.next == nil {
+		// The slot of the caller is counted among the taken ones.
+		nonce, err := fetch(ctx, len(t.slots) > 1)
+		if err != nil {
+			return 0, err
+		}
+		t.next = &nonce
+	}
+	nonce := *t.next
+	*t.next++
+	return nonce, nil
+}
+
+// reset forgets the next nonce, so that it is fetched again. It must be called when a send fails
+// after reserving its nonce, since the nonce may never be taken and would stall the later txs.
+func (t *nonceTracker) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.next = nil
+}
+
+// track records a tx in flight at the nonce.
+func (t *nonceTracker) track(nonce uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.inflight[nonce]++
+}
+
+// untrack forgets a tx in flight at the nonce recorded by track or claimGaps.
+func (t *nonceTracker) untrack(nonce uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.inflight[nonce]--; t.inflight[nonce] <= 0 {
+		delete(t.inflight, nonce)
+	}
+}
+
+// claimGaps returns the nonces from latest up to the given one that no tx in flight holds,
+// i.e. the gaps left by the dropped txs, and tracks them so that each gap is filled once.
+func (t *nonceTracker) claimGaps(latest, nonce uint64) []uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var gaps []uint64
+	for n := latest; n < nonce; n++ {
+		if t.inflight[n] == 0 {
+			gaps = append(gaps, n)
+			t.inflight[n]++
+		}
+	}
+	return gaps
+}
+
+// repairNonceGaps fills the nonce gaps below the pending tx at the nonce, which would stall it forever,
+// with zero-value self-transfers. The gaps are left by the txs dropped after their sends gave up,
+// e.g. on a deadline. The fills are sent in the background, and added to wg.
+func (m *SimpleTxManager) repairNonceGaps(ctx context.Context, nonce uint64, wg *sync.WaitGroup) {
+	if m.nonces == nil {
+		return
+	}
+	cCtx, cancel := context.WithTimeout(ctx, m.NetworkTimeout)
+	latest, err := m.backend.NonceAt(cCtx, m.Config.From, nil)
+	cancel()
+	if err != nil {
+		m.l.Warn("failed to get the nonce to detect the nonce gaps", "err", err)
+		return
+	}
+	for _, gap := range m.nonces.claimGaps(latest, nonce) {
+		m.l.Warn("filling the nonce gap below the pending tx", "gap", gap, "nonce", nonce)
+		m.metr.NonceGapRepaired()
+		wg.Add(1)
+		go func(gap uint64) {
+			defer wg.Done()
+			defer m.nonces.untrack(gap)
+			if err := m.fillNonceGap(ctx, gap); err != nil {
+				m.l.Error("failed to fill the nonce gap", "gap", gap, "err", err)
+			}
+		}(gap)
+	}
+}
+
+// fillNonceGap sends a zero-value self-transfer at the nonce, bumped until it is confirmed.
+func (m *SimpleTxManager) fillNonceGap(ctx context.Context, nonce uint64) error {
+	gasTipCap, basefee, legacy, err := m.suggestGasPriceCaps(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get gas price info: %w", err)
+	}
+	from := m.Config.From
+	rawTx := &types.DynamicFeeTx{
+		ChainID:   m.chainID,
+		Nonce:     nonce,
+		To:        &from,
+		GasTipCap: gasTipCap,
+		GasFeeCap: m.capGasFeeCap(calcGasFeeCap(basefee, gasTipCap)),
+		Gas:       params.TxGas,
+		Value:     common.Big0,
+	}
+	tx, err := m.signTx(ctx, types.NewTx(txData(rawTx, legacy)))
+	if err != nil {
+		return fmt.Errorf("failed to sign the tx: %w", err)
+	}
+	_, err = m.send(ctx, tx, false)
+	return err
+}
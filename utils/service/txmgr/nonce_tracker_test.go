@@ -0,0 +1,59 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonceTracker(t *testing.T) {
+	tracker := newNonceTracker(2)
+	ctx := context.Background()
+	var fetches []bool
+	fetch := func(ctx context.Context, pending bool) (uint64, error) {
+		fetches = append(fetches, pending)
+		if pending {
+			return 8, nil
+		}
+		return 5, nil
+	}
+
+	// Without other txs in flight, the nonce is fetched from the latest block once.
+	require.NoError(t, tracker.acquire(ctx))
+	nonce, err := tracker.reserve(ctx, fetch)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), nonce)
+	require.NoError(t, tracker.acquire(ctx))
+	nonce, err = tracker.reserve(ctx, fetch)
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), nonce)
+	require.Equal(t, []bool{false}, fetches)
+
+	// No slot is free until one is released.
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, tracker.acquire(timeoutCtx), context.DeadlineExceeded)
+
+	// After a reset, the nonce is fetched from the pending state while another tx is in flight.
+	tracker.reset()
+	nonce, err = tracker.reserve(ctx, fetch)
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), nonce)
+	require.Equal(t, []bool{false, true}, fetches)
+
+	// A failed fetch is retried on the next reservation, from the latest block once the caller
+	// is the only one in flight.
+	tracker.release()
+	tracker.reset()
+	_, err = tracker.reserve(ctx, func(ctx context.Context, pending bool) (uint64, error) {
+		return 0, errors.New("fetch failed")
+	})
+	require.Error(t, err)
+	nonce, err = tracker.reserve(ctx, fetch)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), nonce)
+	require.Equal(t, []bool{false, true, false}, fetches)
+}
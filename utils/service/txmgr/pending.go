@@ -0,0 +1,175 @@
+package txmgr
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	// ErrPendingTxDropped is the error returned by Send when its tx is dropped with DropPending.
+	ErrPendingTxDropped = errors.New("pending tx dropped")
+	// ErrNotPending is the error returned by BumpPending and DropPending when no tx is in flight
+	// at the account and the nonce.
+	ErrNotPending = errors.New("no tx in flight")
+)
+
+// PendingTx is a tx in flight, i.e. published by Send and not confirmed yet.
+type PendingTx struct {
+	From      common.Address `json:"from"`
+	Nonce     hexutil.Uint64 `json:"nonce"`
+	Hash      common.Hash    `json:"hash"`
+	GasTipCap *hexutil.Big   `json:"gasTipCap"`
+	GasFeeCap *hexutil.Big   `json:"gasFeeCap"`
+	Bumps     int            `json:"bumps"`
+	Since     time.Time      `json:"since"`
+}
+
+// pendingSend is the send of a tx in flight, which can be bumped or dropped by the operator.
+type pendingSend struct {
+	since time.Time
+	// bump requests the send loop to resubmit the tx at once.
+	bump chan struct{}
+	// drop is closed to make the send loop give up the tx.
+	drop     chan struct{}
+	dropOnce sync.Once
+
+	mu    sync.Mutex
+	tx    *types.Transaction
+	bumps int
+}
+
+func newPendingSend(tx *types.Transaction, since time.Time) *pendingSend {
+	return &pendingSend{
+		since: since,
+		bump:  make(chan struct{}, 1),
+		drop:  make(chan struct{}),
+		tx:    tx,
+	}
+}
+
+// update records the resubmitted tx.
+func (s *pendingSend) update(tx *types.Transaction, bumps int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tx = tx
+	s.bumps = bumps
+}
+
+func (s *pendingSend) info(from common.Address) PendingTx {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return PendingTx{
+		From:      from,
+		Nonce:     hexutil.Uint64(s.tx.Nonce()),
+		Hash:      s.tx.Hash(),
+		GasTipCap: (*hexutil.Big)(s.tx.GasTipCap()),
+		GasFeeCap: (*hexutil.Big)(s.tx.GasFeeCap()),
+		Bumps:     s.bumps,
+		Since:     s.since,
+	}
+}
+
+// pendingSends indexes the sends in flight of an account by nonce.
+type pendingSends struct {
+	mu    sync.Mutex
+	sends map[uint64]*pendingSend
+}
+
+func newPendingSends() *pendingSends {
+	return &pendingSends{sends: make(map[uint64]*pendingSend)}
+}
+
+func (p *pendingSends) get(nonce uint64) (*pendingSend, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s, ok := p.sends[nonce]
+	return s, ok
+}
+
+// trackPending registers the send of the tx until the returned func is called.
+// The send is not registered if the tx manager has no registry.
+func (m *SimpleTxManager) trackPending(tx *types.Transaction) (*pendingSend, func()) {
+	s := newPendingSend(tx, m.clock().Now())
+	if m.pending == nil {
+		return s, func() {}
+	}
+	nonce := tx.Nonce()
+	m.pending.mu.Lock()
+	m.pending.sends[nonce] = s
+	m.pending.mu.Unlock()
+	return s, func() {
+		m.pending.mu.Lock()
+		defer m.pending.mu.Unlock()
+		if m.pending.sends[nonce] == s {
+			delete(m.pending.sends, nonce)
+		}
+	}
+}
+
+// PendingTxs returns the txs in flight of all the accounts, ordered by account and nonce.
+func (m *SimpleTxManager) PendingTxs() []PendingTx {
+	txs := []PendingTx{}
+	for _, am := range append([]*SimpleTxManager{m}, m.secondaryAccountManagers()...) {
+		if am.pending == nil {
+			continue
+		}
+		am.pending.mu.Lock()
+		sends := make([]*pendingSend, 0, len(am.pending.sends))
+		for _, s := range am.pending.sends {
+			sends = append(sends, s)
+		}
+		am.pending.mu.Unlock()
+		first := len(txs)
+		for _, s := range sends {
+			txs = append(txs, s.info(am.Config.From))
+		}
+		sort.Slice(txs[first:], func(i, j int) bool { return txs[first+i].Nonce < txs[first+j].Nonce })
+	}
+	return txs
+}
+
+// BumpPending makes the send of the tx in flight of the account at the nonce resubmit it at once
+// with bumped fees, rather than at the next resubmission timeout. The tx is not bumped while it
+// awaits its confirmations.
+func (m *SimpleTxManager) BumpPending(from common.Address, nonce uint64) error {
+	s, err := m.pendingSend(from, nonce)
+	if err != nil {
+		return err
+	}
+	select {
+	case s.bump <- struct{}{}:
+	default: // a bump is already requested
+	}
+	return nil
+}
+
+// DropPending makes the send of the tx in flight of the account at the nonce give it up, so that
+// Send returns ErrPendingTxDropped. The tx is not resubmitted anymore, but it may still be mined
+// if it was published, and its nonce is reused by the next tx; use Cancel to evict it from the mempool.
+func (m *SimpleTxManager) DropPending(from common.Address, nonce uint64) error {
+	s, err := m.pendingSend(from, nonce)
+	if err != nil {
+		return err
+	}
+	s.dropOnce.Do(func() { close(s.drop) })
+	return nil
+}
+
+func (m *SimpleTxManager) pendingSend(from common.Address, nonce uint64) (*pendingSend, error) {
+	for _, am := range append([]*SimpleTxManager{m}, m.secondaryAccountManagers()...) {
+		if am.Config.From != from || am.pending == nil {
+			continue
+		}
+		if s, ok := am.pending.get(nonce); ok {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: account %v, nonce %d", ErrNotPending, from, nonce)
+}
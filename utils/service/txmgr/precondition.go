@@ -0,0 +1,65 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrPreconditionFailed is the error matched by PreconditionError.
+var ErrPreconditionFailed = errors.New("transaction precondition failed")
+
+// Precondition checks the L1 state that a tx depends on, e.g. that an output root was not published
+// by another validator yet. It returns an error if the tx must not be published anymore.
+// It is called with a context bounded by NetworkTimeout, and should return nil on the transient
+// failures to read the state, which would abort the send otherwise.
+type Precondition func(ctx context.Context) error
+
+// PreconditionError is the error returned by Send when the Precondition of the candidate fails.
+// The txs published before may still be mined, unless they are displaced by a tx at the same nonce.
+type PreconditionError struct {
+	// Nonce is the nonce of the aborted tx.
+	Nonce uint64
+	// Err is the error returned by the Precondition.
+	Err error
+}
+
+func (e *PreconditionError) Error() string {
+	return fmt.Sprintf("%v: nonce %d: %v", ErrPreconditionFailed, e.Nonce, e.Err)
+}
+
+func (e *PreconditionError) Is(target error) bool {
+	return target == ErrPreconditionFailed
+}
+
+func (e *PreconditionError) Unwrap() error {
+	return e.Err
+}
+
+// checkPrecondition returns a PreconditionError if the precondition, if any, of the tx at the nonce fails.
+func (m *SimpleTxManager) checkPrecondition(ctx context.Context, precondition Precondition, nonce uint64) error {
+	if precondition == nil {
+		return nil
+	}
+	cCtx, cancel := context.WithTimeout(ctx, m.NetworkTimeout)
+	defer cancel()
+	if err := precondition(cCtx); err != nil {
+		return &PreconditionError{Nonce: nonce, Err: err}
+	}
+	return nil
+}
+
+// allPreconditions returns the Precondition failing if any of the preconditions fails, or nil if there are none.
+func allPreconditions(preconditions []Precondition) Precondition {
+	if len(preconditions) == 0 {
+		return nil
+	}
+	return func(ctx context.Context) error {
+		for _, precondition := range preconditions {
+			if err := precondition(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
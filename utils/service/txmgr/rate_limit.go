@@ -0,0 +1,164 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"golang.org/x/time/rate"
+)
+
+// rpcEndpoint is an L1 RPC endpoint dialed by the tx manager, supporting all the optional backend capabilities.
+type rpcEndpoint interface {
+	l1Backend
+	GasPriceSuggester
+	FeeHistoryReader
+	ReceiptsFetcher
+	AccessListCreator
+	ethereum.ContractCaller
+	ethereum.PendingContractCaller
+}
+
+var errHeadSubscriptionUnsupported = errors.New("new head subscription is not supported")
+
+// rateLimitedClient limits the rate of the requests sent to an L1 RPC endpoint with a token bucket,
+// so that the bursts of sends and resubmissions do not trip the rate limits of the provider.
+// The requests above the rate wait for their turn, or fail once their context is done.
+type rateLimitedClient struct {
+	endpoint rpcEndpoint
+	limiter  *rate.Limiter
+}
+
+// newRateLimitedClient returns the endpoint limited to perSecond requests per second,
+// with bursts of up to one second of requests.
+func newRateLimitedClient(endpoint rpcEndpoint, perSecond float64) *rateLimitedClient {
+	burst := int(math.Max(1, math.Ceil(perSecond)))
+	return &rateLimitedClient{endpoint: endpoint, limiter: rate.NewLimiter(rate.Limit(perSecond), burst)}
+}
+
+// rateLimited calls the endpoint once the rate limit allows it.
+func rateLimited[T any](ctx context.Context, c *rateLimitedClient, call func() (T, error)) (T, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		var zero T
+		return zero, err
+	}
+	return call()
+}
+
+// Connect connects the endpoint if it is dialed lazily. It is not rate limited, since dialing is not a request.
+func (c *rateLimitedClient) Connect(ctx context.Context) error {
+	if connector, ok := c.endpoint.(Connector); ok {
+		return connector.Connect(ctx)
+	}
+	return nil
+}
+
+func (c *rateLimitedClient) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	subscriber, ok := c.endpoint.(headSubscriber)
+	if !ok {
+		return nil, errHeadSubscriptionUnsupported
+	}
+	return rateLimited(ctx, c, func() (ethereum.Subscription, error) {
+		return subscriber.SubscribeNewHead(ctx, ch)
+	})
+}
+
+func (c *rateLimitedClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return rateLimited(ctx, c, func() (uint64, error) {
+		return c.endpoint.BlockNumber(ctx)
+	})
+}
+
+func (c *rateLimitedClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return rateLimited(ctx, c, func() (*types.Receipt, error) {
+		return c.endpoint.TransactionReceipt(ctx, txHash)
+	})
+}
+
+func (c *rateLimitedClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	_, err := rateLimited(ctx, c, func() (struct{}, error) {
+		return struct{}{}, c.endpoint.SendTransaction(ctx, tx)
+	})
+	return err
+}
+
+func (c *rateLimitedClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return rateLimited(ctx, c, func() (*types.Header, error) {
+		return c.endpoint.HeaderByNumber(ctx, number)
+	})
+}
+
+func (c *rateLimitedClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return rateLimited(ctx, c, func() (*big.Int, error) {
+		return c.endpoint.SuggestGasTipCap(ctx)
+	})
+}
+
+func (c *rateLimitedClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return rateLimited(ctx, c, func() (*big.Int, error) {
+		return c.endpoint.SuggestGasPrice(ctx)
+	})
+}
+
+func (c *rateLimitedClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return rateLimited(ctx, c, func() (uint64, error) {
+		return c.endpoint.NonceAt(ctx, account, blockNumber)
+	})
+}
+
+func (c *rateLimitedClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return rateLimited(ctx, c, func() (uint64, error) {
+		return c.endpoint.PendingNonceAt(ctx, account)
+	})
+}
+
+func (c *rateLimitedClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return rateLimited(ctx, c, func() (uint64, error) {
+		return c.endpoint.EstimateGas(ctx, msg)
+	})
+}
+
+func (c *rateLimitedClient) ChainID(ctx context.Context) (*big.Int, error) {
+	return rateLimited(ctx, c, func() (*big.Int, error) {
+		return c.endpoint.ChainID(ctx)
+	})
+}
+
+func (c *rateLimitedClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return rateLimited(ctx, c, func() ([]byte, error) {
+		return c.endpoint.CallContract(ctx, msg, blockNumber)
+	})
+}
+
+func (c *rateLimitedClient) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	return rateLimited(ctx, c, func() ([]byte, error) {
+		return c.endpoint.PendingCallContract(ctx, msg)
+	})
+}
+
+func (c *rateLimitedClient) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	return rateLimited(ctx, c, func() (*ethereum.FeeHistory, error) {
+		return c.endpoint.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+	})
+}
+
+// TransactionReceipts queries the receipts in a single batch, which counts as a single request.
+func (c *rateLimitedClient) TransactionReceipts(ctx context.Context, hashes []common.Hash) ([]*types.Receipt, []error, error) {
+	result, err := rateLimited(ctx, c, func() (receiptsResult, error) {
+		receipts, errs, err := c.endpoint.TransactionReceipts(ctx, hashes)
+		return receiptsResult{receipts: receipts, errs: errs}, err
+	})
+	return result.receipts, result.errs, err
+}
+
+func (c *rateLimitedClient) CreateAccessList(ctx context.Context, msg ethereum.CallMsg) (*types.AccessList, uint64, string, error) {
+	result, err := rateLimited(ctx, c, func() (accessListResult, error) {
+		accessList, gasUsed, callErr, err := c.endpoint.CreateAccessList(ctx, msg)
+		return accessListResult{accessList: accessList, gasUsed: gasUsed, callErr: callErr}, err
+	})
+	return result.accessList, result.gasUsed, result.callErr, err
+}
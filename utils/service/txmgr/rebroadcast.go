@@ -0,0 +1,46 @@
+package txmgr
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TxBroadcaster is implemented by the backends over several endpoints, like FailoverBackend,
+// to publish a tx to all of them rather than to the active one only.
+type TxBroadcaster interface {
+	BroadcastTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
+// rebroadcastTick returns a ticker channel firing every RebroadcastInterval, or nil if it is disabled.
+// The returned stop function must be called once the ticker is no longer used.
+func (m *SimpleTxManager) rebroadcastTick() (<-chan time.Time, func()) {
+	if m.RebroadcastInterval == 0 {
+		return nil, func() {}
+	}
+	ticker := m.clock().NewTicker(m.RebroadcastInterval)
+	return ticker.Ch(), ticker.Stop
+}
+
+// rebroadcast publishes the pending tx again without bumping its fees, so that a tx evicted from
+// the mempool or not propagated is not left stalled until the next bump. While private is set,
+// it is only republished to the private tx relay. Otherwise, it is republished to every endpoint
+// of the backend if it is a TxBroadcaster. The errors are only logged, since the tx is usually known.
+func (m *SimpleTxManager) rebroadcast(ctx context.Context, tx *types.Transaction, private bool) {
+	cCtx, cancel := context.WithTimeout(ctx, m.NetworkTimeout)
+	defer cancel()
+	var err error
+	if private {
+		err = m.PrivateTxPublisher.SendTransaction(cCtx, tx)
+	} else if broadcaster, ok := m.backend.(TxBroadcaster); ok {
+		err = broadcaster.BroadcastTransaction(cCtx, tx)
+	} else {
+		err = m.backend.SendTransaction(cCtx, tx)
+	}
+	if err != nil {
+		m.l.Debug("failed to rebroadcast the pending tx", "hash", tx.Hash(), "nonce", tx.Nonce(), "err", err)
+		return
+	}
+	m.l.Debug("rebroadcast the pending tx", "hash", tx.Hash(), "nonce", tx.Nonce())
+}
@@ -0,0 +1,120 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/kroma-network/kroma/utils/service/clock"
+)
+
+// receiptBatchWindow is the time during which the receipt queries are collected into a single batch.
+const receiptBatchWindow = 20 * time.Millisecond
+
+// ReceiptsFetcher is implemented by the backends supporting JSON-RPC batches, which can query
+// the receipts of several txs in a single request. The receipts and the errors are in the order
+// of the hashes; the error of a tx not mined yet is ethereum.NotFound. The error is returned if
+// the whole batch fails.
+type ReceiptsFetcher interface {
+	TransactionReceipts(ctx context.Context, hashes []common.Hash) ([]*types.Receipt, []error, error)
+}
+
+// receiptBatcher collects the receipt queries of the in-flight txs made within receiptBatchWindow into
+// a single batch request, so that the number of requests does not grow with the number of pending txs.
+// The receipts are polled at the multiples of the poll interval, so that the queries of all the txs
+// fall into the same batch.
+type receiptBatcher struct {
+	fetcher  ReceiptsFetcher
+	interval time.Duration
+	timeout  time.Duration
+
+	mu sync.Mutex
+	// batch is the batch collecting the queries, or nil if none is.
+	batch *receiptBatch
+}
+
+type receiptBatch struct {
+	hashes []common.Hash
+	// done is closed once the batch is fetched.
+	done     chan struct{}
+	receipts []*types.Receipt
+	errs     []error
+	err      error
+}
+
+func newReceiptBatcher(fetcher ReceiptsFetcher, interval, timeout time.Duration) *receiptBatcher {
+	return &receiptBatcher{
+		fetcher:  fetcher,
+		interval: interval,
+		timeout:  timeout,
+	}
+}
+
+// untilNextPoll returns the duration from now until the next multiple of the poll interval.
+func (b *receiptBatcher) untilNextPoll(now time.Time) time.Duration {
+	return b.interval - time.Duration(now.UnixNano()%int64(b.interval))
+}
+
+// TransactionReceipt returns the receipt of the tx, fetched in the batch of the queries made
+// within receiptBatchWindow.
+func (b *receiptBatcher) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	b.mu.Lock()
+	batch := b.batch
+	if batch == nil {
+		batch = &receiptBatch{done: make(chan struct{})}
+		b.batch = batch
+		go b.fetch(batch)
+	}
+	i := len(batch.hashes)
+	batch.hashes = append(batch.hashes, txHash)
+	b.mu.Unlock()
+
+	select {
+	case <-batch.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if batch.err != nil {
+		return nil, batch.err
+	}
+	return batch.receipts[i], batch.errs[i]
+}
+
+// fetch fetches the batch once its window is over.
+func (b *receiptBatcher) fetch(batch *receiptBatch) {
+	time.Sleep(receiptBatchWindow)
+	b.mu.Lock()
+	b.batch = nil
+	b.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	batch.receipts, batch.errs, batch.err = b.fetcher.TransactionReceipts(ctx, batch.hashes)
+	close(batch.done)
+}
+
+// transactionReceipt returns the receipt of the tx, fetched in a batch if BatchReceipts is set
+// and the backend supports it.
+func (m *SimpleTxManager) transactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	if m.receipts == nil {
+		return m.backend.TransactionReceipt(ctx, txHash)
+	}
+	receipt, err := m.receipts.TransactionReceipt(ctx, txHash)
+	if errors.Is(err, errUnsupportedByEndpoint) {
+		return m.backend.TransactionReceipt(ctx, txHash)
+	}
+	return receipt, err
+}
+
+// receiptPollTimer returns the channel of the next receipt poll: the tick of the ticker, or the next
+// multiple of the poll interval if the receipts are batched.
+func (m *SimpleTxManager) receiptPollTimer(ticker clock.Ticker) <-chan time.Time {
+	if m.receipts == nil {
+		return ticker.Ch()
+	}
+	return m.clock().After(m.receipts.untilNextPoll(m.clock().Now()))
+}
@@ -0,0 +1,93 @@
+package txmgr
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+type countingReceiptsFetcher struct {
+	mu      sync.Mutex
+	batches [][]common.Hash
+}
+
+func (f *countingReceiptsFetcher) TransactionReceipts(_ context.Context, hashes []common.Hash) ([]*types.Receipt, []error, error) {
+	f.mu.Lock()
+	f.batches = append(f.batches, hashes)
+	f.mu.Unlock()
+	receipts := make([]*types.Receipt, len(hashes))
+	errs := make([]error, len(hashes))
+	for i, hash := range hashes {
+		if hash.Big().Bit(0) == 1 {
+			receipts[i] = &types.Receipt{TxHash: hash}
+		} else {
+			errs[i] = ethereum.NotFound
+		}
+	}
+	return receipts, errs, nil
+}
+
+// TestReceiptBatcher asserts that the concurrent receipt queries are fetched in a single batch,
+// and that each query gets the receipt of its tx.
+func TestReceiptBatcher(t *testing.T) {
+	fetcher := &countingReceiptsFetcher{}
+	b := newReceiptBatcher(fetcher, time.Second, time.Second)
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 10; i++ {
+		wg.Add(1)
+		go func(hash common.Hash) {
+			defer wg.Done()
+			receipt, err := b.TransactionReceipt(context.Background(), hash)
+			if hash.Big().Bit(0) == 1 {
+				require.NoError(t, err)
+				require.Equal(t, hash, receipt.TxHash)
+			} else {
+				require.ErrorIs(t, err, ethereum.NotFound)
+			}
+		}(common.BigToHash(big.NewInt(int64(i))))
+	}
+	wg.Wait()
+	require.Len(t, fetcher.batches, 1)
+	require.Len(t, fetcher.batches[0], 10)
+
+	_, err := b.TransactionReceipt(context.Background(), common.BigToHash(big.NewInt(11)))
+	require.NoError(t, err)
+	require.Len(t, fetcher.batches, 2)
+}
+
+type receiptsService struct {
+	mined common.Hash
+}
+
+func (s *receiptsService) GetTransactionReceipt(hash common.Hash) *types.Receipt {
+	if hash != s.mined {
+		return nil
+	}
+	return &types.Receipt{TxHash: hash, Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(1), Logs: []*types.Log{}}
+}
+
+// TestAccessListClientTransactionReceipts asserts that the receipts are queried in a JSON-RPC batch,
+// and that the missing receipts are reported as not found.
+func TestAccessListClientTransactionReceipts(t *testing.T) {
+	mined := common.Hash{1}
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("eth", &receiptsService{mined: mined}))
+	client := newAccessListClient(rpc.DialInProc(srv))
+	defer client.Close()
+
+	receipts, errs, err := client.TransactionReceipts(context.Background(), []common.Hash{{2}, mined})
+	require.NoError(t, err)
+	require.Nil(t, receipts[0])
+	require.ErrorIs(t, errs[0], ethereum.NotFound)
+	require.NoError(t, errs[1])
+	require.Equal(t, mined, receipts[1].TxHash)
+}
@@ -0,0 +1,96 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ReorgedTx describes a confirmed tx reorged out of L1 before its block reached ReorgWatchDepth confirmations.
+type ReorgedTx struct {
+	// Nonce is the nonce of the tx.
+	Nonce uint64
+	// Hash is the hash of the tx.
+	Hash common.Hash
+	// BlockHash and BlockNumber identify the block that the tx was last seen in.
+	BlockHash   common.Hash
+	BlockNumber uint64
+}
+
+// ReorgHandler is called once per confirmed tx reorged out of L1, e.g. to resubmit it. It is called
+// from the watch of the tx, after its send returned, so it may block the watch but no send.
+type ReorgHandler func(ReorgedTx)
+
+// OnReorg registers the handler called when a confirmed tx is reorged out of L1.
+// It must be called before any tx is sent.
+func (m *SimpleTxManager) OnReorg(handler ReorgHandler) {
+	m.reorgHandler = handler
+	for _, am := range m.secondaryAccountManagers() {
+		am.reorgHandler = handler
+	}
+}
+
+// watchReorg watches the receipt of the confirmed tx in the background, if ReorgWatchDepth is set,
+// until its block has ReorgWatchDepth confirmations or the tx manager is closed. The tx is reported
+// as reorged once its receipt is gone; if it is only moved to another block, the new block is watched instead.
+func (m *SimpleTxManager) watchReorg(tx *types.Transaction, receipt *types.Receipt) {
+	if m.ReorgWatchDepth == 0 || receipt.BlockNumber == nil {
+		return
+	}
+	m.bg.run(func(ctx context.Context) {
+		l := m.l.New("hash", receipt.TxHash, "nonce", tx.Nonce())
+		blockHash, blockNumber := receipt.BlockHash, receipt.BlockNumber.Uint64()
+		ticker := m.clock().NewTicker(m.ReceiptQueryInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.Ch():
+			}
+			cCtx, cancel := context.WithTimeout(ctx, m.NetworkTimeout)
+			current, err := m.backend.TransactionReceipt(cCtx, receipt.TxHash)
+			cancel()
+			if errors.Is(err, ethereum.NotFound) {
+				current, err = nil, nil
+			}
+			if err != nil {
+				l.Debug("failed to get the receipt of the watched tx", "err", err)
+				continue
+			}
+			if current == nil {
+				m.reportReorg(ReorgedTx{Nonce: tx.Nonce(), Hash: receipt.TxHash, BlockHash: blockHash, BlockNumber: blockNumber})
+				return
+			}
+			if current.BlockHash != blockHash {
+				l.Warn("confirmed tx moved to another block", "block", blockHash, "newBlock", current.BlockHash)
+				blockHash, blockNumber = current.BlockHash, current.BlockNumber.Uint64()
+			}
+			cCtx, cancel = context.WithTimeout(ctx, m.NetworkTimeout)
+			head, err := m.headNumber(cCtx)
+			cancel()
+			if err != nil {
+				l.Debug("failed to get the L1 head", "err", err)
+				continue
+			}
+			if head >= blockNumber && head-blockNumber+1 >= m.ReorgWatchDepth {
+				return
+			}
+		}
+	})
+}
+
+// reportReorg reports the reorged tx to the metrics, the logs and the registered handler.
+// The nonce of the tx is free again, so the next send fetches its nonce anew.
+func (m *SimpleTxManager) reportReorg(reorged ReorgedTx) {
+	m.metr.TxReorged()
+	m.l.Error("confirmed transaction was reorged out", "hash", reorged.Hash, "nonce", reorged.Nonce,
+		"block", reorged.BlockHash, "blockNumber", reorged.BlockNumber)
+	m.resetNonce()
+	if m.reorgHandler != nil {
+		m.reorgHandler(reorged)
+	}
+}
@@ -0,0 +1,251 @@
+package txmgr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrTxReverted is the error wrapped by RevertError.
+var ErrTxReverted = errors.New("transaction would revert")
+
+// panicSelector is the selector of the Panic(uint256) error of the failed assertions.
+var panicSelector = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+
+// RevertError is the error returned when the eth_call of a tx before publishing it reverts,
+// so that the caller can tell the revert from the other failures without burning gas on L1.
+// It is also wrapped by ReceiptError when the re-execution of a failed tx reverts.
+type RevertError struct {
+	// Contract is the name of the contract whose ABI decoded the revert, or of the contract called by the tx,
+	// if registered with RegisterContractABI.
+	Contract string
+	// Name is the name of the decoded error: "Error" for the reason strings, "Panic" for the failed
+	// assertions, or the name of a custom error of a registered ABI.
+	Name string
+	// Args are the decoded arguments of the error.
+	Args []interface{}
+	// Reason is the decoded reason string of the revert, if any.
+	Reason string
+	// Data is the raw revert data returned by the call, if any.
+	Data []byte
+	// Err is the error returned by the call.
+	Err error
+}
+
+func (e *RevertError) Error() string {
+	var msg string
+	switch {
+	case e.Reason != "":
+		msg = e.Reason
+	case e.Name != "":
+		args := make([]string, len(e.Args))
+		for i, arg := range e.Args {
+			args[i] = fmt.Sprint(arg)
+		}
+		msg = fmt.Sprintf("%s(%s)", e.Name, strings.Join(args, ", "))
+	default:
+		msg = e.Err.Error()
+	}
+	if e.Contract != "" {
+		return fmt.Sprintf("%v: %s: %s", ErrTxReverted, e.Contract, msg)
+	}
+	return fmt.Sprintf("%v: %s", ErrTxReverted, msg)
+}
+
+func (e *RevertError) Is(target error) bool {
+	return target == ErrTxReverted
+}
+
+func (e *RevertError) Unwrap() error {
+	return e.Err
+}
+
+// ReceiptError is the error returned when the tx is confirmed but its status is not success.
+// It wraps the RevertError of the re-execution of the tx, if the re-execution reverts.
+type ReceiptError struct {
+	// TxHash is the hash of the failed tx.
+	TxHash common.Hash
+	// Revert is the revert of the re-execution of the tx, or nil if it could not be reproduced.
+	Revert *RevertError
+}
+
+func (e *ReceiptError) Error() string {
+	if e.Revert == nil {
+		return ErrTxReceiptNotSucceed.Error()
+	}
+	return fmt.Sprintf("%v: %v", ErrTxReceiptNotSucceed, e.Revert)
+}
+
+func (e *ReceiptError) Is(target error) bool {
+	return target == ErrTxReceiptNotSucceed
+}
+
+func (e *ReceiptError) Unwrap() error {
+	if e.Revert == nil {
+		return nil
+	}
+	return e.Revert
+}
+
+// contractABI is the ABI of a contract, registered to decode the reverts of the txs.
+type contractABI struct {
+	name string
+	abi  *abi.ABI
+}
+
+// RegisterContractABI registers the ABI of the contract at the address, e.g. from the bindings package,
+// so that the reverts of the txs are decoded into the custom errors of the contract and attributed to it.
+// It must be called before any tx is sent.
+func (m *SimpleTxManager) RegisterContractABI(addr common.Address, name string, parsed *abi.ABI) {
+	if m.contractABIs == nil {
+		m.contractABIs = make(map[common.Address]contractABI)
+	}
+	m.contractABIs[addr] = contractABI{name: name, abi: parsed}
+	for _, am := range m.secondaryAccountManagers() {
+		am.RegisterContractABI(addr, name, parsed)
+	}
+}
+
+func (m *SimpleTxManager) callMsg(tx *types.Transaction) ethereum.CallMsg {
+	return ethereum.CallMsg{
+		From:       m.Config.From,
+		To:         tx.To(),
+		Gas:        tx.Gas(),
+		GasFeeCap:  tx.GasFeeCap(),
+		GasTipCap:  tx.GasTipCap(),
+		Value:      tx.Value(),
+		Data:       tx.Data(),
+		AccessList: tx.AccessList(),
+	}
+}
+
+// callTx calls the signed tx against the pending state of the backend, or the latest state if
+// the backend does not support pending calls. It returns a RevertError if the call is answered
+// with a JSON-RPC error, and nil if the backend supports no calls at all.
+func (m *SimpleTxManager) callTx(ctx context.Context, tx *types.Transaction) error {
+	msg := m.callMsg(tx)
+
+	cCtx, cancel := context.WithTimeout(ctx, m.NetworkTimeout)
+	defer cancel()
+	var err error
+	if caller, ok := m.backend.(ethereum.PendingContractCaller); ok {
+		_, err = caller.PendingCallContract(cCtx, msg)
+	} else if caller, ok := m.backend.(ethereum.ContractCaller); ok {
+		_, err = caller.CallContract(cCtx, msg, nil)
+	}
+	if err == nil {
+		return nil
+	}
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return fmt.Errorf("failed to call the tx: %w", err)
+	}
+	return m.decodeRevert(tx.To(), newRevertError(err))
+}
+
+// receiptError re-executes the failed tx against the state before its block, and returns the ReceiptError
+// wrapping the decoded revert. The revert is nil if the backend does not support eth_call or
+// the re-execution does not revert, e.g. because the state has changed within the block.
+func (m *SimpleTxManager) receiptError(ctx context.Context, tx *types.Transaction, receipt *types.Receipt) error {
+	receiptErr := &ReceiptError{TxHash: receipt.TxHash}
+	caller, ok := m.backend.(ethereum.ContractCaller)
+	if !ok || receipt.BlockNumber == nil || receipt.BlockNumber.Sign() == 0 {
+		return receiptErr
+	}
+	cCtx, cancel := context.WithTimeout(ctx, m.NetworkTimeout)
+	defer cancel()
+	parent := new(big.Int).Sub(receipt.BlockNumber, common.Big1)
+	_, err := caller.CallContract(cCtx, m.callMsg(tx), parent)
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		receiptErr.Revert = m.decodeRevert(tx.To(), newRevertError(err))
+	} else if err != nil {
+		m.l.Warn("failed to re-execute the failed tx", "hash", receipt.TxHash, "err", err)
+	}
+	return receiptErr
+}
+
+// decodeRevert decodes the revert data into the reason string, the panic code, or a custom error of
+// the ABI registered for the called contract, then of the other registered ABIs, since the revert
+// may come from a nested call.
+func (m *SimpleTxManager) decodeRevert(to *common.Address, revertErr *RevertError) *RevertError {
+	if to != nil {
+		if contract, ok := m.contractABIs[*to]; ok {
+			revertErr.Contract = contract.name
+		}
+	}
+	data := revertErr.Data
+	switch {
+	case revertErr.Reason != "":
+		revertErr.Name = "Error"
+		revertErr.Args = []interface{}{revertErr.Reason}
+		return revertErr
+	case len(data) == 36 && bytes.Equal(data[:4], panicSelector):
+		revertErr.Name = "Panic"
+		revertErr.Args = []interface{}{new(big.Int).SetBytes(data[4:])}
+		return revertErr
+	case len(data) < 4:
+		return revertErr
+	}
+
+	decode := func(contract contractABI) bool {
+		for name, abiErr := range contract.abi.Errors {
+			if !bytes.Equal(data[:4], abiErr.ID[:4]) {
+				continue
+			}
+			args, err := abiErr.Inputs.Unpack(data[4:])
+			if err != nil {
+				continue
+			}
+			revertErr.Contract = contract.name
+			revertErr.Name = name
+			revertErr.Args = args
+			return true
+		}
+		return false
+	}
+	if to != nil {
+		if contract, ok := m.contractABIs[*to]; ok && decode(contract) {
+			return revertErr
+		}
+	}
+	for _, contract := range m.contractABIs {
+		if decode(contract) {
+			return revertErr
+		}
+	}
+	return revertErr
+}
+
+// newRevertError decodes the revert data carried by the JSON-RPC error of the call, if any.
+func newRevertError(err error) *RevertError {
+	revertErr := &RevertError{Err: err}
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return revertErr
+	}
+	hexData, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return revertErr
+	}
+	data, decodeErr := hexutil.Decode(hexData)
+	if decodeErr != nil {
+		return revertErr
+	}
+	revertErr.Data = data
+	if reason, unpackErr := abi.UnpackRevert(data); unpackErr == nil {
+		revertErr.Reason = reason
+	}
+	return revertErr
+}
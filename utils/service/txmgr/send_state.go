@@ -26,6 +26,9 @@ type SendState struct {
 	// Counts of the different types of errors
 	successFullPublishCount   uint64 // nil error => tx made it to the mempool
 	safeAbortNonceTooLowCount uint64 // nonce too low error
+
+	// onStatus is called with the progress of the txn, if set, see SendAsync.
+	onStatus func(TxStatus)
 }
 
 // NewSendStateWithNow creates a new send state with the provided clock.
@@ -62,6 +65,13 @@ func (s *SendState) ProcessSendError(err error) {
 	}
 }
 
+// notifyStatus reports the progress of the txn to the status callback, if any.
+func (s *SendState) notifyStatus(status TxStatus) {
+	if s.onStatus != nil {
+		s.onStatus(status)
+	}
+}
+
 // TxMined records that the txn with txnHash has been mined and is await
 // confirmation. It is safe to call this function multiple times.
 func (s *SendState) TxMined(txHash common.Hash) {
@@ -95,22 +105,32 @@ func (s *SendState) TxNotMined(txHash common.Hash) {
 // This occurs when the set of errors recorded indicates that no further progress can be made
 // on this transaction.
 func (s *SendState) ShouldAbortImmediately() bool {
+	return s.CriticalError() != nil
+}
+
+// CriticalError returns the reason why the txmgr should give up on trying a given txn
+// with the target nonce, or nil if it should keep trying.
+// It returns a *NonceTooLowAbortError if too many nonce too low errors were observed, and
+// ErrNotInMempoolTimeout if no txn was published in the allotted time.
+func (s *SendState) CriticalError() error {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	// Never abort if our latest sample reports having at least one mined txn.
 	if len(s.minedTxs) > 0 {
-		return false
+		return nil
 	}
 
 	// If we have exceeded the nonce too low count, abort
-	if s.nonceTooLowCount >= s.safeAbortNonceTooLowCount ||
-		// If we have not published a transaction in the allotted time, abort
-		(s.successFullPublishCount == 0 && s.now().After(s.txInMempoolDeadline)) {
-		return true
+	if s.nonceTooLowCount >= s.safeAbortNonceTooLowCount {
+		return &NonceTooLowAbortError{Count: s.nonceTooLowCount}
+	}
+	// If we have not published a transaction in the allotted time, abort
+	if s.successFullPublishCount == 0 && s.now().After(s.txInMempoolDeadline) {
+		return ErrNotInMempoolTimeout
 	}
 
-	return false
+	return nil
 }
 
 // IsWaitingForConfirmation returns true if we have at least one confirmation on
@@ -187,3 +187,19 @@ func TestSendStateNoTimeoutAbortIfPublishedTx(t *testing.T) {
 	sendState.ProcessSendError(nil)
 	require.False(t, sendState.ShouldAbortImmediately(), "Should not abort if published transaction successfully")
 }
+
+// TestSendStateCriticalError asserts that CriticalError reports the reason of the abort.
+func TestSendStateCriticalError(t *testing.T) {
+	sendState := newSendState()
+	require.NoError(t, sendState.CriticalError())
+
+	processNSendErrors(sendState, core.ErrNonceTooLow, testSafeAbortNonceTooLowCount)
+	err := sendState.CriticalError()
+	require.ErrorIs(t, err, txmgr.ErrNonceTooLowAbort)
+	var nonceErr *txmgr.NonceTooLowAbortError
+	require.ErrorAs(t, err, &nonceErr)
+	require.Equal(t, uint64(testSafeAbortNonceTooLowCount), nonceErr.Count)
+
+	sendState = newSendStateWithTimeout(10*time.Millisecond, stepClock(20*time.Millisecond))
+	require.ErrorIs(t, sendState.CriticalError(), txmgr.ErrNotInMempoolTimeout)
+}
@@ -0,0 +1,51 @@
+package txmgr
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// StuckTx describes a tx detected as stuck, i.e. resubmitted more than StuckTxBumps times or
+// pending for longer than StuckTxDuration.
+type StuckTx struct {
+	// Nonce is the nonce of the tx.
+	Nonce uint64
+	// Hash is the hash of the latest resubmission of the tx.
+	Hash common.Hash
+	// Bumps is the number of resubmissions of the tx so far.
+	Bumps int
+	// Pending is the duration since the first publication of the tx.
+	Pending time.Duration
+}
+
+// StuckTxHandler is called once per tx detected as stuck, e.g. to page the operators before
+// a submission window is missed. It is called from the send loop, so it must not block.
+type StuckTxHandler func(StuckTx)
+
+// OnStuckTx registers the handler called when a tx is detected as stuck.
+// It must be called before any tx is sent.
+func (m *SimpleTxManager) OnStuckTx(handler StuckTxHandler) {
+	m.stuckTxHandler = handler
+	for _, am := range m.secondaryAccountManagers() {
+		am.stuckTxHandler = handler
+	}
+}
+
+// isStuck returns whether the tx resubmitted bumps times since sendStart must be reported as stuck.
+func (m *SimpleTxManager) isStuck(bumps int, sendStart time.Time) bool {
+	if m.StuckTxBumps != 0 && uint64(bumps) > m.StuckTxBumps {
+		return true
+	}
+	return m.StuckTxDuration != 0 && m.clock().Now().Sub(sendStart) > m.StuckTxDuration
+}
+
+// reportStuck reports the stuck tx to the metrics, the logs and the registered handler.
+func (m *SimpleTxManager) reportStuck(stuck StuckTx) {
+	m.metr.TxStuck()
+	m.l.Error("transaction is stuck", "hash", stuck.Hash, "nonce", stuck.Nonce,
+		"bumps", stuck.Bumps, "pending", stuck.Pending)
+	if m.stuckTxHandler != nil {
+		m.stuckTxHandler(stuck)
+	}
+}
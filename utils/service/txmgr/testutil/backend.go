@@ -0,0 +1,359 @@
+package testutil
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/kroma-network/kroma/utils/service/txmgr"
+)
+
+// replacementBump is the minimum fee increase, in percent, of a tx replacing a tx at the same nonce,
+// as enforced by the geth mempool.
+const replacementBump = 10
+
+var (
+	// DefaultBaseFee is the base fee of the blocks of a new MockBackend.
+	DefaultBaseFee = big.NewInt(params.GWei)
+	// DefaultGasTipCap is the gas tip cap suggested by a new MockBackend.
+	DefaultGasTipCap = big.NewInt(params.GWei / 10)
+)
+
+var _ txmgr.ETHBackend = (*MockBackend)(nil)
+
+// MockBackend is a txmgr.ETHBackend simulating a chain and its mempool, so that the services
+// sending txs with a txmgr.SimpleTxManager can be tested without the e2e framework.
+// The txs are only mined by Mine, and the fees can be changed at any time to simulate fee spikes.
+// The mempool rejects the txs with a stale nonce and the underpriced replacements, like geth.
+type MockBackend struct {
+	mu sync.Mutex
+
+	chainID *big.Int
+	signer  types.Signer
+
+	baseFee   *big.Int
+	gasTipCap *big.Int
+	gasLimit  uint64
+
+	// blocks are the hashes of the blocks by number, from the genesis to the head.
+	blocks []common.Hash
+	// blockTxs are the txs of the blocks by number.
+	blockTxs [][]*types.Transaction
+	// forks is the number of reorgs, mixed into the block hashes so that the reorged blocks differ.
+	forks     uint64
+	finalized uint64
+
+	receipts map[common.Hash]*types.Receipt
+	mempool  map[common.Address]map[uint64]*types.Transaction
+	nonces   map[common.Address]uint64
+
+	sent    []*types.Transaction
+	sendErr error
+}
+
+// NewMockBackend returns a MockBackend of the chain, with a genesis block and the default fees.
+func NewMockBackend(chainID *big.Int) *MockBackend {
+	b := &MockBackend{
+		chainID:   chainID,
+		signer:    types.LatestSignerForChainID(chainID),
+		baseFee:   new(big.Int).Set(DefaultBaseFee),
+		gasTipCap: new(big.Int).Set(DefaultGasTipCap),
+		receipts:  make(map[common.Hash]*types.Receipt),
+		mempool:   make(map[common.Address]map[uint64]*types.Transaction),
+		nonces:    make(map[common.Address]uint64),
+	}
+	b.blocks = append(b.blocks, b.blockHash(0))
+	b.blockTxs = append(b.blockTxs, nil)
+	return b
+}
+
+func (b *MockBackend) blockHash(number uint64) common.Hash {
+	var data [16]byte
+	binary.BigEndian.PutUint64(data[:8], number)
+	binary.BigEndian.PutUint64(data[8:], b.forks)
+	return crypto.Keccak256Hash(data[:])
+}
+
+// SetBaseFee sets the base fee of the next blocks. The txs with a lower fee cap are not mined.
+func (b *MockBackend) SetBaseFee(baseFee *big.Int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.baseFee = new(big.Int).Set(baseFee)
+}
+
+// SetGasTipCap sets the suggested gas tip cap.
+func (b *MockBackend) SetGasTipCap(gasTipCap *big.Int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.gasTipCap = new(big.Int).Set(gasTipCap)
+}
+
+// SetGasLimit sets the gas returned by EstimateGas. If 0, the intrinsic gas of the tx is returned.
+func (b *MockBackend) SetGasLimit(gasLimit uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.gasLimit = gasLimit
+}
+
+// SetSendError makes SendTransaction fail with the error, until it is reset with nil.
+func (b *MockBackend) SetSendError(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sendErr = err
+}
+
+// Sent returns the txs accepted by SendTransaction, in order.
+func (b *MockBackend) Sent() []*types.Transaction {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*types.Transaction(nil), b.sent...)
+}
+
+// Pending returns the txs in the mempool, ordered by sender and nonce.
+func (b *MockBackend) Pending() []*types.Transaction {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var txs []*types.Transaction
+	for _, pool := range b.mempool {
+		for _, tx := range pool {
+			txs = append(txs, tx)
+		}
+	}
+	sort.Slice(txs, func(i, j int) bool {
+		fi, _ := types.Sender(b.signer, txs[i])
+		fj, _ := types.Sender(b.signer, txs[j])
+		if fi != fj {
+			return bytes.Compare(fi[:], fj[:]) < 0
+		}
+		return txs[i].Nonce() < txs[j].Nonce()
+	})
+	return txs
+}
+
+// Mine mines a block including the executable txs of the mempool whose fee cap covers the base fee,
+// and returns the receipts of the txs. The receipts are successful.
+func (b *MockBackend) Mine() []*types.Receipt {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	number := uint64(len(b.blocks))
+	hash := b.blockHash(number)
+	var (
+		txs      []*types.Transaction
+		receipts []*types.Receipt
+	)
+	for from, pool := range b.mempool {
+		for {
+			tx, ok := pool[b.nonces[from]]
+			if !ok || tx.GasFeeCap().Cmp(b.baseFee) < 0 {
+				break
+			}
+			delete(pool, tx.Nonce())
+			b.nonces[from]++
+			receipt := &types.Receipt{
+				Type:              tx.Type(),
+				Status:            types.ReceiptStatusSuccessful,
+				TxHash:            tx.Hash(),
+				GasUsed:           tx.Gas(),
+				EffectiveGasPrice: b.effectiveGasPrice(tx),
+				BlockHash:         hash,
+				BlockNumber:       new(big.Int).SetUint64(number),
+				TransactionIndex:  uint(len(txs)),
+				Logs:              []*types.Log{},
+			}
+			b.receipts[tx.Hash()] = receipt
+			txs = append(txs, tx)
+			receipts = append(receipts, receipt)
+		}
+	}
+	b.blocks = append(b.blocks, hash)
+	b.blockTxs = append(b.blockTxs, txs)
+	return receipts
+}
+
+func (b *MockBackend) effectiveGasPrice(tx *types.Transaction) *big.Int {
+	price := new(big.Int).Add(b.baseFee, tx.GasTipCap())
+	if price.Cmp(tx.GasFeeCap()) > 0 {
+		return new(big.Int).Set(tx.GasFeeCap())
+	}
+	return price
+}
+
+// Reorg reorgs out the last blocks, at most down to the finalized block, and returns their txs
+// to the mempool. The blocks mined next have different hashes.
+func (b *MockBackend) Reorg(depth uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	head := uint64(len(b.blocks)) - 1
+	if head-b.finalized < depth {
+		depth = head - b.finalized
+	}
+	for number := head; number > head-depth; number-- {
+		for _, tx := range b.blockTxs[number] {
+			from, _ := types.Sender(b.signer, tx)
+			delete(b.receipts, tx.Hash())
+			b.pool(from)[tx.Nonce()] = tx
+			if tx.Nonce() < b.nonces[from] {
+				b.nonces[from] = tx.Nonce()
+			}
+		}
+	}
+	b.blocks = b.blocks[:head-depth+1]
+	b.blockTxs = b.blockTxs[:head-depth+1]
+	b.forks++
+}
+
+// Finalize finalizes the blocks mined so far, which cannot be reorged out anymore.
+func (b *MockBackend) Finalize() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.finalized = uint64(len(b.blocks)) - 1
+}
+
+func (b *MockBackend) pool(from common.Address) map[uint64]*types.Transaction {
+	pool, ok := b.mempool[from]
+	if !ok {
+		pool = make(map[uint64]*types.Transaction)
+		b.mempool[from] = pool
+	}
+	return pool
+}
+
+func (b *MockBackend) BlockNumber(_ context.Context) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return uint64(len(b.blocks)) - 1, nil
+}
+
+func (b *MockBackend) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	head := uint64(len(b.blocks)) - 1
+	n := head
+	switch {
+	case number == nil, number.Int64() == int64(rpc.LatestBlockNumber), number.Int64() == int64(rpc.PendingBlockNumber):
+	case number.Int64() == int64(rpc.FinalizedBlockNumber), number.Int64() == int64(rpc.SafeBlockNumber):
+		n = b.finalized
+	case number.Sign() < 0:
+		return nil, errors.New("unsupported block number")
+	case number.Uint64() > head:
+		return nil, ethereum.NotFound
+	default:
+		n = number.Uint64()
+	}
+	header := &types.Header{
+		Number:  new(big.Int).SetUint64(n),
+		BaseFee: new(big.Int).Set(b.baseFee),
+	}
+	if n > 0 {
+		header.ParentHash = b.blocks[n-1]
+	}
+	return header, nil
+}
+
+func (b *MockBackend) SuggestGasTipCap(_ context.Context) (*big.Int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return new(big.Int).Set(b.gasTipCap), nil
+}
+
+// SuggestGasPrice suggests the base fee plus the gas tip cap as the gas price of the legacy txs.
+func (b *MockBackend) SuggestGasPrice(_ context.Context) (*big.Int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return new(big.Int).Add(b.baseFee, b.gasTipCap), nil
+}
+
+func (b *MockBackend) ChainID(_ context.Context) (*big.Int, error) {
+	return new(big.Int).Set(b.chainID), nil
+}
+
+func (b *MockBackend) NonceAt(_ context.Context, account common.Address, _ *big.Int) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.nonces[account], nil
+}
+
+func (b *MockBackend) PendingNonceAt(_ context.Context, account common.Address) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	nonce := b.nonces[account]
+	for {
+		if _, ok := b.mempool[account][nonce]; !ok {
+			return nonce, nil
+		}
+		nonce++
+	}
+}
+
+func (b *MockBackend) EstimateGas(_ context.Context, msg ethereum.CallMsg) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.gasLimit != 0 {
+		return b.gasLimit, nil
+	}
+	return core.IntrinsicGas(msg.Data, msg.AccessList, msg.To == nil, true, true, true)
+}
+
+// SendTransaction adds the tx to the mempool. It fails if the nonce of the tx is already mined,
+// or if the tx replaces a tx at the same nonce without bumping both fees by at least 10%.
+func (b *MockBackend) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.sendErr != nil {
+		return b.sendErr
+	}
+	from, err := types.Sender(b.signer, tx)
+	if err != nil {
+		return err
+	}
+	if tx.Nonce() < b.nonces[from] {
+		return core.ErrNonceTooLow
+	}
+	pool := b.pool(from)
+	if old, ok := pool[tx.Nonce()]; ok {
+		if old.Hash() == tx.Hash() {
+			return txpool.ErrAlreadyKnown
+		}
+		if !bumped(old.GasFeeCap(), tx.GasFeeCap()) || !bumped(old.GasTipCap(), tx.GasTipCap()) {
+			return txpool.ErrReplaceUnderpriced
+		}
+	}
+	pool[tx.Nonce()] = tx
+	b.sent = append(b.sent, tx)
+	return nil
+}
+
+// bumped returns whether the new fee is at least replacementBump percent higher than the old fee.
+func bumped(oldFee, newFee *big.Int) bool {
+	threshold := new(big.Int).Mul(oldFee, big.NewInt(100+replacementBump))
+	return new(big.Int).Mul(newFee, big.NewInt(100)).Cmp(threshold) >= 0
+}
+
+// TransactionReceipt returns the receipt of the mined tx, or ethereum.NotFound if the tx is not mined.
+func (b *MockBackend) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	receipt, ok := b.receipts[txHash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	r := *receipt
+	return &r, nil
+}
@@ -0,0 +1,155 @@
+package testutil_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/utils/service/clock"
+	"github.com/kroma-network/kroma/utils/service/txmgr"
+	"github.com/kroma-network/kroma/utils/service/txmgr/metrics"
+	"github.com/kroma-network/kroma/utils/service/txmgr/testutil"
+)
+
+type sendResult struct {
+	receipt *types.Receipt
+	err     error
+}
+
+func newTestTxManager(t *testing.T, backend *testutil.MockBackend, clk clock.Clock) *txmgr.SimpleTxManager {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	cfg := testutil.NewConfig(backend, key, clk)
+	require.NoError(t, cfg.Check())
+	return txmgr.NewSimpleTxManagerFromConfig("test", testlog.Logger(t, log.LvlCrit), &metrics.NoopTxMetrics{}, cfg)
+}
+
+func send(m *txmgr.SimpleTxManager) <-chan sendResult {
+	done := make(chan sendResult, 1)
+	go func() {
+		to := common.Address{1}
+		receipt, err := m.Send(context.Background(), txmgr.TxCandidate{To: &to, GasLimit: 21_000})
+		done <- sendResult{receipt, err}
+	}()
+	return done
+}
+
+// drive mines a block and advances the clock by a second until the send is done.
+func drive(t *testing.T, backend *testutil.MockBackend, clk *clock.DeterministicClock, done <-chan sendResult) sendResult {
+	for i := 0; i < 1000; i++ {
+		select {
+		case res := <-done:
+			return res
+		case <-time.After(10 * time.Millisecond):
+			backend.Mine()
+			clk.AdvanceTime(time.Second)
+		}
+	}
+	t.Fatal("send not done")
+	return sendResult{}
+}
+
+func waitPending(t *testing.T, backend *testutil.MockBackend) *types.Transaction {
+	require.Eventually(t, func() bool { return len(backend.Pending()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	return backend.Pending()[0]
+}
+
+// TestSendConfirmed asserts that a tx sent with a tx manager is mined and confirmed on the MockBackend.
+func TestSendConfirmed(t *testing.T) {
+	backend := testutil.NewMockBackend(big.NewInt(1))
+	clk := clock.NewDeterministicClock(time.Unix(1_000, 0))
+	m := newTestTxManager(t, backend, clk)
+
+	done := send(m)
+	tx := waitPending(t, backend)
+	res := drive(t, backend, clk, done)
+	require.NoError(t, res.err)
+	require.Equal(t, tx.Hash(), res.receipt.TxHash)
+	require.Equal(t, types.ReceiptStatusSuccessful, res.receipt.Status)
+}
+
+// TestSendFeeSpike asserts that a tx underpriced by a fee spike is resubmitted with bumped fees
+// once the resubmission timeout passes on the clock.
+func TestSendFeeSpike(t *testing.T) {
+	backend := testutil.NewMockBackend(big.NewInt(1))
+	clk := clock.NewDeterministicClock(time.Unix(1_000, 0))
+	m := newTestTxManager(t, backend, clk)
+
+	done := send(m)
+	first := waitPending(t, backend)
+	backend.SetBaseFee(new(big.Int).Mul(testutil.DefaultBaseFee, big.NewInt(10)))
+
+	res := drive(t, backend, clk, done)
+	require.NoError(t, res.err)
+	require.NotEqual(t, first.Hash(), res.receipt.TxHash)
+	require.Greater(t, len(backend.Sent()), 1)
+}
+
+// TestMockBackendReorg asserts that the txs of the reorged blocks return to the mempool,
+// and that they are mined again in a block of a different hash.
+func TestMockBackendReorg(t *testing.T) {
+	ctx := context.Background()
+	backend := testutil.NewMockBackend(big.NewInt(1))
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	tx := signTx(t, key, 0, testutil.DefaultBaseFee)
+	require.NoError(t, backend.SendTransaction(ctx, tx))
+
+	receipts := backend.Mine()
+	require.Len(t, receipts, 1)
+	backend.Mine()
+
+	backend.Reorg(2)
+	_, err = backend.TransactionReceipt(ctx, tx.Hash())
+	require.ErrorIs(t, err, ethereum.NotFound)
+	nonce, err := backend.NonceAt(ctx, crypto.PubkeyToAddress(key.PublicKey), nil)
+	require.NoError(t, err)
+	require.Zero(t, nonce)
+
+	reorged := backend.Mine()
+	require.Len(t, reorged, 1)
+	require.Equal(t, receipts[0].BlockNumber, reorged[0].BlockNumber)
+	require.NotEqual(t, receipts[0].BlockHash, reorged[0].BlockHash)
+}
+
+// TestMockBackendMempool asserts that the stale nonces and the underpriced replacements are rejected.
+func TestMockBackendMempool(t *testing.T) {
+	ctx := context.Background()
+	backend := testutil.NewMockBackend(big.NewInt(1))
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	require.NoError(t, backend.SendTransaction(ctx, signTx(t, key, 0, testutil.DefaultBaseFee)))
+
+	underpriced := new(big.Int).Add(testutil.DefaultBaseFee, big.NewInt(1))
+	require.ErrorIs(t, backend.SendTransaction(ctx, signTx(t, key, 0, underpriced)), txpool.ErrReplaceUnderpriced)
+	bumped := new(big.Int).Mul(testutil.DefaultBaseFee, big.NewInt(2))
+	require.NoError(t, backend.SendTransaction(ctx, signTx(t, key, 0, bumped)))
+
+	backend.Mine()
+	require.ErrorIs(t, backend.SendTransaction(ctx, signTx(t, key, 0, bumped)), core.ErrNonceTooLow)
+}
+
+func signTx(t *testing.T, key *ecdsa.PrivateKey, nonce uint64, gasFeeCap *big.Int) *types.Transaction {
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(big.NewInt(1)), &types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     nonce,
+		GasTipCap: gasFeeCap,
+		GasFeeCap: gasFeeCap,
+		Gas:       21_000,
+		To:        &common.Address{1},
+	})
+	require.NoError(t, err)
+	return tx
+}
@@ -0,0 +1,38 @@
+package testutil
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/kroma-network/kroma/utils/service/clock"
+	kcrypto "github.com/kroma-network/kroma/utils/service/crypto"
+	"github.com/kroma-network/kroma/utils/service/txmgr"
+)
+
+// NewConfig returns the txmgr.Config of a tx manager sending txs to the MockBackend, signed with the key.
+// The resubmissions and the receipt polls are driven by the clock, e.g. a clock.DeterministicClock:
+// the txs are resubmitted every ResubmissionTimeout, and the receipts are polled every second.
+// The tx manager waits for a single confirmation.
+func NewConfig(backend *MockBackend, key *ecdsa.PrivateKey, clk clock.Clock) txmgr.Config {
+	signer := kcrypto.PrivateKeySignerFn(key, backend.chainID)
+	return txmgr.Config{
+		Backend:                   backend,
+		ChainID:                   backend.chainID,
+		ResubmissionTimeout:       time.Minute,
+		ReceiptQueryInterval:      time.Second,
+		NumConfirmations:          1,
+		NetworkTimeout:            time.Second,
+		TxNotInMempoolTimeout:     time.Hour,
+		SafeAbortNonceTooLowCount: 3,
+		Signer: func(_ context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return signer(from, tx)
+		},
+		From:  crypto.PubkeyToAddress(key.PublicKey),
+		Clock: clk,
+	}
+}
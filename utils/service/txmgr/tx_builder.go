@@ -0,0 +1,45 @@
+package txmgr
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TxBuilder builds the txs of a candidate of a custom type, so that they reuse the nonce management,
+// the fee bumping and the confirmation of the tx manager. The types must be signable by the Signer.
+type TxBuilder interface {
+	// BuildTx returns the tx of the fields chosen by the tx manager: the chain ID, the nonce, the fees,
+	// the gas limit and the fields of the candidate. It is called to craft the tx, then on each bump
+	// with the bumped fees and gas limit. The tx must have the nonce, the gas limit and the fees of
+	// the fields; legacy is set if the txs must be priced by a gas price, see Config.LegacyTxs.
+	BuildTx(fields *types.DynamicFeeTx, legacy bool) (types.TxData, error)
+}
+
+// TxBuilderFunc is a TxBuilder of a function.
+type TxBuilderFunc func(fields *types.DynamicFeeTx, legacy bool) (types.TxData, error)
+
+func (f TxBuilderFunc) BuildTx(fields *types.DynamicFeeTx, legacy bool) (types.TxData, error) {
+	return f(fields, legacy)
+}
+
+// buildTx returns the unsigned tx of the fields, built by the builder if set.
+func buildTx(builder TxBuilder, fields *types.DynamicFeeTx, legacy bool) (*types.Transaction, error) {
+	if builder == nil {
+		return types.NewTx(txData(fields, legacy)), nil
+	}
+	data, err := builder.BuildTx(fields, legacy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the tx: %w", err)
+	}
+	tx := types.NewTx(data)
+	if tx.Nonce() != fields.Nonce || tx.Gas() != fields.Gas {
+		return nil, fmt.Errorf("built tx has nonce %d and gas %d, expected %d and %d", tx.Nonce(), tx.Gas(), fields.Nonce, fields.Gas)
+	}
+	return tx, nil
+}
+
+// isBuiltinTxType returns whether the tx is of a type crafted by the tx manager without a TxBuilder.
+func isBuiltinTxType(tx *types.Transaction) bool {
+	return tx.Type() == types.DynamicFeeTxType || tx.Type() == types.LegacyTxType
+}
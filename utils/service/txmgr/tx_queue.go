@@ -0,0 +1,242 @@
+package txmgr
+
+import (
+	"container/heap"
+	"context"
+	"sort"
+	"sync"
+)
+
+// TxPriority is the priority of a tx request in the queue of the buffered txmgr.
+// The requests of higher priority are sent first, and the requests of the same priority
+// are sent in the order they were queued.
+type TxPriority int
+
+const (
+	// TxPriorityNormal is the priority of the routine tx requests.
+	TxPriorityNormal TxPriority = 0
+	// TxPriorityHigh is the priority of the time critical tx requests, like the challenge txs.
+	TxPriorityHigh TxPriority = 10
+)
+
+// txQueue is the bounded priority queue of the tx requests of the buffered txmgr.
+// When the queue is full, it follows the BufferPolicy.
+// Each caller waiting in Pop makes room for one more request, so that a queue of size 0
+// hands the requests over to the listener like an unbuffered channel.
+type txQueue struct {
+	size   int
+	policy BufferPolicy
+
+	mu    sync.Mutex
+	items txHeap
+	seq   uint64
+	// poppers is the number of the callers waiting in Pop.
+	poppers int
+	// changed is closed and replaced whenever an item is pushed or popped,
+	// to wake up the callers waiting for an item or for room.
+	changed chan struct{}
+	// closed is set once the queue no longer accepts requests.
+	closed bool
+}
+
+func newTxQueue(size uint64, policy BufferPolicy) *txQueue {
+	return &txQueue{
+		size:    int(size),
+		policy:  policy,
+		changed: make(chan struct{}),
+	}
+}
+
+// Len returns the number of the queued requests.
+func (q *txQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
+
+// Close makes the queue reject the new requests with ErrTxManagerStopped, and Pop fail
+// once the queue is empty.
+func (q *txQueue) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.notify()
+}
+
+// Drain removes all the queued items, in the order they would be popped.
+func (q *txQueue) Drain() []*txQueueItem {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	items := q.items
+	q.items = nil
+	sort.Sort(items)
+	q.notify()
+	return items
+}
+
+// Push queues the request, following the BufferPolicy if the queue is full.
+// The blocking policy gives up when ctx or the request context is done.
+func (q *txQueue) Push(ctx context.Context, txRequest *TxRequest, priority TxPriority) error {
+	for {
+		q.mu.Lock()
+		if q.closed {
+			q.mu.Unlock()
+			return ErrTxManagerStopped
+		}
+		if len(q.items) < q.size+q.poppers {
+			q.push(txRequest, priority)
+			q.mu.Unlock()
+			return nil
+		}
+
+		switch q.policy {
+		case BufferPolicyBlock:
+			changed := q.changed
+			q.mu.Unlock()
+			select {
+			case <-changed:
+			case <-txRequest.ctx.Done():
+				return txRequest.ctx.Err()
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		case BufferPolicyDropOldest:
+			// Drop the oldest of the least important requests, unless the new one is even less important.
+			// An empty queue of size 0 has nothing to drop, so the new request is dropped.
+			victim := q.items.leastImportant()
+			if victim < 0 || q.items[victim].priority > priority {
+				q.mu.Unlock()
+				return ErrTxDropped
+			}
+			dropped := heap.Remove(&q.items, victim).(*txQueueItem)
+			q.push(txRequest, priority)
+			q.mu.Unlock()
+			dropped.txRequest.responseChan <- &TxResponse{Err: ErrTxDropped}
+			return nil
+		default:
+			q.mu.Unlock()
+			return ErrTxBufferFull
+		}
+	}
+}
+
+// Pop removes the request of the highest priority, waiting for one until ctx is done.
+// It fails with ErrTxManagerStopped once the queue is closed and empty.
+func (q *txQueue) Pop(ctx context.Context) (*TxRequest, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.items) == 0 {
+		// Wake up the blocked callers of Push, since the waiting caller makes room.
+		q.poppers++
+		q.notify()
+		for len(q.items) == 0 {
+			if q.closed {
+				q.poppers--
+				return nil, ErrTxManagerStopped
+			}
+			changed := q.changed
+			q.mu.Unlock()
+			select {
+			case <-changed:
+				q.mu.Lock()
+			case <-ctx.Done():
+				q.mu.Lock()
+				q.poppers--
+				return nil, ctx.Err()
+			}
+		}
+		q.poppers--
+	}
+	item := heap.Pop(&q.items).(*txQueueItem)
+	q.notify()
+	return item.txRequest, nil
+}
+
+// PopAggregatable removes up to max queued requests whose candidates are Aggregatable, in the order
+// they would be popped, without waiting.
+func (q *txQueue) PopAggregatable(max int) []*TxRequest {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var taken, kept txHeap
+	for _, item := range q.items {
+		if item.txRequest.txCandidate.aggregatable() {
+			taken = append(taken, item)
+		} else {
+			kept = append(kept, item)
+		}
+	}
+	if len(taken) == 0 {
+		return nil
+	}
+	sort.Sort(taken)
+	if len(taken) > max {
+		kept = append(kept, taken[max:]...)
+		taken = taken[:max]
+	}
+	q.items = kept
+	heap.Init(&q.items)
+	q.notify()
+
+	txRequests := make([]*TxRequest, len(taken))
+	for i, item := range taken {
+		txRequests[i] = item.txRequest
+	}
+	return txRequests
+}
+
+// push must be called with the lock held.
+func (q *txQueue) push(txRequest *TxRequest, priority TxPriority) {
+	heap.Push(&q.items, &txQueueItem{txRequest: txRequest, priority: priority, seq: q.seq})
+	q.seq++
+	q.notify()
+}
+
+// notify must be called with the lock held.
+func (q *txQueue) notify() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
+
+type txQueueItem struct {
+	txRequest *TxRequest
+	priority  TxPriority
+	// seq is the order in which the item was queued.
+	seq uint64
+}
+
+// txHeap implements heap.Interface, ordering the items by descending priority, then by ascending seq.
+type txHeap []*txQueueItem
+
+func (h txHeap) Len() int { return len(h) }
+
+func (h txHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h txHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *txHeap) Push(x any) { *h = append(*h, x.(*txQueueItem)) }
+
+func (h *txHeap) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return item
+}
+
+// leastImportant returns the index of the oldest item of the lowest priority, or -1 if the heap is empty.
+func (h txHeap) leastImportant() int {
+	victim := -1
+	for i, item := range h {
+		if victim < 0 || item.priority < h[victim].priority ||
+			(item.priority == h[victim].priority && item.seq < h[victim].seq) {
+			victim = i
+		}
+	}
+	return victim
+}
@@ -0,0 +1,138 @@
+package txmgr
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// TxState is the state of a tx sent with SendAsync.
+type TxState int
+
+const (
+	// TxPublished means that the tx, or its latest resubmission, was accepted to the mempool.
+	TxPublished TxState = iota
+	// TxIncluded means that the tx is included in a block, with fewer than NumConfirmations confirmations.
+	TxIncluded
+	// TxConfirmed means that the tx has NumConfirmations confirmations, i.e. Send would return now.
+	TxConfirmed
+	// TxFinalized means that the block of the tx is finalized on L1. It is the last status of a successful tx.
+	TxFinalized
+	// TxFailed means that the send failed. It is the last status of a failed tx.
+	TxFailed
+)
+
+func (s TxState) String() string {
+	switch s {
+	case TxPublished:
+		return "published"
+	case TxIncluded:
+		return "included"
+	case TxConfirmed:
+		return "confirmed"
+	case TxFinalized:
+		return "finalized"
+	case TxFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// TxStatus is a status update of a tx sent with SendAsync.
+type TxStatus struct {
+	State TxState
+	// Tx is the published tx. It is only set for TxPublished.
+	Tx *types.Transaction
+	// Receipt is the receipt of the tx. It is set from TxIncluded on, and for TxFailed if the tx was
+	// confirmed but failed.
+	Receipt *types.Receipt
+	// Confirmations is the number of confirmations of the tx. It is set for TxIncluded and TxConfirmed.
+	Confirmations uint64
+	// Err is the error of the send. It is only set for TxFailed.
+	Err error
+}
+
+// statusNotifier calls the status callback of SendAsync sequentially, skipping the repeated statuses,
+// like the same confirmation count observed by the receipt checks of several resubmissions.
+type statusNotifier struct {
+	mu       sync.Mutex
+	onStatus func(TxStatus)
+	last     statusKey
+}
+
+type statusKey struct {
+	state         TxState
+	hash          common.Hash
+	confirmations uint64
+}
+
+func newStatusNotifier(onStatus func(TxStatus)) *statusNotifier {
+	return &statusNotifier{onStatus: onStatus, last: statusKey{state: -1}}
+}
+
+func (n *statusNotifier) notify(status TxStatus) {
+	key := statusKey{state: status.State, confirmations: status.Confirmations}
+	if status.Tx != nil {
+		key.hash = status.Tx.Hash()
+	} else if status.Receipt != nil {
+		key.hash = status.Receipt.TxHash
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if key == n.last && status.State != TxFailed {
+		return
+	}
+	n.last = key
+	n.onStatus(status)
+}
+
+// SendAsync sends the tx like Send, but returns at once. The progress of the tx is reported to onStatus:
+// TxPublished on each publication, TxIncluded on each new confirmation, then TxConfirmed once the tx has
+// NumConfirmations confirmations, and TxFinalized once its block is finalized on L1, or TxFailed with
+// the error that Send would return. The finalization is no longer awaited once ctx is done.
+// The callbacks are sequential, and must not block.
+//
+// NOTE: SendAsync counts as a call of Send until the TxConfirmed or TxFailed status, so that the
+// concurrent sends follow the same rules as Send.
+func (m *SimpleTxManager) SendAsync(ctx context.Context, candidate TxCandidate, onStatus func(TxStatus)) {
+	notifier := newStatusNotifier(onStatus)
+	go func() {
+		receipt, err := m.sender().sendCandidate(ctx, candidate, notifier.notify)
+		if err != nil {
+			notifier.notify(TxStatus{State: TxFailed, Receipt: receipt, Err: err})
+			return
+		}
+		notifier.notify(TxStatus{State: TxConfirmed, Receipt: receipt, Confirmations: m.NumConfirmations})
+		if m.waitFinalized(ctx, receipt) {
+			notifier.notify(TxStatus{State: TxFinalized, Receipt: receipt, Confirmations: m.NumConfirmations})
+		}
+	}()
+}
+
+// waitFinalized polls the finalized L1 block every ReceiptQueryInterval until it reaches the block of
+// the receipt, and returns whether it did before ctx is done.
+func (m *SimpleTxManager) waitFinalized(ctx context.Context, receipt *types.Receipt) bool {
+	finalized := big.NewInt(int64(rpc.FinalizedBlockNumber))
+	ticker := m.clock().NewTicker(m.ReceiptQueryInterval)
+	defer ticker.Stop()
+	for {
+		cCtx, cancel := context.WithTimeout(ctx, m.NetworkTimeout)
+		header, err := m.backend.HeaderByNumber(cCtx, finalized)
+		cancel()
+		if err != nil {
+			m.l.Debug("failed to get the finalized block", "err", err)
+		} else if header.Number != nil && header.Number.Cmp(receipt.BlockNumber) >= 0 {
+			return true
+		}
+		select {
+		case <-ticker.Ch():
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package txmgr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// txStore persists the signed but unconfirmed txs to a JSON file, keyed by nonce, so that
+// a restarted tx manager resumes monitoring them instead of reusing their nonces.
+// Only the latest tx of each nonce is kept, since a resubmission replaces the previous one.
+type txStore struct {
+	path string
+
+	mu       sync.Mutex
+	txs      map[uint64]*types.Transaction
+	restored map[uint64]bool
+	loaded   bool
+
+	// resumeMu serializes the resumption of the restored txs by the concurrent sends.
+	resumeMu sync.Mutex
+}
+
+func newTxStore(path string) *txStore {
+	return &txStore{path: path}
+}
+
+// Restore returns the latest txs of the nonces found in the file when the store was loaded,
+// ordered by nonce. The txs recorded afterwards are not restored, and a restored tx is
+// no longer returned once it is deleted.
+func (s *txStore) Restore() ([]*types.Transaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	var txs []*types.Transaction
+	for _, tx := range s.sorted() {
+		if s.restored[tx.Nonce()] {
+			txs = append(txs, tx)
+		}
+	}
+	return txs, nil
+}
+
+// Put records the tx, replacing the previous tx of the same nonce.
+func (s *txStore) Put(tx *types.Transaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return err
+	}
+	s.txs[tx.Nonce()] = tx
+	return s.flush()
+}
+
+// Delete forgets the tx of the nonce.
+func (s *txStore) Delete(nonce uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return err
+	}
+	if _, ok := s.txs[nonce]; !ok {
+		return nil
+	}
+	delete(s.txs, nonce)
+	delete(s.restored, nonce)
+	return s.flush()
+}
+
+// load reads the file once. A missing file is an empty store. It must be called with the lock held.
+func (s *txStore) load() error {
+	if s.loaded {
+		return nil
+	}
+	s.txs = make(map[uint64]*types.Transaction)
+	s.restored = make(map[uint64]bool)
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		s.loaded = true
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read the tx store: %w", err)
+	}
+	var rawTxs []hexutil.Bytes
+	if err := json.Unmarshal(data, &rawTxs); err != nil {
+		return fmt.Errorf("failed to decode the tx store %s: %w", s.path, err)
+	}
+	for _, rawTx := range rawTxs {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(rawTx); err != nil {
+			return fmt.Errorf("failed to decode a tx of the tx store %s: %w", s.path, err)
+		}
+		s.txs[tx.Nonce()] = tx
+		s.restored[tx.Nonce()] = true
+	}
+	s.loaded = true
+	return nil
+}
+
+// flush writes the txs to a temporary file and renames it, so that a crash never leaves
+// a partially written store behind. It must be called with the lock held.
+func (s *txStore) flush() error {
+	txs := s.sorted()
+	rawTxs := make([]hexutil.Bytes, 0, len(txs))
+	for _, tx := range txs {
+		rawTx, err := tx.MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("failed to encode the tx %s: %w", tx.Hash(), err)
+		}
+		rawTxs = append(rawTxs, rawTx)
+	}
+	data, err := json.Marshal(rawTxs)
+	if err != nil {
+		return fmt.Errorf("failed to encode the tx store: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create the directory of the tx store: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write the tx store: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to replace the tx store: %w", err)
+	}
+	return nil
+}
+
+func (s *txStore) sorted() []*types.Transaction {
+	txs := make([]*types.Transaction, 0, len(s.txs))
+	for _, tx := range s.txs {
+		txs = append(txs, tx)
+	}
+	sort.Slice(txs, func(i, j int) bool {
+		return txs[i].Nonce() < txs[j].Nonce()
+	})
+	return txs
+}
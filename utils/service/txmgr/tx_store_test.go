@@ -0,0 +1,57 @@
+package txmgr
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func storeTestTx(nonce uint64, gasFeeCap int64) *types.Transaction {
+	return types.NewTx(&types.DynamicFeeTx{
+		Nonce:     nonce,
+		GasFeeCap: big.NewInt(gasFeeCap),
+	})
+}
+
+func TestTxStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "txmgr", "state.json")
+
+	s := newTxStore(path)
+	restored, err := s.Restore()
+	require.NoError(t, err)
+	require.Empty(t, restored, "a missing file is an empty store")
+
+	require.NoError(t, s.Put(storeTestTx(2, 1)))
+	require.NoError(t, s.Put(storeTestTx(1, 1)))
+	bumped := storeTestTx(2, 2)
+	require.NoError(t, s.Put(bumped))
+	restored, err = s.Restore()
+	require.NoError(t, err)
+	require.Empty(t, restored, "the txs recorded by the same process are not restored")
+
+	// Restart
+	s = newTxStore(path)
+	restored, err = s.Restore()
+	require.NoError(t, err)
+	require.Len(t, restored, 2)
+	require.Equal(t, uint64(1), restored[0].Nonce())
+	require.Equal(t, bumped.Hash(), restored[1].Hash(), "only the latest tx of a nonce is kept")
+
+	require.NoError(t, s.Delete(1))
+	require.NoError(t, s.Put(storeTestTx(3, 1)))
+	restored, err = s.Restore()
+	require.NoError(t, err)
+	require.Len(t, restored, 1)
+	require.Equal(t, uint64(2), restored[0].Nonce())
+
+	// Restart
+	s = newTxStore(path)
+	restored, err = s.Restore()
+	require.NoError(t, err)
+	require.Len(t, restored, 2)
+	require.Equal(t, uint64(2), restored[0].Nonce())
+	require.Equal(t, uint64(3), restored[1].Nonce())
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"math/rand"
 	"strings"
 	"sync"
 	"time"
@@ -15,22 +16,57 @@ import (
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 
+	"github.com/kroma-network/kroma/utils/service/backoff"
+	"github.com/kroma-network/kroma/utils/service/clock"
 	"github.com/kroma-network/kroma/utils/service/txmgr/metrics"
 )
 
 // Geth defaults the priceBump to 10
 // Set it to 15% to be more aggressive about including transactions
-const priceBump int64 = 15
+const DefaultFeeBumpPercent uint64 = 15
+
+// minFeeBumpPercent is the minimum price bump geth accepts to replace a pending tx.
+const minFeeBumpPercent uint64 = 10
+
+var oneHundred = big.NewInt(100)
 
-// new = old * (100 + priceBump) / 100
 var (
-	priceBumpPercent = big.NewInt(100 + priceBump)
-	oneHundred       = big.NewInt(100)
+	// ErrTxReceiptNotSucceed is the error wrapped by ReceiptError, returned when tx confirmed but the status is not success.
+	ErrTxReceiptNotSucceed = errors.New("transaction confirmed but the status is not success")
+	// ErrNoPendingTx is the error returned when there is no pending tx to cancel at the given nonce.
+	ErrNoPendingTx = errors.New("no pending transaction at the given nonce")
+	// ErrTxSendTimeout is the error returned when the tx is not confirmed within TxSendTimeout.
+	ErrTxSendTimeout = errors.New("transaction not confirmed within the send timeout")
+	// ErrDeadlineExceeded is the error returned when the tx is not confirmed before the deadline of its candidate.
+	ErrDeadlineExceeded = errors.New("transaction not confirmed before the deadline")
+	// ErrNotInMempoolTimeout is the error returned when the tx could not be published to the mempool
+	// within TxNotInMempoolTimeout.
+	ErrNotInMempoolTimeout = errors.New("transaction not published to the mempool within the timeout")
+	// ErrFeeLimitExceeded is the error returned when the bumped fees exceed FeeLimitMultiplier times the suggested fees.
+	ErrFeeLimitExceeded = errors.New("fee limit exceeded")
+	// ErrNonceTooLowAbort is the error wrapped by NonceTooLowAbortError.
+	ErrNonceTooLowAbort = errors.New("aborted after too many nonce too low errors")
 )
 
-// ErrTxReceiptNotSucceed is the error returned when tx confirmed but the status is not success.
-var ErrTxReceiptNotSucceed = errors.New("transaction confirmed but the status is not success")
+// NonceTooLowAbortError is the error returned when the tx sending is aborted because
+// the nonce too low error was observed SafeAbortNonceTooLowCount times while none of the txs were mined.
+// It means that another tx took the nonce, so the tx should be rebuilt rather than resubmitted.
+type NonceTooLowAbortError struct {
+	// Nonce is the nonce of the aborted tx.
+	Nonce uint64
+	// Count is the number of observed nonce too low errors.
+	Count uint64
+}
+
+func (e *NonceTooLowAbortError) Error() string {
+	return fmt.Sprintf("%v: nonce %d, observed %d times", ErrNonceTooLowAbort, e.Nonce, e.Count)
+}
+
+func (e *NonceTooLowAbortError) Unwrap() error {
+	return ErrNonceTooLowAbort
+}
 
 // TxManager is an interface that allows callers to reliably publish txs,
 // bumping the gas price if needed, and obtain the receipt of the resulting tx.
@@ -42,12 +78,28 @@ type TxManager interface {
 	// It can be stopped by cancelling the provided context; however, the transaction
 	// may be included on L1 even if the context is cancelled.
 	//
-	// NOTE: Send should be called by AT MOST one caller at a time.
+	// NOTE: Send should be called by AT MOST one caller at a time, unless MaxPendingTxs is more than 1
+	// or ExtraAccounts are configured.
 	Send(ctx context.Context, candidate TxCandidate) (*types.Receipt, error)
 
+	// SendAsync sends the transaction like Send, but returns at once and reports the progress of
+	// the transaction to onStatus, up to the finalization of its block.
+	SendAsync(ctx context.Context, candidate TxCandidate, onStatus func(TxStatus))
+
 	// From returns the sending address associated with the instance of the transaction manager.
-	// It is static for a single instance of a TxManager.
+	// It is static for a single instance of a TxManager, unless it is switched to one of the
+	// StandbyAccounts. If ExtraAccounts are configured, it is the primary account, and the txs
+	// may be sent from the extra accounts as well.
 	From() common.Address
+
+	// Cancel evicts the stuck pending transaction at the nonce by a self-transfer at an escalated fee.
+	// The escalated fee is subject to the fee limits and the max gas price, like the bumped fees.
+	Cancel(ctx context.Context, nonce uint64) error
+
+	// Replace evicts the stuck pending transaction at the nonce by the transaction built from the
+	// candidate at an escalated fee, and returns the receipt of the replacement.
+	// The escalated fee is subject to the fee limits and the max gas price, like the bumped fees.
+	Replace(ctx context.Context, nonce uint64, candidate TxCandidate) (*types.Receipt, error)
 }
 
 // ETHBackend is the set of methods that the transaction manager uses to resubmit gas & determine
@@ -65,7 +117,7 @@ type ETHBackend interface {
 	SendTransaction(ctx context.Context, tx *types.Transaction) error
 
 	// These functions are used to estimate what the basefee & priority fee should be set to.
-	// TODO(CLI-3318): Maybe need a generic interface to support different RPC providers
+	// The priority fee is only taken from SuggestGasTipCap if no GasPricer is configured.
 	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
 	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
 	// NonceAt returns the account nonce of the given account.
@@ -78,6 +130,11 @@ type ETHBackend interface {
 	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
 }
 
+// TxPublisher publishes the signed txs, like the private tx relays do.
+type TxPublisher interface {
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
 // SimpleTxManager is an implementation of TxManager that performs linear fee
 // bumping of a tx until it confirms.
 type SimpleTxManager struct {
@@ -88,6 +145,57 @@ type SimpleTxManager struct {
 	backend ETHBackend
 	l       log.Logger
 	metr    metrics.TxMetricer
+
+	// rng is used to randomize the resubmission timeout. It is shared by the send loops
+	// of the concurrent sends, so its source must be safe for concurrent use. It is only
+	// created by newJitterRand, from the ResubmissionJitterSource.
+	rng *rand.Rand
+
+	// nonces reserves the nonces of the concurrent sends. It is nil if MaxPendingTxs is at most 1,
+	// in which case the nonce of each tx is taken from the latest block.
+	nonces *nonceTracker
+
+	// heads caches the L1 head shared by the confirmation checks of the in-flight txs.
+	heads *headTracker
+
+	// receipts batches the receipt queries of the in-flight txs. It is nil if BatchReceipts is not set
+	// or the backend does not support batches.
+	receipts *receiptBatcher
+
+	// store persists the unconfirmed txs across restarts. It is nil if StatePath is not set.
+	store *txStore
+
+	// stuckTxHandler is called when a tx is detected as stuck. It is registered with OnStuckTx.
+	stuckTxHandler StuckTxHandler
+
+	// reorgHandler is called when a confirmed tx is reorged out. It is registered with OnReorg.
+	reorgHandler ReorgHandler
+
+	// contractABIs maps the contract addresses to their ABIs registered with RegisterContractABI.
+	contractABIs map[common.Address]contractABI
+
+	// accounts rotates the sends between the primary account and the ExtraAccounts.
+	// It is nil if there are no ExtraAccounts.
+	accounts *accountPool
+
+	// standby switches the new sends between the primary account and the StandbyAccounts.
+	// It is nil if there are no StandbyAccounts.
+	standby *standbyAccounts
+
+	// circuit pauses the new sends while the backend keeps failing. It is nil if CircuitBreakerThreshold is 0.
+	circuit *circuitBreaker
+
+	// budget refuses the new sends once the fees spent reach DailyFeeBudget. It is nil if DailyFeeBudget is not set.
+	budget *feeBudget
+
+	// hooks are called at the lifecycle events of the txs. They are added with AddHook.
+	hooks []TxHook
+
+	// pending indexes the sends in flight, so that they can be inspected, bumped and dropped.
+	pending *pendingSends
+
+	// bg runs the background work outliving the sends, i.e. the reorg watches, until Close.
+	bg *background
 }
 
 // NewSimpleTxManager initializes a new SimpleTxManager with the passed Config.
@@ -96,19 +204,161 @@ func NewSimpleTxManager(name string, l log.Logger, m metrics.TxMetricer, cfg CLI
 	if err != nil {
 		return nil, err
 	}
+	return NewSimpleTxManagerFromConfig(name, l, m, conf), nil
+}
 
-	return &SimpleTxManager{
+// NewSimpleTxManagerFromConfig initializes a new SimpleTxManager with the passed Config,
+// which is expected to be complete, including the Backend, ChainID and Signer.
+func NewSimpleTxManagerFromConfig(name string, l log.Logger, m metrics.TxMetricer, conf Config) *SimpleTxManager {
+	accountLogger := l
+	l = l.New("service", name)
+	var store *txStore
+	if conf.StatePath != "" {
+		store = newTxStore(conf.StatePath)
+	}
+	var nonces *nonceTracker
+	if conf.MaxPendingTxs > 1 {
+		nonces = newNonceTracker(conf.MaxPendingTxs)
+	}
+	if conf.Clock == nil {
+		conf.Clock = clock.SystemClock
+	}
+	mgr := &SimpleTxManager{
 		chainID: conf.ChainID,
 		name:    name,
 		Config:  conf,
 		backend: conf.Backend,
-		l:       l.New("service", name),
+		l:       l,
 		metr:    m,
-	}, nil
+		rng:     newJitterRand(conf.ResubmissionJitterSource),
+		heads:   newHeadTracker(conf.Backend, conf.ReceiptQueryInterval, conf.Clock, l),
+		store:   store,
+		nonces:  nonces,
+		pending: newPendingSends(),
+		bg:      newBackground(),
+	}
+	if fetcher, ok := conf.Backend.(ReceiptsFetcher); ok && conf.BatchReceipts {
+		mgr.receipts = newReceiptBatcher(fetcher, conf.ReceiptQueryInterval, conf.NetworkTimeout)
+	}
+	if conf.CircuitBreakerThreshold > 0 {
+		mgr.circuit = newCircuitBreaker(conf.CircuitBreakerThreshold, conf.CircuitBreakerProbeInterval, conf.Clock, m, l)
+	}
+	if conf.DailyFeeBudget != nil && conf.DailyFeeBudget.Sign() > 0 {
+		mgr.budget = newFeeBudget(conf.DailyFeeBudget, feeBudgetWindow, m)
+	}
+	if len(conf.ExtraAccounts) > 0 {
+		extra := newAccountManagers(name, accountLogger, m, conf, mgr, conf.ExtraAccounts)
+		mgr.accounts = newAccountPool(extra, conf.MaxPendingTxs)
+	}
+	if len(conf.StandbyAccounts) > 0 {
+		mgr.standby = &standbyAccounts{managers: newAccountManagers(name, accountLogger, m, conf, mgr, conf.StandbyAccounts)}
+	}
+	return mgr
+}
+
+// Start connects the backend if it is dialed lazily, retrying with the network retry backoff,
+// so that the failures to reach L1 surface at the start rather than on the first send.
+func (m *SimpleTxManager) Start(ctx context.Context) error {
+	connector, ok := m.backend.(Connector)
+	if !ok {
+		return nil
+	}
+	if err := connector.Connect(ctx); err != nil {
+		return fmt.Errorf("failed to connect to L1: %w", err)
+	}
+	return nil
 }
 
+// Close stops the background work of the tx manager, i.e. the reorg watches of the confirmed txs,
+// and waits for it to return, then closes the remote signer client. The sends in flight are not aborted.
+func (m *SimpleTxManager) Close() {
+	if m.bg != nil {
+		m.bg.close()
+	}
+	if m.SignerClose != nil {
+		m.SignerClose()
+	}
+}
+
+// background runs the goroutines of the tx manager which outlive the sends, until it is closed.
+type background struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	// mu orders the goroutines started with wg against close.
+	mu sync.Mutex
+	wg sync.WaitGroup
+}
+
+func newBackground() *background {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &background{ctx: ctx, cancel: cancel}
+}
+
+// run runs fn in a goroutine, with a context cancelled by close. fn is not run once closed.
+func (b *background) run(fn func(ctx context.Context)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.ctx.Err() != nil {
+		return
+	}
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		fn(b.ctx)
+	}()
+}
+
+// close cancels the context of the goroutines and waits for them to return.
+func (b *background) close() {
+	b.mu.Lock()
+	b.cancel()
+	b.mu.Unlock()
+	b.wg.Wait()
+}
+
+// headNumber returns the block number of the L1 head. The head is cached, so that
+// the confirmation checks of the in-flight txs do not query the backend each.
+func (m *SimpleTxManager) headNumber(ctx context.Context) (uint64, error) {
+	if m.heads == nil {
+		return m.backend.BlockNumber(ctx)
+	}
+	return m.heads.BlockNumber(ctx)
+}
+
+// newHead returns a channel closed on the next L1 head, or nil if the heads are not notified.
+func (m *SimpleTxManager) newHead() <-chan struct{} {
+	if m.heads == nil {
+		return nil
+	}
+	return m.heads.NewHead()
+}
+
+// From returns the address of the account the new txs are sent from, which changes with SwitchAccount.
 func (m *SimpleTxManager) From() common.Address {
-	return m.Config.From
+	return m.sender().Config.From
+}
+
+// clock returns the Clock of the config, or the system clock if it is not set.
+func (m *SimpleTxManager) clock() clock.Clock {
+	if m.Clock == nil {
+		return clock.SystemClock
+	}
+	return m.Clock
+}
+
+// contractLabel returns the metric label of the recipient of a tx: its label in ContractLabels,
+// "multicall3" for the Multicall3 contract, or "other".
+func (m *SimpleTxManager) contractLabel(to *common.Address) string {
+	if to == nil {
+		return "other"
+	}
+	if label, ok := m.ContractLabels[*to]; ok {
+		return label
+	}
+	if m.MulticallAddress != (common.Address{}) && *to == m.MulticallAddress {
+		return "multicall3"
+	}
+	return "other"
 }
 
 // TxCandidate is a transaction candidate that can be submitted to ask the
@@ -124,6 +374,26 @@ type TxCandidate struct {
 	AccessList types.AccessList
 	// Value is the value that is passed to the constructed tx.
 	Value *big.Int
+	// Deadline is the time after which the tx is useless, like an output submission for an
+	// obsolete block. Once it passes, the tx is no longer bumped and Send returns ErrDeadlineExceeded.
+	// The zero value means no deadline.
+	Deadline time.Time
+	// Aggregatable marks the candidate as one that the buffered txmgr may aggregate with the other
+	// aggregatable candidates into a single Multicall3 tx, see Config.MulticallAddress.
+	// The call is then made by the Multicall3 contract, so it must not depend on msg.sender,
+	// and a failing call reverts all the calls aggregated with it.
+	Aggregatable bool
+	// Precondition, if set, is checked before each publication, resubmission and rebroadcast of the tx.
+	// Once it fails, the tx is no longer sent and Send returns a PreconditionError.
+	Precondition Precondition
+	// Builder, if set, builds the txs of the candidate, e.g. of a type not crafted by the tx manager.
+	// A candidate with a Builder is never aggregated.
+	Builder TxBuilder
+}
+
+// aggregatable returns whether the candidate can be aggregated into a Multicall3 tx.
+func (c *TxCandidate) aggregatable() bool {
+	return c.Aggregatable && c.Builder == nil
 }
 
 // Send is used to publish a transaction with incrementally higher gas prices
@@ -134,46 +404,155 @@ type TxCandidate struct {
 // The transaction manager handles all signing. If and only if the gas limit is 0, the
 // transaction manager will do a gas estimation.
 //
-// NOTE: Send should be called by AT MOST one caller at a time.
+// NOTE: Send should be called by AT MOST one caller at a time, unless MaxPendingTxs is more than 1,
+// in which case up to MaxPendingTxs sends run concurrently, each at its own nonce, or ExtraAccounts
+// are configured, in which case the sends rotate to the next account once one has MaxPendingTxs
+// sends in flight.
 func (m *SimpleTxManager) Send(ctx context.Context, candidate TxCandidate) (*types.Receipt, error) {
+	return m.sender().sendCandidate(ctx, candidate, nil)
+}
+
+// sendCandidate implements Send, reporting the progress of the tx to onStatus if set.
+func (m *SimpleTxManager) sendCandidate(ctx context.Context, candidate TxCandidate, onStatus func(TxStatus)) (*types.Receipt, error) {
+	if !candidate.Deadline.IsZero() && !m.clock().Now().Before(candidate.Deadline) {
+		return nil, fmt.Errorf("%w: deadline %v", ErrDeadlineExceeded, candidate.Deadline)
+	}
+	deadlineCtx := ctx
+	if !candidate.Deadline.IsZero() {
+		var cancel context.CancelFunc
+		deadlineCtx, cancel = context.WithDeadline(ctx, candidate.Deadline)
+		defer cancel()
+	}
+	sendCtx := deadlineCtx
 	if m.TxSendTimeout != 0 {
 		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, m.TxSendTimeout)
+		sendCtx, cancel = context.WithTimeout(deadlineCtx, m.TxSendTimeout)
 		defer cancel()
 	}
-	tx, err := m.craftTx(ctx, candidate)
+	// Distinguish the expiry of the deadline or of TxSendTimeout from the cancellation of the caller's context.
+	expiryErr := func(err error, desc string) error {
+		if !errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil {
+			return err
+		}
+		if deadlineCtx.Err() != nil {
+			return fmt.Errorf("%w: %s, deadline %v", ErrDeadlineExceeded, desc, candidate.Deadline)
+		}
+		return fmt.Errorf("%w: %s, timeout %v", ErrTxSendTimeout, desc, m.TxSendTimeout)
+	}
+
+	if err := m.checkFeeBudget(); err != nil {
+		return nil, err
+	}
+	if err := m.waitCircuit(sendCtx); err != nil {
+		return nil, expiryErr(err, "waiting for the L1 backend to recover")
+	}
+
+	// am is the tx manager of the account the tx is sent from.
+	am := m
+	if m.accounts != nil {
+		i, err := m.accounts.acquire(sendCtx)
+		if err != nil {
+			return nil, expiryErr(err, "waiting for an account to settle a pending tx")
+		}
+		defer m.accounts.release(i)
+		am = m.accountManager(i)
+	}
+
+	if err := am.resumePending(sendCtx); err != nil {
+		return nil, expiryErr(fmt.Errorf("failed to resume the pending txs: %w", err), "resuming")
+	}
+	if err := am.waitForGasPrice(sendCtx); err != nil {
+		return nil, expiryErr(err, "waiting for the gas price")
+	}
+	if am.nonces != nil {
+		if err := am.nonces.acquire(sendCtx); err != nil {
+			return nil, expiryErr(err, "waiting for a pending tx to settle")
+		}
+		defer am.nonces.release()
+	}
+	tx, err := am.craftTx(sendCtx, candidate)
+	if err != nil {
+		am.resetNonce()
+		return nil, expiryErr(fmt.Errorf("failed to create the tx: %w", err), "crafting")
+	}
+	if am.SimulateBeforeSend && !am.DryRun {
+		if err := am.callTx(sendCtx, tx); err != nil {
+			am.l.Warn("not publishing the tx failing the simulation", "hash", tx.Hash(), "nonce", tx.Nonce(), "err", err)
+			am.resetNonce()
+			return nil, err
+		}
+	}
+	receipt, err := am.sendTx(sendCtx, tx, candidate.GasLimit == 0, candidate.Builder, candidate.Precondition, onStatus)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create the tx: %w", err)
+		// A confirmed tx took its nonce even if it failed.
+		if receipt == nil {
+			am.resetNonce()
+		}
+		return receipt, expiryErr(err, fmt.Sprintf("nonce %d", tx.Nonce()))
+	}
+	return receipt, nil
+}
+
+// nextNonce returns the nonce of the new tx: the nonce of the latest block, or the next nonce
+// reserved for the concurrent sends if MaxPendingTxs is more than 1.
+func (m *SimpleTxManager) nextNonce(ctx context.Context) (uint64, error) {
+	fetch := func(ctx context.Context, pending bool) (uint64, error) {
+		if pending {
+			return retryNetwork(ctx, m, "get pending nonce", func(ctx context.Context) (uint64, error) {
+				return m.backend.PendingNonceAt(ctx, m.Config.From)
+			})
+		}
+		// Fetch the sender's nonce from the latest known block (nil `blockNumber`)
+		return retryNetwork(ctx, m, "get nonce", func(ctx context.Context) (uint64, error) {
+			return m.backend.NonceAt(ctx, m.Config.From, nil)
+		})
+	}
+	if m.nonces == nil {
+		return fetch(ctx, false)
+	}
+	return m.nonces.reserve(ctx, fetch)
+}
+
+// resetNonce makes the next concurrent send fetch its nonce again, after a send failed with its
+// nonce possibly untaken.
+func (m *SimpleTxManager) resetNonce() {
+	if m.nonces != nil {
+		m.nonces.reset()
 	}
-	return m.send(ctx, tx)
 }
 
 // craftTx creates the signed transaction
 // It queries L1 for the current fee market conditions as well as for the nonce.
 // NOTE: This method SHOULD NOT publish the resulting transaction.
 // NOTE: If the [TxCandidate.GasLimit] is non-zero, it will be used as the transaction's gas.
-// NOTE: Otherwise, the [SimpleTxManager] will query the specified backend for an estimate.
+// NOTE: Otherwise, the [SimpleTxManager] will query the specified backend for an estimate
+// and add [Config.GasLimitBufferPercent] on top of it.
 func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*types.Transaction, error) {
-	gasTipCap, basefee, err := m.suggestGasPriceCaps(ctx)
+	gasTipCap, basefee, legacy, err := m.suggestGasPriceCaps(ctx)
 	if err != nil {
 		m.metr.RPCError()
 		return nil, fmt.Errorf("failed to get gas price info: %w", err)
 	}
-	gasFeeCap := calcGasFeeCap(basefee, gasTipCap)
+	gasFeeCap := m.capGasFeeCap(calcGasFeeCap(basefee, gasTipCap))
 
-	// Fetch the sender's nonce from the latest known block (nil `blockNumber`)
-	childCtx, cancel := context.WithTimeout(ctx, m.NetworkTimeout)
-	defer cancel()
-	nonce, err := m.backend.NonceAt(childCtx, m.From(), nil)
+	nonce, err := m.nextNonce(ctx)
 	if err != nil {
-		m.metr.RPCError()
-		return nil, fmt.Errorf("failed to get nonce: %w", err)
+		return nil, err
 	}
 	m.metr.RecordNonce(nonce)
 
-	// TODO: If we apply the accessList manually, it's hard to predict and react to other issues,
-	// such as gas prices, due to subsequent code modifications.
-	// To avoid this, we need to add logic to calculate the accessList automatically using `eth_createAccessList`.
+	accessList := candidate.AccessList
+	if m.CreateAccessList && len(accessList) == 0 {
+		accessList = m.createAccessList(ctx, ethereum.CallMsg{
+			From:      m.Config.From,
+			To:        candidate.To,
+			GasFeeCap: gasFeeCap,
+			GasTipCap: gasTipCap,
+			Data:      candidate.TxData,
+			Value:     candidate.Value,
+		})
+	}
+
 	rawTx := &types.DynamicFeeTx{
 		ChainID:    m.chainID,
 		Nonce:      nonce,
@@ -182,84 +561,306 @@ func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*
 		GasFeeCap:  gasFeeCap,
 		Value:      candidate.Value,
 		Data:       candidate.TxData,
-		AccessList: candidate.AccessList,
+		AccessList: accessList,
 	}
 
-	m.l.Info("creating tx", "to", rawTx.To, "from", m.From())
+	m.l.Info("creating tx", "to", rawTx.To, "from", m.Config.From)
 
 	// If the gas limit is set, we can use that as the gas
 	if candidate.GasLimit != 0 {
 		rawTx.Gas = candidate.GasLimit
 	} else {
-		gas, err := m.backend.EstimateGas(ctx, ethereum.CallMsg{
-			From:      m.From(),
-			To:        candidate.To,
-			GasFeeCap: gasFeeCap,
-			GasTipCap: gasTipCap,
-			Data:      rawTx.Data,
-			Value:     candidate.Value,
+		gas, err := m.estimateGas(ctx, ethereum.CallMsg{
+			From:       m.Config.From,
+			To:         candidate.To,
+			GasFeeCap:  gasFeeCap,
+			GasTipCap:  gasTipCap,
+			Data:       rawTx.Data,
+			Value:      candidate.Value,
+			AccessList: accessList,
 		})
 		if err != nil {
-			return nil, fmt.Errorf("failed to estimate gas: %w", err)
+			return nil, err
 		}
 		rawTx.Gas = gas
 	}
 
-	ctx, cancel = context.WithTimeout(ctx, m.NetworkTimeout)
-	defer cancel()
-	return m.Signer(ctx, m.From(), types.NewTx(rawTx))
+	tx, err := buildTx(candidate.Builder, rawTx, legacy)
+	if err != nil {
+		return nil, err
+	}
+	return m.signTx(ctx, tx)
+}
+
+// estimateGas queries the backend for the gas limit of the given call and adds
+// [Config.GasLimitBufferPercent] on top of it.
+func (m *SimpleTxManager) estimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	gas, err := m.backend.EstimateGas(ctx, msg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+	return gas * (100 + m.GasLimitBufferPercent) / 100, nil
+}
+
+// Cancel displaces the pending transaction at the given nonce by sending a zero-value
+// self-transfer at the same nonce with an escalated gas price, which is bumped on resubmission
+// like any other transaction. It waits for the replacement to be confirmed.
+// It returns ErrNoPendingTx if the backend has no pending transaction of the sender at the nonce.
+//
+// NOTE: Cancel must not be called while Send is in progress.
+func (m *SimpleTxManager) Cancel(ctx context.Context, nonce uint64) error {
+	if am := m.sender(); am != m {
+		return am.Cancel(ctx, nonce)
+	}
+	from := m.Config.From
+	m.l.Info("cancelling pending tx", "nonce", nonce)
+	if _, err := m.replaceAt(ctx, nonce, TxCandidate{
+		To:       &from,
+		GasLimit: params.TxGas,
+		Value:    common.Big0,
+	}); err != nil {
+		return fmt.Errorf("failed to send the cancellation tx: %w", err)
+	}
+	return nil
+}
+
+// Replace displaces the pending transaction at the given nonce by the transaction built from
+// the candidate at the same nonce with an escalated gas price, which is bumped on resubmission
+// like any other transaction. It waits for the replacement to be confirmed, and returns its receipt.
+// It returns ErrNoPendingTx if the backend has no pending transaction of the sender at the nonce.
+//
+// NOTE: Replace must not be called while Send is in progress.
+func (m *SimpleTxManager) Replace(ctx context.Context, nonce uint64, candidate TxCandidate) (*types.Receipt, error) {
+	if am := m.sender(); am != m {
+		return am.Replace(ctx, nonce, candidate)
+	}
+	m.l.Info("replacing pending tx", "nonce", nonce, "to", candidate.To)
+	receipt, err := m.replaceAt(ctx, nonce, candidate)
+	if err != nil {
+		return receipt, fmt.Errorf("failed to send the replacement tx: %w", err)
+	}
+	return receipt, nil
+}
+
+// replaceAt sends the candidate at the nonce of a pending transaction. The fees of the stuck tx
+// are unknown, so the suggested tip is doubled to outbid it at once. Like the bumped fees, the
+// doubled fees must be within the fee limits, otherwise ErrFeeLimitExceeded is returned.
+// Like the new txs, the replacement is held while the L1 gas price exceeds MaxGasPrice, and its
+// gas fee cap is capped at MaxGasPrice.
+func (m *SimpleTxManager) replaceAt(ctx context.Context, nonce uint64, candidate TxCandidate) (*types.Receipt, error) {
+	latestNonce, err := retryNetwork(ctx, m, "get nonce", func(ctx context.Context) (uint64, error) {
+		return m.backend.NonceAt(ctx, m.Config.From, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	pendingNonce, err := retryNetwork(ctx, m, "get pending nonce", func(ctx context.Context) (uint64, error) {
+		return m.backend.PendingNonceAt(ctx, m.Config.From)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if nonce < latestNonce || nonce >= pendingNonce {
+		return nil, fmt.Errorf("%w: nonce %d, latest nonce %d, pending nonce %d", ErrNoPendingTx, nonce, latestNonce, pendingNonce)
+	}
+
+	if err := m.waitForGasPrice(ctx); err != nil {
+		return nil, fmt.Errorf("failed to wait for the gas price: %w", err)
+	}
+	tip, basefee, legacy, err := m.suggestGasPriceCaps(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price info: %w", err)
+	}
+	gasTipCap := new(big.Int).Mul(tip, big.NewInt(2))
+	gasFeeCap := calcGasFeeCap(basefee, gasTipCap)
+	if err := m.checkFeeLimits(gasTipCap, gasFeeCap, tip, basefee); err != nil {
+		return nil, err
+	}
+	gasFeeCap = m.capGasFeeCap(gasFeeCap)
+	if gasTipCap.Cmp(gasFeeCap) > 0 {
+		gasTipCap = new(big.Int).Set(gasFeeCap)
+	}
+
+	rawTx := &types.DynamicFeeTx{
+		ChainID:    m.chainID,
+		Nonce:      nonce,
+		To:         candidate.To,
+		GasTipCap:  gasTipCap,
+		GasFeeCap:  gasFeeCap,
+		Gas:        candidate.GasLimit,
+		Value:      candidate.Value,
+		Data:       candidate.TxData,
+		AccessList: candidate.AccessList,
+	}
+	if rawTx.Gas == 0 {
+		gas, err := m.estimateGas(ctx, ethereum.CallMsg{
+			From:       m.Config.From,
+			To:         candidate.To,
+			GasFeeCap:  gasFeeCap,
+			GasTipCap:  gasTipCap,
+			Data:       candidate.TxData,
+			Value:      candidate.Value,
+			AccessList: candidate.AccessList,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rawTx.Gas = gas
+	}
+	m.l.Info("replacing tx at nonce", "nonce", nonce, "gasTipCap", gasTipCap, "gasFeeCap", gasFeeCap)
+
+	tx, err := buildTx(candidate.Builder, rawTx, legacy)
+	if err != nil {
+		return nil, err
+	}
+	tx, err = m.signTx(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign the tx: %w", err)
+	}
+	return m.sendTx(ctx, tx, candidate.GasLimit == 0, candidate.Builder, nil, nil)
 }
 
 // send submits the same transaction several times with increasing gas prices as necessary.
 // It waits for the transaction to be confirmed on chain.
-func (m *SimpleTxManager) send(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+// If reestimateGas is set, the gas limit is estimated again whenever the gas price is increased.
+func (m *SimpleTxManager) send(ctx context.Context, tx *types.Transaction, reestimateGas bool) (*types.Receipt, error) {
+	return m.sendTx(ctx, tx, reestimateGas, nil, nil, nil)
+}
+
+// sendTx implements send, bumping the tx with the builder if set, checking the precondition of the tx if set
+// before each publication, and reporting the publications and the confirmations of the tx to onStatus if set.
+func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction, reestimateGas bool, builder TxBuilder, precondition Precondition, onStatus func(TxStatus)) (*types.Receipt, error) {
+	if m.DryRun {
+		return m.simulate(ctx, tx)
+	}
+
 	var wg sync.WaitGroup
 	defer wg.Wait()
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	sendState := NewSendState(m.SafeAbortNonceTooLowCount, m.TxNotInMempoolTimeout)
+	if err := m.checkPrecondition(ctx, precondition, tx.Nonce()); err != nil {
+		return nil, err
+	}
+	// Record the tx before publishing it, so that its nonce is not reused after a restart.
+	if err := m.recordTx(tx); err != nil {
+		return nil, err
+	}
+	if m.nonces != nil {
+		m.nonces.track(tx.Nonce())
+		defer m.nonces.untrack(tx.Nonce())
+	}
+	pending, untrackPending := m.trackPending(tx)
+	defer untrackPending()
+
+	sendState := NewSendStateWithNow(m.SafeAbortNonceTooLowCount, m.TxNotInMempoolTimeout, m.clock().Now)
+	sendState.onStatus = onStatus
 	receiptChan := make(chan *types.Receipt, 1)
+	sendStart := m.clock().Now()
 	sendTxAsync := func(tx *types.Transaction) {
 		defer wg.Done()
-		m.publishAndWaitForTx(ctx, tx, sendState, receiptChan)
+		m.publishAndWaitForTx(ctx, tx, m.publishPrivately(sendStart), sendState, receiptChan)
 	}
 
 	// Immediately publish a transaction before starting the resubmission loop
 	wg.Add(1)
 	go sendTxAsync(tx)
 
-	ticker := time.NewTicker(m.ResubmissionTimeout)
-	defer ticker.Stop()
+	timer := m.clock().NewTimer(m.resubmissionTimeout())
+	defer func() { timer.Stop() }()
+	resubmit := timer.Ch()
+	rebroadcastTick, stopRebroadcast := m.rebroadcastTick()
+	defer stopRebroadcast()
 
 	bumpCounter := 0
+	stuckReported := false
 	for {
 		select {
-		case <-ticker.C:
+		case <-resubmit:
+			// The jitter is recomputed for every cycle.
+			timer.Stop()
+			timer = m.clock().NewTimer(m.resubmissionTimeout())
+			resubmit = timer.Ch()
 			// Don't resubmit a transaction if it has been mined, but we are waiting for the conf depth.
 			if sendState.IsWaitingForConfirmation() {
 				continue
 			}
 			// If we see lots of unrecoverable errors (and no pending transactions) abort sending the transaction.
-			if sendState.ShouldAbortImmediately() {
-				m.l.Warn("Aborting transaction submission")
-				return nil, errors.New("aborted transaction sending")
+			if err := sendState.CriticalError(); err != nil {
+				var nonceErr *NonceTooLowAbortError
+				if errors.As(err, &nonceErr) {
+					nonceErr.Nonce = tx.Nonce()
+				}
+				m.l.Warn("Aborting transaction submission", "err", err)
+				m.forgetTx(tx.Nonce())
+				return nil, fmt.Errorf("aborted transaction sending: %w", err)
 			}
+			if err := m.checkPrecondition(ctx, precondition, tx.Nonce()); err != nil {
+				m.l.Warn("aborting transaction submission", "err", err)
+				m.forgetTx(tx.Nonce())
+				return nil, err
+			}
+			// The tx is not mined, maybe because a dropped tx left a gap below its nonce.
+			m.repairNonceGaps(ctx, tx.Nonce(), &wg)
 			// Increase the gas price & submit the new transaction
-			tx = m.increaseGasPrice(ctx, tx)
-			wg.Add(1)
+			bumped := m.bumpTx(ctx, tx, reestimateGas, builder)
+			if bumped != tx {
+				m.metr.TxBumped()
+				m.onBump(tx, bumped)
+			}
+			tx = bumped
 			bumpCounter += 1
+			pending.update(tx, bumpCounter)
+			if err := m.recordTx(tx); err != nil {
+				m.l.Warn("failed to record the resubmitted tx", "hash", tx.Hash(), "err", err)
+			}
+			wg.Add(1)
 			go sendTxAsync(tx)
 
+			if !stuckReported && m.isStuck(bumpCounter, sendStart) {
+				stuckReported = true
+				m.reportStuck(StuckTx{Nonce: tx.Nonce(), Hash: tx.Hash(), Bumps: bumpCounter, Pending: m.clock().Now().Sub(sendStart)})
+			}
+
+		case <-pending.bump:
+			// Resubmit at once, as if the resubmission timeout expired.
+			resubmit = m.clock().After(0)
+
+		case <-pending.drop:
+			m.l.Warn("dropping tx", "hash", tx.Hash(), "nonce", tx.Nonce())
+			m.forgetTx(tx.Nonce())
+			return nil, ErrPendingTxDropped
+
+		case <-rebroadcastTick:
+			if sendState.IsWaitingForConfirmation() {
+				continue
+			}
+			if err := m.checkPrecondition(ctx, precondition, tx.Nonce()); err != nil {
+				m.l.Warn("aborting transaction submission", "err", err)
+				m.forgetTx(tx.Nonce())
+				return nil, err
+			}
+			wg.Add(1)
+			go func(tx *types.Transaction) {
+				defer wg.Done()
+				m.rebroadcast(ctx, tx, m.publishPrivately(sendStart))
+			}(tx)
+
 		case <-ctx.Done():
 			return nil, ctx.Err()
 
 		case receipt := <-receiptChan:
 			m.metr.RecordGasBumpCount(bumpCounter)
 			m.metr.TxConfirmed(receipt)
-			// If transaction confirmed but the status is not success, return ErrTxReceiptNotSucceed
+			m.metr.RecordContractGas(m.contractLabel(tx.To()), receipt)
+			m.recordFee(receipt)
+			m.onConfirmed(receipt)
+			m.watchReorg(tx, receipt)
+			m.forgetTx(tx.Nonce())
+			// If transaction confirmed but the status is not success, return a ReceiptError,
+			// which is ErrTxReceiptNotSucceed carrying the revert of the re-execution.
 			if receipt.Status != types.ReceiptStatusSuccessful {
-				return receipt, ErrTxReceiptNotSucceed
+				return receipt, m.receiptError(ctx, tx, receipt)
 			}
 			m.l.Info("Transaction receipt status successful", "hash", receipt.TxHash)
 			return receipt, nil
@@ -267,17 +868,153 @@ func (m *SimpleTxManager) send(ctx context.Context, tx *types.Transaction) (*typ
 	}
 }
 
-// publishAndWaitForTx publishes the transaction to the transaction pool and then waits for it with [waitMined].
+// resumePending resumes sending the txs restored from the store, which were signed but not confirmed
+// before the restart, so that their nonces are not reused while they may still be mined.
+// The restored txs of the nonces already taken on L1 are forgotten without being resent.
+func (m *SimpleTxManager) resumePending(ctx context.Context) error {
+	if m.store == nil {
+		return nil
+	}
+	// The concurrent sends wait for the first one to resume the restored txs.
+	m.store.resumeMu.Lock()
+	defer m.store.resumeMu.Unlock()
+	txs, err := m.store.Restore()
+	if err != nil {
+		return err
+	}
+	if len(txs) == 0 {
+		return nil
+	}
+	nonce, err := retryNetwork(ctx, m, "get nonce", func(ctx context.Context) (uint64, error) {
+		return m.backend.NonceAt(ctx, m.Config.From, nil)
+	})
+	if err != nil {
+		return err
+	}
+	for _, tx := range txs {
+		l := m.l.New("hash", tx.Hash(), "nonce", tx.Nonce())
+		if tx.Nonce() < nonce {
+			l.Info("forgetting restored tx whose nonce is already taken")
+			m.forgetTx(tx.Nonce())
+			continue
+		}
+		l.Info("resuming restored tx")
+		if _, err := m.send(ctx, tx, false); err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			// The tx is settled one way or another, so it must not block the new txs.
+			l.Warn("restored tx failed", "err", err)
+		}
+	}
+	return nil
+}
+
+// recordTx persists the tx in the store, if any.
+func (m *SimpleTxManager) recordTx(tx *types.Transaction) error {
+	if m.store == nil {
+		return nil
+	}
+	if err := m.store.Put(tx); err != nil {
+		return fmt.Errorf("failed to record the tx: %w", err)
+	}
+	return nil
+}
+
+// forgetTx removes the tx of the nonce from the store, if any, once it is settled.
+func (m *SimpleTxManager) forgetTx(nonce uint64) {
+	if m.store == nil {
+		return
+	}
+	if err := m.store.Delete(nonce); err != nil {
+		m.l.Warn("failed to forget the settled tx", "nonce", nonce, "err", err)
+	}
+}
+
+// newJitterRand returns the rand.Rand the resubmission timeout jitter is drawn from, which is safe
+// for concurrent use. If src is nil, it is seeded with the current time.
+func newJitterRand(src rand.Source) *rand.Rand {
+	if src == nil {
+		src = rand.NewSource(time.Now().UnixNano())
+	}
+	return rand.New(&lockedSource{src: src})
+}
+
+// lockedSource is a rand.Source safe for concurrent use.
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
+
+// resubmissionTimeout returns the interval to wait before the next resubmission.
+// If ResubmissionTimeoutJitter is set, the interval is randomized within
+// [timeout*(1-jitter), timeout*(1+jitter)].
+func (m *SimpleTxManager) resubmissionTimeout() time.Duration {
+	if m.ResubmissionTimeoutJitter == 0 {
+		return m.ResubmissionTimeout
+	}
+
+	// Scale the random number from [0, 1) to [1-jitter, 1+jitter)
+	factor := 1 + m.ResubmissionTimeoutJitter*(2*m.rng.Float64()-1)
+	return time.Duration(float64(m.ResubmissionTimeout) * factor)
+}
+
+// publishPrivately returns whether the txs of the send started at sendStart are published to the
+// private tx relay, i.e. the relay is set and PrivateTxFallbackDelay has not elapsed yet.
+func (m *SimpleTxManager) publishPrivately(sendStart time.Time) bool {
+	if m.PrivateTxPublisher == nil {
+		return false
+	}
+	return m.PrivateTxFallbackDelay == 0 || m.clock().Now().Sub(sendStart) < m.PrivateTxFallbackDelay
+}
+
+// publishTx publishes the tx to the private tx relay if private is set, and to the public mempool otherwise.
+// If the relay is unhealthy, the tx is published to the public mempool at once.
+// Each publication is bounded by NetworkTimeout.
+func (m *SimpleTxManager) publishTx(ctx context.Context, tx *types.Transaction, private bool, l log.Logger) error {
+	publish := func(publisher TxPublisher) error {
+		cCtx, cancel := context.WithTimeout(ctx, m.NetworkTimeout)
+		defer cancel()
+		return publisher.SendTransaction(cCtx, tx)
+	}
+	publishPublicly := func() error {
+		err := publish(m.backend)
+		m.recordBackend(ctx, err)
+		return err
+	}
+	if !private {
+		return publishPublicly()
+	}
+	err := publish(m.PrivateTxPublisher)
+	if !isEndpointError(err) || ctx.Err() != nil {
+		return err
+	}
+	l.Warn("private tx relay failed, publishing to the public mempool", "err", err)
+	return publishPublicly()
+}
+
+// publishAndWaitForTx publishes the transaction to the transaction pool, or to the private tx relay if private is set,
+// and then waits for it with [waitMined].
 // It should be called in a new go-routine. It will send the receipt to receiptChan in a non-blocking way if a receipt is found
 // for the transaction.
-func (m *SimpleTxManager) publishAndWaitForTx(ctx context.Context, tx *types.Transaction, sendState *SendState, receiptChan chan *types.Receipt) {
-	l := m.l.New("hash", tx.Hash(), "nonce", tx.Nonce(), "gasTipCap", tx.GasTipCap(), "gasFeeCap", tx.GasFeeCap())
+func (m *SimpleTxManager) publishAndWaitForTx(ctx context.Context, tx *types.Transaction, private bool, sendState *SendState, receiptChan chan *types.Receipt) {
+	l := m.l.New("hash", tx.Hash(), "nonce", tx.Nonce(), "gasTipCap", tx.GasTipCap(), "gasFeeCap", tx.GasFeeCap(), "private", private)
 	l.Info("publishing transaction")
 
-	cCtx, cancel := context.WithTimeout(ctx, m.NetworkTimeout)
-	defer cancel()
-	t := time.Now()
-	err := m.backend.SendTransaction(cCtx, tx)
+	t := m.clock().Now()
+	err := m.publishTx(ctx, tx, private, l)
 	sendState.ProcessSendError(err)
 
 	// Properly log & exit if there is an error
@@ -285,7 +1022,7 @@ func (m *SimpleTxManager) publishAndWaitForTx(ctx context.Context, tx *types.Tra
 		switch {
 		case errStringMatch(err, core.ErrNonceTooLow):
 			l.Warn("nonce too low", "err", err)
-			m.metr.TxPublished("nonce_to_low")
+			m.metr.TxPublished(metrics.NonceTooLowError)
 		case errStringMatch(err, context.Canceled):
 			m.metr.RPCError()
 			l.Warn("transaction send cancelled", "err", err)
@@ -307,6 +1044,8 @@ func (m *SimpleTxManager) publishAndWaitForTx(ctx context.Context, tx *types.Tra
 		return
 	}
 	m.metr.TxPublished("")
+	m.afterBroadcast(tx)
+	sendState.notifyStatus(TxStatus{State: TxPublished, Tx: tx})
 
 	l.Info("Transaction successfully published")
 	// Poll for the transaction to be ready & then send the result to receiptChan
@@ -317,7 +1056,7 @@ func (m *SimpleTxManager) publishAndWaitForTx(ctx context.Context, tx *types.Tra
 	}
 	select {
 	case receiptChan <- receipt:
-		m.metr.RecordTxConfirmationLatency(time.Since(t).Milliseconds())
+		m.metr.RecordTxConfirmationLatency(m.clock().Now().Sub(t).Milliseconds())
 	default:
 	}
 }
@@ -325,31 +1064,46 @@ func (m *SimpleTxManager) publishAndWaitForTx(ctx context.Context, tx *types.Tra
 // waitMined waits for the transaction to be mined or for the context to be cancelled.
 func (m *SimpleTxManager) waitMined(ctx context.Context, tx *types.Transaction, sendState *SendState) (*types.Receipt, error) {
 	txHash := tx.Hash()
-	queryTicker := time.NewTicker(m.ReceiptQueryInterval)
+	queryTicker := m.clock().NewTicker(m.ReceiptQueryInterval)
 	defer queryTicker.Stop()
+	newHead := m.newHead()
 	for {
+		// The receipt is checked on each new head if the backend notifies them, and polled otherwise.
+		var tick <-chan time.Time
+		if newHead == nil {
+			tick = m.receiptPollTimer(queryTicker)
+		}
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
-		case <-queryTicker.C:
-			if receipt := m.queryReceipt(ctx, txHash, sendState); receipt != nil {
-				return receipt, nil
-			}
+		case <-tick:
+		case <-newHead:
+		}
+		// Take the channel of the next head before the query, so that a head during the query is not missed.
+		newHead = m.newHead()
+		if receipt := m.queryReceipt(ctx, txHash, sendState); receipt != nil {
+			return receipt, nil
 		}
 	}
 }
 
 // queryReceipt queries for the receipt and returns the receipt if it has passed the confirmation depth
 func (m *SimpleTxManager) queryReceipt(ctx context.Context, txHash common.Hash, sendState *SendState) *types.Receipt {
-	ctx, cancel := context.WithTimeout(ctx, m.NetworkTimeout)
-	defer cancel()
-	receipt, err := m.backend.TransactionReceipt(ctx, txHash)
-	if errors.Is(err, ethereum.NotFound) {
+	notFound := false
+	receipt, err := retryNetwork(ctx, m, "get receipt", func(ctx context.Context) (*types.Receipt, error) {
+		receipt, err := m.transactionReceipt(ctx, txHash)
+		// A missing receipt is a valid answer, so it must not be retried.
+		if errors.Is(err, ethereum.NotFound) {
+			notFound = true
+			return nil, nil
+		}
+		return receipt, err
+	})
+	if notFound {
 		sendState.TxNotMined(txHash)
 		m.l.Trace("Transaction not yet mined", "hash", txHash)
 		return nil
 	} else if err != nil {
-		m.metr.RPCError()
 		m.l.Info("Receipt retrieval failed", "hash", txHash, "err", err)
 		return nil
 	} else if receipt == nil {
@@ -362,7 +1116,7 @@ func (m *SimpleTxManager) queryReceipt(ctx context.Context, txHash common.Hash,
 	sendState.TxMined(txHash)
 
 	txHeight := receipt.BlockNumber.Uint64()
-	tipHeight, err := m.backend.BlockNumber(ctx)
+	tipHeight, err := retryNetwork(ctx, m, "get block number", m.headNumber)
 	if err != nil {
 		m.l.Error("Unable to fetch block number", "err", err)
 		return nil
@@ -371,6 +1125,23 @@ func (m *SimpleTxManager) queryReceipt(ctx context.Context, txHash common.Hash,
 	m.l.Debug("Transaction mined, checking confirmations", "hash", txHash, "txHeight", txHeight,
 		"tipHeight", tipHeight, "numConfirmations", m.NumConfirmations)
 
+	if tag, ok := m.ConfirmationMode.tag(); ok {
+		taggedHeight, err := m.taggedBlockNumber(ctx, tag)
+		if err != nil {
+			m.l.Error("Unable to fetch the tagged block number", "mode", m.ConfirmationMode, "err", err)
+			return nil
+		}
+		if txHeight <= taggedHeight {
+			m.l.Info("Transaction confirmed", "hash", txHash, "mode", m.ConfirmationMode)
+			return receipt
+		}
+		if tipHeight >= txHeight {
+			sendState.notifyStatus(TxStatus{State: TxIncluded, Receipt: receipt, Confirmations: tipHeight + 1 - txHeight})
+		}
+		m.l.Debug("Transaction not yet confirmed", "hash", txHash, "mode", m.ConfirmationMode, "taggedHeight", taggedHeight)
+		return nil
+	}
+
 	// The transaction is considered confirmed when
 	// txHeight+numConfirmations-1 <= tipHeight. Note that the -1 is
 	// needed to account for the fact that confirmations have an
@@ -383,6 +1154,9 @@ func (m *SimpleTxManager) queryReceipt(ctx context.Context, txHash common.Hash,
 		return receipt
 	}
 
+	if tipHeight >= txHeight {
+		sendState.notifyStatus(TxStatus{State: TxIncluded, Receipt: receipt, Confirmations: tipHeight + 1 - txHeight})
+	}
 	// Safe to subtract since we know the LHS above is greater.
 	confsRemaining := (txHeight + m.NumConfirmations) - (tipHeight + 1)
 	m.l.Debug("Transaction not yet confirmed", "hash", txHash, "confsRemaining", confsRemaining)
@@ -394,36 +1168,79 @@ func (m *SimpleTxManager) queryReceipt(ctx context.Context, txHash common.Hash,
 // will be returned. If they are greater, this function will ensure that they are at least greater by 15% than
 // the previous transaction's value to ensure that the price bump is large enough.
 //
-// We do not re-estimate the amount of gas used because for some stateful transactions (like output proposals) the
-// act of including the transaction renders the repeat of the transaction invalid.
+// The amount of gas used is re-estimated only if reestimateGas is set, i.e. the gas limit was not given explicitly.
+// For some stateful transactions (like output proposals) the act of including the transaction renders the repeat
+// of the transaction invalid, so the previous gas limit is kept if the re-estimation fails.
 //
 // If it encounters an error with creating the new transaction, it will return the old transaction.
-func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transaction) *types.Transaction {
-	tip, basefee, err := m.suggestGasPriceCaps(ctx)
+func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transaction, reestimateGas bool) *types.Transaction {
+	return m.bumpTx(ctx, tx, reestimateGas, nil)
+}
+
+// bumpTx implements increaseGasPrice, building the new tx with the builder if set. The txs of a custom type
+// are kept as they are without a builder, e.g. when they are resumed after a restart.
+func (m *SimpleTxManager) bumpTx(ctx context.Context, tx *types.Transaction, reestimateGas bool, builder TxBuilder) *types.Transaction {
+	if builder == nil && !isBuiltinTxType(tx) {
+		m.l.Warn("not bumping the fees of the tx of a custom type without its builder", "hash", tx.Hash(), "type", tx.Type())
+		return tx
+	}
+	tip, basefee, legacy, err := m.suggestGasPriceCaps(ctx)
 	if err != nil {
 		m.l.Warn("failed to get suggested gas tip and basefee", "err", err)
 		return tx
 	}
-	gasTipCap, gasFeeCap := updateFees(tx.GasTipCap(), tx.GasFeeCap(), tip, basefee, m.l)
+	gasTipCap, gasFeeCap := updateFees(tx.GasTipCap(), tx.GasFeeCap(), tip, basefee, m.feeBumpPercent(), m.l)
 
 	if tx.GasTipCapIntCmp(gasTipCap) == 0 && tx.GasFeeCapIntCmp(gasFeeCap) == 0 {
 		return tx
 	}
+	if err := m.checkFeeLimits(gasTipCap, gasFeeCap, tip, basefee); err != nil {
+		m.l.Warn("not bumping the fees of the tx", "hash", tx.Hash(), "err", err)
+		return tx
+	}
+	if m.MaxGasPrice != nil && m.MaxGasPrice.Sign() > 0 && gasFeeCap.Cmp(m.MaxGasPrice) > 0 {
+		// Hold the tx at its current fees until the L1 gas price falls.
+		m.l.Warn("not bumping the fees of the tx above the max gas price", "hash", tx.Hash(),
+			"gasFeeCap", gasFeeCap, "maxGasPrice", m.MaxGasPrice)
+		return tx
+	}
+
+	gas := tx.Gas()
+	if reestimateGas {
+		// The state may have changed since the last estimation.
+		estimated, err := m.estimateGas(ctx, ethereum.CallMsg{
+			From:       m.Config.From,
+			To:         tx.To(),
+			GasFeeCap:  gasFeeCap,
+			GasTipCap:  gasTipCap,
+			Data:       tx.Data(),
+			Value:      tx.Value(),
+			AccessList: tx.AccessList(),
+		})
+		if err != nil {
+			m.l.Warn("failed to re-estimate gas, keeping the previous gas limit", "err", err)
+		} else {
+			gas = estimated
+		}
+	}
 
 	rawTx := &types.DynamicFeeTx{
 		ChainID:    tx.ChainId(),
 		Nonce:      tx.Nonce(),
 		GasTipCap:  gasTipCap,
 		GasFeeCap:  gasFeeCap,
-		Gas:        tx.Gas(),
+		Gas:        gas,
 		To:         tx.To(),
 		Value:      tx.Value(),
 		Data:       tx.Data(),
 		AccessList: tx.AccessList(),
 	}
-	ctx, cancel := context.WithTimeout(ctx, m.NetworkTimeout)
-	defer cancel()
-	newTx, err := m.Signer(ctx, m.From(), types.NewTx(rawTx))
+	newTx, err := buildTx(builder, rawTx, legacy)
+	if err != nil {
+		m.l.Warn("failed to build new transaction", "err", err)
+		return tx
+	}
+	newTx, err = m.signTx(ctx, newTx)
 	if err != nil {
 		m.l.Warn("failed to sign new transaction", "err", err)
 		return tx
@@ -431,32 +1248,170 @@ func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transa
 	return newTx
 }
 
-// suggestGasPriceCaps suggests what the new tip & new basefee should be based on the current L1 conditions
-func (m *SimpleTxManager) suggestGasPriceCaps(ctx context.Context) (*big.Int, *big.Int, error) {
-	cCtx, cancel := context.WithTimeout(ctx, m.NetworkTimeout)
-	defer cancel()
-	tip, err := m.backend.SuggestGasTipCap(cCtx)
+// suggestGasPriceCaps suggests what the new tip & new basefee should be based on the current L1 conditions.
+// It returns whether the tx must be a legacy tx, see Config.LegacyTxs. The legacy txs are priced
+// with a zero basefee and a tip of the gas price, so that they are bumped like the dynamic fee txs.
+func (m *SimpleTxManager) suggestGasPriceCaps(ctx context.Context) (*big.Int, *big.Int, bool, error) {
+	if m.LegacyTxs {
+		return m.suggestLegacyGasPriceCaps(ctx)
+	}
+	tip, err := retryNetwork(ctx, m, "fetch the suggested gas tip cap", m.gasPricer().SuggestGasTipCap)
 	if err != nil {
-		m.metr.RPCError()
-		return nil, nil, fmt.Errorf("failed to fetch the suggested gas tip cap: %w", err)
+		return nil, nil, false, err
 	} else if tip == nil {
-		return nil, nil, errors.New("the suggested tip was nil")
+		return nil, nil, false, errors.New("the suggested tip was nil")
 	}
-	cCtx, cancel = context.WithTimeout(ctx, m.NetworkTimeout)
-	defer cancel()
-	head, err := m.backend.HeaderByNumber(cCtx, nil)
+	head, err := retryNetwork(ctx, m, "fetch the suggested basefee", func(ctx context.Context) (*types.Header, error) {
+		return m.backend.HeaderByNumber(ctx, nil)
+	})
 	if err != nil {
-		m.metr.RPCError()
-		return nil, nil, fmt.Errorf("failed to fetch the suggested basefee: %w", err)
+		return nil, nil, false, err
 	} else if head.BaseFee == nil {
-		return nil, nil, errors.New("txmgr does not support pre-london blocks that do not have a basefee")
+		// The chain does not support EIP-1559.
+		return m.suggestLegacyGasPriceCaps(ctx)
 	}
-	return tip, head.BaseFee, nil
+	return tip, head.BaseFee, false, nil
+}
+
+// suggestLegacyGasPriceCaps suggests the gas price of a legacy tx as the tip, with a zero basefee.
+func (m *SimpleTxManager) suggestLegacyGasPriceCaps(ctx context.Context) (*big.Int, *big.Int, bool, error) {
+	gasPrice, err := retryNetwork(ctx, m, "fetch the suggested gas price", m.suggestGasPrice)
+	if err != nil {
+		return nil, nil, false, err
+	} else if gasPrice == nil {
+		return nil, nil, false, errors.New("the suggested gas price was nil")
+	}
+	return gasPrice, new(big.Int), true, nil
+}
+
+// suggestGasPrice suggests the gas price of the legacy txs, taken from the GasPricer of the config,
+// if set, or from the backend.
+func (m *SimpleTxManager) suggestGasPrice(ctx context.Context) (*big.Int, error) {
+	if m.GasPricer != nil {
+		return m.GasPricer.SuggestGasTipCap(ctx)
+	}
+	suggester, ok := m.backend.(GasPriceSuggester)
+	if !ok {
+		return nil, fmt.Errorf("eth_gasPrice is %w", errUnsupportedByEndpoint)
+	}
+	return suggester.SuggestGasPrice(ctx)
+}
+
+// txData returns the dynamic fee tx, or the legacy tx priced at its fee cap if legacy is set.
+// The access list of the tx is dropped from the legacy tx.
+func txData(tx *types.DynamicFeeTx, legacy bool) types.TxData {
+	if !legacy {
+		return tx
+	}
+	return &types.LegacyTx{
+		Nonce:    tx.Nonce,
+		GasPrice: tx.GasFeeCap,
+		Gas:      tx.Gas,
+		To:       tx.To,
+		Value:    tx.Value,
+		Data:     tx.Data,
+	}
+}
+
+// gasPricer returns the GasPricer of the config, or the backend if none is set.
+func (m *SimpleTxManager) gasPricer() GasPricer {
+	if m.GasPricer == nil {
+		return m.backend
+	}
+	return m.GasPricer
+}
+
+// retryNetwork runs the replayable network operation op, bounding each attempt by NetworkTimeout and
+// retrying the failed attempts with the network retry backoff of the config.
+// If all the attempts fail, it returns an error identifying the operation.
+func retryNetwork[T any](ctx context.Context, m *SimpleTxManager, operation string, op func(ctx context.Context) (T, error)) (T, error) {
+	var result T
+	err := backoff.DoCtx(ctx, m.networkRetryAttempts(), m.networkRetryStrategy(), func() error {
+		cCtx, cancel := context.WithTimeout(ctx, m.NetworkTimeout)
+		defer cancel()
+		var err error
+		result, err = op(cCtx)
+		m.recordBackend(ctx, err)
+		if err != nil {
+			m.metr.RPCError()
+			m.l.Debug("network operation failed", "operation", operation, "err", err)
+		}
+		return err
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed to %s: %w", operation, err)
+	}
+	return result, nil
+}
+
+// waitForGasPrice holds the tx until the L1 gas price, i.e. the basefee plus the suggested tip,
+// falls to MaxGasPrice, so that the operating expenses are bounded. It polls the gas price every
+// ResubmissionTimeout, and returns the error of ctx if it is done while holding.
+func (m *SimpleTxManager) waitForGasPrice(ctx context.Context) error {
+	if m.MaxGasPrice == nil || m.MaxGasPrice.Sign() == 0 {
+		return nil
+	}
+	held := false
+	defer func() {
+		if held {
+			m.metr.RecordGasPriceHeld(false)
+		}
+	}()
+	for {
+		tip, basefee, _, err := m.suggestGasPriceCaps(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get gas price info: %w", err)
+		}
+		gasPrice := new(big.Int).Add(basefee, tip)
+		if gasPrice.Cmp(m.MaxGasPrice) <= 0 {
+			if held {
+				m.l.Info("releasing the held tx", "gasPrice", gasPrice, "maxGasPrice", m.MaxGasPrice)
+			}
+			return nil
+		}
+		if !held {
+			m.l.Warn("holding the tx while the L1 gas price exceeds the max gas price",
+				"gasPrice", gasPrice, "maxGasPrice", m.MaxGasPrice)
+			m.metr.RecordGasPriceHeld(true)
+			held = true
+		}
+		select {
+		case <-m.clock().After(m.ResubmissionTimeout):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// capGasFeeCap caps the gas fee cap at MaxGasPrice, if set.
+func (m *SimpleTxManager) capGasFeeCap(gasFeeCap *big.Int) *big.Int {
+	if m.MaxGasPrice == nil || m.MaxGasPrice.Sign() == 0 || gasFeeCap.Cmp(m.MaxGasPrice) <= 0 {
+		return gasFeeCap
+	}
+	return new(big.Int).Set(m.MaxGasPrice)
+}
+
+// checkFeeLimits returns ErrFeeLimitExceeded if the bumped fees exceed FeeLimitMultiplier times
+// the suggested fees, so that the fees do not run away while the tx is stuck.
+func (m *SimpleTxManager) checkFeeLimits(gasTipCap, gasFeeCap, suggestedTip, basefee *big.Int) error {
+	if m.FeeLimitMultiplier == 0 {
+		return nil
+	}
+	multiplier := new(big.Int).SetUint64(m.FeeLimitMultiplier)
+	tipLimit := new(big.Int).Mul(multiplier, suggestedTip)
+	if gasTipCap.Cmp(tipLimit) > 0 {
+		return fmt.Errorf("%w: tip cap %v, limit %v", ErrFeeLimitExceeded, gasTipCap, tipLimit)
+	}
+	feeCapLimit := new(big.Int).Mul(multiplier, calcGasFeeCap(basefee, suggestedTip))
+	if gasFeeCap.Cmp(feeCapLimit) > 0 {
+		return fmt.Errorf("%w: fee cap %v, limit %v", ErrFeeLimitExceeded, gasFeeCap, feeCapLimit)
+	}
+	return nil
 }
 
-// calcThresholdValue returns x * priceBumpPercent / 100
-func calcThresholdValue(x *big.Int) *big.Int {
-	threshold := new(big.Int).Mul(priceBumpPercent, x)
+// calcThresholdValue returns x * (100 + priceBump) / 100
+func calcThresholdValue(x *big.Int, priceBump uint64) *big.Int {
+	threshold := new(big.Int).Mul(new(big.Int).SetUint64(100+priceBump), x)
 	threshold = threshold.Div(threshold, oneHundred)
 	return threshold
 }
@@ -464,7 +1419,7 @@ func calcThresholdValue(x *big.Int) *big.Int {
 // updateFees takes the old tip/basefee & the new tip/basefee and then suggests
 // a gasTipCap and gasFeeCap that satisfies geth's required fee bumps
 // Geth: FC and Tip must be bumped if any increase
-func updateFees(oldTip, oldFeeCap, newTip, newBaseFee *big.Int, lgr log.Logger) (*big.Int, *big.Int) {
+func updateFees(oldTip, oldFeeCap, newTip, newBaseFee *big.Int, priceBump uint64, lgr log.Logger) (*big.Int, *big.Int) {
 	newFeeCap := calcGasFeeCap(newBaseFee, newTip)
 	lgr = lgr.New("old_tip", oldTip, "old_feecap", oldFeeCap, "new_tip", newTip, "new_feecap", newFeeCap)
 	// If the new prices are less than the old price, reuse the old prices
@@ -473,8 +1428,8 @@ func updateFees(oldTip, oldFeeCap, newTip, newBaseFee *big.Int, lgr log.Logger)
 		return oldTip, oldFeeCap
 	}
 	// Determine if we need to increase the suggested values
-	thresholdTip := calcThresholdValue(oldTip)
-	thresholdFeeCap := calcThresholdValue(oldFeeCap)
+	thresholdTip := calcThresholdValue(oldTip, priceBump)
+	thresholdFeeCap := calcThresholdValue(oldFeeCap, priceBump)
 	if newTip.Cmp(thresholdTip) >= 0 && newFeeCap.Cmp(thresholdFeeCap) >= 0 {
 		lgr.Debug("Using new tip and feecap")
 		return newTip, newFeeCap
@@ -5,18 +5,30 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"math/rand"
+	"path/filepath"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
 
 	"github.com/kroma-network/kroma/components/node/testlog"
+	"github.com/kroma-network/kroma/utils/service/backoff"
+	"github.com/kroma-network/kroma/utils/service/clock"
 	"github.com/kroma-network/kroma/utils/service/txmgr/metrics"
 )
 
@@ -47,6 +59,8 @@ func newTestHarnessWithConfig(t *testing.T, cfg Config) *testHarness {
 		backend: cfg.Backend,
 		l:       testlog.Logger(t, log.LvlCrit),
 		metr:    &metrics.NoopTxMetrics{},
+		rng:     newJitterRand(cfg.ResubmissionJitterSource),
+		bg:      newBackground(),
 	}
 
 	return &testHarness{
@@ -163,6 +177,27 @@ type mockBackend struct {
 
 	// minedTxs maps the hash of a mined transaction to its details.
 	minedTxs map[common.Hash]minedTxInfo
+
+	// estimateGasErr is returned by EstimateGas if set.
+	estimateGasErr error
+
+	// estimateGasMsgs records the messages passed to EstimateGas.
+	estimateGasMsgs []ethereum.CallMsg
+
+	// nonce and pendingNonce are the latest and pending nonces of the sender.
+	nonce, pendingNonce uint64
+
+	// nonceFailures is the number of the next NonceAt calls to fail with errRpcFailure.
+	nonceFailures int
+
+	// blockNumberCalls is the number of the BlockNumber calls.
+	blockNumberCalls int
+
+	// receiptStatus is the status of the receipts of the mined transactions.
+	receiptStatus uint64
+
+	// finalizedHeight is the height of the finalized block.
+	finalizedHeight uint64
 }
 
 // newMockBackend initializes a new mockBackend.
@@ -194,21 +229,50 @@ func (b *mockBackend) mine(txHash *common.Hash, gasFeeCap *big.Int) {
 	}
 }
 
+// reorg removes the mined transaction, as if its block was reorged out.
+func (b *mockBackend) reorg(txHash common.Hash) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.minedTxs, txHash)
+}
+
 // BlockNumber returns the most recent block number.
 func (b *mockBackend) BlockNumber(ctx context.Context) (uint64, error) {
-	b.mu.RLock()
-	defer b.mu.RUnlock()
+	b.mu.Lock()
+	defer b.mu.Unlock()
 
+	b.blockNumberCalls++
 	return b.blockHeight, nil
 }
 
+// finalize finalizes the blocks mined so far.
+func (b *mockBackend) finalize() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.finalizedHeight = b.blockHeight
+}
+
 func (b *mockBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
-	return &types.Header{
+	header := &types.Header{
 		BaseFee: b.g.basefee(),
-	}, nil
+	}
+	if number != nil && (number.Int64() == int64(rpc.FinalizedBlockNumber) || number.Int64() == int64(rpc.SafeBlockNumber)) {
+		b.mu.RLock()
+		header.Number = new(big.Int).SetUint64(b.finalizedHeight)
+		b.mu.RUnlock()
+	}
+	return header, nil
 }
 
 func (b *mockBackend) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	b.mu.Lock()
+	b.estimateGasMsgs = append(b.estimateGasMsgs, msg)
+	b.mu.Unlock()
+	if b.estimateGasErr != nil {
+		return 0, b.estimateGasErr
+	}
 	return b.g.basefee().Uint64(), nil
 }
 
@@ -217,6 +281,12 @@ func (b *mockBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
 	return tip, nil
 }
 
+// SuggestGasPrice suggests the fee cap of the next epoch as the gas price of the legacy txs.
+func (b *mockBackend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	_, gasFeeCap := b.g.sample()
+	return gasFeeCap, nil
+}
+
 func (b *mockBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
 	if b.send == nil {
 		panic("set sender function was not set")
@@ -225,11 +295,17 @@ func (b *mockBackend) SendTransaction(ctx context.Context, tx *types.Transaction
 }
 
 func (b *mockBackend) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
-	return 0, nil
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.nonceFailures > 0 {
+		b.nonceFailures--
+		return 0, errRpcFailure
+	}
+	return b.nonce, nil
 }
 
 func (b *mockBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
-	return 0, nil
+	return b.pendingNonce, nil
 }
 
 func (*mockBackend) ChainID(ctx context.Context) (*big.Int, error) {
@@ -253,6 +329,7 @@ func (b *mockBackend) TransactionReceipt(ctx context.Context, txHash common.Hash
 	// we can assert the proper tx confirmed in our tests.
 	return &types.Receipt{
 		TxHash:      txHash,
+		Status:      b.receiptStatus,
 		GasUsed:     txInfo.gasFeeCap.Uint64(),
 		BlockNumber: big.NewInt(int64(txInfo.blockNumber)),
 	}, nil
@@ -284,7 +361,7 @@ func TestTxMgrConfirmAtMinGasPrice(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.send(ctx, tx)
+	receipt, err := h.mgr.send(ctx, tx, false)
 	require.ErrorIs(t, err, ErrTxReceiptNotSucceed)
 	require.NotNil(t, receipt)
 	require.Equal(t, gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -312,7 +389,7 @@ func TestTxMgrNeverConfirmCancel(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.send(ctx, tx)
+	receipt, err := h.mgr.send(ctx, tx, false)
 	require.Equal(t, err, context.DeadlineExceeded)
 	require.Nil(t, receipt)
 }
@@ -340,7 +417,7 @@ func TestTxMgrConfirmsAtHigherGasPrice(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.send(ctx, tx)
+	receipt, err := h.mgr.send(ctx, tx, false)
 	require.ErrorIs(t, err, ErrTxReceiptNotSucceed)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -371,7 +448,7 @@ func TestTxMgrBlocksOnFailingRpcCalls(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.send(ctx, tx)
+	receipt, err := h.mgr.send(ctx, tx, false)
 	require.Equal(t, err, context.DeadlineExceeded)
 	require.Nil(t, receipt)
 }
@@ -421,6 +498,181 @@ func TestTxMgr_EstimateGas(t *testing.T) {
 	require.Equal(t, gasEstimate, tx.Gas())
 }
 
+// TestTxMgr_EstimateGasWithBuffer ensures that the tx manager adds the gas limit
+// buffer to the estimated gas, but not to the explicit gas limit of the candidate.
+func TestTxMgr_EstimateGasWithBuffer(t *testing.T) {
+	t.Parallel()
+	h := newTestHarness(t)
+	h.mgr.GasLimitBufferPercent = 20
+	candidate := h.createTxCandidate()
+	explicitGasLimit := candidate.GasLimit
+
+	// Set the gas limit to zero to trigger gas estimation.
+	candidate.GasLimit = 0
+	gasEstimate := h.gasPricer.baseBaseFee.Uint64()
+
+	tx, err := h.mgr.craftTx(context.Background(), candidate)
+	require.NoError(t, err)
+	require.Equal(t, gasEstimate*120/100, tx.Gas())
+
+	// The explicit gas limit bypasses the estimation.
+	candidate.GasLimit = explicitGasLimit
+	tx, err = h.mgr.craftTx(context.Background(), candidate)
+	require.NoError(t, err)
+	require.Equal(t, explicitGasLimit, tx.Gas())
+}
+
+// TestTxMgr_EstimateGasError ensures that the tx manager fails to craft the tx
+// when the gas estimation fails, and does not estimate an explicit gas limit.
+func TestTxMgr_EstimateGasError(t *testing.T) {
+	t.Parallel()
+	h := newTestHarness(t)
+	errEstimate := errors.New("execution reverted")
+	h.backend.estimateGasErr = errEstimate
+	candidate := h.createTxCandidate()
+
+	tx, err := h.mgr.craftTx(context.Background(), candidate)
+	require.NoError(t, err)
+	require.Equal(t, candidate.GasLimit, tx.Gas())
+
+	candidate.GasLimit = 0
+	_, err = h.mgr.craftTx(context.Background(), candidate)
+	require.ErrorIs(t, err, errEstimate)
+}
+
+// TestTxMgrRetriesNetworkErrors asserts that the failed network requests are retried
+// with a backoff capped at NetworkRetryMax, and that an error identifying the request
+// is returned once the attempts are exhausted.
+func TestTxMgrRetriesNetworkErrors(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.NetworkRetryInitial = 40 * time.Millisecond
+	cfg.NetworkRetryMax = 50 * time.Millisecond
+	cfg.NetworkRetryAttempts = 5
+	h := newTestHarnessWithConfig(t, cfg)
+	candidate := h.createTxCandidate()
+
+	// Without the cap, the backoff of the 3 retries could take up to 40+80+160ms.
+	h.backend.nonceFailures = 3
+	start := time.Now()
+	tx, err := h.mgr.craftTx(context.Background(), candidate)
+	require.NoError(t, err)
+	require.NotNil(t, tx)
+	require.Less(t, time.Since(start), 3*cfg.NetworkRetryMax+50*time.Millisecond)
+
+	h.mgr.NetworkRetryAttempts = 2
+	h.backend.nonceFailures = 3
+	_, err = h.mgr.craftTx(context.Background(), candidate)
+	require.ErrorContains(t, err, "failed to get nonce")
+	require.ErrorIs(t, err, errRpcFailure)
+	var failedErr *backoff.ErrFailedPermanently
+	require.ErrorAs(t, err, &failedErr)
+}
+
+// TestTxMgrAccessListSurvivesResubmission asserts that the access list of the candidate is
+// used for the gas estimation, and kept in the signed dynamic fee tx across the gas price bumps.
+func TestTxMgrAccessListSurvivesResubmission(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainID := big.NewInt(1)
+	signer := types.LatestSignerForChainID(chainID)
+
+	cfg := configWithNumConfs(1)
+	cfg.ChainID = chainID
+	cfg.From = crypto.PubkeyToAddress(key.PublicKey)
+	cfg.Signer = func(ctx context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		return types.SignTx(tx, signer, key)
+	}
+	h := newTestHarnessWithConfig(t, cfg)
+	candidate := h.createTxCandidate()
+	candidate.GasLimit = 0
+
+	tx, err := h.mgr.craftTx(context.Background(), candidate)
+	require.NoError(t, err)
+	require.Equal(t, candidate.AccessList, tx.AccessList())
+
+	newTx := h.mgr.increaseGasPrice(context.Background(), tx, true)
+	require.NotEqual(t, tx.Hash(), newTx.Hash(), "tx must be bumped")
+	require.Equal(t, 1, newTx.GasFeeCap().Cmp(tx.GasFeeCap()), "new tx fee cap must be larger")
+	require.Equal(t, candidate.AccessList, newTx.AccessList())
+
+	// The signature covers the dynamic fee tx including the access list.
+	require.Equal(t, uint8(types.DynamicFeeTxType), newTx.Type())
+	sender, err := types.Sender(signer, newTx)
+	require.NoError(t, err)
+	require.Equal(t, cfg.From, sender)
+
+	// Both the initial estimation and the re-estimation take the access list into account.
+	require.Len(t, h.backend.estimateGasMsgs, 2)
+	for _, msg := range h.backend.estimateGasMsgs {
+		require.Equal(t, candidate.AccessList, msg.AccessList)
+	}
+}
+
+// TestTxMgrCancel asserts that Cancel replaces the pending tx at the
+// given nonce with a zero-value self-transfer, and fails if there is no pending tx.
+func TestTxMgrCancel(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+	h.mgr.Config.From = common.HexToAddress("0x1234")
+	h.backend.nonce = 3
+	h.backend.pendingNonce = 5
+	h.backend.receiptStatus = types.ReceiptStatusSuccessful
+
+	var sentTx *types.Transaction
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		sentTx = tx
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// already confirmed nonce
+	require.ErrorIs(t, h.mgr.Cancel(ctx, 2), ErrNoPendingTx)
+	// no tx at the pending nonce yet
+	require.ErrorIs(t, h.mgr.Cancel(ctx, 5), ErrNoPendingTx)
+	require.Nil(t, sentTx)
+
+	require.NoError(t, h.mgr.Cancel(ctx, 4))
+	require.NotNil(t, sentTx)
+	require.Equal(t, uint64(4), sentTx.Nonce())
+	require.Equal(t, h.mgr.From(), *sentTx.To())
+	require.Zero(t, sentTx.Value().Sign())
+	require.Equal(t, params.TxGas, sentTx.Gas())
+	require.Empty(t, sentTx.Data())
+}
+
+// TestTxMgrCancelFeeLimit asserts that Cancel does not send the replacement tx if its doubled
+// fees exceed the fee limits.
+func TestTxMgrCancelFeeLimit(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.FeeLimitMultiplier = 1
+	h := newTestHarnessWithConfig(t, cfg)
+	h.backend.nonce = 3
+	h.backend.pendingNonce = 4
+
+	sent := false
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		sent = true
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	require.ErrorIs(t, h.mgr.Cancel(ctx, 3), ErrFeeLimitExceeded)
+	require.False(t, sent)
+}
+
 // TestTxMgrOnlyOnePublicationSucceeds asserts that the tx manager will return a
 // receipt so long as at least one of the publications is able to succeed with a
 // simulated rpc failure.
@@ -449,7 +701,7 @@ func TestTxMgrOnlyOnePublicationSucceeds(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.send(ctx, tx)
+	receipt, err := h.mgr.send(ctx, tx, false)
 	require.ErrorIs(t, err, ErrTxReceiptNotSucceed)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -483,7 +735,7 @@ func TestTxMgrConfirmsMinGasPriceAfterBumping(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.send(ctx, tx)
+	receipt, err := h.mgr.send(ctx, tx, false)
 	require.ErrorIs(t, err, ErrTxReceiptNotSucceed)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -528,12 +780,132 @@ func TestTxMgrDoesntAbortNonceTooLowAfterMiningTx(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.send(ctx, tx)
+	receipt, err := h.mgr.send(ctx, tx, false)
 	require.ErrorIs(t, err, ErrTxReceiptNotSucceed)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
 }
 
+// TestTxMgrSendTimeout asserts that Send returns ErrTxSendTimeout if the tx is not
+// confirmed within TxSendTimeout, and the caller's context is still alive.
+func TestTxMgrSendTimeout(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.TxSendTimeout = 100 * time.Millisecond
+	h := newTestHarnessWithConfig(t, cfg)
+
+	// Accept the tx to the mempool, but never mine it.
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		return nil
+	})
+
+	receipt, err := h.mgr.Send(context.Background(), h.createTxCandidate())
+	require.ErrorIs(t, err, ErrTxSendTimeout)
+	require.Nil(t, receipt)
+
+	// The cancellation of the caller's context is not reported as ErrTxSendTimeout.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = h.mgr.Send(ctx, h.createTxCandidate())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.NotErrorIs(t, err, ErrTxSendTimeout)
+}
+
+// TestTxMgrDeadline asserts that Send stops bumping and returns ErrDeadlineExceeded once the deadline
+// of the candidate passes, and that a candidate already past its deadline is never published.
+func TestTxMgrDeadline(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.ResubmissionTimeout = 20 * time.Millisecond
+	cfg.NetworkTimeout = time.Second
+	h := newTestHarnessWithConfig(t, cfg)
+
+	// Accept the tx to the mempool, but never mine it.
+	var published atomic.Int64
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		published.Add(1)
+		return nil
+	})
+
+	candidate := h.createTxCandidate()
+	candidate.Deadline = time.Now().Add(-time.Second)
+	receipt, err := h.mgr.Send(context.Background(), candidate)
+	require.ErrorIs(t, err, ErrDeadlineExceeded)
+	require.Nil(t, receipt)
+	require.Zero(t, published.Load())
+
+	candidate.Deadline = time.Now().Add(200 * time.Millisecond)
+	receipt, err = h.mgr.Send(context.Background(), candidate)
+	require.ErrorIs(t, err, ErrDeadlineExceeded)
+	require.NotErrorIs(t, err, ErrTxSendTimeout)
+	require.Nil(t, receipt)
+	require.NotZero(t, published.Load())
+
+	// No tx is published after the deadline.
+	publishedAtDeadline := published.Load()
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, publishedAtDeadline, published.Load())
+}
+
+// TestTxMgrNotInMempoolTimeout asserts that send aborts with ErrNotInMempoolTimeout if
+// the tx could not be published within TxNotInMempoolTimeout.
+func TestTxMgrNotInMempoolTimeout(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.ResubmissionTimeout = 50 * time.Millisecond
+	cfg.TxNotInMempoolTimeout = 100 * time.Millisecond
+	h := newTestHarnessWithConfig(t, cfg)
+
+	gasTipCap, gasFeeCap := h.gasPricer.sample()
+	tx := types.NewTx(&types.DynamicFeeTx{
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+	})
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		return errRpcFailure
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.send(ctx, tx, false)
+	require.ErrorIs(t, err, ErrNotInMempoolTimeout)
+	require.Nil(t, receipt)
+}
+
+// TestTxMgrNonceTooLowAbort asserts that send aborts with a NonceTooLowAbortError if
+// the nonce too low error is observed SafeAbortNonceTooLowCount times.
+func TestTxMgrNonceTooLowAbort(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.ResubmissionTimeout = 50 * time.Millisecond
+	h := newTestHarnessWithConfig(t, cfg)
+
+	gasTipCap, gasFeeCap := h.gasPricer.sample()
+	tx := types.NewTx(&types.DynamicFeeTx{
+		Nonce:     7,
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+	})
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		return core.ErrNonceTooLow
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.send(ctx, tx, false)
+	require.ErrorIs(t, err, ErrNonceTooLowAbort)
+	require.Nil(t, receipt)
+
+	var nonceErr *NonceTooLowAbortError
+	require.ErrorAs(t, err, &nonceErr)
+	require.Equal(t, uint64(7), nonceErr.Nonce)
+	require.Equal(t, cfg.SafeAbortNonceTooLowCount, nonceErr.Count)
+}
+
 // TestWaitMinedReturnsReceiptOnFirstSuccess insta-mines a transaction and
 // asserts that waitMined returns the appropriate receipt.
 func TestWaitMinedReturnsReceiptOnFirstSuccess(t *testing.T) {
@@ -713,22 +1085,26 @@ func TestWaitMinedReturnsReceiptAfterFailure(t *testing.T) {
 }
 
 func doGasPriceIncrease(t *testing.T, txTipCap, txFeeCap, newTip, newBaseFee int64) (*types.Transaction, *types.Transaction) {
+	return doGasPriceIncreaseWithConfig(t, Config{
+		ResubmissionTimeout:       time.Second,
+		ReceiptQueryInterval:      50 * time.Millisecond,
+		NumConfirmations:          1,
+		SafeAbortNonceTooLowCount: 3,
+		Signer: func(ctx context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return tx, nil
+		},
+		From: common.Address{},
+	}, txTipCap, txFeeCap, newTip, newBaseFee)
+}
+
+func doGasPriceIncreaseWithConfig(t *testing.T, cfg Config, txTipCap, txFeeCap, newTip, newBaseFee int64) (*types.Transaction, *types.Transaction) {
 	borkedBackend := failingBackend{
 		gasTip:  big.NewInt(newTip),
 		baseFee: big.NewInt(newBaseFee),
 	}
 
 	mgr := &SimpleTxManager{
-		Config: Config{
-			ResubmissionTimeout:       time.Second,
-			ReceiptQueryInterval:      50 * time.Millisecond,
-			NumConfirmations:          1,
-			SafeAbortNonceTooLowCount: 3,
-			Signer: func(ctx context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
-				return tx, nil
-			},
-			From: common.Address{},
-		},
+		Config:  cfg,
 		name:    "TEST",
 		backend: &borkedBackend,
 		l:       testlog.Logger(t, log.LvlCrit),
@@ -739,7 +1115,7 @@ func doGasPriceIncrease(t *testing.T, txTipCap, txFeeCap, newTip, newBaseFee int
 		GasTipCap: big.NewInt(txTipCap),
 		GasFeeCap: big.NewInt(txFeeCap),
 	})
-	newTx := mgr.increaseGasPrice(context.Background(), tx)
+	newTx := mgr.increaseGasPrice(context.Background(), tx, false)
 	return tx, newTx
 }
 
@@ -846,13 +1222,87 @@ func TestIncreaseGasPriceNotExponential(t *testing.T) {
 	// Run IncreaseGasPrice a bunch of times in a row to simulate a very fast resubmit loop.
 	for i := 0; i < 20; i++ {
 		ctx := context.Background()
-		newTx := mgr.increaseGasPrice(ctx, tx)
+		newTx := mgr.increaseGasPrice(ctx, tx, false)
 		require.True(t, newTx.GasFeeCap().Cmp(feeCap) == 0, "new tx fee cap must be equal L1")
 		require.True(t, newTx.GasTipCap().Cmp(borkedBackend.gasTip) == 0, "new tx tip must be equal L1")
 		tx = newTx
 	}
 }
 
+// TestIncreaseGasPriceReestimatesGas asserts that the gas limit is re-estimated
+// on resubmission only if requested, i.e. the candidate had no explicit gas limit.
+func TestIncreaseGasPriceReestimatesGas(t *testing.T) {
+	t.Parallel()
+
+	borkedBackend := failingBackend{
+		gasTip:  big.NewInt(101),
+		baseFee: big.NewInt(460),
+	}
+
+	mgr := &SimpleTxManager{
+		Config: Config{
+			ResubmissionTimeout:       time.Second,
+			ReceiptQueryInterval:      50 * time.Millisecond,
+			NumConfirmations:          1,
+			SafeAbortNonceTooLowCount: 3,
+			GasLimitBufferPercent:     10,
+			Signer: func(ctx context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				return tx, nil
+			},
+			From: common.Address{},
+		},
+		name:    "TEST",
+		backend: &borkedBackend,
+		l:       testlog.Logger(t, log.LvlCrit),
+		metr:    &metrics.NoopTxMetrics{},
+	}
+	tx := types.NewTx(&types.DynamicFeeTx{
+		GasTipCap: big.NewInt(100),
+		GasFeeCap: big.NewInt(1000),
+		Gas:       21000,
+	})
+
+	newTx := mgr.increaseGasPrice(context.Background(), tx, false)
+	require.Equal(t, tx.Gas(), newTx.Gas(), "explicit gas limit must be kept")
+
+	newTx = mgr.increaseGasPrice(context.Background(), tx, true)
+	require.Equal(t, borkedBackend.baseFee.Uint64()*110/100, newTx.Gas(), "gas limit must be re-estimated with the buffer")
+}
+
+// TestResubmissionTimeoutJitter asserts that the resubmission timeout is
+// randomized within the configured bounds on every call and that it is
+// reproducible given the same random source.
+func TestResubmissionTimeoutJitter(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.ResubmissionTimeout = 10 * time.Second
+	h := newTestHarnessWithConfig(t, cfg)
+	require.Equal(t, cfg.ResubmissionTimeout, h.mgr.resubmissionTimeout(), "no jitter by default")
+
+	cfg.ResubmissionTimeoutJitter = 0.2
+	cfg.ResubmissionJitterSource = rand.NewSource(1)
+	h = newTestHarnessWithConfig(t, cfg)
+	lower := 8 * time.Second
+	upper := 12 * time.Second
+
+	var timeouts []time.Duration
+	for i := 0; i < 100; i++ {
+		timeout := h.mgr.resubmissionTimeout()
+		require.GreaterOrEqual(t, timeout, lower)
+		require.LessOrEqual(t, timeout, upper)
+		timeouts = append(timeouts, timeout)
+	}
+	require.NotEqual(t, timeouts[0], timeouts[1], "jitter must be recomputed on each call")
+
+	// The same seed must yield the same sequence.
+	cfg.ResubmissionJitterSource = rand.NewSource(1)
+	h = newTestHarnessWithConfig(t, cfg)
+	for i := 0; i < 100; i++ {
+		require.Equal(t, timeouts[i], h.mgr.resubmissionTimeout())
+	}
+}
+
 func TestErrStringMatch(t *testing.T) {
 	tests := []struct {
 		err    error
@@ -874,3 +1324,1589 @@ func TestErrStringMatch(t *testing.T) {
 		})
 	}
 }
+
+// TestTxMgrSharesHeadAcrossInFlightTxs asserts that the confirmation checks of the in-flight
+// txs share the cached L1 head, so the backend head calls do not grow with the number of txs.
+func TestTxMgrSharesHeadAcrossInFlightTxs(t *testing.T) {
+	t.Parallel()
+
+	for _, numTxs := range []int{1, 16} {
+		cfg := configWithNumConfs(1)
+		cfg.ReceiptQueryInterval = time.Hour
+		h := newTestHarnessWithConfig(t, cfg)
+		h.mgr.heads = newHeadTracker(h.backend, cfg.ReceiptQueryInterval, clock.SystemClock, h.mgr.l)
+
+		txHashes := make([]common.Hash, numTxs)
+		for i := range txHashes {
+			txHashes[i] = common.BigToHash(big.NewInt(int64(i + 1)))
+			h.backend.mine(&txHashes[i], big.NewInt(1))
+		}
+
+		var wg sync.WaitGroup
+		for _, txHash := range txHashes {
+			wg.Add(1)
+			go func(txHash common.Hash) {
+				defer wg.Done()
+				receipt := h.mgr.queryReceipt(context.Background(), txHash, testSendState())
+				require.NotNil(t, receipt)
+			}(txHash)
+		}
+		wg.Wait()
+		require.Equal(t, 1, h.backend.blockNumberCalls, "num txs: %d", numTxs)
+	}
+}
+
+// TestTxMgrHeadStaleness asserts that the cached L1 head is refreshed once it is older
+// than the receipt query interval.
+func TestTxMgrHeadStaleness(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+	h.mgr.heads = newHeadTracker(h.backend, h.cfg.ReceiptQueryInterval, clock.SystemClock, h.mgr.l)
+
+	head, err := h.mgr.headNumber(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), head)
+
+	h.backend.mine(nil, nil)
+	head, err = h.mgr.headNumber(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), head, "head must be cached")
+
+	time.Sleep(h.cfg.ReceiptQueryInterval)
+	head, err = h.mgr.headNumber(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), head, "stale head must be refreshed")
+	require.Equal(t, 2, h.backend.blockNumberCalls)
+}
+
+// subscribingBackend is a mockBackend supporting new head subscriptions.
+type subscribingBackend struct {
+	*mockBackend
+	heads chan *types.Header
+}
+
+func (b *subscribingBackend) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		for {
+			select {
+			case header := <-b.heads:
+				ch <- header
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// TestTxMgrReceiptOnNewHead asserts that the receipt is checked on each new head notified
+// by the backend, instead of waiting for the receipt query interval.
+func TestTxMgrReceiptOnNewHead(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.ReceiptQueryInterval = time.Hour
+	cfg.NetworkTimeout = time.Second
+	h := newTestHarnessWithConfig(t, cfg)
+	backend := &subscribingBackend{mockBackend: h.backend, heads: make(chan *types.Header)}
+	h.mgr.backend = backend
+	h.mgr.heads = newHeadTracker(backend, cfg.ReceiptQueryInterval, clock.SystemClock, h.mgr.l)
+	h.backend.receiptStatus = types.ReceiptStatusSuccessful
+
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		go func() {
+			backend.heads <- &types.Header{Number: big.NewInt(1)}
+		}()
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.Send(ctx, h.createTxCandidate())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), receipt.BlockNumber.Uint64())
+}
+
+// callingBackend is a mockBackend supporting eth_call.
+type callingBackend struct {
+	*mockBackend
+
+	callErr  error
+	callMsgs []ethereum.CallMsg
+}
+
+func (b *callingBackend) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	b.callMsgs = append(b.callMsgs, msg)
+	return nil, b.callErr
+}
+
+// TestTxMgrDryRun asserts that the tx is built, signed and called against the backend,
+// but never published in the dry-run mode.
+func TestTxMgrDryRun(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.DryRun = true
+	h := newTestHarnessWithConfig(t, cfg)
+	backend := &callingBackend{mockBackend: h.backend}
+	h.mgr.backend = backend
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		t.Fatal("tx must not be published in the dry-run mode")
+		return nil
+	})
+	h.backend.mine(nil, nil)
+
+	candidate := h.createTxCandidate()
+	receipt, err := h.mgr.Send(context.Background(), candidate)
+	require.NoError(t, err)
+	require.True(t, IsSimulated(receipt))
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	require.Equal(t, uint64(1), receipt.BlockNumber.Uint64())
+	require.Len(t, backend.callMsgs, 1)
+	require.Equal(t, candidate.To, backend.callMsgs[0].To)
+	require.Equal(t, candidate.TxData, backend.callMsgs[0].Data)
+	require.Equal(t, candidate.GasLimit, backend.callMsgs[0].Gas)
+
+	// The revert of the simulated call is surfaced.
+	backend.callErr = errors.New("execution reverted")
+	_, err = h.mgr.Send(context.Background(), candidate)
+	require.ErrorContains(t, err, "execution reverted")
+	require.False(t, IsSimulated(nil))
+}
+
+// TestIncreaseGasPriceFeeBumpPolicy asserts that the fees are bumped by FeeBumpPercent,
+// and that the bump is held back once the fees exceed FeeLimitMultiplier times the suggested ones.
+func TestIncreaseGasPriceFeeBumpPolicy(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.FeeBumpPercent = 50
+	tx, newTx := doGasPriceIncreaseWithConfig(t, cfg, 100, 1000, 101, 460)
+	require.Equal(t, big.NewInt(150), newTx.GasTipCap(), "tip must be bumped by FeeBumpPercent")
+	require.Equal(t, big.NewInt(1500), newTx.GasFeeCap(), "fee cap must be bumped by FeeBumpPercent")
+	require.NotEqual(t, tx.Hash(), newTx.Hash())
+
+	// The suggested fee cap is 101 + 2*460 = 1021, so the bumped fee cap of 1500 is within 2x but not 1x.
+	cfg.FeeLimitMultiplier = 2
+	_, newTx = doGasPriceIncreaseWithConfig(t, cfg, 100, 1000, 101, 460)
+	require.Equal(t, big.NewInt(1500), newTx.GasFeeCap(), "fee cap within the limit must be bumped")
+
+	cfg.FeeLimitMultiplier = 1
+	tx, newTx = doGasPriceIncreaseWithConfig(t, cfg, 100, 1000, 101, 460)
+	require.Equal(t, tx.Hash(), newTx.Hash(), "fee cap over the limit must not be bumped")
+
+	// The default bump is applied if FeeBumpPercent is not set.
+	cfg = configWithNumConfs(1)
+	_, newTx = doGasPriceIncreaseWithConfig(t, cfg, 100, 1000, 101, 460)
+	require.Equal(t, big.NewInt(115), newTx.GasTipCap())
+	require.Equal(t, big.NewInt(1150), newTx.GasFeeCap())
+}
+
+// TestTxMgrResumesRestoredTxs asserts that the txs recorded before a restart are resumed
+// before a new tx is sent, and that the ones whose nonce is already taken are forgotten.
+func TestTxMgrResumesRestoredTxs(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "state.json")
+	_, gasFeeCap := newGasPricer(1).sample()
+	mined := types.NewTx(&types.DynamicFeeTx{Nonce: 4, GasFeeCap: gasFeeCap, GasTipCap: common.Big1})
+	pending := types.NewTx(&types.DynamicFeeTx{Nonce: 5, GasFeeCap: gasFeeCap, GasTipCap: common.Big1})
+	before := newTxStore(path)
+	require.NoError(t, before.Put(mined))
+	require.NoError(t, before.Put(pending))
+
+	h := newTestHarness(t)
+	h.mgr.store = newTxStore(path)
+	h.backend.nonce = 5
+	h.backend.receiptStatus = types.ReceiptStatusSuccessful
+
+	var (
+		mu        sync.Mutex
+		published []common.Hash
+	)
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		mu.Lock()
+		defer mu.Unlock()
+		txHash := tx.Hash()
+		published = append(published, txHash)
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		// The restored tx takes the nonce once it is mined.
+		h.backend.nonce = tx.Nonce() + 1
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.Send(ctx, h.createTxCandidate())
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, published, 2)
+	require.Equal(t, pending.Hash(), published[0], "the restored tx must be resumed first")
+	require.Equal(t, receipt.TxHash, published[1])
+
+	restored, err := newTxStore(path).Restore()
+	require.NoError(t, err)
+	require.Empty(t, restored, "settled txs must be forgotten")
+}
+
+// heldMetrics records the gas price hold metric.
+type heldMetrics struct {
+	metrics.NoopTxMetrics
+	mu   sync.Mutex
+	held []bool
+}
+
+func (m *heldMetrics) RecordGasPriceHeld(held bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.held = append(m.held, held)
+}
+
+func (m *heldMetrics) records() []bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]bool(nil), m.held...)
+}
+
+// TestTxMgrHoldsAboveMaxGasPrice asserts that the tx is held while the L1 gas price exceeds
+// the max gas price, and that its gas fee cap never exceeds the max gas price.
+func TestTxMgrHoldsAboveMaxGasPrice(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.ResubmissionTimeout = 50 * time.Millisecond
+	cfg.MaxGasPrice = big.NewInt(100)
+	h := newTestHarnessWithConfig(t, cfg)
+	metr := &heldMetrics{}
+	h.mgr.metr = metr
+	h.backend.receiptStatus = types.ReceiptStatusSuccessful
+	h.gasPricer.mu.Lock()
+	h.gasPricer.baseBaseFee = big.NewInt(1000)
+	h.gasPricer.mu.Unlock()
+
+	var (
+		mu        sync.Mutex
+		published []*types.Transaction
+	)
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		mu.Lock()
+		defer mu.Unlock()
+		published = append(published, tx)
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resCh := make(chan error, 1)
+	go func() {
+		_, err := h.mgr.Send(ctx, h.createTxCandidate())
+		resCh <- err
+	}()
+
+	require.Eventually(t, func() bool {
+		return len(metr.records()) > 0
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, []bool{true}, metr.records())
+	select {
+	case err := <-resCh:
+		t.Fatalf("tx must be held, but Send returned: %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+	mu.Lock()
+	require.Empty(t, published)
+	mu.Unlock()
+
+	// The basefee falls, so the tip plus the basefee is below the max gas price for a few epochs.
+	h.gasPricer.mu.Lock()
+	h.gasPricer.baseBaseFee = big.NewInt(0)
+	h.gasPricer.baseGasTipFee = big.NewInt(1)
+	h.gasPricer.mu.Unlock()
+	require.NoError(t, <-resCh)
+	require.Equal(t, []bool{true, false}, metr.records())
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.NotEmpty(t, published)
+	for _, tx := range published {
+		require.LessOrEqual(t, tx.GasFeeCap().Cmp(cfg.MaxGasPrice), 0, "gas fee cap must not exceed the max gas price")
+	}
+}
+
+// TestTxMgrCancelMaxGasPrice asserts that Cancel holds the replacement tx while the L1 gas price
+// exceeds the max gas price, and never signs a tx whose gas fee cap exceeds it.
+func TestTxMgrCancelMaxGasPrice(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu     sync.Mutex
+		signed []*types.Transaction
+	)
+	cfg := configWithNumConfs(1)
+	cfg.ResubmissionTimeout = 50 * time.Millisecond
+	cfg.MaxGasPrice = big.NewInt(100)
+	cfg.Signer = func(ctx context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		signed = append(signed, tx)
+		return tx, nil
+	}
+	h := newTestHarnessWithConfig(t, cfg)
+	h.backend.nonce = 3
+	h.backend.pendingNonce = 4
+	h.backend.receiptStatus = types.ReceiptStatusSuccessful
+	h.gasPricer.mu.Lock()
+	h.gasPricer.baseBaseFee = big.NewInt(1000)
+	h.gasPricer.mu.Unlock()
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resCh := make(chan error, 1)
+	go func() {
+		resCh <- h.mgr.Cancel(ctx, 3)
+	}()
+
+	select {
+	case err := <-resCh:
+		t.Fatalf("cancellation tx must be held, but Cancel returned: %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+	mu.Lock()
+	require.Empty(t, signed)
+	mu.Unlock()
+
+	// The basefee falls, so the tip plus the basefee of the next epoch is below the max gas price,
+	// but the doubled fees exceed it.
+	h.gasPricer.mu.Lock()
+	h.gasPricer.baseBaseFee = big.NewInt(0)
+	h.gasPricer.baseGasTipFee = big.NewInt(100 / (h.gasPricer.epoch + 1))
+	h.gasPricer.mu.Unlock()
+	require.NoError(t, <-resCh)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.NotEmpty(t, signed)
+	require.Equal(t, cfg.MaxGasPrice, signed[0].GasFeeCap(), "doubled gas fee cap must be capped at the max gas price")
+	for _, tx := range signed {
+		require.LessOrEqual(t, tx.GasFeeCap().Cmp(cfg.MaxGasPrice), 0, "gas fee cap must not exceed the max gas price")
+		require.LessOrEqual(t, tx.GasTipCap().Cmp(tx.GasFeeCap()), 0)
+	}
+}
+
+func TestCapGasFeeCap(t *testing.T) {
+	m := &SimpleTxManager{}
+	require.Equal(t, big.NewInt(500), m.capGasFeeCap(big.NewInt(500)), "disabled without a max gas price")
+	m.MaxGasPrice = big.NewInt(100)
+	require.Equal(t, big.NewInt(100), m.capGasFeeCap(big.NewInt(500)))
+	require.Equal(t, big.NewInt(50), m.capGasFeeCap(big.NewInt(50)))
+	require.Nil(t, gweiToWei(0))
+	require.Equal(t, big.NewInt(1_500_000_000), gweiToWei(1.5))
+}
+
+// revertRPCError is the JSON-RPC error of a reverted eth_call carrying the revert data.
+type revertRPCError struct{ data string }
+
+func (e revertRPCError) Error() string          { return "execution reverted" }
+func (e revertRPCError) ErrorCode() int         { return 3 }
+func (e revertRPCError) ErrorData() interface{} { return e.data }
+
+// pendingCallingBackend is a callingBackend that supports the calls against the pending state.
+type pendingCallingBackend struct {
+	*callingBackend
+	pendingCalls int
+}
+
+func (b *pendingCallingBackend) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	b.pendingCalls++
+	return b.CallContract(ctx, msg, nil)
+}
+
+// TestTxMgrSimulateBeforeSend asserts that a tx reverting in the simulation is never published,
+// and that the revert is returned to the caller as a RevertError.
+func TestTxMgrSimulateBeforeSend(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.SimulateBeforeSend = true
+	h := newTestHarnessWithConfig(t, cfg)
+	backend := &pendingCallingBackend{callingBackend: &callingBackend{mockBackend: h.backend}}
+	h.mgr.backend = backend
+	h.backend.receiptStatus = types.ReceiptStatusSuccessful
+	published := 0
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		published++
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	reason, err := abi.Arguments{{Type: stringType}}.Pack("output already submitted")
+	require.NoError(t, err)
+	revertData := append(crypto.Keccak256([]byte("Error(string)"))[:4], reason...)
+	backend.callErr = revertRPCError{data: hexutil.Encode(revertData)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err = h.mgr.Send(ctx, h.createTxCandidate())
+	require.ErrorIs(t, err, ErrTxReverted)
+	var revertErr *RevertError
+	require.ErrorAs(t, err, &revertErr)
+	require.Equal(t, "output already submitted", revertErr.Reason)
+	require.Equal(t, revertData, revertErr.Data)
+	require.Equal(t, 0, published)
+	require.Equal(t, 1, backend.pendingCalls)
+
+	// A transport failure is not a revert, but the tx is not published either.
+	backend.callErr = errors.New("connection refused")
+	_, err = h.mgr.Send(ctx, h.createTxCandidate())
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrTxReverted)
+	require.Equal(t, 0, published)
+
+	backend.callErr = nil
+	_, err = h.mgr.Send(ctx, h.createTxCandidate())
+	require.NoError(t, err)
+	require.Equal(t, 1, published)
+}
+
+// TestTxMgrReplace asserts that Replace sends the candidate at the nonce of the pending tx
+// with escalated fees, and returns the receipt of the replacement.
+func TestTxMgrReplace(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+	h.backend.nonce = 3
+	h.backend.pendingNonce = 4
+	h.backend.receiptStatus = types.ReceiptStatusSuccessful
+
+	var sentTx *types.Transaction
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		sentTx = tx
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	candidate := h.createTxCandidate()
+	_, err := h.mgr.Replace(ctx, 4, candidate)
+	require.ErrorIs(t, err, ErrNoPendingTx)
+	require.Nil(t, sentTx)
+
+	receipt, err := h.mgr.Replace(ctx, 3, candidate)
+	require.NoError(t, err)
+	require.Equal(t, sentTx.Hash(), receipt.TxHash)
+	require.Equal(t, uint64(3), sentTx.Nonce())
+	require.Equal(t, candidate.To, sentTx.To())
+	require.Equal(t, candidate.TxData, sentTx.Data())
+	require.Equal(t, candidate.GasLimit, sentTx.Gas())
+	require.Equal(t, candidate.AccessList, sentTx.AccessList())
+	// The replacement doubles the suggested tip of the first epoch.
+	tip, _ := newGasPricer(3).sample()
+	require.Equal(t, new(big.Int).Mul(tip, big.NewInt(2)), sentTx.GasTipCap())
+}
+
+// TestTxMgrReplaceLimits asserts that Replace, through the TxManager interface, does not send
+// a replacement tx above the fee limits, and holds it while the L1 gas price exceeds the max gas price.
+func TestTxMgrReplaceLimits(t *testing.T) {
+	t.Parallel()
+
+	newHarness := func(cfg Config) (*testHarness, *int32) {
+		h := newTestHarnessWithConfig(t, cfg)
+		h.backend.nonce = 3
+		h.backend.pendingNonce = 4
+		var sent int32
+		h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+			atomic.AddInt32(&sent, 1)
+			return nil
+		})
+		return h, &sent
+	}
+
+	cfg := configWithNumConfs(1)
+	cfg.FeeLimitMultiplier = 1
+	h, sent := newHarness(cfg)
+	var mgr TxManager = h.mgr
+	_, err := mgr.Replace(context.Background(), 3, h.createTxCandidate())
+	require.ErrorIs(t, err, ErrFeeLimitExceeded)
+	require.Zero(t, atomic.LoadInt32(sent))
+
+	cfg = configWithNumConfs(1)
+	cfg.ResubmissionTimeout = 50 * time.Millisecond
+	cfg.MaxGasPrice = big.NewInt(100)
+	h, sent = newHarness(cfg)
+	h.gasPricer.mu.Lock()
+	h.gasPricer.baseBaseFee = big.NewInt(1000)
+	h.gasPricer.mu.Unlock()
+	mgr = h.mgr
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_, err = mgr.Replace(ctx, 3, h.createTxCandidate())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Zero(t, atomic.LoadInt32(sent))
+}
+
+// privatePublisher is a private tx relay recording the published txs, which are never mined.
+type privatePublisher struct {
+	mu        sync.Mutex
+	err       error
+	published []common.Hash
+}
+
+func (p *privatePublisher) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.published = append(p.published, tx.Hash())
+	return p.err
+}
+
+func (p *privatePublisher) count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.published)
+}
+
+// TestTxMgrPrivateTxFallback asserts that the txs are published to the private tx relay,
+// and to the public mempool once PrivateTxFallbackDelay elapsed or if the relay fails.
+func TestTxMgrPrivateTxFallback(t *testing.T) {
+	t.Parallel()
+
+	newHarness := func(private *privatePublisher, fallbackDelay time.Duration) (*testHarness, *int32) {
+		cfg := configWithNumConfs(1)
+		cfg.ResubmissionTimeout = 50 * time.Millisecond
+		cfg.PrivateTxPublisher = private
+		cfg.PrivateTxFallbackDelay = fallbackDelay
+		h := newTestHarnessWithConfig(t, cfg)
+		h.backend.receiptStatus = types.ReceiptStatusSuccessful
+		var public int32
+		h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+			atomic.AddInt32(&public, 1)
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap())
+			return nil
+		})
+		return h, &public
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	private := &privatePublisher{}
+	h, public := newHarness(private, 200*time.Millisecond)
+	start := time.Now()
+	_, err := h.mgr.Send(ctx, h.createTxCandidate())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond, "must wait for the fallback delay")
+	require.Greater(t, private.count(), 1, "the resubmissions before the delay go to the relay")
+	require.NotZero(t, atomic.LoadInt32(public))
+
+	// The unreachable relay falls back at once, long before the delay.
+	private = &privatePublisher{err: errors.New("connection refused")}
+	h, public = newHarness(private, time.Hour)
+	// No resubmission, which could reach the relay while the send returns, before falling back.
+	h.mgr.ResubmissionTimeout = time.Hour
+	_, err = h.mgr.Send(ctx, h.createTxCandidate())
+	require.NoError(t, err)
+	require.NotZero(t, private.count())
+	require.Equal(t, int32(private.count()), atomic.LoadInt32(public), "every failed relay publication must fall back")
+}
+
+// accessListBackend is a mockBackend supporting eth_createAccessList.
+type accessListBackend struct {
+	*mockBackend
+
+	accessList types.AccessList
+	err        error
+	calls      int
+}
+
+func (b *accessListBackend) CreateAccessList(ctx context.Context, msg ethereum.CallMsg) (*types.AccessList, uint64, string, error) {
+	b.calls++
+	if b.err != nil {
+		return nil, 0, "", b.err
+	}
+	return &b.accessList, 21_000, "", nil
+}
+
+// TestTxMgrCreateAccessList asserts that the access list returned by the backend is attached to
+// the tx and used for the gas estimation, unless the candidate has its own access list or the query fails.
+func TestTxMgrCreateAccessList(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.CreateAccessList = true
+	cfg.NetworkTimeout = time.Second
+	h := newTestHarnessWithConfig(t, cfg)
+	created := types.AccessList{{
+		Address:     common.HexToAddress("0x1234"),
+		StorageKeys: []common.Hash{common.HexToHash("0x01")},
+	}}
+	backend := &accessListBackend{mockBackend: h.backend, accessList: created}
+	h.mgr.backend = backend
+
+	candidate := h.createTxCandidate()
+	candidate.GasLimit = 0
+	candidate.AccessList = nil
+	tx, err := h.mgr.craftTx(context.Background(), candidate)
+	require.NoError(t, err)
+	require.Equal(t, created, tx.AccessList())
+	require.Equal(t, created, h.backend.estimateGasMsgs[len(h.backend.estimateGasMsgs)-1].AccessList)
+
+	// The access list of the candidate is kept.
+	candidate.AccessList = types.AccessList{{Address: common.HexToAddress("0x5678")}}
+	tx, err = h.mgr.craftTx(context.Background(), candidate)
+	require.NoError(t, err)
+	require.Equal(t, candidate.AccessList, tx.AccessList())
+	require.Equal(t, 1, backend.calls)
+
+	// The tx is sent without an access list if the query fails.
+	candidate.AccessList = nil
+	backend.err = errors.New("method not found")
+	tx, err = h.mgr.craftTx(context.Background(), candidate)
+	require.NoError(t, err)
+	require.Empty(t, tx.AccessList())
+}
+
+// TestTxMgrConcurrentSends asserts that up to MaxPendingTxs sends are in flight at once,
+// each publishing its tx at its own nonce.
+func TestTxMgrConcurrentSends(t *testing.T) {
+	t.Parallel()
+
+	const maxPendingTxs = 3
+	cfg := configWithNumConfs(1)
+	cfg.MaxPendingTxs = maxPendingTxs
+	cfg.NetworkTimeout = time.Second
+	h := newTestHarnessWithConfig(t, cfg)
+	h.mgr.nonces = newNonceTracker(maxPendingTxs)
+	h.backend.receiptStatus = types.ReceiptStatusSuccessful
+
+	// Mine the txs only once every sender's tx is published, which never happens if the sends are serialized.
+	var mu sync.Mutex
+	published := make(map[uint64]*types.Transaction)
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		mu.Lock()
+		defer mu.Unlock()
+		published[tx.Nonce()] = tx
+		if len(published) == maxPendingTxs {
+			for _, tx := range published {
+				txHash := tx.Hash()
+				h.backend.mine(&txHash, tx.GasFeeCap())
+			}
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var wg sync.WaitGroup
+	for i := 0; i < maxPendingTxs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := h.mgr.Send(ctx, h.createTxCandidate())
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	for nonce := uint64(0); nonce < maxPendingTxs; nonce++ {
+		require.Contains(t, published, nonce)
+	}
+}
+
+// TestTxMgrStuckTx asserts that a tx resubmitted more than StuckTxBumps times, or pending for longer
+// than StuckTxDuration, is reported once to the registered handler.
+func TestTxMgrStuckTx(t *testing.T) {
+	t.Parallel()
+
+	send := func(t *testing.T, bumps uint64, duration time.Duration) []StuckTx {
+		cfg := configWithNumConfs(1)
+		cfg.ResubmissionTimeout = 10 * time.Millisecond
+		cfg.NetworkTimeout = time.Second
+		cfg.StuckTxBumps = bumps
+		cfg.StuckTxDuration = duration
+		h := newTestHarnessWithConfig(t, cfg)
+		// Accept the tx to the mempool, but never mine it.
+		h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+			return nil
+		})
+		var stuck []StuckTx
+		h.mgr.OnStuckTx(func(tx StuckTx) {
+			stuck = append(stuck, tx)
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+		defer cancel()
+		_, err := h.mgr.Send(ctx, h.createTxCandidate())
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		return stuck
+	}
+
+	t.Run("bumps", func(t *testing.T) {
+		stuck := send(t, 3, 0)
+		require.Len(t, stuck, 1)
+		require.Equal(t, 4, stuck[0].Bumps)
+		require.Equal(t, uint64(0), stuck[0].Nonce)
+	})
+
+	t.Run("duration", func(t *testing.T) {
+		stuck := send(t, 0, 100*time.Millisecond)
+		require.Len(t, stuck, 1)
+		require.Greater(t, stuck[0].Pending, 100*time.Millisecond)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		require.Empty(t, send(t, 0, 0))
+	})
+}
+
+// TestTxMgrReceiptRevert asserts that a confirmed tx with a failed status is re-executed, and
+// its revert is decoded against the registered contract ABIs.
+func TestTxMgrReceiptRevert(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+	backend := &callingBackend{mockBackend: h.backend}
+	h.mgr.backend = backend
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+
+	parsed, err := abi.JSON(strings.NewReader(`[{"type":"error","name":"NotAllowed","inputs":[{"name":"caller","type":"address"}]}]`))
+	require.NoError(t, err)
+	candidate := h.createTxCandidate()
+	h.mgr.RegisterContractABI(*candidate.To, "Colosseum", &parsed)
+	caller := common.HexToAddress("0xabcd")
+	notAllowed := parsed.Errors["NotAllowed"]
+	args, err := notAllowed.Inputs.Pack(caller)
+	require.NoError(t, err)
+	revertData := append(notAllowed.ID.Bytes()[:4], args...)
+	backend.callErr = revertRPCError{data: hexutil.Encode(revertData)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.Send(ctx, candidate)
+	require.NotNil(t, receipt)
+	require.ErrorIs(t, err, ErrTxReceiptNotSucceed)
+	require.ErrorIs(t, err, ErrTxReverted)
+	var revertErr *RevertError
+	require.ErrorAs(t, err, &revertErr)
+	require.Equal(t, "Colosseum", revertErr.Contract)
+	require.Equal(t, "NotAllowed", revertErr.Name)
+	require.Equal(t, []interface{}{caller}, revertErr.Args)
+	require.Contains(t, err.Error(), "Colosseum: NotAllowed(0x000000000000000000000000000000000000ABcD)")
+
+	// The tx is re-executed against the state before its block.
+	require.Len(t, backend.callMsgs, 1)
+	require.Equal(t, candidate.TxData, backend.callMsgs[0].Data)
+
+	// A panic is decoded even without a registered ABI.
+	panicData := append(crypto.Keccak256([]byte("Panic(uint256)"))[:4:4], common.LeftPadBytes([]byte{0x11}, 32)...)
+	revertErr = h.mgr.decodeRevert(nil, &RevertError{Data: panicData})
+	require.Equal(t, "Panic", revertErr.Name)
+	require.Equal(t, []interface{}{big.NewInt(0x11)}, revertErr.Args)
+}
+
+// TestTxMgrGasPricer asserts that the tip cap is suggested by the configured GasPricer
+// instead of the backend.
+func TestTxMgrGasPricer(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.NetworkTimeout = time.Second
+	cfg.GasPricer = NewFixedGasPricer(big.NewInt(42))
+	h := newTestHarnessWithConfig(t, cfg)
+
+	tx, err := h.mgr.craftTx(context.Background(), h.createTxCandidate())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(42), tx.GasTipCap())
+	_, basefee := h.gasPricer.feesForEpoch(h.gasPricer.epoch)
+	require.Equal(t, calcGasFeeCap(basefee, big.NewInt(42)), tx.GasFeeCap())
+}
+
+// TestTxMgrExtraAccounts asserts that the sends rotate to the extra accounts while the primary one
+// has MaxPendingTxs sends in flight.
+func TestTxMgrExtraAccounts(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	senders := make(map[common.Hash]common.Address)
+	signer := func(ctx context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		senders[tx.Hash()] = from
+		return tx, nil
+	}
+	extra := common.HexToAddress("0x1234")
+	cfg := configWithNumConfs(1)
+	cfg.NetworkTimeout = time.Second
+	cfg.Signer = signer
+	cfg.ExtraAccounts = []Account{{Signer: signer, From: extra}}
+	h := newTestHarnessWithConfig(t, cfg)
+	h.mgr.accounts = newAccountPool(newAccountManagers("TEST", testlog.Logger(t, log.LvlCrit),
+		&metrics.NoopTxMetrics{}, h.mgr.Config, h.mgr, cfg.ExtraAccounts), cfg.MaxPendingTxs)
+	h.backend.receiptStatus = types.ReceiptStatusSuccessful
+
+	// Mine the txs only once both accounts published theirs, which never happens if the sends are serialized.
+	published := make(map[common.Hash]*types.Transaction)
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		mu.Lock()
+		defer mu.Unlock()
+		published[tx.Hash()] = tx
+		if len(published) == 2 {
+			for _, tx := range published {
+				txHash := tx.Hash()
+				h.backend.mine(&txHash, tx.GasFeeCap())
+			}
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		candidate := h.createTxCandidate()
+		candidate.TxData = []byte{byte(i)}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := h.mgr.Send(ctx, candidate)
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	var from []common.Address
+	for txHash := range published {
+		from = append(from, senders[txHash])
+	}
+	require.ElementsMatch(t, []common.Address{cfg.From, extra}, from)
+	require.Equal(t, 2, h.mgr.maxConcurrentSends())
+}
+
+// TestTxMgrStandbyAccounts asserts that the txs are sent from the standby account switched to by
+// the admin API, and from the primary account again once switched back.
+func TestTxMgrStandbyAccounts(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	senders := make(map[common.Hash]common.Address)
+	signer := func(ctx context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		senders[tx.Hash()] = from
+		return tx, nil
+	}
+	standby := common.HexToAddress("0x1234")
+	cfg := configWithNumConfs(1)
+	cfg.NetworkTimeout = time.Second
+	cfg.Signer = signer
+	cfg.StandbyAccounts = []Account{{Signer: signer, From: standby}}
+	h := newTestHarnessWithConfig(t, cfg)
+	h.mgr.standby = &standbyAccounts{managers: newAccountManagers("TEST", testlog.Logger(t, log.LvlCrit),
+		&metrics.NoopTxMetrics{}, h.mgr.Config, h.mgr, cfg.StandbyAccounts)}
+	h.backend.receiptStatus = types.ReceiptStatusSuccessful
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+
+	srv := rpc.NewServer()
+	api := NewAdminAPI(h.mgr)
+	require.NoError(t, srv.RegisterName(api.Namespace, api.Service))
+	client := rpc.DialInProc(srv)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	sendFrom := func() common.Address {
+		receipt, err := h.mgr.Send(ctx, h.createTxCandidate())
+		require.NoError(t, err)
+		mu.Lock()
+		defer mu.Unlock()
+		return senders[receipt.TxHash]
+	}
+	require.Equal(t, cfg.From, sendFrom())
+
+	var switched common.Address
+	require.NoError(t, client.CallContext(ctx, &switched, "txmgr_switchAccount"))
+	require.Equal(t, standby, switched)
+	require.Equal(t, standby, h.mgr.From())
+	require.Equal(t, standby, sendFrom())
+
+	require.ErrorContains(t, client.CallContext(ctx, &switched, "txmgr_switchAccount"), "no standby account left")
+	require.ErrorContains(t, client.CallContext(ctx, &switched, "txmgr_switchAccount", common.HexToAddress("0x42")), "unknown account")
+	require.NoError(t, client.CallContext(ctx, &switched, "txmgr_switchAccount", cfg.From))
+	require.Equal(t, cfg.From, h.mgr.From())
+	require.Equal(t, cfg.From, sendFrom())
+}
+
+// TestTxMgrRebroadcast asserts that the pending tx is republished every RebroadcastInterval without
+// waiting for a fee bump.
+func TestTxMgrRebroadcast(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.ResubmissionTimeout = time.Hour
+	cfg.RebroadcastInterval = 10 * time.Millisecond
+	cfg.NetworkTimeout = time.Second
+	h := newTestHarnessWithConfig(t, cfg)
+	h.backend.receiptStatus = types.ReceiptStatusSuccessful
+
+	// Mine the tx only once it is published for the third time, as if it was evicted until then.
+	var mu sync.Mutex
+	published := 0
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		mu.Lock()
+		defer mu.Unlock()
+		published++
+		if published == 3 {
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap())
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	tx, err := h.mgr.craftTx(ctx, h.createTxCandidate())
+	require.NoError(t, err)
+	receipt, err := h.mgr.send(ctx, tx, false)
+	require.NoError(t, err)
+	require.Equal(t, tx.Hash(), receipt.TxHash)
+}
+
+// TestTxMgrCircuitBreaker asserts that the new sends are paused once the backend failed
+// CircuitBreakerThreshold times in a row, until a probe of the backend succeeds.
+func TestTxMgrCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.NetworkTimeout = time.Second
+	h := newTestHarnessWithConfig(t, cfg)
+	h.mgr.circuit = newCircuitBreaker(2, 10*time.Millisecond, clock.SystemClock, &metrics.NoopTxMetrics{}, testlog.Logger(t, log.LvlCrit))
+	ctx := context.Background()
+
+	// The JSON-RPC errors are answers of a healthy backend.
+	for i := 0; i < 3; i++ {
+		h.mgr.recordBackend(ctx, rpcError{"nonce too low"})
+	}
+	require.NoError(t, h.mgr.Ready())
+	h.mgr.recordBackend(ctx, errors.New("connection refused"))
+	h.mgr.recordBackend(ctx, nil)
+	h.mgr.recordBackend(ctx, errors.New("connection refused"))
+	require.NoError(t, h.mgr.Ready(), "the failures must be consecutive")
+	h.mgr.recordBackend(ctx, errors.New("connection refused"))
+	require.ErrorIs(t, h.mgr.Ready(), ErrCircuitOpen)
+
+	// The new sends wait while the probes fail.
+	endpoint := &fakeEndpoint{err: errors.New("connection refused")}
+	h.mgr.backend = endpoint
+	sendCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	_, err := h.mgr.Send(sendCtx, h.createTxCandidate())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Empty(t, endpoint.sentTxs())
+	require.ErrorIs(t, h.mgr.Ready(), ErrCircuitOpen)
+
+	// The circuit closes once a probe succeeds.
+	endpoint.setErr(nil)
+	waitCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	require.NoError(t, h.mgr.waitCircuit(waitCtx))
+	require.NoError(t, h.mgr.Ready())
+}
+
+// TestTxMgrReadySigner asserts that the tx manager is not ready while the remote signer is unreachable.
+func TestTxMgrReadySigner(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+	var healthy atomic.Bool
+	h.mgr.SignerHealthy = healthy.Load
+	require.ErrorIs(t, h.mgr.Ready(), ErrSignerUnhealthy)
+	healthy.Store(true)
+	require.NoError(t, h.mgr.Ready())
+}
+
+// TestTxMgrSendAsync asserts that SendAsync reports the publication, the confirmations and
+// the finalization of the tx, or the failure of the send.
+func TestTxMgrSendAsync(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(2)
+	cfg.ReceiptQueryInterval = 10 * time.Millisecond
+	cfg.NetworkTimeout = time.Second
+	h := newTestHarnessWithConfig(t, cfg)
+	h.backend.receiptStatus = types.ReceiptStatusSuccessful
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	statuses := make(chan TxStatus, 10)
+	h.mgr.SendAsync(ctx, h.createTxCandidate(), func(status TxStatus) {
+		statuses <- status
+		// Confirm then finalize the tx once it is included.
+		switch status.State {
+		case TxIncluded:
+			go h.backend.mine(nil, nil)
+		case TxConfirmed:
+			go h.backend.finalize()
+		}
+	})
+
+	var states []TxState
+	for status := range statuses {
+		states = append(states, status.State)
+		switch status.State {
+		case TxPublished:
+			require.NotNil(t, status.Tx)
+		case TxIncluded:
+			require.Equal(t, uint64(1), status.Confirmations)
+		case TxConfirmed:
+			require.Equal(t, uint64(2), status.Confirmations)
+			require.NotNil(t, status.Receipt)
+		case TxFailed:
+			t.Fatalf("send failed: %v", status.Err)
+		}
+		if status.State == TxFinalized {
+			break
+		}
+	}
+	require.Equal(t, []TxState{TxPublished, TxIncluded, TxConfirmed, TxFinalized}, states)
+
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	failed := make(chan TxStatus, 1)
+	h.mgr.SendAsync(canceledCtx, h.createTxCandidate(), func(status TxStatus) {
+		// The mock backend ignores the context, so the tx may be published before the send fails.
+		if status.State != TxPublished {
+			failed <- status
+		}
+	})
+	select {
+	case status := <-failed:
+		require.Equal(t, TxFailed, status.State)
+		require.Error(t, status.Err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no status reported")
+	}
+}
+
+// TestTxMgrLegacyTxs asserts that the legacy txs are priced by the suggested gas price, and bumped until mined.
+func TestTxMgrLegacyTxs(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.LegacyTxs = true
+	cfg.NetworkTimeout = time.Second
+	h := newTestHarnessWithConfig(t, cfg)
+	h.backend.receiptStatus = types.ReceiptStatusSuccessful
+
+	var mu sync.Mutex
+	var published []*types.Transaction
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		mu.Lock()
+		published = append(published, tx)
+		mu.Unlock()
+		if h.gasPricer.shouldMine(tx.GasPrice()) {
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasPrice())
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.Send(ctx, h.createTxCandidate())
+	require.NoError(t, err)
+	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Greater(t, len(published), 1)
+	for _, tx := range published {
+		require.Equal(t, uint8(types.LegacyTxType), tx.Type())
+	}
+}
+
+// TestTxMgrFeeBudget asserts that the new sends are refused while the fees spent within the window
+// reach the budget, and that the spends age out of the window.
+func TestTxMgrFeeBudget(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	budget := newFeeBudget(big.NewInt(100), time.Hour, &metrics.NoopTxMetrics{})
+	budget.record(now, big.NewInt(60))
+	require.NoError(t, budget.check(now))
+	budget.record(now.Add(time.Minute), big.NewInt(40))
+	require.ErrorIs(t, budget.check(now.Add(time.Minute)), ErrFeeBudgetExhausted)
+	require.NoError(t, budget.check(now.Add(time.Hour)), "the first spend is out of the window")
+	require.Len(t, budget.spends, 1)
+
+	cfg := configWithNumConfs(1)
+	cfg.NetworkTimeout = time.Second
+	h := newTestHarnessWithConfig(t, cfg)
+	h.mgr.budget = newFeeBudget(big.NewInt(1_000_000), feeBudgetWindow, &metrics.NoopTxMetrics{})
+	h.mgr.recordFee(&types.Receipt{EffectiveGasPrice: big.NewInt(10), GasUsed: 100_000})
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		t.Fatal("the tx must not be published once the budget is exhausted")
+		return nil
+	})
+	_, err := h.mgr.Send(context.Background(), h.createTxCandidate())
+	require.ErrorIs(t, err, ErrFeeBudgetExhausted)
+}
+
+// TestTxMgrNonceGapRepair asserts that the gap left by a dropped tx below a pending tx
+// is filled with a self-transfer, so that the pending tx is mined.
+func TestTxMgrNonceGapRepair(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.MaxPendingTxs = 2
+	cfg.ResubmissionTimeout = 50 * time.Millisecond
+	cfg.NetworkTimeout = time.Second
+	h := newTestHarnessWithConfig(t, cfg)
+	h.mgr.nonces = newNonceTracker(cfg.MaxPendingTxs)
+	h.backend.receiptStatus = types.ReceiptStatusSuccessful
+	// The tx at nonce 5 was dropped after its send gave up, so the next tx takes nonce 6.
+	h.backend.nonce = 5
+	next := uint64(6)
+	h.mgr.nonces.next = &next
+
+	var mu sync.Mutex
+	var filled *types.Transaction
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		mu.Lock()
+		defer mu.Unlock()
+		h.backend.mu.Lock()
+		nonce := h.backend.nonce
+		h.backend.mu.Unlock()
+		if tx.Nonce() != nonce {
+			// The tx waits in the queue of the mempool for the gap to be filled.
+			return nil
+		}
+		if tx.Nonce() == 5 {
+			filled = tx
+		}
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		h.backend.mu.Lock()
+		h.backend.nonce++
+		h.backend.mu.Unlock()
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.Send(ctx, h.createTxCandidate())
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.NotNil(t, filled)
+	require.Equal(t, h.mgr.From(), *filled.To())
+	require.Zero(t, filled.Value().Sign())
+	require.Empty(t, h.mgr.nonces.inflight)
+}
+
+// recordingHook records the lifecycle events of the txs, and rejects the signing once reject is set.
+type recordingHook struct {
+	NoopTxHook
+	mu        sync.Mutex
+	events    []string
+	reject    error
+	confirmed *types.Receipt
+}
+
+func (h *recordingHook) record(event string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, event)
+}
+
+func (h *recordingHook) BeforeSign(ctx context.Context, tx *types.Transaction) error {
+	h.record("sign")
+	return h.reject
+}
+
+func (h *recordingHook) AfterBroadcast(tx *types.Transaction) { h.record("broadcast") }
+
+func (h *recordingHook) OnBump(replaced, bumped *types.Transaction) {
+	h.record("bump")
+}
+
+func (h *recordingHook) OnConfirmed(receipt *types.Receipt) {
+	h.record("confirmed")
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.confirmed = receipt
+}
+
+// TestTxMgrHooks asserts that the hooks are called at the lifecycle events of the txs,
+// and that a tx rejected before signing is not sent.
+func TestTxMgrHooks(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.ResubmissionTimeout = 50 * time.Millisecond
+	cfg.NetworkTimeout = time.Second
+	h := newTestHarnessWithConfig(t, cfg)
+	h.backend.receiptStatus = types.ReceiptStatusSuccessful
+	hook := &recordingHook{}
+	h.mgr.AddHook(hook)
+
+	// Mine the tx once its fees were bumped.
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		if h.gasPricer.shouldMine(tx.GasFeeCap()) {
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap())
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.Send(ctx, h.createTxCandidate())
+	require.NoError(t, err)
+
+	hook.mu.Lock()
+	require.Equal(t, []string{"sign", "broadcast"}, hook.events[:2])
+	counts := make(map[string]int)
+	for _, event := range hook.events {
+		counts[event]++
+	}
+	require.Equal(t, counts["sign"], counts["bump"]+1, "every signing but the first is a bump")
+	require.Equal(t, 1, counts["confirmed"])
+	require.Equal(t, receipt, hook.confirmed)
+	hook.events = nil
+	errNotApproved := errors.New("not approved")
+	hook.reject = errNotApproved
+	hook.mu.Unlock()
+
+	_, err = h.mgr.Send(ctx, h.createTxCandidate())
+	require.ErrorIs(t, err, errNotApproved)
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	require.Equal(t, []string{"sign"}, hook.events)
+}
+
+// TestTxMgrAdminAPI asserts that the admin API lists the txs in flight, bumps them on request,
+// and drops them, failing their send.
+func TestTxMgrAdminAPI(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	// Only the requested bumps resubmit the tx.
+	cfg.ResubmissionTimeout = time.Hour
+	cfg.NetworkTimeout = time.Second
+	h := newTestHarnessWithConfig(t, cfg)
+	h.mgr.pending = newPendingSends()
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		return nil
+	})
+
+	srv := rpc.NewServer()
+	api := NewAdminAPI(h.mgr)
+	require.NoError(t, srv.RegisterName(api.Namespace, api.Service))
+	client := rpc.DialInProc(srv)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	sendErr := make(chan error, 1)
+	go func() {
+		_, err := h.mgr.Send(ctx, h.createTxCandidate())
+		sendErr <- err
+	}()
+
+	pendingTxs := func() []PendingTx {
+		var txs []PendingTx
+		require.NoError(t, client.CallContext(ctx, &txs, "txmgr_pending"))
+		return txs
+	}
+	require.Eventually(t, func() bool { return len(pendingTxs()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	published := pendingTxs()[0]
+	require.Equal(t, h.mgr.From(), published.From)
+	require.Zero(t, published.Bumps)
+
+	require.Error(t, client.CallContext(ctx, nil, "txmgr_bump", published.Nonce+1))
+	require.NoError(t, client.CallContext(ctx, nil, "txmgr_bump", published.Nonce))
+	require.Eventually(t, func() bool { return pendingTxs()[0].Bumps == 1 }, 5*time.Second, 10*time.Millisecond)
+	bumped := pendingTxs()[0]
+	require.NotEqual(t, published.Hash, bumped.Hash)
+	require.Equal(t, 1, bumped.GasFeeCap.ToInt().Cmp(published.GasFeeCap.ToInt()))
+
+	require.NoError(t, client.CallContext(ctx, nil, "txmgr_drop", published.Nonce, h.mgr.From()))
+	require.ErrorIs(t, <-sendErr, ErrPendingTxDropped)
+	require.Empty(t, pendingTxs())
+}
+
+// TestTxMgrReorgWatch asserts that the confirmed txs reorged out before ReorgWatchDepth confirmations
+// are reported, and the deeper ones are not.
+func TestTxMgrReorgWatch(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.ReorgWatchDepth = 3
+	cfg.ReceiptQueryInterval = 10 * time.Millisecond
+	cfg.NetworkTimeout = time.Second
+	h := newTestHarnessWithConfig(t, cfg)
+	h.backend.receiptStatus = types.ReceiptStatusSuccessful
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+	reorged := make(chan ReorgedTx, 1)
+	h.mgr.OnReorg(func(tx ReorgedTx) {
+		reorged <- tx
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.Send(ctx, h.createTxCandidate())
+	require.NoError(t, err)
+	h.backend.reorg(receipt.TxHash)
+	select {
+	case tx := <-reorged:
+		require.Equal(t, receipt.TxHash, tx.Hash)
+		require.Equal(t, receipt.BlockNumber.Uint64(), tx.BlockNumber)
+	case <-time.After(5 * time.Second):
+		t.Fatal("reorg not reported")
+	}
+
+	receipt, err = h.mgr.Send(ctx, h.createTxCandidate())
+	require.NoError(t, err)
+	h.backend.mine(nil, nil)
+	h.backend.mine(nil, nil)
+	// The watch ends once the block has ReorgWatchDepth confirmations.
+	time.Sleep(100 * time.Millisecond)
+	h.backend.reorg(receipt.TxHash)
+	select {
+	case tx := <-reorged:
+		t.Fatalf("reorg reported beyond the watch depth: %v", tx.Hash)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+// TestTxMgrReorgWatchClose asserts that the reorg watches end once the tx manager is closed.
+func TestTxMgrReorgWatchClose(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.ReorgWatchDepth = 1000
+	cfg.ReceiptQueryInterval = 10 * time.Millisecond
+	cfg.NetworkTimeout = time.Second
+	h := newTestHarnessWithConfig(t, cfg)
+	h.backend.receiptStatus = types.ReceiptStatusSuccessful
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+	h.mgr.OnReorg(func(tx ReorgedTx) {
+		t.Errorf("reorg reported after the close: %v", tx.Hash)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.Send(ctx, h.createTxCandidate())
+	require.NoError(t, err)
+
+	closed := make(chan struct{})
+	go func() {
+		h.mgr.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("reorg watch not stopped by the close")
+	}
+	h.backend.reorg(receipt.TxHash)
+	time.Sleep(100 * time.Millisecond)
+
+	// No watch is started once closed.
+	h.mgr.watchReorg(types.NewTx(&types.DynamicFeeTx{}), receipt)
+	h.mgr.Close()
+}
+
+// TestTxMgrPrecondition asserts that the tx is not published if its precondition fails, and that
+// the send is aborted once the precondition fails on a resubmission.
+func TestTxMgrPrecondition(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.ResubmissionTimeout = 50 * time.Millisecond
+	cfg.NetworkTimeout = time.Second
+	h := newTestHarnessWithConfig(t, cfg)
+	var published atomic.Int32
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		published.Add(1)
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	errSubmitted := errors.New("already submitted")
+	candidate := h.createTxCandidate()
+	candidate.Precondition = func(ctx context.Context) error {
+		return errSubmitted
+	}
+	_, err := h.mgr.Send(ctx, candidate)
+	require.ErrorIs(t, err, ErrPreconditionFailed)
+	require.ErrorIs(t, err, errSubmitted)
+	require.Zero(t, published.Load())
+
+	var checks atomic.Int32
+	candidate.Precondition = func(ctx context.Context) error {
+		// Fail on the first resubmission.
+		if checks.Add(1) > 1 {
+			return errSubmitted
+		}
+		return nil
+	}
+	_, err = h.mgr.Send(ctx, candidate)
+	var precondErr *PreconditionError
+	require.ErrorAs(t, err, &precondErr)
+	require.ErrorIs(t, precondErr.Err, errSubmitted)
+	require.Equal(t, int32(2), checks.Load())
+	require.Equal(t, int32(1), published.Load())
+}
+
+// TestContractLabel asserts that the recipients of the txs are labeled by ContractLabels,
+// and that the unknown recipients share the "other" label.
+func TestContractLabel(t *testing.T) {
+	t.Parallel()
+
+	oracle, multicall, unknown := common.Address{1}, common.Address{2}, common.Address{3}
+	cfg := configWithNumConfs(1)
+	cfg.ContractLabels = map[common.Address]string{oracle: "l2_output_oracle"}
+	cfg.MulticallAddress = multicall
+	h := newTestHarnessWithConfig(t, cfg)
+
+	require.Equal(t, "l2_output_oracle", h.mgr.contractLabel(&oracle))
+	require.Equal(t, "multicall3", h.mgr.contractLabel(&multicall))
+	require.Equal(t, "other", h.mgr.contractLabel(&unknown))
+	require.Equal(t, "other", h.mgr.contractLabel(nil))
+}
+
+// TestTxMgrTxBuilder asserts that the txs of a candidate with a TxBuilder are built by it,
+// both when they are crafted and when they are bumped.
+func TestTxMgrTxBuilder(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.NetworkTimeout = time.Second
+	h := newTestHarnessWithConfig(t, cfg)
+	h.backend.receiptStatus = types.ReceiptStatusSuccessful
+
+	var mu sync.Mutex
+	var published []*types.Transaction
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		mu.Lock()
+		defer mu.Unlock()
+		published = append(published, tx)
+		// Mine the first bumped tx.
+		if len(published) == 2 {
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap())
+		}
+		return nil
+	})
+
+	candidate := h.createTxCandidate()
+	candidate.Builder = TxBuilderFunc(func(fields *types.DynamicFeeTx, legacy bool) (types.TxData, error) {
+		require.False(t, legacy)
+		return &types.AccessListTx{
+			ChainID:    fields.ChainID,
+			Nonce:      fields.Nonce,
+			GasPrice:   fields.GasFeeCap,
+			Gas:        fields.Gas,
+			To:         fields.To,
+			Value:      fields.Value,
+			Data:       fields.Data,
+			AccessList: fields.AccessList,
+		}, nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.Send(ctx, candidate)
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, published, 2)
+	require.Equal(t, published[1].Hash(), receipt.TxHash)
+	require.Equal(t, 1, published[1].GasPrice().Cmp(published[0].GasPrice()))
+	for _, tx := range published {
+		require.Equal(t, uint8(types.AccessListTxType), tx.Type())
+	}
+
+	// Without its builder, e.g. once resumed after a restart, the tx of a custom type is not bumped.
+	tx := published[0]
+	require.Equal(t, tx, h.mgr.increaseGasPrice(ctx, tx, false))
+
+	// The built tx must keep the nonce chosen by the tx manager.
+	_, err = buildTx(TxBuilderFunc(func(fields *types.DynamicFeeTx, _ bool) (types.TxData, error) {
+		return &types.AccessListTx{Nonce: fields.Nonce + 1, Gas: fields.Gas}, nil
+	}), &types.DynamicFeeTx{Nonce: 1, Gas: 21_000}, false)
+	require.Error(t, err)
+}
+
+// TestTxMgrConfirmationModeFinalized asserts that in the finalized confirmation mode, a tx is only
+// confirmed once its block is finalized, however many blocks are mined on top of it.
+func TestTxMgrConfirmationModeFinalized(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.NetworkTimeout = time.Second
+	cfg.ConfirmationMode = ConfirmationModeFinalized
+	h := newTestHarnessWithConfig(t, cfg)
+	h.backend.receiptStatus = types.ReceiptStatusSuccessful
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		_, err := h.mgr.Send(ctx, h.createTxCandidate())
+		done <- err
+	}()
+
+	for i := 0; i < 3; i++ {
+		select {
+		case err := <-done:
+			t.Fatalf("the tx was confirmed before its block was finalized: %v", err)
+		case <-time.After(2 * cfg.ReceiptQueryInterval):
+		}
+		h.backend.mine(nil, nil)
+	}
+	h.backend.finalize()
+	require.NoError(t, <-done)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"reflect"
@@ -14,6 +15,8 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/urfave/cli/v2"
+
+	"github.com/kroma-network/kroma/utils/service/httputil"
 )
 
 func PrefixEnvVar(prefix, suffix string) string {
@@ -75,6 +78,9 @@ func ParseAddress(address string) (common.Address, error) {
 	return common.Address{}, fmt.Errorf("invalid address: %v", address)
 }
 
+// closeGracePeriod is how long a closed action is given to stop after the shutdown is signaled.
+const closeGracePeriod = 10 * time.Second
+
 // CloseAction runs the function in the background, until it finishes or until it is closed by the user with an interrupt.
 func CloseAction(fn func(ctx context.Context, shutdown <-chan struct{}) error) error {
 	stopped := make(chan error, 1)
@@ -101,7 +107,7 @@ func CloseAction(fn func(ctx context.Context, shutdown <-chan struct{}) error) e
 		select {
 		case err := <-stopped:
 			return err
-		case <-time.After(time.Second * 10):
+		case <-time.After(closeGracePeriod):
 			return errors.New("command action is unresponsive for more than 10 seconds... shutting down")
 		}
 	case err := <-stopped:
@@ -109,3 +115,145 @@ func CloseAction(fn func(ctx context.Context, shutdown <-chan struct{}) error) e
 		return err
 	}
 }
+
+// CloseActions runs the functions concurrently, until one of them finishes or until they are closed
+// by the user with an interrupt. Either way, the shutdown is fanned out to all the functions, and each of
+// them is given the grace period to stop. A panic in a function is recovered and treated as its failure.
+// The returned error wraps the first failure, and also reports the other failures and unresponsive functions.
+func CloseActions(fns ...func(ctx context.Context, shutdown <-chan struct{}) error) error {
+	doneCh := make(chan os.Signal, 1)
+	signal.Notify(doneCh, []os.Signal{
+		os.Interrupt,
+		os.Kill,
+		syscall.SIGTERM,
+		syscall.SIGQUIT,
+	}...)
+	defer signal.Stop(doneCh)
+
+	return runGroup(doneCh, closeGracePeriod, fns...)
+}
+
+type groupResult struct {
+	index int
+	err   error
+}
+
+func runGroup(interrupt <-chan os.Signal, gracePeriod time.Duration, fns ...func(ctx context.Context, shutdown <-chan struct{}) error) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	results := make(chan groupResult, len(fns))
+	shutdowns := make([]chan struct{}, len(fns))
+	for i, fn := range fns {
+		shutdowns[i] = make(chan struct{}, 1)
+		go func(i int, fn func(ctx context.Context, shutdown <-chan struct{}) error) {
+			results <- groupResult{index: i, err: runRecovered(ctx, shutdowns[i], fn)}
+		}(i, fn)
+	}
+
+	var errs []error
+	stopped := make(map[int]bool)
+	collect := func(res groupResult) {
+		stopped[res.index] = true
+		if res.err != nil {
+			errs = append(errs, fmt.Errorf("action %d: %w", res.index, res.err))
+		}
+	}
+
+	if len(fns) > 0 {
+		select {
+		case <-interrupt:
+		case res := <-results:
+			collect(res)
+		}
+	}
+
+	cancel()
+	for _, shutdown := range shutdowns {
+		shutdown <- struct{}{}
+	}
+
+	// All the functions are shut down at the same time, so a single timer gives each of them the grace period.
+	timer := time.NewTimer(gracePeriod)
+	defer timer.Stop()
+	for len(stopped) < len(fns) {
+		select {
+		case res := <-results:
+			collect(res)
+		case <-timer.C:
+			for i := range fns {
+				if !stopped[i] {
+					errs = append(errs, fmt.Errorf("action %d is unresponsive for more than %v", i, gracePeriod))
+					stopped[i] = true
+				}
+			}
+		}
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return fmt.Errorf("%w (other errors: %v)", errs[0], errs[1:])
+	}
+}
+
+// runRecovered runs the function, and returns the panic as an error if it panics.
+func runRecovered(ctx context.Context, shutdown <-chan struct{}, fn func(ctx context.Context, shutdown <-chan struct{}) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn(ctx, shutdown)
+}
+
+// HealthServer serves the liveness endpoint /healthz and the readiness endpoint /readyz.
+// /healthz always responds with 200 while the server is up, and /readyz responds with 503
+// and the error as body while the readiness check fails.
+type HealthServer struct {
+	server *http.Server
+}
+
+// NewHealthServer creates a HealthServer listening on addr.
+// If ready is nil, the service is always considered ready.
+func NewHealthServer(addr string, ready func() error) *HealthServer {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("OK"))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if ready != nil {
+			if err := ready(); err != nil {
+				http.Error(w, err.Error(), http.StatusServiceUnavailable)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("OK"))
+	})
+	return &HealthServer{
+		server: &http.Server{
+			Addr:    addr,
+			Handler: mux,
+		},
+	}
+}
+
+// Run serves the health endpoints until ctx is cancelled or shutdown is signaled.
+// It can be passed to CloseAction directly, so the server shuts down on interrupt.
+func (s *HealthServer) Run(ctx context.Context, shutdown <-chan struct{}) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-shutdown:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return httputil.ListenAndServeContext(ctx, s.server)
+}
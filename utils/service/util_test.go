@@ -1,7 +1,16 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli/v2"
@@ -30,3 +39,110 @@ func TestValidateEnvVars(t *testing.T) {
 	invalids := validateEnvVars("BATCHER", provided, defined)
 	require.ElementsMatch(t, invalids, []string{"BATCHER_FAKE=false"})
 }
+
+func TestHealthServer(t *testing.T) {
+	var synced atomic.Bool
+	ready := func() error {
+		if !synced.Load() {
+			return errors.New("not synced yet")
+		}
+		return nil
+	}
+
+	// pick a free port for the server
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	hs := NewHealthServer(addr, ready)
+	shutdown := make(chan struct{}, 1)
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- hs.Run(context.Background(), shutdown)
+	}()
+
+	get := func(path string) (int, string) {
+		var res *http.Response
+		require.Eventually(t, func() bool {
+			res, err = http.Get(fmt.Sprintf("http://%s%s", addr, path))
+			return err == nil
+		}, 5*time.Second, 10*time.Millisecond)
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return res.StatusCode, string(body)
+	}
+
+	status, _ := get("/healthz")
+	require.Equal(t, http.StatusOK, status)
+	status, body := get("/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, status)
+	require.Contains(t, body, "not synced yet")
+
+	synced.Store(true)
+	status, _ = get("/healthz")
+	require.Equal(t, http.StatusOK, status)
+	status, _ = get("/readyz")
+	require.Equal(t, http.StatusOK, status)
+
+	shutdown <- struct{}{}
+	select {
+	case err := <-stopped:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("health server did not shut down")
+	}
+}
+
+func TestRunGroup(t *testing.T) {
+	waitShutdown := func(stopped *atomic.Int32) func(ctx context.Context, shutdown <-chan struct{}) error {
+		return func(ctx context.Context, shutdown <-chan struct{}) error {
+			<-shutdown
+			stopped.Add(1)
+			return nil
+		}
+	}
+
+	t.Run("fans out interrupt", func(t *testing.T) {
+		var stopped atomic.Int32
+		interrupt := make(chan os.Signal, 1)
+		interrupt <- os.Interrupt
+		err := runGroup(interrupt, time.Second, waitShutdown(&stopped), waitShutdown(&stopped), waitShutdown(&stopped))
+		require.NoError(t, err)
+		require.Equal(t, int32(3), stopped.Load())
+	})
+
+	t.Run("shuts down others on failure", func(t *testing.T) {
+		var stopped atomic.Int32
+		errFailed := errors.New("failed")
+		failing := func(ctx context.Context, shutdown <-chan struct{}) error {
+			return errFailed
+		}
+		err := runGroup(make(chan os.Signal), time.Second, waitShutdown(&stopped), failing, waitShutdown(&stopped))
+		require.ErrorIs(t, err, errFailed)
+		require.Equal(t, int32(2), stopped.Load())
+	})
+
+	t.Run("recovers panic", func(t *testing.T) {
+		var stopped atomic.Int32
+		panicking := func(ctx context.Context, shutdown <-chan struct{}) error {
+			panic("boom")
+		}
+		err := runGroup(make(chan os.Signal), time.Second, panicking, waitShutdown(&stopped))
+		require.ErrorContains(t, err, "panic: boom")
+		require.Equal(t, int32(1), stopped.Load())
+	})
+
+	t.Run("reports unresponsive action", func(t *testing.T) {
+		var stopped atomic.Int32
+		unresponsive := func(ctx context.Context, shutdown <-chan struct{}) error {
+			select {}
+		}
+		interrupt := make(chan os.Signal, 1)
+		interrupt <- os.Interrupt
+		err := runGroup(interrupt, 50*time.Millisecond, waitShutdown(&stopped), unresponsive)
+		require.ErrorContains(t, err, "action 1 is unresponsive")
+		require.Equal(t, int32(1), stopped.Load())
+	})
+}
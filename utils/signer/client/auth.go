@@ -0,0 +1,132 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// ProtocolV1 is the signer protocol authenticating the client by its tls certificate only.
+	ProtocolV1 = 1
+	// ProtocolV2 is the signer protocol also signing each request with the shared request key,
+	// binding it to a timestamp and a nonce, so that a captured request cannot be replayed.
+	ProtocolV2 = 2
+
+	ProtocolHeader  = "X-Signer-Protocol"
+	TimestampHeader = "X-Signer-Timestamp"
+	NonceHeader     = "X-Signer-Nonce"
+	SignatureHeader = "X-Signer-Signature"
+
+	minRequestKeyLength = 32
+)
+
+// ReadRequestKey reads the hex-encoded request key shared with the signer from the file.
+func ReadRequestKey(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the signer request key: %w", err)
+	}
+	key, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid signer request key: %w", err)
+	}
+	if len(key) < minRequestKeyLength {
+		return nil, fmt.Errorf("signer request key must be at least %d bytes", minRequestKeyLength)
+	}
+	return key, nil
+}
+
+// RequestSignature returns the signature of the request of the protocol v2, which is the
+// HMAC-SHA256 with the request key of:
+//
+//	"2\n" || timestamp || "\n" || nonce || "\n" || method || " " || path || "\n" || hex(sha256(body))
+//
+// where the timestamp is in unix seconds, the nonce is hex-encoded and the path is escaped, "/" if empty.
+// The signer rejects the requests whose timestamp is out of its window, or whose nonce it already saw within it.
+func RequestSignature(key []byte, timestamp, nonce, method, path string, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+	mac := hmac.New(sha256.New, key)
+	fmt.Fprintf(mac, "%d\n%s\n%s\n%s %s\n%x", ProtocolV2, timestamp, nonce, method, path, bodyHash)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// requestSigner signs the requests of the protocol v2. The signer of the protocol v1 ignores the headers.
+type requestSigner struct {
+	base http.RoundTripper
+	key  []byte
+	now  func() time.Time
+}
+
+func (s *requestSigner) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate the request nonce: %w", err)
+	}
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	nonceHex := hex.EncodeToString(nonce)
+
+	// The request must not be modified by the transport, so sign a clone.
+	signed := req.Clone(req.Context())
+	signed.Body = io.NopCloser(bytes.NewReader(body))
+	signed.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	signed.ContentLength = int64(len(body))
+	signed.Header.Set(ProtocolHeader, strconv.Itoa(ProtocolV2))
+	signed.Header.Set(TimestampHeader, timestamp)
+	signed.Header.Set(NonceHeader, nonceHex)
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	signed.Header.Set(SignatureHeader, RequestSignature(s.key, timestamp, nonceHex, req.Method, path, body))
+	return s.base.RoundTrip(signed)
+}
+
+// protocolVersion negotiates the protocol version with the signer endpoint, which is the highest
+// version both of them speak. The signer of the protocol v1 does not know the version method.
+func (s *SignerClient) protocolVersion(index int) (int, error) {
+	if s.requestKey == nil {
+		return ProtocolV1, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	var version int
+	err := s.endpoints[index].client.CallContext(ctx, &version, "health_protocolVersion")
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == methodNotFoundCode {
+		return ProtocolV1, nil
+	} else if err != nil {
+		return 0, err
+	}
+	if version > ProtocolV2 {
+		version = ProtocolV2
+	}
+	return version, nil
+}
+
+// methodNotFoundCode is the JSON-RPC error code of an unknown method.
+const methodNotFoundCode = -32601
@@ -3,39 +3,80 @@ package client
 import (
 	"context"
 	"crypto/tls"
-	"crypto/x509"
+	"errors"
 	"fmt"
 	"math/big"
 	"net/http"
-	"os"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 
 	ktls "github.com/kroma-network/kroma/utils/service/tls"
 	"github.com/kroma-network/kroma/utils/service/tls/certman"
 )
 
 type SignerClient struct {
-	client *rpc.Client
-	status string
-	logger log.Logger
+	endpoints     []endpoint
+	active        atomic.Int32
+	retryAttempts int
+	hedgeDelay    time.Duration
+	status        string
+	logger        log.Logger
+	// healthy is whether an endpoint answered the last ping.
+	healthy atomic.Bool
+	// requestKey signs the requests of the protocol v2, if set.
+	requestKey []byte
+
+	// certman watches the client certificate, if the tls config is set.
+	certman *certman.CertMan
+	// closing is closed by Close to stop the background work of the client.
+	closing   chan struct{}
+	closeOnce sync.Once
+}
+
+// NewSignerClient dials the signer at the comma-separated endpoints, without retries nor hedging.
+// See NewSignerClientFromConfig.
+func NewSignerClient(logger log.Logger, endpoint string, tlsConfig ktls.CLIConfig, reloadInterval time.Duration) (*SignerClient, error) {
+	return NewSignerClientFromConfig(logger, CLIConfig{Endpoint: endpoint, TLSConfig: tlsConfig, TLSReloadInterval: reloadInterval})
 }
 
-func NewSignerClient(logger log.Logger, endpoint string, tlsConfig ktls.CLIConfig) (*SignerClient, error) {
+// NewSignerClientFromConfig dials the signer at the comma-separated endpoints, failing over between them.
+// At least one of them must be reachable. If the tls config is set, the client authenticates with its
+// certificate, which is reloaded on change. If TLSReloadInterval is set, the CA bundle is also
+// re-read on the interval, so that it can be rotated without restart.
+// If HealthInterval is set, the endpoints are pinged on the interval for Healthy.
+// The protocol version of each reachable endpoint is negotiated, which must be at least MinProtocolVersion.
+// If RequestKeyFile is set, the requests are signed (protocol v2), and the endpoints must speak it.
+// The client must be closed to stop its background work.
+func NewSignerClientFromConfig(logger log.Logger, config CLIConfig) (_ *SignerClient, err error) {
+	signer := &SignerClient{
+		retryAttempts: config.RetryAttempts,
+		hedgeDelay:    config.HedgeDelay,
+		logger:        logger,
+		closing:       make(chan struct{}),
+	}
+	defer func() {
+		if err != nil {
+			signer.Close()
+		}
+	}()
+
+	tlsConfig, reloadInterval := config.TLSConfig, config.TLSReloadInterval
 	var httpClient *http.Client
 	if tlsConfig.TLSCaCert != "" {
 		logger.Info("tlsConfig specified, loading tls config")
-		caCert, err := os.ReadFile(tlsConfig.TLSCaCert)
+		caPool, err := newCAPool(tlsConfig.TLSCaCert)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read tls.ca: %w", err)
+			return nil, err
 		}
-		caCertPool := x509.NewCertPool()
-		caCertPool.AppendCertsFromPEM(caCert)
 
 		// certman watches for newer client certificates and automatically reloads them
 		cm, err := certman.New(logger, tlsConfig.TLSCert, tlsConfig.TLSKey)
@@ -47,47 +88,151 @@ func NewSignerClient(logger log.Logger, endpoint string, tlsConfig ktls.CLIConfi
 			logger.Error("failed to start certman watcher", "err", err)
 			return nil, err
 		}
+		signer.certman = cm
 
-		httpClient = &http.Client{
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{
-					MinVersion: tls.VersionTLS13,
-					RootCAs:    caCertPool,
-					GetClientCertificate: func(_ *tls.CertificateRequestInfo) (*tls.Certificate, error) {
-						return cm.GetCertificate(nil)
-					},
-				},
+		clientTLSConfig := &tls.Config{
+			MinVersion: tls.VersionTLS13,
+			RootCAs:    caPool.get(),
+			GetClientCertificate: func(_ *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+				return cm.GetCertificate(nil)
 			},
 		}
+		if reloadInterval != 0 {
+			// The RootCAs cannot be swapped, so verify the server against the latest CA bundle instead.
+			clientTLSConfig.RootCAs = nil
+			clientTLSConfig.InsecureSkipVerify = true
+			clientTLSConfig.VerifyConnection = caPool.verifyConnection
+			go reloadCAPool(logger, reloadInterval, caPool, signer.closing)
+		}
+		httpClient = &http.Client{
+			Transport: &http.Transport{TLSClientConfig: clientTLSConfig},
+		}
 	} else {
 		logger.Info("no tlsConfig specified, using default http client")
 		httpClient = http.DefaultClient
 	}
+	var requestKey []byte
+	if config.RequestKeyFile != "" {
+		if requestKey, err = ReadRequestKey(config.RequestKeyFile); err != nil {
+			return nil, err
+		}
+		transport := httpClient.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+		httpClient = &http.Client{Transport: &requestSigner{base: transport, key: requestKey, now: time.Now}}
+	}
+	minProtocol := config.MinProtocolVersion
+	if minProtocol == 0 {
+		minProtocol = ProtocolV1
+	}
+	if requestKey != nil {
+		// A signer of the protocol v1 would not check the request signatures, so that its requests
+		// could be replayed. It is refused, rather than warned about.
+		minProtocol = ProtocolV2
+	}
 
-	rpcClient, err := rpc.DialOptions(context.Background(), endpoint, rpc.WithHTTPClient(httpClient))
-	if err != nil {
-		return nil, err
+	urls := SplitEndpoints(config.Endpoint)
+	if len(urls) == 0 {
+		return nil, errors.New("no signer endpoint")
+	}
+	signer.endpoints = make([]endpoint, len(urls))
+	signer.requestKey = requestKey
+	if signer.retryAttempts < 1 {
+		signer.retryAttempts = 1
+	}
+	for i, url := range urls {
+		rpcClient, err := rpc.DialOptions(context.Background(), url, rpc.WithHTTPClient(httpClient))
+		if err != nil {
+			return nil, err
+		}
+		signer.endpoints[i] = endpoint{url: url, client: rpcClient}
 	}
 
-	signer := &SignerClient{logger: logger, client: rpcClient}
 	// Check if reachable
-	version, err := signer.pingVersion()
-	if err != nil {
+	for i := range signer.endpoints {
+		var version string
+		if version, err = signer.pingVersion(i); err != nil {
+			logger.Warn("signer endpoint is not reachable", "endpoint", signer.endpoints[i].url, "err", err)
+			continue
+		}
+		var protocol int
+		if protocol, err = signer.protocolVersion(i); err != nil {
+			logger.Warn("failed to negotiate the signer protocol version", "endpoint", signer.endpoints[i].url, "err", err)
+			continue
+		}
+		if protocol < minProtocol {
+			return nil, fmt.Errorf("signer %s speaks protocol v%d, v%d is required", signer.endpoints[i].url, protocol, minProtocol)
+		}
+		if signer.status == "" {
+			signer.active.Store(int32(i))
+			signer.status = fmt.Sprintf("ok [version=%v, protocol=v%d]", version, protocol)
+		}
+	}
+	if signer.status == "" {
 		return nil, err
 	}
-	signer.status = fmt.Sprintf("ok [version=%v]", version)
+	signer.healthy.Store(true)
+	if config.HealthInterval != 0 {
+		go signer.monitorHealth(config.HealthInterval)
+	}
 	return signer, nil
 }
 
-func NewSignerClientFromConfig(logger log.Logger, config CLIConfig) (*SignerClient, error) {
-	return NewSignerClient(logger, config.Endpoint, config.TLSConfig)
+// Close stops the background work of the client, i.e. the health monitor, the watch and the reloads
+// of the tls files, and closes the connections to the endpoints. It is safe to call more than once.
+func (s *SignerClient) Close() {
+	s.closeOnce.Do(func() {
+		close(s.closing)
+		if s.certman != nil {
+			s.certman.Stop()
+		}
+		for _, e := range s.endpoints {
+			if e.client != nil {
+				e.client.Close()
+			}
+		}
+	})
+}
+
+// Healthy returns whether a signer endpoint answered the last ping. It is true if the endpoints are not pinged.
+func (s *SignerClient) Healthy() bool {
+	return s.healthy.Load()
 }
 
-func (s *SignerClient) pingVersion() (string, error) {
+// monitorHealth pings the endpoints on the interval until Close. The signer is healthy if any of them answers.
+func (s *SignerClient) monitorHealth(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.closing:
+			return
+		case <-ticker.C:
+		}
+		var err error
+		healthy := false
+		for i := range s.endpoints {
+			if _, err = s.pingVersion(i); err == nil {
+				healthy = true
+				break
+			}
+		}
+		if prev := s.healthy.Swap(healthy); prev != healthy {
+			if healthy {
+				s.logger.Info("signer is reachable again")
+			} else {
+				s.logger.Error("no signer endpoint is reachable", "err", err)
+			}
+		}
+	}
+}
+
+func (s *SignerClient) pingVersion(index int) (string, error) {
 	var v string
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
 	defer cancel()
-	if err := s.client.CallContext(ctx, &v, "health_status"); err != nil {
+	if err := s.endpoints[index].client.CallContext(ctx, &v, "health_status"); err != nil {
 		return "", err
 	}
 	return v, nil
@@ -97,7 +242,7 @@ func (s *SignerClient) SignTransaction(ctx context.Context, chainId *big.Int, fr
 	args := NewTransactionArgsFromTransaction(chainId, from, tx)
 
 	var result hexutil.Bytes
-	if err := s.client.CallContext(ctx, &result, "eth_signTransaction", args); err != nil {
+	if err := s.call(ctx, &result, "eth_signTransaction", args); err != nil {
 		return nil, fmt.Errorf("eth_signTransaction failed: %w", err)
 	}
 
@@ -108,3 +253,34 @@ func (s *SignerClient) SignTransaction(ctx context.Context, chainId *big.Int, fr
 
 	return signed, nil
 }
+
+// SignTypedData requests the EIP-712 signature of the typed data by the from address. The signature
+// is in the [R || S || V] format, where V is 27 or 28. It is checked to be signed by the from address.
+func (s *SignerClient) SignTypedData(ctx context.Context, from common.Address, typedData apitypes.TypedData) ([]byte, error) {
+	var result hexutil.Bytes
+	if err := s.call(ctx, &result, "eth_signTypedData", from, typedData); err != nil {
+		return nil, fmt.Errorf("eth_signTypedData failed: %w", err)
+	}
+	if len(result) != crypto.SignatureLength {
+		return nil, fmt.Errorf("invalid signature length %d", len(result))
+	}
+	signature := []byte(result)
+	if signature[crypto.RecoveryIDOffset] < 27 {
+		signature[crypto.RecoveryIDOffset] += 27
+	}
+
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash the typed data: %w", err)
+	}
+	sig := common.CopyBytes(signature)
+	sig[crypto.RecoveryIDOffset] -= 27
+	pubKey, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover the signer: %w", err)
+	}
+	if signer := crypto.PubkeyToAddress(*pubKey); signer != from {
+		return nil, fmt.Errorf("typed data signed by %s, expected %s", signer, from)
+	}
+	return signature, nil
+}
@@ -0,0 +1,648 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+
+	"github.com/kroma-network/kroma/components/node/testlog"
+	ktls "github.com/kroma-network/kroma/utils/service/tls"
+)
+
+type healthService struct{}
+
+func (h *healthService) Status() string {
+	return "v1.0.0"
+}
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func newTestCert(t *testing.T, template *x509.Certificate, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	parentCert, parentKey := template, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func newTestCA(t *testing.T, name string) *testCert {
+	return newTestCert(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}, nil)
+}
+
+func newTestLeafCert(t *testing.T, ca *testCert, usage x509.ExtKeyUsage) *testCert {
+	return newTestCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}, ca)
+}
+
+// writeTLSConfig writes the given certs into files and returns the tls config pointing to them.
+func writeTLSConfig(t *testing.T, ca *testCert, client *testCert) ktls.CLIConfig {
+	dir := t.TempDir()
+	cfg := ktls.CLIConfig{
+		TLSCaCert: filepath.Join(dir, "ca.crt"),
+		TLSCert:   filepath.Join(dir, "tls.crt"),
+		TLSKey:    filepath.Join(dir, "tls.key"),
+	}
+	require.NoError(t, os.WriteFile(cfg.TLSCaCert, ca.certPEM, 0o600))
+	require.NoError(t, os.WriteFile(cfg.TLSCert, client.certPEM, 0o600))
+	require.NoError(t, os.WriteFile(cfg.TLSKey, client.keyPEM, 0o600))
+	return cfg
+}
+
+// newDiscardLogger returns the logger of the clients with tls, whose watchers may log while
+// stopping after the test, so they must not log to it.
+func newDiscardLogger() log.Logger {
+	logger := log.New()
+	logger.SetHandler(log.DiscardHandler())
+	return logger
+}
+
+func TestSignerClientMutualTLS(t *testing.T) {
+	ca := newTestCA(t, "signer-ca")
+	serverCert := newTestLeafCert(t, ca, x509.ExtKeyUsageServerAuth)
+	clientCert := newTestLeafCert(t, ca, x509.ExtKeyUsageClientAuth)
+	untrustedCA := newTestCA(t, "untrusted-ca")
+	untrustedClientCert := newTestLeafCert(t, untrustedCA, x509.ExtKeyUsageClientAuth)
+
+	rpcServer := rpc.NewServer()
+	require.NoError(t, rpcServer.RegisterName("health", &healthService{}))
+	defer rpcServer.Stop()
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+	server := httptest.NewUnstartedServer(rpcServer)
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{{
+			Certificate: [][]byte{serverCert.cert.Raw},
+			PrivateKey:  serverCert.key,
+		}},
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	logger := newDiscardLogger()
+
+	t.Run("valid client cert", func(t *testing.T) {
+		client, err := NewSignerClient(logger, server.URL, writeTLSConfig(t, ca, clientCert), 0)
+		require.NoError(t, err)
+		defer client.Close()
+		require.Equal(t, "ok [version=v1.0.0, protocol=v1]", client.status)
+	})
+
+	t.Run("client cert without client auth usage", func(t *testing.T) {
+		_, err := NewSignerClient(logger, server.URL, writeTLSConfig(t, ca, serverCert), 0)
+		require.ErrorContains(t, err, "tls: ")
+	})
+
+	t.Run("untrusted client cert", func(t *testing.T) {
+		_, err := NewSignerClient(logger, server.URL, writeTLSConfig(t, ca, untrustedClientCert), 0)
+		require.ErrorContains(t, err, "tls: ")
+	})
+}
+
+// TestSignerClientTLSRotation asserts that the rotated tls files are used by the new connections
+// once reloaded, the ca file on the reload interval and the cert and key files on change.
+func TestSignerClientTLSRotation(t *testing.T) {
+	oldCA := newTestCA(t, "old-ca")
+	newCA := newTestCA(t, "new-ca")
+	serverCert := &atomic.Pointer[tls.Certificate]{}
+	setServerCert := func(ca *testCert) {
+		cert := newTestLeafCert(t, ca, x509.ExtKeyUsageServerAuth)
+		serverCert.Store(&tls.Certificate{Certificate: [][]byte{cert.cert.Raw}, PrivateKey: cert.key})
+	}
+	setServerCert(oldCA)
+
+	rpcServer := rpc.NewServer()
+	require.NoError(t, rpcServer.RegisterName("health", &healthService{}))
+	defer rpcServer.Stop()
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(oldCA.cert)
+	clientCAs.AddCert(newCA.cert)
+	server := httptest.NewUnstartedServer(rpcServer)
+	server.TLS = &tls.Config{
+		// httptest sets its own certificate, which is preferred to GetCertificate without SNI.
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return &tls.Config{
+				Certificates: []tls.Certificate{*serverCert.Load()},
+				ClientAuth:   tls.RequireAndVerifyClientCert,
+				ClientCAs:    clientCAs,
+			}, nil
+		},
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	logger := newDiscardLogger()
+	for _, reloadInterval := range []time.Duration{0, 50 * time.Millisecond} {
+		setServerCert(oldCA)
+		tlsConfig := writeTLSConfig(t, oldCA, newTestLeafCert(t, oldCA, x509.ExtKeyUsageClientAuth))
+		client, err := NewSignerClient(logger, server.URL, tlsConfig, reloadInterval)
+		require.NoError(t, err)
+		defer client.Close()
+
+		// Rotate the CA of the signer, and the client cert and ca files in place.
+		newClientCert := newTestLeafCert(t, newCA, x509.ExtKeyUsageClientAuth)
+		require.NoError(t, os.WriteFile(tlsConfig.TLSCaCert, newCA.certPEM, 0o600))
+		require.NoError(t, os.WriteFile(tlsConfig.TLSCert, newClientCert.certPEM, 0o600))
+		require.NoError(t, os.WriteFile(tlsConfig.TLSKey, newClientCert.keyPEM, 0o600))
+		setServerCert(newCA)
+
+		ping := func() bool {
+			server.CloseClientConnections()
+			_, err := client.pingVersion(0)
+			return err == nil
+		}
+		if reloadInterval == 0 {
+			require.False(t, ping(), "the rotated ca must not be trusted without reloading")
+		} else {
+			require.Eventually(t, ping, 5*time.Second, 50*time.Millisecond)
+		}
+	}
+}
+
+// signerService mimics the eth_signTypedData and eth_signTransaction of the signer with a local key.
+type signerService struct {
+	key   *ecdsa.PrivateKey
+	delay time.Duration
+	err   error
+	calls atomic.Int32
+}
+
+func (s *signerService) SignTypedData(_ common.Address, typedData apitypes.TypedData) (hexutil.Bytes, error) {
+	s.calls.Add(1)
+	time.Sleep(s.delay)
+	if s.err != nil {
+		return nil, s.err
+	}
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Sign(hash, s.key)
+}
+
+func (s *signerService) SignTransaction(args TransactionArgs) (hexutil.Bytes, error) {
+	s.calls.Add(1)
+	if s.err != nil {
+		return nil, s.err
+	}
+	tx := args.ToTransaction()
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(args.ChainID.ToInt()), s.key)
+	if err != nil {
+		return nil, err
+	}
+	return signed.MarshalBinary()
+}
+
+func newTestTypedData() apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+			},
+			"Attestation": {
+				{Name: "subject", Type: "address"},
+				{Name: "outputRoot", Type: "bytes32"},
+			},
+		},
+		PrimaryType: "Attestation",
+		Domain: apitypes.TypedDataDomain{
+			Name:    "Kroma",
+			ChainId: math.NewHexOrDecimal256(900),
+		},
+		Message: apitypes.TypedDataMessage{
+			"subject":    "0x0000000000000000000000000000000000000042",
+			"outputRoot": "0x0101010101010101010101010101010101010101010101010101010101010101",
+		},
+	}
+}
+
+func newTestSignerServer(t *testing.T, service *signerService) *httptest.Server {
+	rpcServer := rpc.NewServer()
+	require.NoError(t, rpcServer.RegisterName("health", &healthService{}))
+	require.NoError(t, rpcServer.RegisterName("eth", service))
+	t.Cleanup(rpcServer.Stop)
+	server := httptest.NewServer(rpcServer)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSignerClientSignTypedData(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	server := newTestSignerServer(t, &signerService{key: key})
+
+	client, err := NewSignerClient(testlog.Logger(t, log.LvlInfo), server.URL, ktls.CLIConfig{}, 0)
+	require.NoError(t, err)
+	defer client.Close()
+
+	typedData := newTestTypedData()
+	signature, err := client.SignTypedData(context.Background(), from, typedData)
+	require.NoError(t, err)
+	require.Contains(t, []byte{27, 28}, signature[crypto.RecoveryIDOffset])
+
+	_, err = client.SignTypedData(context.Background(), common.Address{1}, typedData)
+	require.ErrorContains(t, err, "expected")
+}
+
+// TestSignerClientFailover asserts that the requests fail over to the next endpoint if one is down,
+// and are hedged to it if one is slow, but not if a signer refuses to sign.
+func TestSignerClientFailover(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	typedData := newTestTypedData()
+	logger := testlog.Logger(t, log.LvlInfo)
+
+	t.Run("down endpoint", func(t *testing.T) {
+		down := newTestSignerServer(t, &signerService{key: key})
+		up := &signerService{key: key}
+		client, err := NewSignerClientFromConfig(logger, CLIConfig{Endpoint: down.URL + "," + newTestSignerServer(t, up).URL})
+		require.NoError(t, err)
+		defer client.Close()
+		down.Close()
+
+		_, err = client.SignTypedData(context.Background(), from, typedData)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, client.active.Load())
+		require.EqualValues(t, 1, up.calls.Load())
+	})
+
+	t.Run("slow endpoint", func(t *testing.T) {
+		slow := &signerService{key: key, delay: time.Second}
+		fast := &signerService{key: key}
+		client, err := NewSignerClientFromConfig(logger, CLIConfig{
+			Endpoint:   newTestSignerServer(t, slow).URL + "," + newTestSignerServer(t, fast).URL,
+			HedgeDelay: 50 * time.Millisecond,
+		})
+		require.NoError(t, err)
+		defer client.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		defer cancel()
+		_, err = client.SignTypedData(ctx, from, typedData)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, fast.calls.Load())
+	})
+
+	t.Run("refused", func(t *testing.T) {
+		refusing := &signerService{key: key, err: errors.New("policy violation")}
+		other := &signerService{key: key}
+		client, err := NewSignerClientFromConfig(logger, CLIConfig{
+			Endpoint:      newTestSignerServer(t, refusing).URL + "," + newTestSignerServer(t, other).URL,
+			RetryAttempts: 3,
+		})
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.SignTypedData(context.Background(), from, typedData)
+		require.ErrorContains(t, err, "policy violation")
+		require.EqualValues(t, 1, refusing.calls.Load())
+		require.Zero(t, other.calls.Load())
+	})
+
+	t.Run("all down", func(t *testing.T) {
+		down := newTestSignerServer(t, &signerService{key: key})
+		client, err := NewSignerClientFromConfig(logger, CLIConfig{Endpoint: down.URL, RetryAttempts: 2})
+		require.NoError(t, err)
+		defer client.Close()
+		down.Close()
+
+		_, err = client.SignTypedData(context.Background(), from, typedData)
+		require.ErrorContains(t, err, "after 2 attempts")
+	})
+}
+
+// TestSignerClientHealthy asserts that the signer is reported unhealthy once no endpoint answers
+// the pings, and healthy again once one does.
+func TestSignerClientHealthy(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	rpcServer := rpc.NewServer()
+	require.NoError(t, rpcServer.RegisterName("health", &healthService{}))
+	require.NoError(t, rpcServer.RegisterName("eth", &signerService{key: key}))
+	t.Cleanup(rpcServer.Stop)
+	var down atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		rpcServer.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewSignerClientFromConfig(newDiscardLogger(), CLIConfig{Endpoint: server.URL, HealthInterval: 10 * time.Millisecond})
+	require.NoError(t, err)
+	defer client.Close()
+	require.True(t, client.Healthy())
+
+	down.Store(true)
+	require.Eventually(t, func() bool { return !client.Healthy() }, 5*time.Second, 10*time.Millisecond)
+	down.Store(false)
+	require.Eventually(t, client.Healthy, 5*time.Second, 10*time.Millisecond)
+}
+
+// v2HealthService is the health service of a signer of the protocol v2.
+type v2HealthService struct {
+	healthService
+}
+
+func (h *v2HealthService) ProtocolVersion() int {
+	return ProtocolV2
+}
+
+// verifyingHandler serves the rpc server to the requests signed with the key, rejecting the replayed ones.
+type verifyingHandler struct {
+	rpcServer *rpc.Server
+	key       []byte
+
+	mu       sync.Mutex
+	nonces   map[string]bool
+	requests [][]byte
+	headers  []http.Header
+}
+
+func (h *verifyingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.mu.Lock()
+	h.requests = append(h.requests, body)
+	h.headers = append(h.headers, r.Header.Clone())
+	timestamp, nonce := r.Header.Get(TimestampHeader), r.Header.Get(NonceHeader)
+	seen := h.nonces[nonce]
+	h.nonces[nonce] = true
+	h.mu.Unlock()
+
+	signature := RequestSignature(h.key, timestamp, nonce, r.Method, r.URL.EscapedPath(), body)
+	unix, _ := strconv.ParseInt(timestamp, 10, 64)
+	switch {
+	case r.Header.Get(ProtocolHeader) != "2" || !hmac.Equal([]byte(signature), []byte(r.Header.Get(SignatureHeader))):
+		http.Error(w, "invalid request signature", http.StatusUnauthorized)
+	case time.Since(time.Unix(unix, 0)).Abs() > time.Minute:
+		http.Error(w, "stale request", http.StatusUnauthorized)
+	case seen:
+		http.Error(w, "replayed request", http.StatusUnauthorized)
+	default:
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		h.rpcServer.ServeHTTP(w, r)
+	}
+}
+
+func writeRequestKey(t *testing.T) ([]byte, string) {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "request.key")
+	require.NoError(t, os.WriteFile(path, []byte(hex.EncodeToString(key)+"\n"), 0o600))
+	return key, path
+}
+
+func TestSignerClientRequestSigning(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	requestKey, requestKeyFile := writeRequestKey(t)
+	rpcServer := rpc.NewServer()
+	require.NoError(t, rpcServer.RegisterName("health", &v2HealthService{}))
+	require.NoError(t, rpcServer.RegisterName("eth", &signerService{key: key}))
+	t.Cleanup(rpcServer.Stop)
+	handler := &verifyingHandler{rpcServer: rpcServer, key: requestKey, nonces: make(map[string]bool)}
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewSignerClientFromConfig(newDiscardLogger(), CLIConfig{
+		Endpoint:           server.URL,
+		RequestKeyFile:     requestKeyFile,
+		MinProtocolVersion: ProtocolV2,
+	})
+	require.NoError(t, err)
+	defer client.Close()
+	require.Equal(t, "ok [version=v1.0.0, protocol=v2]", client.status)
+
+	chainID := big.NewInt(900)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 1, To: &from, Gas: 21000, GasTipCap: common.Big1, GasFeeCap: common.Big2})
+	_, err = client.SignTransaction(context.Background(), chainID, from, tx)
+	require.NoError(t, err)
+
+	// A captured request cannot be replayed, nor altered.
+	handler.mu.Lock()
+	captured, headers := handler.requests[len(handler.requests)-1], handler.headers[len(handler.headers)-1]
+	handler.mu.Unlock()
+	replay := func(body []byte) int {
+		req, err := http.NewRequest(http.MethodPost, server.URL, bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header = headers
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp.StatusCode
+	}
+	require.Equal(t, http.StatusUnauthorized, replay(captured))
+	headers.Set(NonceHeader, "00")
+	require.Equal(t, http.StatusUnauthorized, replay(captured))
+	require.Equal(t, http.StatusUnauthorized, replay(bytes.Replace(captured, []byte(`"nonce":"0x1"`), []byte(`"nonce":"0x2"`), 1)))
+}
+
+// TestSignerClientProtocolNegotiation asserts that the signer of the protocol v1 is still used
+// without a request key, but refused with one, as it does not check the request signatures.
+func TestSignerClientProtocolNegotiation(t *testing.T) {
+	_, requestKeyFile := writeRequestKey(t)
+	rpcServer := rpc.NewServer()
+	require.NoError(t, rpcServer.RegisterName("health", &healthService{}))
+	t.Cleanup(rpcServer.Stop)
+	var signed atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signed.Store(r.Header.Get(SignatureHeader) != "")
+		rpcServer.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewSignerClientFromConfig(newDiscardLogger(), CLIConfig{Endpoint: server.URL})
+	require.NoError(t, err)
+	defer client.Close()
+	require.Equal(t, "ok [version=v1.0.0, protocol=v1]", client.status)
+	require.False(t, signed.Load())
+
+	_, err = NewSignerClientFromConfig(newDiscardLogger(), CLIConfig{Endpoint: server.URL, RequestKeyFile: requestKeyFile})
+	require.ErrorContains(t, err, "speaks protocol v1, v2 is required")
+	require.True(t, signed.Load())
+
+	// Nor if the config allowing the protocol v1 is not checked.
+	_, err = NewSignerClientFromConfig(newDiscardLogger(), CLIConfig{
+		Endpoint:           server.URL,
+		RequestKeyFile:     requestKeyFile,
+		MinProtocolVersion: ProtocolV1,
+	})
+	require.ErrorContains(t, err, "speaks protocol v1, v2 is required")
+}
+
+func TestReadRequestKey(t *testing.T) {
+	key, path := writeRequestKey(t)
+	read, err := ReadRequestKey(path)
+	require.NoError(t, err)
+	require.Equal(t, key, read)
+
+	require.NoError(t, os.WriteFile(path, []byte("0x0102"), 0o600))
+	_, err = ReadRequestKey(path)
+	require.ErrorContains(t, err, "at least 32 bytes")
+	require.NoError(t, os.WriteFile(path, []byte("key"), 0o600))
+	_, err = ReadRequestKey(path)
+	require.ErrorContains(t, err, "invalid signer request key")
+}
+
+func TestFailoverDrill(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	primary := newTestSignerServer(t, &signerService{key: key})
+	secondary := newTestSignerServer(t, &signerService{key: otherKey})
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	results := FailoverDrill(context.Background(), newDiscardLogger(), CLIConfig{
+		Endpoint: primary.URL + "," + secondary.URL + ",http://127.0.0.1:1",
+		Address:  from.Hex(),
+	}, big.NewInt(900), time.Minute)
+	require.Len(t, results, 3)
+	require.NoError(t, results[0].Err)
+	require.Equal(t, from, results[0].Address)
+	require.ErrorContains(t, results[1].Err, "expected "+from.Hex())
+	require.Equal(t, crypto.PubkeyToAddress(otherKey.PublicKey), results[1].Address)
+	require.ErrorContains(t, results[2].Err, "failed to connect")
+
+	results = FailoverDrill(context.Background(), newDiscardLogger(), CLIConfig{Endpoint: primary.URL, Address: from.Hex()}, big.NewInt(900), time.Nanosecond)
+	require.ErrorContains(t, results[0].Err, "more than 1ns")
+}
+
+func TestFailoverDrillCommand(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	server := newTestSignerServer(t, &signerService{key: key})
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	var out bytes.Buffer
+	app := cli.NewApp()
+	app.Writer = &out
+	app.Commands = []cli.Command{FailoverDrillCommand("SIGNER_DRILL_TEST")}
+	run := func(args ...string) error {
+		return app.Run(append([]string{"app", "signer-drill", "--signer.endpoint=" + server.URL}, args...))
+	}
+	require.NoError(t, run("--signer.address="+from.Hex(), "--chain-id=900"))
+	require.Contains(t, out.String(), "OK   "+server.URL+": signed by "+from.Hex())
+
+	require.ErrorContains(t, run("--signer.address="+from.Hex()), "chain id must be set")
+	require.ErrorContains(t, run("--signer.address=0x42", "--chain-id=900"), "failover drill failed for 1 of 1")
+}
+
+func TestCLIConfigCheck(t *testing.T) {
+	tlsConfig := ktls.CLIConfig{
+		TLSCaCert: "tls/ca.crt",
+		TLSCert:   "tls/tls.crt",
+		TLSKey:    "tls/tls.key",
+	}
+	cfg := CLIConfig{Endpoint: "https://localhost:8080", Address: "0x42", TLSConfig: tlsConfig}
+	require.NoError(t, cfg.Check())
+
+	cfg.TLSConfig = ktls.CLIConfig{}
+	require.NoError(t, cfg.Check())
+
+	for _, partial := range []ktls.CLIConfig{
+		{TLSCaCert: tlsConfig.TLSCaCert},
+		{TLSCert: tlsConfig.TLSCert, TLSKey: tlsConfig.TLSKey},
+		{TLSCaCert: tlsConfig.TLSCaCert, TLSCert: tlsConfig.TLSCert},
+	} {
+		cfg.TLSConfig = partial
+		require.ErrorContains(t, cfg.Check(), "all tls flags must be set")
+	}
+
+	cfg.TLSConfig = tlsConfig
+	cfg.TLSReloadInterval = -time.Second
+	require.ErrorContains(t, cfg.Check(), "reload interval")
+
+	cfg.TLSReloadInterval = 0
+	cfg.MinProtocolVersion = ProtocolV2
+	require.ErrorContains(t, cfg.Check(), "requires the signer request key file")
+	cfg.RequestKeyFile = "request.key"
+	require.NoError(t, cfg.Check())
+	cfg.MinProtocolVersion = 0
+	require.NoError(t, cfg.Check())
+	cfg.MinProtocolVersion = ProtocolV1
+	require.ErrorContains(t, cfg.Check(), "requires the signer protocol v2")
+	cfg.MinProtocolVersion = 3
+	require.ErrorContains(t, cfg.Check(), "invalid signer min protocol version")
+}
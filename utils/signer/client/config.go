@@ -2,6 +2,8 @@ package client
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/urfave/cli"
 
@@ -10,16 +12,53 @@ import (
 )
 
 const (
-	EndpointFlagName = "signer.endpoint"
-	AddressFlagName  = "signer.address"
+	EndpointFlagName          = "signer.endpoint"
+	AddressFlagName           = "signer.address"
+	BackendFlagName           = "signer.backend"
+	TLSReloadIntervalFlagName = "signer.tls.reload-interval"
+	RetryAttemptsFlagName     = "signer.retry-attempts"
+	HedgeDelayFlagName        = "signer.hedge-delay"
+	HealthIntervalFlagName    = "signer.health-interval"
+	RequestKeyFileFlagName    = "signer.request-key-file"
+	MinProtocolFlagName       = "signer.min-protocol-version"
 )
 
+// Backend is the backend signing the transactions instead of a local key.
+type Backend string
+
+const (
+	// BackendRemote signs with the remote signer at the signer endpoint, if set.
+	BackendRemote Backend = "remote"
+	// BackendGCPKMS signs with the Google Cloud KMS key version of the kms.gcp-key-name flag.
+	BackendGCPKMS Backend = "gcpkms"
+	// BackendAzureKV signs with the Azure Key Vault or Managed HSM key of the kms.azure-key-id flag.
+	BackendAzureKV Backend = "azurekv"
+	// BackendLedger signs with a Ledger connected over USB, confirming each transaction on the device.
+	BackendLedger Backend = "ledger"
+	// BackendTrezor signs with an unlocked Trezor connected over USB, confirming each transaction on the device.
+	BackendTrezor Backend = "trezor"
+)
+
+func (b Backend) Check() error {
+	switch b {
+	case BackendRemote, BackendGCPKMS, BackendAzureKV, BackendLedger, BackendTrezor:
+		return nil
+	default:
+		return fmt.Errorf("invalid signer backend: %s", b)
+	}
+}
+
+// IsHardware returns whether the backend is a hardware wallet.
+func (b Backend) IsHardware() bool {
+	return b == BackendLedger || b == BackendTrezor
+}
+
 func CLIFlags(envPrefix string) []cli.Flag {
 	envPrefix += "_SIGNER"
 	flags := []cli.Flag{
 		cli.StringFlag{
 			Name:   EndpointFlagName,
-			Usage:  "Signer endpoint the client will connect to",
+			Usage:  "Signer endpoint the client will connect to. Several comma-separated endpoints of the same signer may be given, which are failed over in order",
 			EnvVar: kservice.PrefixEnvVar(envPrefix, "ENDPOINT"),
 		},
 		cli.StringFlag{
@@ -27,21 +66,97 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			Usage:  "Address the signer is signing transactions for",
 			EnvVar: kservice.PrefixEnvVar(envPrefix, "ADDRESS"),
 		},
+		cli.StringFlag{
+			Name:   BackendFlagName,
+			Usage:  "Backend signing the transactions: remote (the remote signer at the signer endpoint, if set), gcpkms (the Google Cloud KMS key of kms.gcp-key-name), azurekv (the Azure Key Vault key of kms.azure-key-id), ledger or trezor (the hardware wallet key of hd-path, confirming each transaction on the device)",
+			Value:  string(BackendRemote),
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "BACKEND"),
+		},
+		cli.IntFlag{
+			Name:   RetryAttemptsFlagName,
+			Usage:  "Number of attempts of a signing request, with backoff, once all the signer endpoints failed to answer it",
+			Value:  3,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "RETRY_ATTEMPTS"),
+		},
+		cli.DurationFlag{
+			Name:   HedgeDelayFlagName,
+			Usage:  "Delay after which a signing request still unanswered is also sent to the next signer endpoint, the first answer winning. If 0, the next endpoint is only tried once the previous one failed",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "HEDGE_DELAY"),
+		},
+		cli.DurationFlag{
+			Name:   HealthIntervalFlagName,
+			Usage:  "Interval at which the signer endpoints are pinged, the service being reported as not ready while none of them answers. If 0, they are not pinged",
+			Value:  10 * time.Second,
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "HEALTH_INTERVAL"),
+		},
+		cli.StringFlag{
+			Name:   RequestKeyFileFlagName,
+			Usage:  "Path of the file holding the hex-encoded key shared with the signer, which signs each request with a timestamp and a nonce (signer protocol v2), so that a captured request cannot be replayed",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "REQUEST_KEY_FILE"),
+		},
+		cli.IntFlag{
+			Name:   MinProtocolFlagName,
+			Usage:  "Minimum signer protocol version the signer endpoints must speak, which is negotiated when connecting: 1, or 2 to refuse the signers not checking the request signatures. 2 requires the request key file, with which 1 is not allowed. If 0, it is 2 with the request key file, and 1 otherwise",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "MIN_PROTOCOL_VERSION"),
+		},
 	}
 	flags = append(flags, ktls.CLIFlagsWithFlagPrefix(envPrefix, "signer")...)
+	flags = append(flags, cli.DurationFlag{
+		Name:   TLSReloadIntervalFlagName,
+		Usage:  "Interval at which the tls ca file is re-read, so that it can be rotated without restart. The cert and key files are reloaded on change regardless. If 0, the ca file is not re-read",
+		EnvVar: kservice.PrefixEnvVar(envPrefix, "TLS_RELOAD_INTERVAL"),
+	})
 	return flags
 }
 
 type CLIConfig struct {
 	Endpoint  string
 	Address   string
+	Backend   Backend
 	TLSConfig ktls.CLIConfig
+	// TLSReloadInterval is the interval at which the tls ca file is re-read. If 0, it is not.
+	TLSReloadInterval time.Duration
+	// RetryAttempts is the number of attempts of a signing request over all the endpoints.
+	RetryAttempts int
+	// HedgeDelay is the delay after which a pending request is also sent to the next endpoint. If 0, it is not.
+	HedgeDelay time.Duration
+	// HealthInterval is the interval at which the endpoints are pinged for Healthy. If 0, they are not.
+	HealthInterval time.Duration
+	// RequestKeyFile is the file of the key signing the requests of the protocol v2. If empty, they are not signed.
+	RequestKeyFile string
+	// MinProtocolVersion is the minimum protocol version of the endpoints. If 0, it is ProtocolV2 with
+	// a RequestKeyFile, and ProtocolV1 otherwise. It cannot be ProtocolV1 with a RequestKeyFile, so that
+	// the signed requests are never sent to a signer not checking them.
+	MinProtocolVersion int
 }
 
 func (c CLIConfig) Check() error {
 	if err := c.TLSConfig.Check(); err != nil {
 		return err
 	}
+	if c.Backend != "" {
+		if err := c.Backend.Check(); err != nil {
+			return err
+		}
+	}
+	if c.TLSReloadInterval < 0 {
+		return errors.New("signer tls reload interval must not be negative")
+	}
+	if c.RetryAttempts < 0 || c.HedgeDelay < 0 || c.HealthInterval < 0 {
+		return errors.New("signer retry attempts, hedge delay and health interval must not be negative")
+	}
+	if c.MinProtocolVersion < 0 || c.MinProtocolVersion > ProtocolV2 {
+		return fmt.Errorf("invalid signer min protocol version %d", c.MinProtocolVersion)
+	}
+	if c.MinProtocolVersion == ProtocolV2 && c.RequestKeyFile == "" {
+		return errors.New("signer protocol v2 requires the signer request key file")
+	}
+	if c.MinProtocolVersion == ProtocolV1 && c.RequestKeyFile != "" {
+		return errors.New("signer request key file requires the signer protocol v2")
+	}
+	if c.Backend != "" && c.Backend != BackendRemote && c.Endpoint != "" {
+		return fmt.Errorf("signer endpoint must not be set with the %s signer backend", c.Backend)
+	}
 	if !((c.Endpoint == "" && c.Address == "") || (c.Endpoint != "" && c.Address != "")) {
 		return errors.New("signer endpoint and address must both be set or not set")
 	}
@@ -57,9 +172,16 @@ func (c CLIConfig) Enabled() bool {
 
 func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 	cfg := CLIConfig{
-		Endpoint:  ctx.String(EndpointFlagName),
-		Address:   ctx.String(AddressFlagName),
-		TLSConfig: ktls.ReadCLIConfigWithPrefix(ctx, "signer"),
+		Endpoint:           ctx.String(EndpointFlagName),
+		Address:            ctx.String(AddressFlagName),
+		Backend:            Backend(ctx.String(BackendFlagName)),
+		TLSConfig:          ktls.ReadCLIConfigWithPrefix(ctx, "signer"),
+		TLSReloadInterval:  ctx.Duration(TLSReloadIntervalFlagName),
+		RetryAttempts:      ctx.Int(RetryAttemptsFlagName),
+		HedgeDelay:         ctx.Duration(HedgeDelayFlagName),
+		HealthInterval:     ctx.Duration(HealthIntervalFlagName),
+		RequestKeyFile:     ctx.String(RequestKeyFileFlagName),
+		MinProtocolVersion: ctx.Int(MinProtocolFlagName),
 	}
 	return cfg
 }
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli"
+)
+
+const (
+	drillChainIDFlagName    = "chain-id"
+	drillMaxLatencyFlagName = "max-latency"
+)
+
+// DrillResult is the outcome of the failover drill of a signer endpoint.
+type DrillResult struct {
+	Endpoint string
+	// Address is the address which signed the drill tx.
+	Address common.Address
+	Latency time.Duration
+	Err     error
+}
+
+// FailoverDrillCommand returns the command rehearsing a failover of the remote signer configured by
+// the signer flags, e.g. before a key loss: each endpoint signs a tx in turn, as if the others failed.
+// The txs are never broadcast. The signer flags are read from the environment of the service like
+// the service does, so that the drill runs against its production configuration.
+func FailoverDrillCommand(envPrefix string) cli.Command {
+	return cli.Command{
+		Name:  "signer-drill",
+		Usage: "Rehearse a failover of the remote signer by signing a tx, never broadcast, with each of its endpoints",
+		Flags: append([]cli.Flag{
+			cli.Uint64Flag{
+				Name:  drillChainIDFlagName,
+				Usage: "Chain ID the drill txs are signed for",
+			},
+			cli.DurationFlag{
+				Name:  drillMaxLatencyFlagName,
+				Usage: "Maximum signing latency of an endpoint",
+				Value: 2 * time.Second,
+			},
+		}, CLIFlags(envPrefix)...),
+		Action: runFailoverDrill,
+	}
+}
+
+func runFailoverDrill(ctx *cli.Context) error {
+	config := ReadCLIConfig(ctx)
+	if err := config.Check(); err != nil {
+		return err
+	}
+	if !config.Enabled() {
+		return errors.New("the signer endpoint and address must be set")
+	}
+	if !ctx.IsSet(drillChainIDFlagName) {
+		return errors.New("the chain id must be set")
+	}
+	chainID := new(big.Int).SetUint64(ctx.Uint64(drillChainIDFlagName))
+
+	results := FailoverDrill(context.Background(), log.Root(), config, chainID, ctx.Duration(drillMaxLatencyFlagName))
+	failed := 0
+	for _, res := range results {
+		if res.Err != nil {
+			failed++
+			fmt.Fprintf(ctx.App.Writer, "FAIL %s: %v\n", res.Endpoint, res.Err)
+		} else {
+			fmt.Fprintf(ctx.App.Writer, "OK   %s: signed by %s in %s\n", res.Endpoint, res.Address, res.Latency)
+		}
+	}
+	if failed != 0 {
+		return fmt.Errorf("failover drill failed for %d of %d signer endpoints", failed, len(results))
+	}
+	return nil
+}
+
+// FailoverDrill signs a drill tx with each of the signer endpoints on its own, without retries,
+// and checks that it is signed by the signer address within the max latency.
+func FailoverDrill(ctx context.Context, logger log.Logger, config CLIConfig, chainID *big.Int, maxLatency time.Duration) []DrillResult {
+	from := common.HexToAddress(config.Address)
+	urls := SplitEndpoints(config.Endpoint)
+	results := make([]DrillResult, len(urls))
+	for i, url := range urls {
+		results[i] = drillEndpoint(ctx, logger, config, url, chainID, from)
+		if results[i].Err == nil && maxLatency != 0 && results[i].Latency > maxLatency {
+			results[i].Err = fmt.Errorf("signing took %s, more than %s", results[i].Latency, maxLatency)
+		}
+	}
+	return results
+}
+
+func drillEndpoint(ctx context.Context, logger log.Logger, config CLIConfig, url string, chainID *big.Int, from common.Address) DrillResult {
+	res := DrillResult{Endpoint: url}
+	config.Endpoint = url
+	config.RetryAttempts = 1
+	config.HedgeDelay = 0
+	config.HealthInterval = 0
+	client, err := NewSignerClientFromConfig(logger, config)
+	if err != nil {
+		res.Err = fmt.Errorf("failed to connect: %w", err)
+		return res
+	}
+	defer client.Close()
+
+	start := time.Now()
+	signed, err := client.SignTransaction(ctx, chainID, from, newDrillTx(chainID, from))
+	res.Latency = time.Since(start)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	if res.Address, err = types.Sender(types.LatestSignerForChainID(chainID), signed); err != nil {
+		res.Err = fmt.Errorf("invalid signature: %w", err)
+	} else if res.Address != from {
+		res.Err = fmt.Errorf("signed by %s, expected %s", res.Address, from)
+	}
+	return res
+}
+
+// newDrillTx returns the tx signed by the drill: a transfer of nothing to the signer itself, which
+// can never be included, having no fees and the highest nonce.
+func newDrillTx(chainID *big.Int, from common.Address) *types.Transaction {
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     math.MaxUint64 - 1,
+		GasTipCap: new(big.Int),
+		GasFeeCap: new(big.Int),
+		Gas:       21000,
+		To:        &from,
+		Value:     new(big.Int),
+	})
+}
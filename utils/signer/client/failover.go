@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/kroma-network/kroma/utils/service/backoff"
+)
+
+// SplitEndpoints splits the comma-separated signer endpoints.
+func SplitEndpoints(endpoints string) []string {
+	var urls []string
+	for _, url := range strings.Split(endpoints, ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
+
+type endpoint struct {
+	url    string
+	client *rpc.Client
+}
+
+type response struct {
+	index  int
+	result json.RawMessage
+	err    error
+}
+
+// call calls the method on the signer endpoints, starting with the last one which answered.
+// An endpoint failing to answer is failed over to the next one, and once all of them failed,
+// the call is retried with backoff up to retryAttempts times. If hedgeDelay is set, the next
+// endpoint is also called if the pending ones do not answer within it, and the first answer wins.
+// An error answered by a signer, e.g. a refusal to sign, is returned without retrying.
+func (s *SignerClient) call(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	var raw json.RawMessage
+	var answered error
+	err := backoff.DoCtx(ctx, s.retryAttempts, backoff.Exponential(), func() error {
+		res, err := s.callEndpoints(ctx, method, args...)
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) {
+			answered = err
+			return nil
+		}
+		raw = res
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if answered != nil {
+		return answered
+	}
+	return json.Unmarshal(raw, result)
+}
+
+func (s *SignerClient) callEndpoints(ctx context.Context, method string, args ...interface{}) (json.RawMessage, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	responses := make(chan response, len(s.endpoints))
+	start := int(s.active.Load())
+	launched, failed := 0, 0
+	launch := func() {
+		index := (start + launched) % len(s.endpoints)
+		launched++
+		go func() {
+			var result json.RawMessage
+			err := s.endpoints[index].client.CallContext(ctx, &result, method, args...)
+			responses <- response{index: index, result: result, err: err}
+		}()
+	}
+	launch()
+
+	var lastErr error
+	for {
+		var hedge <-chan time.Time
+		if s.hedgeDelay != 0 && launched < len(s.endpoints) {
+			timer := time.NewTimer(s.hedgeDelay)
+			hedge = timer.C
+			defer timer.Stop()
+		}
+		select {
+		case res := <-responses:
+			var rpcErr rpc.Error
+			if res.err == nil || errors.As(res.err, &rpcErr) {
+				s.setActive(res.index)
+				return res.result, res.err
+			}
+			failed++
+			lastErr = fmt.Errorf("signer %s: %w", s.endpoints[res.index].url, res.err)
+			s.logger.Warn("signer endpoint failed", "endpoint", s.endpoints[res.index].url, "method", method, "err", res.err)
+			if failed == launched {
+				if launched == len(s.endpoints) {
+					return nil, lastErr
+				}
+				launch()
+			}
+		case <-hedge:
+			launch()
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (s *SignerClient) setActive(index int) {
+	if prev := s.active.Swap(int32(index)); int(prev) != index {
+		s.logger.Info("switched signer endpoint", "endpoint", s.endpoints[index].url)
+	}
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// caPool is the CA bundle verifying the signer, which can be reloaded from its file.
+type caPool struct {
+	file string
+
+	mu   sync.RWMutex
+	pool *x509.CertPool
+}
+
+func newCAPool(file string) (*caPool, error) {
+	p := &caPool{file: file}
+	if err := p.load(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// load reads the CA bundle. If it fails, the previous bundle continues to be used.
+func (p *caPool) load() error {
+	caCert, err := os.ReadFile(p.file)
+	if err != nil {
+		return fmt.Errorf("failed to read tls.ca: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCert) {
+		return errors.New("no certificate found in tls.ca")
+	}
+	p.mu.Lock()
+	p.pool = pool
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *caPool) get() *x509.CertPool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.pool
+}
+
+// verifyConnection verifies the certificate chain of the server against the latest CA bundle,
+// like the default verification does against the RootCAs.
+func (p *caPool) verifyConnection(cs tls.ConnectionState) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("no server certificate")
+	}
+	opts := x509.VerifyOptions{
+		Roots:         p.get(),
+		DNSName:       cs.ServerName,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range cs.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := cs.PeerCertificates[0].Verify(opts)
+	return err
+}
+
+// reloadCAPool re-reads the CA bundle on the interval until closing is closed. The client
+// certificate and key are not, as certman reloads them on change.
+func reloadCAPool(logger log.Logger, interval time.Duration, caPool *caPool, closing <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-closing:
+			return
+		case <-ticker.C:
+		}
+		if err := caPool.load(); err != nil {
+			logger.Error("failed to reload tls ca", "err", err)
+		}
+	}
+}
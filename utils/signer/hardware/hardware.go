@@ -0,0 +1,109 @@
+package hardware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/usbwallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	Ledger = "ledger"
+	Trezor = "trezor"
+)
+
+// ErrNoDevice is returned if no hardware wallet of the kind is connected.
+var ErrNoDevice = errors.New("no hardware wallet connected")
+
+// Signer signs transactions with a key of a Ledger or Trezor hardware wallet connected over USB.
+// Each transaction must be confirmed on the device. The devices only sign legacy transactions.
+type Signer struct {
+	kind    string
+	wallet  accounts.Wallet
+	account accounts.Account
+}
+
+// NewSigner opens the first connected hardware wallet of the kind, ledger or trezor, and derives
+// the account of the HD path. If the HD path is empty, the default Ethereum path is used.
+func NewSigner(kind, hdPath string) (*Signer, error) {
+	var hub *usbwallet.Hub
+	var err error
+	switch kind {
+	case Ledger:
+		hub, err = usbwallet.NewLedgerHub()
+	case Trezor:
+		hub, err = usbwallet.NewTrezorHubWithHID()
+	default:
+		return nil, fmt.Errorf("unknown hardware wallet: %s", kind)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to start the %s hub: %w", kind, err)
+	}
+	return newSigner(kind, hub.Wallets(), hdPath)
+}
+
+func newSigner(kind string, wallets []accounts.Wallet, hdPath string) (*Signer, error) {
+	if len(wallets) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoDevice, kind)
+	}
+	path := accounts.DefaultBaseDerivationPath
+	if hdPath != "" {
+		var err error
+		if path, err = accounts.ParseDerivationPath(hdPath); err != nil {
+			return nil, fmt.Errorf("invalid hd path %s: %w", hdPath, err)
+		}
+	}
+
+	wallet := wallets[0]
+	// The Trezor devices ask for their PIN, which cannot be entered here, so they must be unlocked already.
+	if err := wallet.Open(""); err != nil {
+		return nil, fmt.Errorf("failed to open the %s: %w", kind, err)
+	}
+	account, err := wallet.Derive(path, true)
+	if err != nil {
+		_ = wallet.Close()
+		return nil, fmt.Errorf("failed to derive the %s account: %w", kind, err)
+	}
+	return &Signer{kind: kind, wallet: wallet, account: account}, nil
+}
+
+// Address returns the address of the derived account.
+func (s *Signer) Address() common.Address {
+	return s.account.Address
+}
+
+// SignTransaction signs the given transaction for the given chain ID, once it is confirmed on the device.
+// If the context is done first, it returns an error, and the request left on the device is ignored.
+func (s *Signer) SignTransaction(ctx context.Context, chainID *big.Int, tx *types.Transaction) (*types.Transaction, error) {
+	if tx.Type() != types.LegacyTxType {
+		return nil, fmt.Errorf("%s only signs legacy transactions, got type %d", s.kind, tx.Type())
+	}
+	type result struct {
+		tx  *types.Transaction
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		signed, err := s.wallet.SignTx(s.account, tx, chainID)
+		done <- result{signed, err}
+	}()
+	select {
+	case res := <-done:
+		if res.err != nil {
+			return nil, fmt.Errorf("failed to sign with the %s: %w", s.kind, res.err)
+		}
+		return res.tx, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("transaction not confirmed on the %s: %w", s.kind, ctx.Err())
+	}
+}
+
+// Close closes the device.
+func (s *Signer) Close() error {
+	return s.wallet.Close()
+}
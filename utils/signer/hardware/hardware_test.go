@@ -0,0 +1,73 @@
+package hardware
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWallet mimics a hardware wallet with a local key. The signing waits for confirm.
+type fakeWallet struct {
+	accounts.Wallet
+	key     *ecdsa.PrivateKey
+	path    accounts.DerivationPath
+	confirm chan struct{}
+}
+
+func (w *fakeWallet) Open(string) error { return nil }
+
+func (w *fakeWallet) Close() error { return nil }
+
+func (w *fakeWallet) Derive(path accounts.DerivationPath, _ bool) (accounts.Account, error) {
+	w.path = path
+	return accounts.Account{Address: crypto.PubkeyToAddress(w.key.PublicKey)}, nil
+}
+
+func (w *fakeWallet) SignTx(_ accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	<-w.confirm
+	return types.SignTx(tx, types.NewEIP155Signer(chainID), w.key)
+}
+
+func TestSignTransaction(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	wallet := &fakeWallet{key: key, confirm: make(chan struct{})}
+	chainID := big.NewInt(900)
+	to := common.HexToAddress("0x42")
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(10), Gas: 21000, To: &to})
+
+	_, err = newSigner(Ledger, nil, "")
+	require.ErrorIs(t, err, ErrNoDevice)
+	_, err = newSigner(Ledger, []accounts.Wallet{wallet}, "invalid")
+	require.Error(t, err)
+
+	signer, err := newSigner(Ledger, []accounts.Wallet{wallet}, "m/44'/60'/0'/0/1")
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer.Address())
+	require.Equal(t, "m/44'/60'/0'/0/1", wallet.path.String())
+
+	// The tx is not signed until confirmed on the device.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = signer.SignTransaction(ctx, chainID, tx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(wallet.confirm)
+	signed, err := signer.SignTransaction(context.Background(), chainID, tx)
+	require.NoError(t, err)
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	require.NoError(t, err)
+	require.Equal(t, signer.Address(), sender)
+
+	dynamicFeeTx := types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 1, Gas: 21000, To: &to})
+	_, err = signer.SignTransaction(context.Background(), chainID, dynamicFeeTx)
+	require.ErrorContains(t, err, "legacy")
+}
@@ -0,0 +1,327 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	azureAPIVersion           = "7.4"
+	azureIMDSTokenURL         = "http://169.254.169.254/metadata/identity/oauth2/token"
+	azureLoginEndpoint        = "https://login.microsoftonline.com/"
+	azureVaultResource        = "https://vault.azure.net"
+	azureManagedHSMResource   = "https://managedhsm.azure.net"
+	azureSecp256k1Curve       = "P-256K"
+	azureSecp256k1SignAlgo    = "ES256K"
+	azureManagedHSMHostSuffix = ".managedhsm.azure.net"
+)
+
+// AzureClient is the subset of the Azure Key Vault and Managed HSM API used to sign transactions.
+type AzureClient interface {
+	// GetKey returns the public key of the key and its identifier including its version.
+	GetKey(ctx context.Context, keyID string) (*ecdsa.PublicKey, string, error)
+	// Sign signs the digest with the key and returns the signature in the [R || S] format.
+	Sign(ctx context.Context, keyID string, digest []byte) ([]byte, error)
+}
+
+// AzureSigner signs transactions with a secp256k1 key stored in Azure Key Vault or Managed HSM.
+type AzureSigner struct {
+	client  AzureClient
+	keyID   string
+	address common.Address
+}
+
+// NewAzureSignerFromConfig creates an AzureSigner calling the Key Vault REST API. It authenticates
+// with the client secret of the app registration if set, and with the managed identity otherwise.
+func NewAzureSignerFromConfig(ctx context.Context, cfg CLIConfig) (*AzureSigner, error) {
+	resource, err := azureResource(cfg.AzureKeyID)
+	if err != nil {
+		return nil, err
+	}
+	var tokens azureTokenSource
+	if cfg.AzureClientSecret != "" {
+		tokens = &azureClientSecretTokens{
+			tokenURL:     azureLoginEndpoint + url.PathEscape(cfg.AzureTenantID) + "/oauth2/v2.0/token",
+			clientID:     cfg.AzureClientID,
+			clientSecret: cfg.AzureClientSecret,
+			scope:        resource + "/.default",
+		}
+	} else {
+		tokens = &azureManagedIdentityTokens{
+			tokenURL: azureIMDSTokenURL,
+			clientID: cfg.AzureClientID,
+			resource: resource,
+		}
+	}
+	return NewAzureSigner(ctx, newAzureRESTClient(tokens), cfg.AzureKeyID)
+}
+
+// NewAzureSigner creates an AzureSigner for the given key, deriving the signing address from its public key.
+// If the key identifier has no version, the current version is pinned, so that the address does not change
+// when the key is rotated.
+func NewAzureSigner(ctx context.Context, client AzureClient, keyID string) (*AzureSigner, error) {
+	pubKey, versionedID, err := client.GetKey(ctx, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the azure key %s: %w", keyID, err)
+	}
+	return &AzureSigner{
+		client:  client,
+		keyID:   versionedID,
+		address: crypto.PubkeyToAddress(*pubKey),
+	}, nil
+}
+
+// Address returns the address of the Azure key.
+func (s *AzureSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTransaction signs the given transaction for the given chain ID.
+func (s *AzureSigner) SignTransaction(ctx context.Context, chainID *big.Int, tx *types.Transaction) (*types.Transaction, error) {
+	signer := types.LatestSignerForChainID(chainID)
+	signature, err := s.SignHash(ctx, signer.Hash(tx))
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(signer, signature)
+}
+
+// SignHash signs the given digest and returns the signature in the [R || S || V] format, where V is 0 or 1.
+func (s *AzureSigner) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	rs, err := s.client.Sign(ctx, s.keyID, hash[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign with azure key %s: %w", s.keyID, err)
+	}
+	if len(rs) != 64 {
+		return nil, fmt.Errorf("invalid azure signature length %d", len(rs))
+	}
+	return signatureFromRS(hash, new(big.Int).SetBytes(rs[:32]), new(big.Int).SetBytes(rs[32:]), s.address)
+}
+
+// azureResource returns the resource the access tokens are requested for, which depends on whether
+// the key is stored in a Key Vault or a Managed HSM.
+func azureResource(keyID string) (string, error) {
+	u, err := url.Parse(keyID)
+	if err != nil || u.Scheme != "https" || !strings.HasPrefix(u.Path, "/keys/") {
+		return "", fmt.Errorf("invalid azure key id %s, expected https://<vault>/keys/<name>[/<version>]", keyID)
+	}
+	if strings.HasSuffix(u.Hostname(), azureManagedHSMHostSuffix) {
+		return azureManagedHSMResource, nil
+	}
+	return azureVaultResource, nil
+}
+
+// azureTokenSource returns the access tokens of the Key Vault API.
+type azureTokenSource interface {
+	token(ctx context.Context, client *http.Client) (token string, expiresIn time.Duration, err error)
+}
+
+// azureManagedIdentityTokens gets the tokens of the managed identity of the host from the instance
+// metadata service. The client ID selects a user-assigned identity, if set.
+type azureManagedIdentityTokens struct {
+	tokenURL string
+	clientID string
+	resource string
+}
+
+func (t *azureManagedIdentityTokens) token(ctx context.Context, client *http.Client) (string, time.Duration, error) {
+	query := url.Values{"api-version": {"2018-02-01"}, "resource": {t.resource}}
+	if t.clientID != "" {
+		query.Set("client_id", t.clientID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.tokenURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Metadata", "true")
+	return doTokenRequest(client, req)
+}
+
+// azureClientSecretTokens gets the tokens of an app registration with its client secret.
+type azureClientSecretTokens struct {
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	scope        string
+}
+
+func (t *azureClientSecretTokens) token(ctx context.Context, client *http.Client) (string, time.Duration, error) {
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {t.clientID},
+		"client_secret": {t.clientSecret},
+		"scope":         {t.scope},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return doTokenRequest(client, req)
+}
+
+func doTokenRequest(client *http.Client, req *http.Request) (string, time.Duration, error) {
+	var res struct {
+		AccessToken string `json:"access_token"`
+		// ExpiresIn is a string in the responses of the instance metadata service.
+		ExpiresIn json.Number `json:"expires_in"`
+	}
+	if err := doAzureRequest(client, req, &res); err != nil {
+		return "", 0, err
+	}
+	if res.AccessToken == "" {
+		return "", 0, errors.New("empty access token")
+	}
+	expiresIn, err := res.ExpiresIn.Int64()
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid token expiry %q: %w", res.ExpiresIn, err)
+	}
+	return res.AccessToken, time.Duration(expiresIn) * time.Second, nil
+}
+
+// azureRESTClient calls the Key Vault REST API. The access token is cached until shortly before it expires.
+type azureRESTClient struct {
+	http   *http.Client
+	tokens azureTokenSource
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+func newAzureRESTClient(tokens azureTokenSource) *azureRESTClient {
+	return &azureRESTClient{
+		http:   &http.Client{Timeout: 10 * time.Second},
+		tokens: tokens,
+	}
+}
+
+func (c *azureRESTClient) GetKey(ctx context.Context, keyID string) (*ecdsa.PublicKey, string, error) {
+	var res struct {
+		Key struct {
+			Kid string `json:"kid"`
+			Crv string `json:"crv"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		} `json:"key"`
+	}
+	if err := c.call(ctx, http.MethodGet, keyID, nil, &res); err != nil {
+		return nil, "", err
+	}
+	if res.Key.Crv != azureSecp256k1Curve {
+		return nil, "", fmt.Errorf("unsupported key curve %s, expected %s", res.Key.Crv, azureSecp256k1Curve)
+	}
+	x, err := decodeBase64URL(res.Key.X)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid key x coordinate: %w", err)
+	}
+	y, err := decodeBase64URL(res.Key.Y)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid key y coordinate: %w", err)
+	}
+	if len(x) != 32 || len(y) != 32 {
+		return nil, "", errors.New("invalid key coordinates length")
+	}
+	pubKey, err := crypto.UnmarshalPubkey(append(append([]byte{4}, x...), y...))
+	if err != nil {
+		return nil, "", err
+	}
+	if res.Key.Kid == "" {
+		return pubKey, keyID, nil
+	}
+	return pubKey, res.Key.Kid, nil
+}
+
+func (c *azureRESTClient) Sign(ctx context.Context, keyID string, digest []byte) ([]byte, error) {
+	req := struct {
+		Alg   string `json:"alg"`
+		Value string `json:"value"`
+	}{
+		Alg:   azureSecp256k1SignAlgo,
+		Value: base64.RawURLEncoding.EncodeToString(digest),
+	}
+	var res struct {
+		Value string `json:"value"`
+	}
+	if err := c.call(ctx, http.MethodPost, keyID+"/sign", req, &res); err != nil {
+		return nil, err
+	}
+	return decodeBase64URL(res.Value)
+}
+
+func (c *azureRESTClient) call(ctx context.Context, method, endpoint string, body, result interface{}) error {
+	token, err := c.accessToken(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the azure access token: %w", err)
+	}
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint+"?api-version="+azureAPIVersion, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return doAzureRequest(c.http, req, result)
+}
+
+func (c *azureRESTClient) accessToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token != "" && time.Now().Before(c.tokenExpiry) {
+		return c.token, nil
+	}
+	token, expiresIn, err := c.tokens.token(ctx, c.http)
+	if err != nil {
+		return "", err
+	}
+	c.token = token
+	// Refresh the token a minute before it expires, so that it does not expire in flight.
+	c.tokenExpiry = time.Now().Add(expiresIn - time.Minute)
+	return c.token, nil
+}
+
+func doAzureRequest(client *http.Client, req *http.Request, result interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+	return json.Unmarshal(data, result)
+}
+
+// decodeBase64URL decodes the base64url values of the Key Vault API, which are not padded.
+func decodeBase64URL(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+}
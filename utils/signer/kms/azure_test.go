@@ -0,0 +1,122 @@
+package kms
+
+import (
+	"context"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	awskms "github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeAzureServer serves the managed identity and client secret tokens, and the Key Vault key
+// and sign endpoints of the key of the fakeClient, as the version v1 of the key k.
+func newFakeAzureServer(t *testing.T, c *fakeClient) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/identity/token", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "true", r.Header.Get("Metadata"))
+		require.Equal(t, azureVaultResource, r.URL.Query().Get("resource"))
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "test-token", "expires_in": "3600"})
+	})
+	mux.HandleFunc("/tenant/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		require.Equal(t, "secret", r.PostForm.Get("client_secret"))
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "test-token", "expires_in": 3600})
+	})
+	srv := httptest.NewTLSServer(mux)
+	t.Cleanup(srv.Close)
+	mux.HandleFunc("/keys/k", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		pubKey := crypto.FromECDSAPub(&c.key.PublicKey)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"key": map[string]string{
+			"kid": srv.URL + "/keys/k/v1",
+			"kty": "EC-HSM",
+			"crv": azureSecp256k1Curve,
+			"x":   base64.RawURLEncoding.EncodeToString(pubKey[1:33]),
+			"y":   base64.RawURLEncoding.EncodeToString(pubKey[33:]),
+		}})
+	})
+	mux.HandleFunc("/keys/k/v1/sign", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		var req struct {
+			Alg   string `json:"alg"`
+			Value string `json:"value"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, azureSecp256k1SignAlgo, req.Alg)
+		digest, err := decodeBase64URL(req.Value)
+		require.NoError(t, err)
+		out, err := c.Sign(r.Context(), &awskms.SignInput{Message: digest})
+		require.NoError(t, err)
+		var sig struct {
+			R, S *big.Int
+		}
+		_, err = asn1.Unmarshal(out.Signature, &sig)
+		require.NoError(t, err)
+		rs := make([]byte, 64)
+		sig.R.FillBytes(rs[:32])
+		sig.S.FillBytes(rs[32:])
+		_ = json.NewEncoder(w).Encode(map[string]string{"kid": srv.URL + "/keys/k/v1", "value": base64.RawURLEncoding.EncodeToString(rs)})
+	})
+	return srv
+}
+
+func TestAzureSignTransaction(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	chainID := big.NewInt(900)
+	to := common.HexToAddress("0x42")
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     1,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(10),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1),
+	})
+
+	for _, highS := range []bool{false, true} {
+		srv := newFakeAzureServer(t, &fakeClient{key: key, highS: highS})
+		for _, tokens := range []azureTokenSource{
+			&azureManagedIdentityTokens{tokenURL: srv.URL + "/identity/token", resource: azureVaultResource},
+			&azureClientSecretTokens{tokenURL: srv.URL + "/tenant/token", clientID: "id", clientSecret: "secret", scope: azureVaultResource + "/.default"},
+		} {
+			client := newAzureRESTClient(tokens)
+			client.http = srv.Client()
+			signer, err := NewAzureSigner(context.Background(), client, srv.URL+"/keys/k")
+			require.NoError(t, err)
+			require.Equal(t, from, signer.Address())
+			require.Equal(t, srv.URL+"/keys/k/v1", signer.keyID)
+
+			signed, err := signer.SignTransaction(context.Background(), chainID, tx)
+			require.NoError(t, err)
+			sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+			require.NoError(t, err)
+			require.Equal(t, from, sender)
+			_, _, s := signed.RawSignatureValues()
+			require.LessOrEqual(t, s.Cmp(secp256k1HalfN), 0, "S value must be in the lower half of the curve order")
+		}
+	}
+}
+
+func TestAzureResource(t *testing.T) {
+	resource, err := azureResource("https://vault.vault.azure.net/keys/k/v1")
+	require.NoError(t, err)
+	require.Equal(t, azureVaultResource, resource)
+	resource, err = azureResource("https://hsm.managedhsm.azure.net/keys/k")
+	require.NoError(t, err)
+	require.Equal(t, azureManagedHSMResource, resource)
+	_, err = azureResource("http://vault.vault.azure.net/secrets/k")
+	require.ErrorContains(t, err, "invalid azure key id")
+}
@@ -0,0 +1,105 @@
+package kms
+
+import (
+	"errors"
+
+	"github.com/urfave/cli"
+
+	kservice "github.com/kroma-network/kroma/utils/service"
+)
+
+const (
+	KeyIDFlagName      = "kms.key-id"
+	RegionFlagName     = "kms.region"
+	GCPKeyNameFlagName = "kms.gcp-key-name"
+	// Azure Key Vault flags
+	AzureKeyIDFlagName        = "kms.azure-key-id"
+	AzureTenantIDFlagName     = "kms.azure-tenant-id"
+	AzureClientIDFlagName     = "kms.azure-client-id"
+	AzureClientSecretFlagName = "kms.azure-client-secret"
+)
+
+func CLIFlags(envPrefix string) []cli.Flag {
+	envPrefix += "_KMS"
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:   KeyIDFlagName,
+			Usage:  "ID or ARN of the AWS KMS key used to sign transactions",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "KEY_ID"),
+		},
+		cli.StringFlag{
+			Name:   RegionFlagName,
+			Usage:  "AWS region of the KMS key. If not set, the region of the default AWS config is used",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "REGION"),
+		},
+		cli.StringFlag{
+			Name:   GCPKeyNameFlagName,
+			Usage:  "Resource name of the Google Cloud KMS key version used to sign transactions, i.e. projects/*/locations/*/keyRings/*/cryptoKeys/*/cryptoKeyVersions/*. Used if the signer backend is gcpkms",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "GCP_KEY_NAME"),
+		},
+		cli.StringFlag{
+			Name:   AzureKeyIDFlagName,
+			Usage:  "Identifier of the Azure Key Vault or Managed HSM key used to sign transactions, i.e. https://<vault>/keys/<name>[/<version>]. Used if the signer backend is azurekv",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "AZURE_KEY_ID"),
+		},
+		cli.StringFlag{
+			Name:   AzureTenantIDFlagName,
+			Usage:  "Azure tenant of the app registration authenticating with the client secret",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "AZURE_TENANT_ID"),
+		},
+		cli.StringFlag{
+			Name:   AzureClientIDFlagName,
+			Usage:  "Client ID of the app registration authenticating with the client secret, or of the user-assigned managed identity. If the client secret is not set, the managed identity of the host is used",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "AZURE_CLIENT_ID"),
+		},
+		cli.StringFlag{
+			Name:   AzureClientSecretFlagName,
+			Usage:  "Client secret of the app registration. The tenant and client ID must also be set",
+			EnvVar: kservice.PrefixEnvVar(envPrefix, "AZURE_CLIENT_SECRET"),
+		},
+	}
+}
+
+type CLIConfig struct {
+	KeyID      string
+	Region     string
+	GCPKeyName string
+
+	AzureKeyID        string
+	AzureTenantID     string
+	AzureClientID     string
+	AzureClientSecret string
+}
+
+func (c CLIConfig) Check() error {
+	if c.KeyID == "" && c.Region != "" {
+		return errors.New("kms key id must be set if kms region is set")
+	}
+	if c.KeyID != "" && c.GCPKeyName != "" {
+		return errors.New("kms key id and gcp kms key name must not both be set")
+	}
+	if c.AzureKeyID != "" && (c.KeyID != "" || c.GCPKeyName != "") {
+		return errors.New("azure key id must not be set with the kms key id or gcp kms key name")
+	}
+	if c.AzureClientSecret != "" && (c.AzureTenantID == "" || c.AzureClientID == "") {
+		return errors.New("azure tenant id and client id must be set with the azure client secret")
+	}
+	return nil
+}
+
+func (c CLIConfig) Enabled() bool {
+	return c.KeyID != ""
+}
+
+func ReadCLIConfig(ctx *cli.Context) CLIConfig {
+	return CLIConfig{
+		KeyID:      ctx.String(KeyIDFlagName),
+		Region:     ctx.String(RegionFlagName),
+		GCPKeyName: ctx.String(GCPKeyNameFlagName),
+
+		AzureKeyID:        ctx.String(AzureKeyIDFlagName),
+		AzureTenantID:     ctx.String(AzureTenantIDFlagName),
+		AzureClientID:     ctx.String(AzureClientIDFlagName),
+		AzureClientSecret: ctx.String(AzureClientSecretFlagName),
+	}
+}
@@ -0,0 +1,210 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	gcpKMSEndpoint      = "https://cloudkms.googleapis.com/v1/"
+	gcpMetadataTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+	gcpSecp256k1Algo    = "EC_SIGN_SECP256K1_SHA256"
+)
+
+// GCPClient is the subset of the Google Cloud KMS API used to sign transactions.
+type GCPClient interface {
+	// GetPublicKey returns the DER-encoded SubjectPublicKeyInfo of the key version.
+	GetPublicKey(ctx context.Context, keyName string) ([]byte, error)
+	// AsymmetricSign signs the SHA-256 digest with the key version and returns the DER-encoded signature.
+	AsymmetricSign(ctx context.Context, keyName string, digest []byte) ([]byte, error)
+}
+
+// GCPSigner signs transactions with a secp256k1 key version stored in Google Cloud KMS.
+type GCPSigner struct {
+	client  GCPClient
+	keyName string
+	address common.Address
+}
+
+// NewGCPSignerFromConfig creates a GCPSigner calling the Cloud KMS REST API, authenticated with
+// the token of the default service account served by the GCP metadata server.
+func NewGCPSignerFromConfig(ctx context.Context, cfg CLIConfig) (*GCPSigner, error) {
+	return NewGCPSigner(ctx, newGCPRESTClient(gcpKMSEndpoint, gcpMetadataTokenURL), cfg.GCPKeyName)
+}
+
+// NewGCPSigner creates a GCPSigner for the given key version, deriving the signing address from its public key.
+func NewGCPSigner(ctx context.Context, client GCPClient, keyName string) (*GCPSigner, error) {
+	der, err := client.GetPublicKey(ctx, keyName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the public key of gcp kms key %s: %w", keyName, err)
+	}
+	pubKey, err := parsePublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the public key of gcp kms key %s: %w", keyName, err)
+	}
+
+	return &GCPSigner{
+		client:  client,
+		keyName: keyName,
+		address: crypto.PubkeyToAddress(*pubKey),
+	}, nil
+}
+
+// Address returns the address of the KMS key.
+func (s *GCPSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTransaction signs the given transaction for the given chain ID.
+func (s *GCPSigner) SignTransaction(ctx context.Context, chainID *big.Int, tx *types.Transaction) (*types.Transaction, error) {
+	signer := types.LatestSignerForChainID(chainID)
+	signature, err := s.SignHash(ctx, signer.Hash(tx))
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(signer, signature)
+}
+
+// SignHash signs the given digest and returns the signature in the [R || S || V] format, where V is 0 or 1.
+func (s *GCPSigner) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	der, err := s.client.AsymmetricSign(ctx, s.keyName, hash[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign with gcp kms key %s: %w", s.keyName, err)
+	}
+	return signatureFromDER(hash, der, s.address)
+}
+
+// gcpRESTClient calls the Cloud KMS REST API. The access token is fetched from the metadata server,
+// and cached until shortly before it expires.
+type gcpRESTClient struct {
+	http     *http.Client
+	endpoint string
+	tokenURL string
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+func newGCPRESTClient(endpoint, tokenURL string) *gcpRESTClient {
+	return &gcpRESTClient{
+		http:     &http.Client{Timeout: 10 * time.Second},
+		endpoint: endpoint,
+		tokenURL: tokenURL,
+	}
+}
+
+func (c *gcpRESTClient) GetPublicKey(ctx context.Context, keyName string) ([]byte, error) {
+	var res struct {
+		Pem       string `json:"pem"`
+		Algorithm string `json:"algorithm"`
+	}
+	if err := c.call(ctx, http.MethodGet, keyName+"/publicKey", nil, &res); err != nil {
+		return nil, err
+	}
+	if res.Algorithm != gcpSecp256k1Algo {
+		return nil, fmt.Errorf("unsupported key algorithm %s, expected %s", res.Algorithm, gcpSecp256k1Algo)
+	}
+	block, _ := pem.Decode([]byte(res.Pem))
+	if block == nil {
+		return nil, errors.New("invalid public key pem")
+	}
+	return block.Bytes, nil
+}
+
+func (c *gcpRESTClient) AsymmetricSign(ctx context.Context, keyName string, digest []byte) ([]byte, error) {
+	req := struct {
+		Digest struct {
+			Sha256 []byte `json:"sha256"`
+		} `json:"digest"`
+	}{}
+	req.Digest.Sha256 = digest
+	var res struct {
+		Signature []byte `json:"signature"`
+	}
+	if err := c.call(ctx, http.MethodPost, keyName+":asymmetricSign", req, &res); err != nil {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+func (c *gcpRESTClient) call(ctx context.Context, method, path string, body, result interface{}) error {
+	token, err := c.accessToken(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the gcp access token: %w", err)
+	}
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+strings.TrimPrefix(path, "/"), reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.do(req, result)
+}
+
+func (c *gcpRESTClient) accessToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token != "" && time.Now().Before(c.tokenExpiry) {
+		return c.token, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.tokenURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	var res struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := c.do(req, &res); err != nil {
+		return "", err
+	}
+	if res.AccessToken == "" {
+		return "", errors.New("empty access token")
+	}
+	c.token = res.AccessToken
+	// Refresh the token a minute before it expires, so that it does not expire in flight.
+	c.tokenExpiry = time.Now().Add(time.Duration(res.ExpiresIn)*time.Second - time.Minute)
+	return c.token, nil
+}
+
+func (c *gcpRESTClient) do(req *http.Request, result interface{}) error {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+	return json.Unmarshal(data, result)
+}
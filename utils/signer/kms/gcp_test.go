@@ -0,0 +1,93 @@
+package kms
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	awskms "github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+const testGCPKeyName = "projects/p/locations/global/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1"
+
+// newFakeGCPServer serves the metadata token and the Cloud KMS public key and sign endpoints
+// of the key of the fakeClient.
+func newFakeGCPServer(t *testing.T, c *fakeClient) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Google", r.Header.Get("Metadata-Flavor"))
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "test-token", "expires_in": 3600})
+	})
+	mux.HandleFunc("/v1/"+testGCPKeyName+"/publicKey", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		out, err := c.GetPublicKey(r.Context(), nil)
+		require.NoError(t, err)
+		pemKey := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: out.PublicKey})
+		_ = json.NewEncoder(w).Encode(map[string]string{"pem": string(pemKey), "algorithm": gcpSecp256k1Algo})
+	})
+	mux.HandleFunc("/v1/"+testGCPKeyName+":asymmetricSign", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		var req struct {
+			Digest struct {
+				Sha256 []byte `json:"sha256"`
+			} `json:"digest"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		out, err := c.Sign(r.Context(), &awskms.SignInput{Message: req.Digest.Sha256})
+		require.NoError(t, err)
+		_ = json.NewEncoder(w).Encode(map[string][]byte{"signature": out.Signature})
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestGCPSignTransaction(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	chainID := big.NewInt(900)
+	to := common.HexToAddress("0x42")
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     1,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(10),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1),
+	})
+
+	for _, highS := range []bool{false, true} {
+		srv := newFakeGCPServer(t, &fakeClient{key: key, highS: highS})
+		client := newGCPRESTClient(srv.URL+"/v1/", srv.URL+"/token")
+		signer, err := NewGCPSigner(context.Background(), client, testGCPKeyName)
+		require.NoError(t, err)
+		require.Equal(t, from, signer.Address())
+
+		signed, err := signer.SignTransaction(context.Background(), chainID, tx)
+		require.NoError(t, err)
+		sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+		require.NoError(t, err)
+		require.Equal(t, from, sender)
+		_, _, s := signed.RawSignatureValues()
+		require.LessOrEqual(t, s.Cmp(secp256k1HalfN), 0, "S value must be in the lower half of the curve order")
+	}
+}
+
+func TestGCPSignerUnknownKey(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	srv := newFakeGCPServer(t, &fakeClient{key: key})
+	client := newGCPRESTClient(srv.URL+"/v1/", srv.URL+"/token")
+	_, err = NewGCPSigner(context.Background(), client, "projects/p/locations/global/keyRings/r/cryptoKeys/unknown/cryptoKeyVersions/1")
+	require.ErrorContains(t, err, "404")
+}
@@ -0,0 +1,144 @@
+package kms
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	awskms "github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	secp256k1N     = crypto.S256().Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+// Client is the subset of the AWS KMS API used to sign transactions.
+type Client interface {
+	GetPublicKey(ctx context.Context, params *awskms.GetPublicKeyInput, optFns ...func(*awskms.Options)) (*awskms.GetPublicKeyOutput, error)
+	Sign(ctx context.Context, params *awskms.SignInput, optFns ...func(*awskms.Options)) (*awskms.SignOutput, error)
+}
+
+// Signer signs transactions with a secp256k1 key stored in AWS KMS.
+type Signer struct {
+	client  Client
+	keyID   string
+	address common.Address
+}
+
+// NewSignerFromConfig creates a Signer using the default AWS config, which resolves the credentials
+// from the environment, the shared config files or the instance role.
+func NewSignerFromConfig(ctx context.Context, cfg CLIConfig) (*Signer, error) {
+	var opts []func(*config.LoadOptions) error
+	if cfg.Region != "" {
+		opts = append(opts, config.WithRegion(cfg.Region))
+	}
+	awsCfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load aws config: %w", err)
+	}
+	return NewSigner(ctx, awskms.NewFromConfig(awsCfg), cfg.KeyID)
+}
+
+// NewSigner creates a Signer for the given KMS key, deriving the signing address from its public key.
+func NewSigner(ctx context.Context, client Client, keyID string) (*Signer, error) {
+	out, err := client.GetPublicKey(ctx, &awskms.GetPublicKeyInput{KeyId: aws.String(keyID)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the public key of kms key %s: %w", keyID, err)
+	}
+	pubKey, err := parsePublicKey(out.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the public key of kms key %s: %w", keyID, err)
+	}
+
+	return &Signer{
+		client:  client,
+		keyID:   keyID,
+		address: crypto.PubkeyToAddress(*pubKey),
+	}, nil
+}
+
+// Address returns the address of the KMS key.
+func (s *Signer) Address() common.Address {
+	return s.address
+}
+
+// SignTransaction signs the given transaction for the given chain ID.
+func (s *Signer) SignTransaction(ctx context.Context, chainID *big.Int, tx *types.Transaction) (*types.Transaction, error) {
+	signer := types.LatestSignerForChainID(chainID)
+	signature, err := s.SignHash(ctx, signer.Hash(tx))
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(signer, signature)
+}
+
+// SignHash signs the given digest and returns the signature in the [R || S || V] format, where V is 0 or 1.
+func (s *Signer) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	out, err := s.client.Sign(ctx, &awskms.SignInput{
+		KeyId:            aws.String(s.keyID),
+		Message:          hash[:],
+		MessageType:      kmstypes.MessageTypeDigest,
+		SigningAlgorithm: kmstypes.SigningAlgorithmSpecEcdsaSha256,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign with kms key %s: %w", s.keyID, err)
+	}
+	return signatureFromDER(hash, out.Signature, s.address)
+}
+
+// signatureFromDER converts a DER-encoded ECDSA signature of the hash into the [R || S || V] format.
+func signatureFromDER(hash common.Hash, der []byte, address common.Address) ([]byte, error) {
+	var sig struct {
+		R, S *big.Int
+	}
+	if _, err := asn1.Unmarshal(der, &sig); err != nil {
+		return nil, fmt.Errorf("failed to parse the kms signature: %w", err)
+	}
+	return signatureFromRS(hash, sig.R, sig.S, address)
+}
+
+// signatureFromRS converts the R and S values of an ECDSA signature of the hash into the [R || S || V] format,
+// normalizing the S value and finding the recovery id that recovers the address.
+func signatureFromRS(hash common.Hash, r, s *big.Int, address common.Address) ([]byte, error) {
+	// Ethereum only accepts signatures with the S value in the lower half of the curve order.
+	if s.Cmp(secp256k1HalfN) > 0 {
+		s = new(big.Int).Sub(secp256k1N, s)
+	}
+
+	signature := make([]byte, crypto.SignatureLength)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:64])
+	// KMS does not return the recovery id, so find the one that recovers the address of the key.
+	for v := byte(0); v < 2; v++ {
+		signature[crypto.RecoveryIDOffset] = v
+		pubKey, err := crypto.SigToPub(hash[:], signature)
+		if err == nil && crypto.PubkeyToAddress(*pubKey) == address {
+			return signature, nil
+		}
+	}
+	return nil, errors.New("failed to recover the address of the kms key from the signature")
+}
+
+// parsePublicKey parses a DER-encoded SubjectPublicKeyInfo of a secp256k1 key,
+// which x509.ParsePKIXPublicKey does not support.
+func parsePublicKey(der []byte) (*ecdsa.PublicKey, error) {
+	var info struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, err
+	}
+	return crypto.UnmarshalPubkey(info.PublicKey.Bytes)
+}
@@ -0,0 +1,111 @@
+package kms
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+
+	awskms "github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidSecp256k1      = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+// fakeClient mimics the AWS KMS API with a local key.
+type fakeClient struct {
+	key *ecdsa.PrivateKey
+	// highS makes the signatures use the S value in the upper half of the curve order.
+	highS bool
+}
+
+func (c *fakeClient) GetPublicKey(_ context.Context, _ *awskms.GetPublicKeyInput, _ ...func(*awskms.Options)) (*awskms.GetPublicKeyOutput, error) {
+	oidParams, err := asn1.Marshal(oidSecp256k1)
+	if err != nil {
+		return nil, err
+	}
+	der, err := asn1.Marshal(struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}{
+		Algorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPublicKeyECDSA,
+			Parameters: asn1.RawValue{FullBytes: oidParams},
+		},
+		PublicKey: asn1.BitString{Bytes: crypto.FromECDSAPub(&c.key.PublicKey)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &awskms.GetPublicKeyOutput{PublicKey: der}, nil
+}
+
+func (c *fakeClient) Sign(_ context.Context, params *awskms.SignInput, _ ...func(*awskms.Options)) (*awskms.SignOutput, error) {
+	r, s, err := ecdsa.Sign(rand.Reader, c.key, params.Message)
+	if err != nil {
+		return nil, err
+	}
+	if c.highS == (s.Cmp(secp256k1HalfN) <= 0) {
+		s = new(big.Int).Sub(secp256k1N, s)
+	}
+	der, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+	if err != nil {
+		return nil, err
+	}
+	return &awskms.SignOutput{Signature: der}, nil
+}
+
+func TestSignTransaction(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	chainID := big.NewInt(900)
+	to := common.HexToAddress("0x42")
+
+	txs := map[string]*types.Transaction{
+		"legacy": types.NewTx(&types.LegacyTx{
+			Nonce:    1,
+			GasPrice: big.NewInt(10),
+			Gas:      21000,
+			To:       &to,
+			Value:    big.NewInt(1),
+		}),
+		"dynamic fee": types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     1,
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(10),
+			Gas:       21000,
+			To:        &to,
+			Value:     big.NewInt(1),
+		}),
+	}
+
+	for _, highS := range []bool{false, true} {
+		signer, err := NewSigner(context.Background(), &fakeClient{key: key, highS: highS}, "test-key")
+		require.NoError(t, err)
+		require.Equal(t, from, signer.Address())
+
+		for name, tx := range txs {
+			signed, err := signer.SignTransaction(context.Background(), chainID, tx)
+			require.NoError(t, err, name)
+
+			sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+			require.NoError(t, err, name)
+			require.Equal(t, from, sender, name)
+			require.Equal(t, chainID, signed.ChainId(), name)
+
+			_, _, s := signed.RawSignatureValues()
+			require.LessOrEqual(t, s.Cmp(secp256k1HalfN), 0, "%s: S value must be in the lower half of the curve order", name)
+		}
+	}
+}